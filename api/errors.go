@@ -41,4 +41,13 @@ func Error(err error) *ErrorResponse {
 	}
 }
 
+func ServiceUnavailableError(err error) *ErrorResponse {
+	return &ErrorResponse{
+		Err:     err,
+		Code:    503,
+		Status:  http.StatusText(503),
+		Message: err.Error(),
+	}
+}
+
 var ErrNotFound = &ErrorResponse{Code: 404, Status: "Resource not found."}
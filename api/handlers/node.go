@@ -6,10 +6,16 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 
+	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/message/validation"
 	networkpeers "github.com/bloxapp/ssv/network/peers"
 	"github.com/bloxapp/ssv/nodeprobe"
+	"github.com/bloxapp/ssv/protocol/v2/message"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
@@ -23,6 +29,35 @@ type TopicIndex interface {
 	PeersByTopic() ([]peer.ID, map[string][]peer.ID)
 }
 
+// TopicScoreParamsProvider provides the last-computed score params of each joined topic.
+type TopicScoreParamsProvider interface {
+	TopicScoreParams() map[string]*pubsub.TopicScoreParams
+}
+
+// EventTopicsProvider provides the beacon event topics with an active subscription.
+type EventTopicsProvider interface {
+	SubscribedTopics() []string
+}
+
+// ConsensusClientProbe distinguishes between the consensus client being reachable
+// and being ready to serve duties.
+type ConsensusClientProbe interface {
+	Live(ctx context.Context) error
+	Ready(ctx context.Context) error
+}
+
+// RoleValidationSwitch toggles the validation of each role's messages at runtime.
+type RoleValidationSwitch interface {
+	SetRoleValidation(role spectypes.BeaconRole, enabled bool) error
+	DisabledRoles() []spectypes.BeaconRole
+}
+
+// PubsubTraceLogSwitch adjusts the logging of pubsub trace events at runtime.
+type PubsubTraceLogSwitch interface {
+	PubsubTraceLog() (enabled bool, types []string)
+	SetPubsubTraceLog(enabled bool, types []string) error
+}
+
 type AllPeersAndTopicsJSON struct {
 	AllPeers     []peer.ID        `json:"all_peers"`
 	PeersByTopic []topicIndexJSON `json:"peers_by_topic"`
@@ -33,6 +68,54 @@ type topicIndexJSON struct {
 	Peers     []peer.ID `json:"peers"`
 }
 
+type topicScoreParamsJSON struct {
+	TopicName   string  `json:"topic"`
+	TopicWeight float64 `json:"topic_weight"`
+
+	TimeInMeshWeight  float64 `json:"time_in_mesh_weight"`
+	TimeInMeshQuantum string  `json:"time_in_mesh_quantum"`
+	TimeInMeshCap     float64 `json:"time_in_mesh_cap"`
+
+	FirstMessageDeliveriesWeight float64 `json:"first_message_deliveries_weight"`
+	FirstMessageDeliveriesDecay  float64 `json:"first_message_deliveries_decay"`
+	FirstMessageDeliveriesCap    float64 `json:"first_message_deliveries_cap"`
+
+	MeshMessageDeliveriesWeight     float64 `json:"mesh_message_deliveries_weight"`
+	MeshMessageDeliveriesDecay      float64 `json:"mesh_message_deliveries_decay"`
+	MeshMessageDeliveriesCap        float64 `json:"mesh_message_deliveries_cap"`
+	MeshMessageDeliveriesThreshold  float64 `json:"mesh_message_deliveries_threshold"`
+	MeshMessageDeliveriesWindow     string  `json:"mesh_message_deliveries_window"`
+	MeshMessageDeliveriesActivation string  `json:"mesh_message_deliveries_activation"`
+	MeshFailurePenaltyWeight        float64 `json:"mesh_failure_penalty_weight"`
+	MeshFailurePenaltyDecay         float64 `json:"mesh_failure_penalty_decay"`
+
+	InvalidMessageDeliveriesWeight float64 `json:"invalid_message_deliveries_weight"`
+	InvalidMessageDeliveriesDecay  float64 `json:"invalid_message_deliveries_decay"`
+}
+
+func newTopicScoreParamsJSON(topic string, p *pubsub.TopicScoreParams) topicScoreParamsJSON {
+	return topicScoreParamsJSON{
+		TopicName:                       topic,
+		TopicWeight:                     p.TopicWeight,
+		TimeInMeshWeight:                p.TimeInMeshWeight,
+		TimeInMeshQuantum:               p.TimeInMeshQuantum.String(),
+		TimeInMeshCap:                   p.TimeInMeshCap,
+		FirstMessageDeliveriesWeight:    p.FirstMessageDeliveriesWeight,
+		FirstMessageDeliveriesDecay:     p.FirstMessageDeliveriesDecay,
+		FirstMessageDeliveriesCap:       p.FirstMessageDeliveriesCap,
+		MeshMessageDeliveriesWeight:     p.MeshMessageDeliveriesWeight,
+		MeshMessageDeliveriesDecay:      p.MeshMessageDeliveriesDecay,
+		MeshMessageDeliveriesCap:        p.MeshMessageDeliveriesCap,
+		MeshMessageDeliveriesThreshold:  p.MeshMessageDeliveriesThreshold,
+		MeshMessageDeliveriesWindow:     p.MeshMessageDeliveriesWindow.String(),
+		MeshMessageDeliveriesActivation: p.MeshMessageDeliveriesActivation.String(),
+		MeshFailurePenaltyWeight:        p.MeshFailurePenaltyWeight,
+		MeshFailurePenaltyDecay:         p.MeshFailurePenaltyDecay,
+		InvalidMessageDeliveriesWeight:  p.InvalidMessageDeliveriesWeight,
+		InvalidMessageDeliveriesDecay:   p.InvalidMessageDeliveriesDecay,
+	}
+}
+
 type connectionJSON struct {
 	Address   string `json:"address"`
 	Direction string `json:"direction"`
@@ -65,6 +148,10 @@ func (h healthStatus) MarshalJSON() ([]byte, error) {
 	return json.Marshal(fmt.Sprintf("bad: %s", h.err.Error()))
 }
 
+type probeJSON struct {
+	Status string `json:"status"`
+}
+
 type healthCheckJSON struct {
 	P2P           healthStatus `json:"p2p"`
 	BeaconNode    healthStatus `json:"beacon_node"`
@@ -75,6 +162,7 @@ type healthCheckJSON struct {
 		InboundConns    int      `json:"inbound_conns"`
 		OutboundConns   int      `json:"outbound_conns"`
 		ListenAddresses []string `json:"p2p_listen_addresses"`
+		EventTopics     []string `json:"beacon_event_topics,omitempty"`
 	} `json:"advanced"`
 }
 
@@ -92,6 +180,12 @@ type Node struct {
 	TopicIndex      TopicIndex
 	Network         network.Network
 	NodeProber      *nodeprobe.Prober
+	EventTopics     EventTopicsProvider         // Optional.
+	ConsensusClient ConsensusClientProbe        // Optional.
+	ScoreParams     TopicScoreParamsProvider    // Optional.
+	RoleValidation  RoleValidationSwitch        // Optional.
+	ValidationPause validation.ValidationPauser // Optional.
+	TraceLog        PubsubTraceLogSwitch        // Optional.
 }
 
 func (h *Node) Identity(w http.ResponseWriter, r *http.Request) error {
@@ -126,6 +220,24 @@ func (h *Node) Topics(w http.ResponseWriter, r *http.Request) error {
 	return api.Render(w, r, resp)
 }
 
+// TopicScoreParams responds with the score params that were last computed for each joined topic.
+func (h *Node) TopicScoreParams(w http.ResponseWriter, r *http.Request) error {
+	if h.ScoreParams == nil {
+		return api.ErrNotFound
+	}
+
+	byTopic := h.ScoreParams.TopicScoreParams()
+	resp := make([]topicScoreParamsJSON, 0, len(byTopic))
+	for topic, p := range byTopic {
+		resp = append(resp, newTopicScoreParamsJSON(topic, p))
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].TopicName < resp[j].TopicName
+	})
+
+	return api.Render(w, r, resp)
+}
+
 func (h *Node) Health(w http.ResponseWriter, r *http.Request) error {
 	ctx := context.Background()
 	var resp healthCheckJSON
@@ -162,9 +274,169 @@ func (h *Node) Health(w http.ResponseWriter, r *http.Request) error {
 	resp.ExecutionNode = healthStatus{h.NodeProber.CheckExecutionNodeHealth(ctx)}
 	resp.EventSyncer = healthStatus{(h.NodeProber.CheckEventSyncerHealth(ctx))}
 
+	// Report the beacon event topics we're currently subscribed to.
+	if h.EventTopics != nil {
+		resp.Advanced.EventTopics = h.EventTopics.SubscribedTopics()
+	}
+
 	return api.Render(w, r, resp)
 }
 
+// Live responds successfully as long as the process is up and can reach the beacon node,
+// to be used as a liveness probe.
+func (h *Node) Live(w http.ResponseWriter, r *http.Request) error {
+	if h.ConsensusClient != nil {
+		if err := h.ConsensusClient.Live(r.Context()); err != nil {
+			return api.ServiceUnavailableError(err)
+		}
+	}
+	return api.Render(w, r, probeJSON{Status: "live"})
+}
+
+// Ready responds successfully only when the node can serve duties: the beacon node is synced,
+// non-optimistic and subscribed to events, and the execution node is online.
+// To be used as a readiness probe.
+func (h *Node) Ready(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	beaconNodeReady := h.NodeProber.CheckBeaconNodeHealth
+	if h.ConsensusClient != nil {
+		beaconNodeReady = h.ConsensusClient.Ready
+	}
+	if err := beaconNodeReady(ctx); err != nil {
+		return api.ServiceUnavailableError(fmt.Errorf("beacon node is not ready: %w", err))
+	}
+	if err := h.NodeProber.CheckExecutionNodeHealth(ctx); err != nil {
+		return api.ServiceUnavailableError(fmt.Errorf("execution node is not ready: %w", err))
+	}
+	return api.Render(w, r, probeJSON{Status: "ready"})
+}
+
+type roleValidationJSON struct {
+	DisabledRoles []string `json:"disabled_roles"`
+}
+
+// DisabledRoles responds with the roles whose messages are ignored rather than validated.
+func (h *Node) DisabledRoles(w http.ResponseWriter, r *http.Request) error {
+	if h.RoleValidation == nil {
+		return api.ErrNotFound
+	}
+	return api.Render(w, r, h.roleValidation())
+}
+
+// SetRoleValidation enables or disables the validation of a role's messages, responding with the disabled roles.
+// Messages of a disabled role are ignored, so that they're neither processed nor rebroadcast.
+func (h *Node) SetRoleValidation(w http.ResponseWriter, r *http.Request) error {
+	if h.RoleValidation == nil {
+		return api.ErrNotFound
+	}
+
+	var request struct {
+		Role    string `json:"role" form:"role"`
+		Enabled bool   `json:"enabled" form:"enabled"`
+	}
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	role, err := message.BeaconRoleFromString(request.Role)
+	if err != nil {
+		return api.InvalidRequestError(err)
+	}
+	if err := h.RoleValidation.SetRoleValidation(role, request.Enabled); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	return api.Render(w, r, h.roleValidation())
+}
+
+func (h *Node) roleValidation() roleValidationJSON {
+	roles := h.RoleValidation.DisabledRoles()
+	resp := roleValidationJSON{DisabledRoles: make([]string, len(roles))}
+	for i, role := range roles {
+		resp.DisabledRoles[i] = role.String()
+	}
+	return resp
+}
+
+type validationPauseJSON struct {
+	Paused bool `json:"paused"`
+}
+
+// ValidationPaused responds with whether message validation is paused.
+func (h *Node) ValidationPaused(w http.ResponseWriter, r *http.Request) error {
+	if h.ValidationPause == nil {
+		return api.ErrNotFound
+	}
+	return api.Render(w, r, validationPauseJSON{Paused: h.ValidationPause.Paused()})
+}
+
+// SetValidationPaused pauses or resumes message validation, responding with whether it's paused.
+// While paused, the messages of other peers are ignored, so that they're neither processed nor rebroadcast.
+func (h *Node) SetValidationPaused(w http.ResponseWriter, r *http.Request) error {
+	if h.ValidationPause == nil {
+		return api.ErrNotFound
+	}
+
+	var request struct {
+		Paused bool `json:"paused" form:"paused"`
+	}
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	if request.Paused {
+		h.ValidationPause.Pause()
+	} else {
+		h.ValidationPause.Resume()
+	}
+	return api.Render(w, r, validationPauseJSON{Paused: h.ValidationPause.Paused()})
+}
+
+type pubsubTraceLogJSON struct {
+	Enabled bool     `json:"enabled"`
+	Types   []string `json:"types"`
+}
+
+// PubsubTraceLog responds with whether pubsub trace events are logged, and the logged event types (all if empty).
+func (h *Node) PubsubTraceLog(w http.ResponseWriter, r *http.Request) error {
+	if h.TraceLog == nil {
+		return api.ErrNotFound
+	}
+	return api.Render(w, r, h.pubsubTraceLog())
+}
+
+// SetPubsubTraceLog enables or disables the logging of pubsub trace events, limited to the given
+// comma-separated event types (e.g. "REJECT_MESSAGE,DUPLICATE_MESSAGE"), or all of them if empty.
+func (h *Node) SetPubsubTraceLog(w http.ResponseWriter, r *http.Request) error {
+	if h.TraceLog == nil {
+		return api.ErrNotFound
+	}
+
+	var request struct {
+		Enabled bool   `json:"enabled" form:"enabled"`
+		Types   string `json:"types" form:"types"`
+	}
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	var types []string
+	for _, t := range strings.Split(request.Types, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, strings.ToUpper(t))
+		}
+	}
+	if err := h.TraceLog.SetPubsubTraceLog(request.Enabled, types); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	return api.Render(w, r, h.pubsubTraceLog())
+}
+
+func (h *Node) pubsubTraceLog() pubsubTraceLogJSON {
+	enabled, types := h.TraceLog.PubsubTraceLog()
+	if types == nil {
+		types = []string{}
+	}
+	return pubsubTraceLogJSON{Enabled: enabled, Types: types}
+}
+
 func (h *Node) peers(peers []peer.ID) []peerJSON {
 	resp := make([]peerJSON, len(peers))
 	for i, id := range peers {
@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/nodeprobe"
+)
+
+type testNode struct {
+	live  error
+	ready error
+}
+
+func (n *testNode) Healthy(context.Context) error { return n.ready }
+func (n *testNode) Live(context.Context) error    { return n.live }
+func (n *testNode) Ready(context.Context) error   { return n.ready }
+
+func TestLiveAndReady(t *testing.T) {
+	consensusClient := &testNode{}
+	executionClient := &testNode{}
+	node := &Node{
+		NodeProber: nodeprobe.NewProber(zap.NewNop(), nil, map[string]nodeprobe.Node{
+			"consensus client": consensusClient,
+			"execution client": executionClient,
+		}),
+		ConsensusClient: consensusClient,
+	}
+	status := func(handler api.HandlerFunc) int {
+		w := httptest.NewRecorder()
+		api.Handler(handler)(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusOK, status(node.Live))
+	require.Equal(t, http.StatusOK, status(node.Ready))
+
+	// A syncing beacon node is live but not ready.
+	consensusClient.ready = errors.New("syncing")
+	require.Equal(t, http.StatusOK, status(node.Live))
+	require.Equal(t, http.StatusServiceUnavailable, status(node.Ready))
+
+	// An offline execution node makes the node not ready.
+	consensusClient.ready = nil
+	executionClient.ready = errors.New("offline")
+	require.Equal(t, http.StatusOK, status(node.Live))
+	require.Equal(t, http.StatusServiceUnavailable, status(node.Ready))
+
+	// An unreachable beacon node makes the node not live.
+	consensusClient.live = errors.New("unreachable")
+	require.Equal(t, http.StatusServiceUnavailable, status(node.Live))
+}
+
+type testScoreParams map[string]*pubsub.TopicScoreParams
+
+func (p testScoreParams) TopicScoreParams() map[string]*pubsub.TopicScoreParams { return p }
+
+func TestTopicScoreParams(t *testing.T) {
+	node := &Node{
+		ScoreParams: testScoreParams{
+			"ssv.v2.2": {TopicWeight: 0.5, TimeInMeshQuantum: 12 * time.Second, MeshMessageDeliveriesThreshold: 10},
+			"ssv.v2.1": {TopicWeight: 0.5, TimeInMeshQuantum: 12 * time.Second, MeshMessageDeliveriesThreshold: 20},
+		},
+	}
+
+	w := httptest.NewRecorder()
+	api.Handler(node.TopicScoreParams)(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp []topicScoreParamsJSON
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp, 2)
+	require.Equal(t, "ssv.v2.1", resp[0].TopicName)
+	require.Equal(t, 20.0, resp[0].MeshMessageDeliveriesThreshold)
+	require.Equal(t, "12s", resp[0].TimeInMeshQuantum)
+	require.Equal(t, "ssv.v2.2", resp[1].TopicName)
+
+	// Without scoring, there are no score params to show.
+	w = httptest.NewRecorder()
+	api.Handler((&Node{}).TopicScoreParams)(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+type testRoleValidation map[spectypes.BeaconRole]bool
+
+func (v testRoleValidation) SetRoleValidation(role spectypes.BeaconRole, enabled bool) error {
+	if role > spectypes.BNRoleVoluntaryExit {
+		return errors.New("invalid role")
+	}
+	if enabled {
+		delete(v, role)
+	} else {
+		v[role] = true
+	}
+	return nil
+}
+
+func (v testRoleValidation) DisabledRoles() []spectypes.BeaconRole {
+	var roles []spectypes.BeaconRole
+	for role := range v {
+		roles = append(roles, role)
+	}
+	return roles
+}
+
+func TestRoleValidation(t *testing.T) {
+	node := &Node{RoleValidation: testRoleValidation{}}
+	set := func(form url.Values) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		api.Handler(node.SetRoleValidation)(w, r)
+		return w
+	}
+
+	w := set(url.Values{"role": {"ATTESTER"}, "enabled": {"false"}})
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp roleValidationJSON
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, []string{"ATTESTER"}, resp.DisabledRoles)
+
+	w = httptest.NewRecorder()
+	api.Handler(node.DisabledRoles)(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, []string{"ATTESTER"}, resp.DisabledRoles)
+
+	w = set(url.Values{"role": {"ATTESTER"}, "enabled": {"true"}})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Empty(t, resp.DisabledRoles)
+
+	// Unknown roles are rejected.
+	require.Equal(t, http.StatusBadRequest, set(url.Values{"role": {"FOO"}, "enabled": {"false"}}).Code)
+
+	// Without a message validator, there are no roles to toggle.
+	w = httptest.NewRecorder()
+	api.Handler((&Node{}).DisabledRoles)(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+type testTraceLog struct {
+	enabled bool
+	types   []string
+}
+
+func (l *testTraceLog) PubsubTraceLog() (bool, []string) {
+	return l.enabled, l.types
+}
+
+func (l *testTraceLog) SetPubsubTraceLog(enabled bool, types []string) error {
+	for _, t := range types {
+		if t != "GRAFT" && t != "PRUNE" {
+			return errors.New("unknown pubsub event type")
+		}
+	}
+	l.enabled, l.types = enabled, types
+	return nil
+}
+
+func TestPubsubTraceLog(t *testing.T) {
+	node := &Node{TraceLog: &testTraceLog{}}
+	set := func(form url.Values) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		api.Handler(node.SetPubsubTraceLog)(w, r)
+		return w
+	}
+
+	w := set(url.Values{"enabled": {"true"}, "types": {"graft, PRUNE"}})
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp pubsubTraceLogJSON
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, pubsubTraceLogJSON{Enabled: true, Types: []string{"GRAFT", "PRUNE"}}, resp)
+
+	// Without types, all of them are logged.
+	w = set(url.Values{"enabled": {"true"}})
+	require.Equal(t, http.StatusOK, w.Code)
+	w = httptest.NewRecorder()
+	api.Handler(node.PubsubTraceLog)(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, pubsubTraceLogJSON{Enabled: true, Types: []string{}}, resp)
+
+	// Unknown types are rejected.
+	require.Equal(t, http.StatusBadRequest, set(url.Values{"enabled": {"true"}, "types": {"FOO"}}).Code)
+
+	w = httptest.NewRecorder()
+	api.Handler((&Node{}).PubsubTraceLog)(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+type testValidationPause struct {
+	paused bool
+}
+
+func (p *testValidationPause) Pause()       { p.paused = true }
+func (p *testValidationPause) Resume()      { p.paused = false }
+func (p *testValidationPause) Paused() bool { return p.paused }
+
+func TestValidationPause(t *testing.T) {
+	node := &Node{ValidationPause: &testValidationPause{}}
+	set := func(form url.Values) validationPauseJSON {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		api.Handler(node.SetValidationPaused)(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp validationPauseJSON
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp
+	}
+
+	require.True(t, set(url.Values{"paused": {"true"}}).Paused)
+
+	w := httptest.NewRecorder()
+	api.Handler(node.ValidationPaused)(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp validationPauseJSON
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.True(t, resp.Paused)
+
+	require.False(t, set(url.Values{"paused": {"false"}}).Paused)
+
+	// Without a message validator, there's no validation to pause.
+	w = httptest.NewRecorder()
+	api.Handler((&Node{}).ValidationPaused)(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
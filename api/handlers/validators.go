@@ -2,20 +2,29 @@ package handlers
 
 import (
 	"bytes"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 
 	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/beacon/goclient"
 	"github.com/bloxapp/ssv/protocol/v2/types"
 	registrystorage "github.com/bloxapp/ssv/registry/storage"
 )
 
+// RegistrationStatusProvider provides the state of validators' cached registrations.
+type RegistrationStatusProvider interface {
+	RegistrationStatus(pubkey phase0.BLSPubKey) (*goclient.RegistrationInfo, bool)
+}
+
 type Validators struct {
-	Shares registrystorage.Shares
+	Shares        registrystorage.Shares
+	Registrations RegistrationStatusProvider // Optional.
 }
 
 func (h *Validators) List(w http.ResponseWriter, r *http.Request) error {
@@ -63,6 +72,50 @@ func (h *Validators) List(w http.ResponseWriter, r *http.Request) error {
 	return api.Render(w, r, response)
 }
 
+type registrationJSON struct {
+	PubKey            api.Hex     `json:"public_key"`
+	Cached            bool        `json:"cached"`
+	FeeRecipient      api.Hex     `json:"fee_recipient,omitempty"`
+	GasLimit          uint64      `json:"gas_limit,omitempty"`
+	Timestamp         *time.Time  `json:"timestamp,omitempty"`
+	Pending           bool        `json:"pending"`
+	Submitted         bool        `json:"submitted"`
+	LastSubmittedSlot phase0.Slot `json:"last_submitted_slot,omitempty"`
+}
+
+// Registration responds with the state of the validator's cached registration.
+func (h *Validators) Registration(w http.ResponseWriter, r *http.Request) error {
+	if h.Registrations == nil {
+		return api.ErrNotFound
+	}
+
+	var request struct {
+		PubKey api.Hex `json:"pubkey" form:"pubkey"`
+	}
+	if err := api.Bind(r, &request); err != nil {
+		return err
+	}
+	if len(request.PubKey) != len(phase0.BLSPubKey{}) {
+		return api.InvalidRequestError(fmt.Errorf("pubkey must be %d bytes", len(phase0.BLSPubKey{})))
+	}
+
+	var pubkey phase0.BLSPubKey
+	copy(pubkey[:], request.PubKey)
+
+	resp := registrationJSON{PubKey: request.PubKey}
+	info, ok := h.Registrations.RegistrationStatus(pubkey)
+	if ok {
+		resp.Cached = true
+		resp.FeeRecipient = api.Hex(info.FeeRecipient[:])
+		resp.GasLimit = info.GasLimit
+		resp.Timestamp = &info.Timestamp
+		resp.Pending = info.Pending
+		resp.Submitted = info.Submitted
+		resp.LastSubmittedSlot = info.LastSubmittedSlot
+	}
+	return api.Render(w, r, resp)
+}
+
 func byOwners(owners []api.Hex) registrystorage.SharesFilter {
 	return func(share *types.SSVShare) bool {
 		for _, a := range owners {
@@ -1,9 +1,20 @@
 package handlers
 
 import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/beacon/goclient"
 	"github.com/bloxapp/ssv/protocol/v2/types"
 )
 
@@ -92,3 +103,60 @@ func TestByClusters(t *testing.T) {
 		})
 	}
 }
+
+type testRegistrations map[phase0.BLSPubKey]*goclient.RegistrationInfo
+
+func (r testRegistrations) RegistrationStatus(pubkey phase0.BLSPubKey) (*goclient.RegistrationInfo, bool) {
+	info, ok := r[pubkey]
+	return info, ok
+}
+
+func TestRegistration(t *testing.T) {
+	cached := phase0.BLSPubKey{1}
+	feeRecipient := bellatrix.ExecutionAddress{2}
+	validators := &Validators{
+		Registrations: testRegistrations{
+			cached: {
+				FeeRecipient:      feeRecipient,
+				GasLimit:          30_000_000,
+				Timestamp:         time.Unix(1700000000, 0).UTC(),
+				Submitted:         true,
+				LastSubmittedSlot: 100,
+			},
+		},
+	}
+	get := func(validators *Validators, pubkey string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		api.Handler(validators.Registration)(w, httptest.NewRequest(http.MethodGet, "/?pubkey="+pubkey, nil))
+		return w
+	}
+
+	w := get(validators, hex.EncodeToString(cached[:]))
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp registrationJSON
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.True(t, resp.Cached)
+	require.Equal(t, api.Hex(cached[:]), resp.PubKey)
+	require.Equal(t, api.Hex(feeRecipient[:]), resp.FeeRecipient)
+	require.EqualValues(t, 30_000_000, resp.GasLimit)
+	require.False(t, resp.Pending)
+	require.True(t, resp.Submitted)
+	require.EqualValues(t, 100, resp.LastSubmittedSlot)
+
+	// Validators without a cached registration are reported as such.
+	uncached := phase0.BLSPubKey{3}
+	w = get(validators, hex.EncodeToString(uncached[:]))
+	require.Equal(t, http.StatusOK, w.Code)
+	resp = registrationJSON{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.False(t, resp.Cached)
+	require.Nil(t, resp.Timestamp)
+
+	// Malformed public keys are rejected.
+	w = get(validators, "0102")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Without a registration provider, there is nothing to show.
+	w = get(&Validators{}, hex.EncodeToString(cached[:]))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -14,45 +14,82 @@ import (
 )
 
 type Server struct {
-	logger *zap.Logger
-	addr   string
+	logger    *zap.Logger
+	addr      string
+	adminAddr string
 
 	node       *handlers.Node
 	validators *handlers.Validators
 }
 
+// New creates a server serving the SSV API at addr. Endpoints which change the node's state or expose
+// its internals are served separately at adminAddr, see RunAdmin. Either may be served without the other.
 func New(
 	logger *zap.Logger,
 	addr string,
+	adminAddr string,
 	node *handlers.Node,
 	validators *handlers.Validators,
 ) *Server {
 	return &Server{
 		logger:     logger,
 		addr:       addr,
+		adminAddr:  adminAddr,
 		node:       node,
 		validators: validators,
 	}
 }
 
 func (s *Server) Run() error {
-	router := chi.NewRouter()
-	router.Use(middleware.Recoverer)
-	router.Use(middleware.Throttle(runtime.NumCPU() * 4))
-	router.Use(middleware.Compress(5, "application/json"))
-	router.Use(middlewareLogger(s.logger))
+	s.logger.Info("Serving SSV API", zap.String("addr", s.addr))
+	return s.serve(s.addr, s.router())
+}
 
+// RunAdmin serves the endpoints which change the node's state or expose its internals. As they aren't authenticated,
+// adminAddr is expected to be reachable only by the node's operator, e.g. on localhost.
+func (s *Server) RunAdmin() error {
+	s.logger.Info("Serving SSV admin API", zap.String("addr", s.adminAddr))
+	return s.serve(s.adminAddr, s.adminRouter())
+}
+
+func (s *Server) router() http.Handler {
+	router := s.newRouter()
 	router.Get("/v1/node/identity", api.Handler(s.node.Identity))
 	router.Get("/v1/node/peers", api.Handler(s.node.Peers))
 	router.Get("/v1/node/topics", api.Handler(s.node.Topics))
 	router.Get("/v1/node/health", api.Handler(s.node.Health))
+	router.Get("/v1/node/live", api.Handler(s.node.Live))
+	router.Get("/v1/node/ready", api.Handler(s.node.Ready))
+	router.Get("/v1/node/validation/roles", api.Handler(s.node.DisabledRoles))
+	router.Get("/v1/node/validation/pause", api.Handler(s.node.ValidationPaused))
+	router.Get("/v1/node/pubsub/trace", api.Handler(s.node.PubsubTraceLog))
 	router.Get("/v1/validators", api.Handler(s.validators.List))
+	return router
+}
 
-	s.logger.Info("Serving SSV API", zap.String("addr", s.addr))
+func (s *Server) adminRouter() http.Handler {
+	router := s.newRouter()
+	router.Get("/v1/node/topics/scoring", api.Handler(s.node.TopicScoreParams))
+	router.Get("/v1/validators/registration", api.Handler(s.validators.Registration))
+	router.Post("/v1/node/validation/roles", api.Handler(s.node.SetRoleValidation))
+	router.Post("/v1/node/validation/pause", api.Handler(s.node.SetValidationPaused))
+	router.Post("/v1/node/pubsub/trace", api.Handler(s.node.SetPubsubTraceLog))
+	return router
+}
+
+func (s *Server) newRouter() *chi.Mux {
+	router := chi.NewRouter()
+	router.Use(middleware.Recoverer)
+	router.Use(middleware.Throttle(runtime.NumCPU() * 4))
+	router.Use(middleware.Compress(5, "application/json"))
+	router.Use(middlewareLogger(s.logger))
+	return router
+}
 
+func (s *Server) serve(addr string, handler http.Handler) error {
 	server := &http.Server{
-		Addr:         s.addr,
-		Handler:      router,
+		Addr:         addr,
+		Handler:      handler,
 		ReadTimeout:  12 * time.Second,
 		WriteTimeout: 12 * time.Second,
 	}
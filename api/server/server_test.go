@@ -0,0 +1,95 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/api/handlers"
+)
+
+type testRoleValidation struct {
+	disabled []spectypes.BeaconRole
+}
+
+func (v *testRoleValidation) SetRoleValidation(role spectypes.BeaconRole, enabled bool) error {
+	if !enabled {
+		v.disabled = append(v.disabled, role)
+	}
+	return nil
+}
+
+func (v *testRoleValidation) DisabledRoles() []spectypes.BeaconRole {
+	return v.disabled
+}
+
+type testValidationPause struct {
+	paused bool
+}
+
+func (p *testValidationPause) Pause()       { p.paused = true }
+func (p *testValidationPause) Resume()      { p.paused = false }
+func (p *testValidationPause) Paused() bool { return p.paused }
+
+type testTraceLog struct {
+	enabled bool
+}
+
+func (l *testTraceLog) PubsubTraceLog() (bool, []string) { return l.enabled, nil }
+
+func (l *testTraceLog) SetPubsubTraceLog(enabled bool, _ []string) error {
+	l.enabled = enabled
+	return nil
+}
+
+func TestAdminRoutes(t *testing.T) {
+	roleValidation := &testRoleValidation{}
+	validationPause := &testValidationPause{}
+	traceLog := &testTraceLog{}
+	s := New(zap.NewNop(), "", "", &handlers.Node{
+		RoleValidation:  roleValidation,
+		ValidationPause: validationPause,
+		TraceLog:        traceLog,
+	}, &handlers.Validators{})
+
+	post := func(router http.Handler, path, body string) int {
+		r := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w.Code
+	}
+	get := func(router http.Handler, path string) int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w.Code
+	}
+
+	// Endpoints which change the node's state are served only by the admin API.
+	require.Equal(t, http.StatusMethodNotAllowed, post(s.router(), "/v1/node/validation/roles", `{"role":"ATTESTER","enabled":false}`))
+	require.Empty(t, roleValidation.disabled)
+
+	require.Equal(t, http.StatusOK, post(s.adminRouter(), "/v1/node/validation/roles", `{"role":"ATTESTER","enabled":false}`))
+	require.Equal(t, []spectypes.BeaconRole{spectypes.BNRoleAttester}, roleValidation.disabled)
+
+	require.Equal(t, http.StatusMethodNotAllowed, post(s.router(), "/v1/node/validation/pause", `{"paused":true}`))
+	require.False(t, validationPause.paused)
+
+	require.Equal(t, http.StatusOK, post(s.adminRouter(), "/v1/node/validation/pause", `{"paused":true}`))
+	require.True(t, validationPause.paused)
+
+	require.Equal(t, http.StatusMethodNotAllowed, post(s.router(), "/v1/node/pubsub/trace", `{"enabled":true}`))
+	require.False(t, traceLog.enabled)
+
+	require.Equal(t, http.StatusOK, post(s.adminRouter(), "/v1/node/pubsub/trace", `{"enabled":true}`))
+	require.True(t, traceLog.enabled)
+
+	// Endpoints which expose the node's internals are served only by the admin API.
+	require.Equal(t, http.StatusNotFound, get(s.router(), "/v1/node/topics/scoring"))
+	require.Equal(t, http.StatusNotFound, get(s.router(), "/v1/validators/registration"))
+}
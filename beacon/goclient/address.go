@@ -0,0 +1,40 @@
+package goclient
+
+import (
+	"net/url"
+	"strings"
+)
+
+// redactedValue replaces the parts of an address which may hold secrets.
+const redactedValue = "xxxxx"
+
+// RedactAddress returns the beacon node address for logging, keeping its scheme and host:port
+// but masking any credentials within it: the userinfo, the path (which some providers use for API keys),
+// and the values of the query parameters.
+func RedactAddress(address string) string {
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		// The address can't be taken apart, so none of it is safe to log.
+		return redactedValue
+	}
+
+	if u.User != nil {
+		u.User = url.User(redactedValue)
+	}
+	if strings.Trim(u.Path, "/") != "" {
+		u.Path = "/" + redactedValue
+		u.RawPath = ""
+	}
+	if u.RawQuery != "" {
+		query := u.Query()
+		for key := range query {
+			query[key] = []string{redactedValue}
+		}
+		u.RawQuery = query.Encode()
+	}
+	u.Fragment = ""
+	return u.String()
+}
@@ -1,25 +1,46 @@
 package goclient
 
 import (
+	"context"
 	"encoding/binary"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
 	ssz "github.com/ferranbt/fastssz"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
 )
 
+var metricsAggregationCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "ssv_beacon_aggregation_cache_lookups",
+	Help: "Count of aggregation decisions by whether they were served from the cache",
+}, []string{"result"})
+
+func init() {
+	if err := prometheus.Register(metricsAggregationCacheLookups); err != nil {
+		zap.L().Debug("could not register prometheus collector")
+	}
+}
+
 // SubmitAggregateSelectionProof returns an AggregateAndProof object
 func (gc *goClient) SubmitAggregateSelectionProof(slot phase0.Slot, committeeIndex phase0.CommitteeIndex, committeeLength uint64, index phase0.ValidatorIndex, slotSig []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	if err := gc.checkEndpoint(endpointAggregation); err != nil {
+		return nil, DataVersionNil, err
+	}
+
 	// As specified in spec, an aggregator should wait until two thirds of the way through slot
 	// to broadcast the best aggregate to the global aggregate channel.
 	// https://github.com/ethereum/consensus-specs/blob/v0.9.3/specs/validator/0_beacon-chain-validator.md#broadcast-aggregate
 	gc.waitToSlotTwoThirds(slot)
 
 	// differ from spec because we need to subscribe to subnet
-	isAggregator, err := isAggregator(committeeLength, slotSig)
+	isAggregator, err := gc.isAggregator(slot, index, committeeLength, slotSig)
 	if err != nil {
 		return nil, DataVersionNil, fmt.Errorf("failed to check if validator is an aggregator: %w", err)
 	}
@@ -45,11 +66,20 @@ func (gc *goClient) SubmitAggregateSelectionProof(slot phase0.Slot, committeeInd
 		return nil, DataVersionNil, fmt.Errorf("failed to get attestation data root: %w", err)
 	}
 
-	aggDataReqStart := time.Now()
-	aggDataResp, err := gc.client.AggregateAttestation(gc.ctx, &api.AggregateAttestationOpts{
-		Slot:                slot,
-		AttestationDataRoot: root,
+	ctx, cancel := context.WithTimeout(gc.ctx, gc.timeouts.aggregateAttestation)
+	defer cancel()
+
+	var aggDataResp *api.Response[*phase0.Attestation]
+	span := gc.startRequest(ctx, spectypes.BNRoleAggregator, slot, "aggregate_attestation", metricsAggregatorDataRequest)
+	err = gc.fetchDutyData(spectypes.BNRoleAggregator, slot, "aggregate_attestation", func(client Client) (err error) {
+		aggDataResp, err = client.AggregateAttestation(span.ctx, &api.AggregateAttestationOpts{
+			Slot:                slot,
+			AttestationDataRoot: root,
+			Common:              api.CommonOpts{Timeout: gc.timeouts.aggregateAttestation},
+		})
+		return err
 	})
+	span.end(err)
 	if err != nil {
 		return nil, DataVersionNil, fmt.Errorf("failed to get aggregate attestation: %w", err)
 	}
@@ -60,8 +90,6 @@ func (gc *goClient) SubmitAggregateSelectionProof(slot phase0.Slot, committeeInd
 		return nil, DataVersionNil, fmt.Errorf("aggregate attestation data is nil")
 	}
 
-	metricsAggregatorDataRequest.Observe(time.Since(aggDataReqStart).Seconds())
-
 	var selectionProof phase0.BLSSignature
 	copy(selectionProof[:], slotSig)
 
@@ -74,7 +102,90 @@ func (gc *goClient) SubmitAggregateSelectionProof(slot phase0.Slot, committeeInd
 
 // SubmitSignedAggregateSelectionProof broadcasts a signed aggregator msg
 func (gc *goClient) SubmitSignedAggregateSelectionProof(msg *phase0.SignedAggregateAndProof) error {
-	return gc.client.SubmitAggregateAttestations(gc.ctx, []*phase0.SignedAggregateAndProof{msg})
+	if err := gc.checkEndpoint(endpointAggregation); err != nil {
+		return err
+	}
+
+	if msg.Message == nil || msg.Message.Aggregate == nil || msg.Message.Aggregate.Data == nil {
+		return fmt.Errorf("aggregate and proof is incomplete")
+	}
+
+	slot := msg.Message.Aggregate.Data.Slot
+	if err := gc.submitDuty(gc.ctx, submissionAttestation, spectypes.BNRoleAggregator, slot, func(client Client) error {
+		return client.SubmitAggregateAttestations(gc.ctx, []*phase0.SignedAggregateAndProof{msg})
+	}); err != nil {
+		return err
+	}
+
+	dutySubmitted(spectypes.BNRoleAggregator)
+	return nil
+}
+
+// isAggregator returns whether the validator is an aggregator in the given slot, caching the decision
+// for the rest of the slot if the aggregation cache is enabled.
+func (gc *goClient) isAggregator(slot phase0.Slot, index phase0.ValidatorIndex, committeeLength uint64, slotSig []byte) (bool, error) {
+	if gc.aggregationCache == nil {
+		return isAggregator(committeeLength, slotSig)
+	}
+
+	if aggregator, ok := gc.aggregationCache.get(slot, index); ok {
+		metricsAggregationCacheLookups.WithLabelValues("hit").Inc()
+		return aggregator, nil
+	}
+	metricsAggregationCacheLookups.WithLabelValues("miss").Inc()
+
+	aggregator, err := isAggregator(committeeLength, slotSig)
+	if err != nil {
+		return false, err
+	}
+	gc.aggregationCache.set(slot, index, aggregator)
+	return aggregator, nil
+}
+
+// aggregationCache caches the aggregation decisions of the latest slot by validator,
+// since a validator's selection proof and thus its decision are fixed within a slot.
+type aggregationCache struct {
+	mu        sync.Mutex
+	size      int
+	slot      phase0.Slot
+	decisions map[phase0.ValidatorIndex]bool
+}
+
+// newAggregationCache returns an aggregationCache of up to size decisions per slot.
+func newAggregationCache(size int) *aggregationCache {
+	return &aggregationCache{
+		size:      size,
+		decisions: map[phase0.ValidatorIndex]bool{},
+	}
+}
+
+func (c *aggregationCache) get(slot phase0.Slot, index phase0.ValidatorIndex) (aggregator, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if slot != c.slot {
+		return false, false
+	}
+	aggregator, ok = c.decisions[index]
+	return aggregator, ok
+}
+
+// set caches the decision, dropping the decisions of earlier slots. Decisions of earlier slots
+// than the cached ones, or beyond the cache's size, aren't cached.
+func (c *aggregationCache) set(slot phase0.Slot, index phase0.ValidatorIndex, aggregator bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case slot > c.slot:
+		c.slot = slot
+		c.decisions = map[phase0.ValidatorIndex]bool{}
+	case slot < c.slot:
+		return
+	}
+	if len(c.decisions) < c.size {
+		c.decisions[index] = aggregator
+	}
 }
 
 // IsAggregator returns true if the signature is from the input validator. The committee
@@ -0,0 +1,73 @@
+package goclient
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregationCache(t *testing.T) {
+	cache := newAggregationCache(2)
+
+	cache.set(10, 1, true)
+	cache.set(10, 2, false)
+	aggregator, ok := cache.get(10, 1)
+	require.True(t, ok)
+	require.True(t, aggregator)
+	aggregator, ok = cache.get(10, 2)
+	require.True(t, ok)
+	require.False(t, aggregator)
+
+	// Decisions beyond the size aren't cached.
+	cache.set(10, 3, true)
+	_, ok = cache.get(10, 3)
+	require.False(t, ok)
+
+	// Decisions of earlier slots are dropped on slot rollover.
+	cache.set(11, 3, true)
+	_, ok = cache.get(10, 1)
+	require.False(t, ok)
+	_, ok = cache.get(11, 3)
+	require.True(t, ok)
+
+	// Late decisions of earlier slots aren't cached.
+	cache.set(10, 1, true)
+	_, ok = cache.get(10, 1)
+	require.False(t, ok)
+}
+
+func TestIsAggregatorCached(t *testing.T) {
+	gc := &goClient{aggregationCache: newAggregationCache(100)}
+	slotSig := make([]byte, 96)
+	want, err := isAggregator(128, slotSig)
+	require.NoError(t, err)
+
+	hits := func() float64 {
+		return testutil.ToFloat64(metricsAggregationCacheLookups.WithLabelValues("hit"))
+	}
+	misses := func() float64 {
+		return testutil.ToFloat64(metricsAggregationCacheLookups.WithLabelValues("miss"))
+	}
+	initialHits, initialMisses := hits(), misses()
+
+	// Parallel aggregator runners check their own validators concurrently.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(index phase0.ValidatorIndex) {
+			defer wg.Done()
+			for j := 0; j < 3; j++ {
+				aggregator, err := gc.isAggregator(5, index, 128, slotSig)
+				require.NoError(t, err)
+				require.Equal(t, want, aggregator)
+			}
+		}(phase0.ValidatorIndex(i))
+	}
+	wg.Wait()
+
+	require.Equal(t, initialMisses+10, misses())
+	require.Equal(t, initialHits+20, hits())
+}
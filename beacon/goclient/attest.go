@@ -16,9 +16,13 @@ import (
 
 // AttesterDuties returns attester duties for a given epoch.
 func (gc *goClient) AttesterDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*eth2apiv1.AttesterDuty, error) {
-	resp, err := gc.client.AttesterDuties(ctx, &api.AttesterDutiesOpts{
-		Epoch:   epoch,
-		Indices: validatorIndices,
+	var resp *api.Response[[]*eth2apiv1.AttesterDuty]
+	err := gc.route(gc.readNode, readRequestDuties, func(client Client) (err error) {
+		resp, err = client.AttesterDuties(ctx, &api.AttesterDutiesOpts{
+			Epoch:   epoch,
+			Indices: validatorIndices,
+		})
+		return err
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to obtain attester duties: %w", err)
@@ -27,29 +31,55 @@ func (gc *goClient) AttesterDuties(ctx context.Context, epoch phase0.Epoch, vali
 		return nil, fmt.Errorf("attester duties response is nil")
 	}
 
+	gc.duties.assignAttesterDuties(gc.network.EstimatedCurrentSlot(), resp.Data)
+	gc.flagWarmUp("attester_duties")
 	return resp.Data, nil
 }
 
 func (gc *goClient) GetAttestationData(slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (ssz.Marshaler, spec.DataVersion, error) {
-	attDataReqStart := time.Now()
-	resp, err := gc.client.AttestationData(gc.ctx, &api.AttestationDataOpts{
-		Slot:           slot,
-		CommitteeIndex: committeeIndex,
+	if err := gc.checkEndpoint(endpointAttestation); err != nil {
+		return nil, DataVersionNil, err
+	}
+
+	// Give proposer boost a chance to be reflected in the head during contested slots.
+	gc.waitForSlotHead(gc.ctx, slot)
+
+	ctx, cancel := context.WithTimeout(gc.ctx, gc.timeouts.attestationData)
+	defer cancel()
+
+	headChanged := gc.attestationHeadWatch()
+	var resp *api.Response[*phase0.AttestationData]
+	span := gc.startRequest(ctx, spectypes.BNRoleAttester, slot, "attestation_data", metricsAttesterDataRequest)
+	err := gc.fetchDutyData(spectypes.BNRoleAttester, slot, "attestation_data", func(client Client) (err error) {
+		resp, err = client.AttestationData(span.ctx, &api.AttestationDataOpts{
+			Slot:           slot,
+			CommitteeIndex: committeeIndex,
+			Common:         api.CommonOpts{Timeout: gc.timeouts.attestationData},
+		})
+		return err
 	})
+	span.end(err)
 	if err != nil {
 		return nil, DataVersionNil, fmt.Errorf("failed to get attestation data: %w", err)
 	}
 	if resp == nil {
 		return nil, DataVersionNil, fmt.Errorf("attestation data response is nil")
 	}
-
-	metricsAttesterDataRequest.Observe(time.Since(attDataReqStart).Seconds())
+	gc.checkAttestationDataHead(resp.Data, headChanged)
 
 	return resp.Data, spec.DataVersionPhase0, nil
 }
 
 // SubmitAttestation implements Beacon interface
 func (gc *goClient) SubmitAttestation(attestation *phase0.Attestation) error {
+	if err := gc.checkEndpoint(endpointAttestation); err != nil {
+		return err
+	}
+
+	if err := gc.attestationSanityCheck(attestation); err != nil {
+		return err
+	}
+
 	signingRoot, err := gc.getSigningRoot(attestation.Data)
 	if err != nil {
 		return errors.Wrap(err, "failed to get signing root")
@@ -59,7 +89,30 @@ func (gc *goClient) SubmitAttestation(attestation *phase0.Attestation) error {
 		return errors.Wrap(err, "failed attestation slashing protection check")
 	}
 
-	return gc.client.SubmitAttestations(gc.ctx, []*phase0.Attestation{attestation})
+	if gc.attestationBatcher != nil {
+		err = gc.attestationBatcher.Submit(gc.ctx, attestation)
+	} else {
+		err = gc.submitAttestations(gc.ctx, []*phase0.Attestation{attestation})
+	}
+	if err != nil {
+		return err
+	}
+
+	dutySubmitted(spectypes.BNRoleAttester)
+	gc.duties.submitAttestation(attestation)
+	return nil
+}
+
+func (gc *goClient) submitAttestations(ctx context.Context, attestations []*phase0.Attestation) error {
+	return gc.submitDuty(ctx, submissionAttestation, spectypes.BNRoleAttester, attestations[0].Data.Slot, func(client Client) error {
+		return client.SubmitAttestations(ctx, attestations)
+	})
+}
+
+// attestationSubmissionDeadline returns the time by which attestations of the given slot
+// should be submitted in order to be aggregated, which happens at 2/3 of the slot.
+func (gc *goClient) attestationSubmissionDeadline(slot phase0.Slot) time.Time {
+	return gc.slotStartTime(slot).Add(gc.network.SlotDurationSec() * 2 / time.Duration(IntervalsPerSlot))
 }
 
 // getSigningRoot returns signing root
@@ -0,0 +1,101 @@
+package goclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var metricsAttestationBatchSize = promauto.NewHistogram(prometheus.HistogramOpts{
+	Name:    "ssv_beacon_attestation_batch_size",
+	Help:    "Number of attestations submitted to the beacon node in a single request",
+	Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500},
+})
+
+func init() {
+	logger := zap.L()
+	if err := prometheus.Register(metricsAttestationBatchSize); err != nil {
+		logger.Debug("could not register prometheus collector")
+	}
+}
+
+// attestationBatcher accumulates attestations submitted within a short window
+// and submits them to the beacon node in a single request.
+type attestationBatcher struct {
+	window   time.Duration
+	deadline func(slot phase0.Slot) time.Time // latest time to submit an attestation of the slot
+	submit   func(ctx context.Context, attestations []*phase0.Attestation) error
+
+	mu    sync.Mutex
+	batch *attestationBatch
+}
+
+type attestationBatch struct {
+	attestations []*phase0.Attestation
+	flushAt      time.Time
+	timer        *time.Timer
+	done         chan struct{} // closed once the batch is submitted
+	err          error
+}
+
+func newAttestationBatcher(
+	window time.Duration,
+	deadline func(slot phase0.Slot) time.Time,
+	submit func(ctx context.Context, attestations []*phase0.Attestation) error,
+) *attestationBatcher {
+	return &attestationBatcher{
+		window:   window,
+		deadline: deadline,
+		submit:   submit,
+	}
+}
+
+// Submit adds the attestation to the current batch and blocks until the batch is submitted.
+// The batch is submitted once the window passes, or earlier if the deadline of any of its attestations is reached.
+func (b *attestationBatcher) Submit(ctx context.Context, attestation *phase0.Attestation) error {
+	flushAt := time.Now().Add(b.window)
+	if deadline := b.deadline(attestation.Data.Slot); deadline.Before(flushAt) {
+		flushAt = deadline
+	}
+
+	b.mu.Lock()
+	batch := b.batch
+	if batch == nil {
+		batch = &attestationBatch{
+			flushAt: flushAt,
+			done:    make(chan struct{}),
+		}
+		batch.timer = time.AfterFunc(time.Until(flushAt), func() {
+			b.flush(ctx, batch)
+		})
+		b.batch = batch
+	} else if flushAt.Before(batch.flushAt) && batch.timer.Stop() {
+		// If the timer already fired, the batch is being flushed and will include this attestation.
+		batch.flushAt = flushAt
+		batch.timer.Reset(time.Until(flushAt))
+	}
+	batch.attestations = append(batch.attestations, attestation)
+	b.mu.Unlock()
+
+	<-batch.done
+	return batch.err
+}
+
+func (b *attestationBatcher) flush(ctx context.Context, batch *attestationBatch) {
+	b.mu.Lock()
+	if b.batch == batch {
+		b.batch = nil
+	}
+	attestations := batch.attestations
+	b.mu.Unlock()
+
+	metricsAttestationBatchSize.Observe(float64(len(attestations)))
+
+	batch.err = b.submit(ctx, attestations)
+	close(batch.done)
+}
@@ -0,0 +1,120 @@
+package goclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]*phase0.Attestation
+	err     error
+}
+
+func (r *batchRecorder) submit(ctx context.Context, attestations []*phase0.Attestation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, attestations)
+	return r.err
+}
+
+func (r *batchRecorder) batchSizes() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var sizes []int
+	for _, batch := range r.batches {
+		sizes = append(sizes, len(batch))
+	}
+	return sizes
+}
+
+func TestAttestationBatcher(t *testing.T) {
+	ctx := context.Background()
+	newAttestation := func(slot phase0.Slot) *phase0.Attestation {
+		return &phase0.Attestation{Data: &phase0.AttestationData{Slot: slot}}
+	}
+	submitAll := func(batcher *attestationBatcher, attestations ...*phase0.Attestation) []error {
+		errs := make([]error, len(attestations))
+		var wg sync.WaitGroup
+		for i, attestation := range attestations {
+			wg.Add(1)
+			go func(i int, attestation *phase0.Attestation) {
+				defer wg.Done()
+				errs[i] = batcher.Submit(ctx, attestation)
+			}(i, attestation)
+		}
+		wg.Wait()
+		return errs
+	}
+	farDeadline := func(phase0.Slot) time.Time {
+		return time.Now().Add(time.Hour)
+	}
+
+	t.Run("batches attestations within the window", func(t *testing.T) {
+		recorder := &batchRecorder{}
+		batcher := newAttestationBatcher(100*time.Millisecond, farDeadline, recorder.submit)
+
+		errs := submitAll(batcher, newAttestation(1), newAttestation(1), newAttestation(1))
+		require.Equal(t, []error{nil, nil, nil}, errs)
+		require.Equal(t, []int{3}, recorder.batchSizes())
+
+		// A new batch starts after the previous one is submitted.
+		require.NoError(t, batcher.Submit(ctx, newAttestation(2)))
+		require.Equal(t, []int{3, 1}, recorder.batchSizes())
+	})
+
+	t.Run("submits before the deadline", func(t *testing.T) {
+		recorder := &batchRecorder{}
+		deadline := time.Now().Add(50 * time.Millisecond)
+		batcher := newAttestationBatcher(time.Hour, func(phase0.Slot) time.Time {
+			return deadline
+		}, recorder.submit)
+
+		start := time.Now()
+		require.NoError(t, batcher.Submit(ctx, newAttestation(1)))
+		require.Less(t, time.Since(start), time.Second)
+		require.Equal(t, []int{1}, recorder.batchSizes())
+	})
+
+	t.Run("earlier deadline flushes the batch", func(t *testing.T) {
+		recorder := &batchRecorder{}
+		pastDeadline := time.Now().Add(-time.Second)
+		batcher := newAttestationBatcher(time.Hour, func(slot phase0.Slot) time.Time {
+			if slot == 1 {
+				return pastDeadline
+			}
+			return time.Now().Add(time.Hour)
+		}, recorder.submit)
+
+		done := make(chan error)
+		go func() {
+			done <- batcher.Submit(ctx, newAttestation(2))
+		}()
+		require.Eventually(t, func() bool {
+			batcher.mu.Lock()
+			defer batcher.mu.Unlock()
+			return batcher.batch != nil
+		}, time.Second, time.Millisecond)
+
+		require.NoError(t, batcher.Submit(ctx, newAttestation(1)))
+		require.NoError(t, <-done)
+		require.Equal(t, []int{2}, recorder.batchSizes())
+	})
+
+	t.Run("error is returned to all submitters", func(t *testing.T) {
+		recorder := &batchRecorder{err: errors.New("test error")}
+		batcher := newAttestationBatcher(50*time.Millisecond, farDeadline, recorder.submit)
+
+		errs := submitAll(batcher, newAttestation(1), newAttestation(1))
+		for _, err := range errs {
+			require.ErrorContains(t, err, "test error")
+		}
+		require.Equal(t, []int{2}, recorder.batchSizes())
+	})
+}
@@ -0,0 +1,117 @@
+package goclient
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+)
+
+var metricsInvalidAttestationData = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "ssv_beacon_invalid_attestation_data",
+	Help: "Count of attestations with inconsistent or stale data, by reason",
+}, []string{"reason", "rejected"})
+
+func init() {
+	logger := zap.L()
+	if err := prometheus.Register(metricsInvalidAttestationData); err != nil {
+		logger.Debug("could not register prometheus collector")
+	}
+}
+
+// ErrInvalidAttestationData is returned when an attestation's data is inconsistent or stale,
+// so submitting it would be wasted or risk a slashable vote.
+var ErrInvalidAttestationData = errors.New("invalid attestation data")
+
+// Reasons of invalid attestation data.
+const (
+	attestationIncomplete        = "incomplete"
+	attestationSourceAfterTarget = "source_after_target"
+	attestationTargetMismatch    = "target_epoch_mismatch"
+	attestationStaleSlot         = "stale_slot"
+	attestationFutureSlot        = "future_slot"
+)
+
+// attestationDataError describes why attestation data is invalid.
+type attestationDataError struct {
+	reason string
+	text   string
+}
+
+func (e *attestationDataError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrInvalidAttestationData, e.text)
+}
+
+func (e *attestationDataError) Is(target error) bool {
+	return target == ErrInvalidAttestationData
+}
+
+// checkAttestationData checks that the attestation data is internally consistent
+// and that its slot is within the epoch preceding the current slot.
+func (gc *goClient) checkAttestationData(data *phase0.AttestationData) *attestationDataError {
+	if data == nil || data.Source == nil || data.Target == nil {
+		return &attestationDataError{reason: attestationIncomplete, text: "missing source or target checkpoint"}
+	}
+	if data.Source.Epoch > data.Target.Epoch {
+		return &attestationDataError{
+			reason: attestationSourceAfterTarget,
+			text:   fmt.Sprintf("source epoch %d is after target epoch %d", data.Source.Epoch, data.Target.Epoch),
+		}
+	}
+	if epoch := gc.network.EstimatedEpochAtSlot(data.Slot); data.Target.Epoch != epoch {
+		return &attestationDataError{
+			reason: attestationTargetMismatch,
+			text:   fmt.Sprintf("target epoch %d doesn't match epoch %d of slot %d", data.Target.Epoch, epoch, data.Slot),
+		}
+	}
+
+	// Attestations may be included up to an epoch after their slot, and a slot ahead
+	// of the current one is tolerated for clock disparity with the beacon node.
+	currentSlot := gc.network.EstimatedCurrentSlot()
+	if data.Slot > currentSlot+1 {
+		return &attestationDataError{
+			reason: attestationFutureSlot,
+			text:   fmt.Sprintf("slot %d is ahead of current slot %d", data.Slot, currentSlot),
+		}
+	}
+	if slotsPerEpoch := phase0.Slot(gc.network.SlotsPerEpoch()); data.Slot+slotsPerEpoch < currentSlot {
+		return &attestationDataError{
+			reason: attestationStaleSlot,
+			text:   fmt.Sprintf("slot %d is more than an epoch behind current slot %d", data.Slot, currentSlot),
+		}
+	}
+	return nil
+}
+
+// attestationSanityCheck checks the attestation's data before it's submitted, returning an error
+// wrapping ErrInvalidAttestationData if it's invalid, unless configured to only warn about it.
+func (gc *goClient) attestationSanityCheck(attestation *phase0.Attestation) error {
+	invalid := gc.checkAttestationData(attestation.Data)
+	if invalid == nil {
+		return nil
+	}
+
+	metricsInvalidAttestationData.WithLabelValues(invalid.reason, strconv.FormatBool(!gc.attestationWarnOnly)).Inc()
+	if gc.attestationWarnOnly {
+		gc.log.Warn("submitting attestation with invalid data",
+			fields.Slot(attestationSlot(attestation)),
+			zap.String("reason", invalid.reason),
+			zap.Error(invalid),
+		)
+		return nil
+	}
+	return invalid
+}
+
+func attestationSlot(attestation *phase0.Attestation) phase0.Slot {
+	if attestation.Data == nil {
+		return 0
+	}
+	return attestation.Data.Slot
+}
@@ -0,0 +1,92 @@
+package goclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+func TestAttestationSanityCheck(t *testing.T) {
+	network := beacon.NewNetwork(types.MainNetwork)
+	gc := &goClient{
+		log:     zap.NewNop(),
+		network: network,
+	}
+
+	currentSlot := network.EstimatedCurrentSlot()
+	attestation := func(slot phase0.Slot, source, target phase0.Epoch) *phase0.Attestation {
+		return &phase0.Attestation{
+			Data: &phase0.AttestationData{
+				Slot:   slot,
+				Source: &phase0.Checkpoint{Epoch: source},
+				Target: &phase0.Checkpoint{Epoch: target},
+			},
+		}
+	}
+	epochOf := network.EstimatedEpochAtSlot
+	slotsPerEpoch := phase0.Slot(network.SlotsPerEpoch())
+
+	tests := []struct {
+		name        string
+		attestation *phase0.Attestation
+		reason      string
+	}{
+		{
+			name:        "valid",
+			attestation: attestation(currentSlot, epochOf(currentSlot)-1, epochOf(currentSlot)),
+		},
+		{
+			name:        "previous epoch",
+			attestation: attestation(currentSlot-slotsPerEpoch, epochOf(currentSlot)-2, epochOf(currentSlot-slotsPerEpoch)),
+		},
+		{
+			name:        "missing checkpoint",
+			attestation: &phase0.Attestation{Data: &phase0.AttestationData{Slot: currentSlot}},
+			reason:      attestationIncomplete,
+		},
+		{
+			name:        "source after target",
+			attestation: attestation(currentSlot, epochOf(currentSlot)+1, epochOf(currentSlot)),
+			reason:      attestationSourceAfterTarget,
+		},
+		{
+			name:        "target of another epoch",
+			attestation: attestation(currentSlot, epochOf(currentSlot)-1, epochOf(currentSlot)-1),
+			reason:      attestationTargetMismatch,
+		},
+		{
+			name:        "future slot",
+			attestation: attestation(currentSlot+2*slotsPerEpoch, epochOf(currentSlot), epochOf(currentSlot+2*slotsPerEpoch)),
+			reason:      attestationFutureSlot,
+		},
+		{
+			name:        "stale slot",
+			attestation: attestation(currentSlot-2*slotsPerEpoch, epochOf(currentSlot)-3, epochOf(currentSlot-2*slotsPerEpoch)),
+			reason:      attestationStaleSlot,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gc.attestationWarnOnly = false
+			err := gc.attestationSanityCheck(tt.attestation)
+			if tt.reason == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidAttestationData)
+			var invalid *attestationDataError
+			require.True(t, errors.As(err, &invalid))
+			require.Equal(t, tt.reason, invalid.reason)
+
+			// In warn-only mode, invalid attestations are submitted regardless.
+			gc.attestationWarnOnly = true
+			require.NoError(t, gc.attestationSanityCheck(tt.attestation))
+		})
+	}
+}
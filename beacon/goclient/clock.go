@@ -0,0 +1,93 @@
+package goclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+)
+
+var (
+	metricsClockOffset = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv_beacon_clock_offset_seconds",
+		Help: "Earliest arrival of the beacon node's head events relative to their slot start in the last epoch (seconds), negative if the local clock is behind",
+	})
+	metricsClockOffsetExceeded = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv_beacon_clock_offset_exceeded",
+		Help: "Count of epochs in which the estimated offset from the beacon node's clock exceeded the threshold",
+	})
+)
+
+func init() {
+	logger := zap.L()
+	for _, c := range []prometheus.Collector{metricsClockOffset, metricsClockOffsetExceeded} {
+		if err := prometheus.Register(c); err != nil {
+			logger.Debug("could not register prometheus collector")
+		}
+	}
+}
+
+// clockOffsetTracker estimates the offset between the local clock and the beacon node's clock by the arrival
+// of head events relative to the start of their slots by the local clock. A head event can't arrive before its
+// slot starts, and the earliest ones of an epoch arrive shortly after, so the earliest arrival of each epoch
+// approximates the offset: it's negative if the local clock is behind, and high if it's ahead.
+type clockOffsetTracker struct {
+	mu       sync.Mutex
+	epoch    phase0.Epoch
+	earliest time.Duration
+	observed bool
+}
+
+// observe records the arrival of a head event of the given epoch relative to its slot start. Once an event
+// of a later epoch arrives, it returns the earliest arrival of the previously observed epoch.
+func (t *clockOffsetTracker) observe(epoch phase0.Epoch, arrival time.Duration) (observedEpoch phase0.Epoch, offset time.Duration, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch {
+	case !t.observed || epoch > t.epoch:
+		observedEpoch, offset, ok = t.epoch, t.earliest, t.observed
+		t.epoch = epoch
+		t.earliest = arrival
+		t.observed = true
+	case epoch == t.epoch && arrival < t.earliest:
+		t.earliest = arrival
+	}
+	return observedEpoch, offset, ok
+}
+
+func (gc *goClient) subscribeToClockOffsetEvents(ctx context.Context) error {
+	return gc.Events(ctx, []string{headEventTopic}, func(event *eth2apiv1.Event) {
+		data, ok := event.Data.(*eth2apiv1.HeadEvent)
+		if !ok || data == nil {
+			return
+		}
+		gc.checkClockOffset(data.Slot, time.Now())
+	})
+}
+
+// checkClockOffset records the arrival of a head event of the given slot, and once an epoch passes,
+// reports its estimated clock offset and warns if it exceeds the threshold.
+func (gc *goClient) checkClockOffset(slot phase0.Slot, receivedAt time.Time) {
+	epoch, offset, ok := gc.clockOffset.observe(gc.network.EstimatedEpochAtSlot(slot), receivedAt.Sub(gc.slotStartTime(slot)))
+	if !ok {
+		return
+	}
+
+	metricsClockOffset.Set(offset.Seconds())
+	if offset < -gc.clockOffsetThreshold || offset > gc.clockOffsetThreshold {
+		metricsClockOffsetExceeded.Inc()
+		gc.log.Warn("local clock may be out of sync with the beacon node, check NTP: head events arrive at an unexpected time into their slots",
+			fields.Epoch(epoch),
+			zap.Duration("earliest_head_arrival", offset),
+			zap.Duration("threshold", gc.clockOffsetThreshold),
+		)
+	}
+}
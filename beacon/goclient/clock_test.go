@@ -0,0 +1,66 @@
+package goclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+func TestClockOffsetTracker(t *testing.T) {
+	tracker := &clockOffsetTracker{}
+
+	// The offset of an epoch is reported once a later epoch is observed.
+	_, _, ok := tracker.observe(1, 2*time.Second)
+	require.False(t, ok)
+	_, _, ok = tracker.observe(1, 500*time.Millisecond)
+	require.False(t, ok)
+	_, _, ok = tracker.observe(1, 3*time.Second)
+	require.False(t, ok)
+
+	// Events of earlier epochs (e.g. reorgs) are ignored.
+	_, _, ok = tracker.observe(0, -time.Minute)
+	require.False(t, ok)
+
+	epoch, offset, ok := tracker.observe(3, -time.Second)
+	require.True(t, ok)
+	require.Equal(t, phase0.Epoch(1), epoch)
+	require.Equal(t, 500*time.Millisecond, offset)
+
+	epoch, offset, ok = tracker.observe(4, time.Second)
+	require.True(t, ok)
+	require.Equal(t, phase0.Epoch(3), epoch)
+	require.Equal(t, -time.Second, offset)
+}
+
+func TestCheckClockOffset(t *testing.T) {
+	network := beacon.NewNetwork(types.MainNetwork)
+	gc := &goClient{
+		log:                  zap.NewNop(),
+		network:              network,
+		clockOffset:          &clockOffsetTracker{},
+		clockOffsetThreshold: time.Second,
+	}
+	exceeded := func() float64 {
+		return testutil.ToFloat64(metricsClockOffsetExceeded)
+	}
+	slotsPerEpoch := phase0.Slot(network.SlotsPerEpoch())
+
+	// Head events arriving shortly after their slots start are within the threshold.
+	initial := exceeded()
+	gc.checkClockOffset(10, gc.slotStartTime(10).Add(300*time.Millisecond))
+	gc.checkClockOffset(10+slotsPerEpoch, gc.slotStartTime(10+slotsPerEpoch).Add(-2*time.Second))
+	require.Equal(t, 0.3, testutil.ToFloat64(metricsClockOffset))
+	require.Equal(t, initial, exceeded())
+
+	// Head events arriving before their slots start indicate the local clock is behind.
+	gc.checkClockOffset(10+2*slotsPerEpoch, gc.slotStartTime(10+2*slotsPerEpoch))
+	require.Equal(t, -2.0, testutil.ToFloat64(metricsClockOffset))
+	require.Equal(t, initial+1, exceeded())
+}
@@ -2,16 +2,72 @@ package goclient
 
 import (
 	"context"
+	"sort"
 
 	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 )
 
 // SubmitBeaconCommitteeSubscriptions is implementation for subscribing committee to subnet (p2p topic)
 func (gc *goClient) SubmitBeaconCommitteeSubscriptions(ctx context.Context, subscription []*eth2apiv1.BeaconCommitteeSubscription) error {
-	return gc.client.SubmitBeaconCommitteeSubscriptions(ctx, subscription)
+	if err := gc.checkEndpoint(endpointSubscription); err != nil {
+		return err
+	}
+
+	return gc.submit(ctx, submissionSubscription, func(client Client) error {
+		return client.SubmitBeaconCommitteeSubscriptions(ctx, subscription)
+	})
 }
 
-// SubmitSyncCommitteeSubscriptions is implementation for subscribing sync committee to subnet (p2p topic)
+// SubmitSyncCommitteeSubscriptions is implementation for subscribing sync committee to subnet (p2p topic).
+// The subscriptions are merged into one per subnet, since many validators of the same sync committee
+// subscribe to the same subnets. Only the subscriptions of a single call are merged, so that
+// subscriptions are always submitted again, e.g. after the beacon node restarted or another node was routed to.
 func (gc *goClient) SubmitSyncCommitteeSubscriptions(ctx context.Context, subscription []*eth2apiv1.SyncCommitteeSubscription) error {
-	return gc.client.SubmitSyncCommitteeSubscriptions(ctx, subscription)
+	if err := gc.checkEndpoint(endpointSubscription); err != nil {
+		return err
+	}
+
+	merged := mergeSyncCommitteeSubscriptions(subscription)
+	metricsSyncCommitteeSubscriptionsMerged.Add(float64(len(subscription) - len(merged)))
+	if len(merged) == 0 {
+		return nil
+	}
+
+	return gc.submit(ctx, submissionSubscription, func(client Client) error {
+		return client.SubmitSyncCommitteeSubscriptions(ctx, merged)
+	})
+}
+
+// mergeSyncCommitteeSubscriptions returns a single subscription per subnet of the given subscriptions,
+// until the latest of their epochs.
+func mergeSyncCommitteeSubscriptions(subscriptions []*eth2apiv1.SyncCommitteeSubscription) []*eth2apiv1.SyncCommitteeSubscription {
+	bySubnet := map[uint64]*eth2apiv1.SyncCommitteeSubscription{}
+	for _, subscription := range subscriptions {
+		for _, index := range subscription.SyncCommitteeIndices {
+			subnet := uint64(index) / (SyncCommitteeSize / SyncCommitteeSubnetCount)
+			if merged, ok := bySubnet[subnet]; ok && merged.UntilEpoch >= subscription.UntilEpoch {
+				continue
+			}
+			bySubnet[subnet] = &eth2apiv1.SyncCommitteeSubscription{
+				ValidatorIndex:       subscription.ValidatorIndex,
+				SyncCommitteeIndices: []phase0.CommitteeIndex{index},
+				UntilEpoch:           subscription.UntilEpoch,
+			}
+		}
+	}
+
+	subnets := make([]uint64, 0, len(bySubnet))
+	for subnet := range bySubnet {
+		subnets = append(subnets, subnet)
+	}
+	sort.Slice(subnets, func(i, j int) bool {
+		return subnets[i] < subnets[j]
+	})
+
+	merged := make([]*eth2apiv1.SyncCommitteeSubscription, len(subnets))
+	for i, subnet := range subnets {
+		merged[i] = bySubnet[subnet]
+	}
+	return merged
 }
@@ -0,0 +1,45 @@
+package goclient
+
+import (
+	"testing"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncCommitteeSubscriptionsMerge(t *testing.T) {
+	subscription := func(validator phase0.ValidatorIndex, until phase0.Epoch, indices ...phase0.CommitteeIndex) *eth2apiv1.SyncCommitteeSubscription {
+		return &eth2apiv1.SyncCommitteeSubscription{
+			ValidatorIndex:       validator,
+			SyncCommitteeIndices: indices,
+			UntilEpoch:           until,
+		}
+	}
+
+	// Validators in the same subcommittees are merged into one subscription per subnet.
+	merged := mergeSyncCommitteeSubscriptions([]*eth2apiv1.SyncCommitteeSubscription{
+		subscription(1, 10, 5, 300),
+		subscription(2, 10, 6),
+		subscription(3, 10, 130, 301),
+	})
+	require.Equal(t, []*eth2apiv1.SyncCommitteeSubscription{
+		subscription(1, 10, 5),
+		subscription(3, 10, 130),
+		subscription(1, 10, 300),
+	}, merged)
+
+	// Subnets are subscribed again by later calls, as the beacon node may have lost the earlier subscriptions.
+	require.Equal(t, merged, mergeSyncCommitteeSubscriptions(merged))
+
+	// Subscriptions of a subnet are merged until the latest of their epochs.
+	merged = mergeSyncCommitteeSubscriptions([]*eth2apiv1.SyncCommitteeSubscription{
+		subscription(6, 11, 8),
+		subscription(7, 12, 9),
+		subscription(8, 10, 400),
+	})
+	require.Equal(t, []*eth2apiv1.SyncCommitteeSubscription{
+		subscription(7, 12, 9),
+		subscription(8, 10, 400),
+	}, merged)
+}
@@ -0,0 +1,203 @@
+package goclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/operator/slotticker"
+)
+
+// domainKey identifies a domain, which changes only with the fork version
+// since the genesis validators root is fixed.
+type domainKey struct {
+	domainType  phase0.DomainType
+	forkVersion phase0.Version
+}
+
+// domainCache caches domains by the fork version that is active at their epoch according to the fork schedule,
+// so that domains are recomputed exactly at the fork epoch rather than on a fixed epoch comparison.
+type domainCache struct {
+	mu       sync.Mutex
+	schedule []*phase0.Fork // sorted by epoch
+	domains  map[domainKey]phase0.Domain
+}
+
+func newDomainCache() *domainCache {
+	return &domainCache{
+		domains: map[domainKey]phase0.Domain{},
+	}
+}
+
+// preloadedDomainTypes are the domains which are preloaded at startup, as the first duties sign with them.
+var preloadedDomainTypes = []phase0.DomainType{
+	spectypes.DomainAttester,
+	spectypes.DomainProposer,
+	spectypes.DomainSyncCommittee,
+	spectypes.DomainRandao,
+}
+
+// forkVersion returns the fork version active at the given epoch.
+// It must be called with the lock held.
+func (c *domainCache) forkVersion(epoch phase0.Epoch) (phase0.Version, bool) {
+	for i := len(c.schedule) - 1; i >= 0; i-- {
+		if c.schedule[i].Epoch <= epoch {
+			return c.schedule[i].CurrentVersion, true
+		}
+	}
+	return phase0.Version{}, false
+}
+
+// get returns the cached domain of the given type at the given epoch.
+// Nothing is cached until the fork schedule is known.
+func (c *domainCache) get(epoch phase0.Epoch, domainType phase0.DomainType) (phase0.Domain, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	forkVersion, ok := c.forkVersion(epoch)
+	if !ok {
+		return phase0.Domain{}, false
+	}
+	domain, ok := c.domains[domainKey{domainType: domainType, forkVersion: forkVersion}]
+	return domain, ok
+}
+
+func (c *domainCache) set(epoch phase0.Epoch, domainType phase0.DomainType, domain phase0.Domain) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	forkVersion, ok := c.forkVersion(epoch)
+	if !ok {
+		return
+	}
+	c.domains[domainKey{domainType: domainType, forkVersion: forkVersion}] = domain
+}
+
+// setSchedule replaces the fork schedule, clearing the cached domains if it changed.
+// It returns whether the schedule changed.
+func (c *domainCache) setSchedule(schedule []*phase0.Fork) bool {
+	schedule = append([]*phase0.Fork(nil), schedule...)
+	sort.SliceStable(schedule, func(i, j int) bool {
+		return schedule[i].Epoch < schedule[j].Epoch
+	})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if sameSchedule(c.schedule, schedule) {
+		return false
+	}
+	c.schedule = schedule
+	c.domains = map[domainKey]phase0.Domain{}
+	return true
+}
+
+// hasSchedule returns whether the fork schedule is known, and thus whether domains are cached.
+func (c *domainCache) hasSchedule() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.schedule) > 0
+}
+
+// prune drops the domains of forks which are no longer active at the given epoch,
+// and returns how many were dropped.
+func (c *domainCache) prune(epoch phase0.Epoch) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	forkVersion, ok := c.forkVersion(epoch)
+	if !ok {
+		return 0
+	}
+	pruned := 0
+	for key := range c.domains {
+		if key.forkVersion != forkVersion {
+			delete(c.domains, key)
+			pruned++
+		}
+	}
+	return pruned
+}
+
+func sameSchedule(a, b []*phase0.Fork) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Epoch != b[i].Epoch || a[i].CurrentVersion != b[i].CurrentVersion {
+			return false
+		}
+	}
+	return true
+}
+
+// updateForkSchedule fetches the fork schedule from the beacon node into the domain cache.
+func (gc *goClient) updateForkSchedule(ctx context.Context) error {
+	resp, err := gc.client.ForkSchedule(ctx, &api.ForkScheduleOpts{})
+	if err != nil {
+		return fmt.Errorf("failed to obtain fork schedule: %w", err)
+	}
+	if resp == nil || len(resp.Data) == 0 {
+		return fmt.Errorf("fork schedule response is empty")
+	}
+	if gc.domainCache.setSchedule(resp.Data) {
+		gc.log.Debug("updated fork schedule", zap.Int("forks", len(resp.Data)))
+	}
+	return nil
+}
+
+// preloadDomains caches the domains of preloadedDomainTypes at the given epoch and the next one, so that
+// the first signings after startup don't wait for them. It stops at the first failure, leaving the rest
+// to be fetched on first use, and returns the number of domains preloaded.
+func (gc *goClient) preloadDomains(epoch phase0.Epoch) (int, error) {
+	if !gc.domainCache.hasSchedule() {
+		return 0, fmt.Errorf("fork schedule is unknown")
+	}
+
+	preloaded := 0
+	for _, e := range []phase0.Epoch{epoch, epoch + 1} {
+		for _, domainType := range preloadedDomainTypes {
+			if _, err := gc.DomainData(e, domainType); err != nil {
+				return preloaded, err
+			}
+			preloaded++
+		}
+	}
+	return preloaded, nil
+}
+
+// forkScheduleWatcher polls the fork schedule every epoch, so that forks scheduled after startup
+// are accounted for, and drops the domains of the previous fork at the first slot of a fork epoch.
+func (gc *goClient) forkScheduleWatcher(slotTickerProvider slotticker.Provider) {
+	ticker := slotTickerProvider()
+	lastEpoch := gc.network.EstimatedCurrentEpoch()
+	for {
+		select {
+		case <-gc.ctx.Done():
+			return
+		case <-ticker.Next():
+			epoch := gc.network.EstimatedEpochAtSlot(ticker.Slot())
+			if epoch == lastEpoch {
+				continue
+			}
+			lastEpoch = epoch
+
+			if err := gc.updateForkSchedule(gc.ctx); err != nil {
+				gc.log.Warn("failed to update fork schedule", zap.Error(err))
+			}
+			if pruned := gc.domainCache.prune(epoch); pruned > 0 {
+				gc.log.Info("fork activated, cleared domains of the previous fork",
+					zap.Uint64("epoch", uint64(epoch)),
+					zap.Int("domains", pruned),
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package goclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type domainRecorder struct {
+	Client
+	schedule []*phase0.Fork
+	requests []phase0.Epoch
+}
+
+func (r *domainRecorder) ForkSchedule(context.Context, *api.ForkScheduleOpts) (*api.Response[[]*phase0.Fork], error) {
+	return &api.Response[[]*phase0.Fork]{Data: r.schedule}, nil
+}
+
+func (r *domainRecorder) Domain(_ context.Context, domainType phase0.DomainType, epoch phase0.Epoch) (phase0.Domain, error) {
+	r.requests = append(r.requests, epoch)
+
+	// Derive the domain from the fork version, like the beacon node would.
+	var domain phase0.Domain
+	copy(domain[:], domainType[:])
+	for _, fork := range r.schedule {
+		if fork.Epoch <= epoch {
+			copy(domain[4:], fork.CurrentVersion[:])
+		}
+	}
+	return domain, nil
+}
+
+func TestDomainCacheForkTransition(t *testing.T) {
+	const forkEpoch = phase0.Epoch(10)
+	recorder := &domainRecorder{
+		schedule: []*phase0.Fork{
+			{PreviousVersion: phase0.Version{1}, CurrentVersion: phase0.Version{1}, Epoch: 0},
+			{PreviousVersion: phase0.Version{1}, CurrentVersion: phase0.Version{2}, Epoch: forkEpoch},
+		},
+	}
+	gc := &goClient{
+		log:         zap.NewNop(),
+		ctx:         context.Background(),
+		client:      recorder,
+		domainCache: newDomainCache(),
+	}
+	domainType := phase0.DomainType{7}
+
+	// Domains aren't cached until the fork schedule is known.
+	_, err := gc.DomainData(forkEpoch-1, domainType)
+	require.NoError(t, err)
+	_, err = gc.DomainData(forkEpoch-1, domainType)
+	require.NoError(t, err)
+	require.Len(t, recorder.requests, 2)
+	recorder.requests = nil
+
+	require.NoError(t, gc.updateForkSchedule(gc.ctx))
+
+	// Domains of epochs before the fork are fetched once.
+	preFork, err := gc.DomainData(forkEpoch-2, domainType)
+	require.NoError(t, err)
+	cached, err := gc.DomainData(forkEpoch-1, domainType)
+	require.NoError(t, err)
+	require.Equal(t, preFork, cached)
+	require.Equal(t, []phase0.Epoch{forkEpoch - 2}, recorder.requests)
+
+	// The domain at the fork epoch differs, and is fetched rather than served from the previous fork.
+	postFork, err := gc.DomainData(forkEpoch, domainType)
+	require.NoError(t, err)
+	require.NotEqual(t, preFork, postFork)
+	cached, err = gc.DomainData(forkEpoch+1, domainType)
+	require.NoError(t, err)
+	require.Equal(t, postFork, cached)
+	require.Equal(t, []phase0.Epoch{forkEpoch - 2, forkEpoch}, recorder.requests)
+
+	// At the fork epoch, the domains of the previous fork are dropped.
+	require.Equal(t, 1, gc.domainCache.prune(forkEpoch))
+	require.Equal(t, 0, gc.domainCache.prune(forkEpoch+1))
+	_, ok := gc.domainCache.get(forkEpoch-1, domainType)
+	require.False(t, ok)
+	_, ok = gc.domainCache.get(forkEpoch, domainType)
+	require.True(t, ok)
+
+	// A changed fork schedule clears the cache, and an unchanged one doesn't.
+	require.NoError(t, gc.updateForkSchedule(gc.ctx))
+	_, ok = gc.domainCache.get(forkEpoch, domainType)
+	require.True(t, ok)
+
+	recorder.schedule = append(recorder.schedule, &phase0.Fork{
+		PreviousVersion: phase0.Version{2},
+		CurrentVersion:  phase0.Version{3},
+		Epoch:           forkEpoch * 2,
+	})
+	require.NoError(t, gc.updateForkSchedule(gc.ctx))
+	_, ok = gc.domainCache.get(forkEpoch, domainType)
+	require.False(t, ok)
+}
+
+func TestPreloadDomains(t *testing.T) {
+	recorder := &domainRecorder{
+		schedule: []*phase0.Fork{
+			{PreviousVersion: phase0.Version{1}, CurrentVersion: phase0.Version{1}, Epoch: 0},
+		},
+	}
+	gc := &goClient{
+		log:         zap.NewNop(),
+		ctx:         context.Background(),
+		client:      recorder,
+		domainCache: newDomainCache(),
+	}
+
+	// Domains aren't cached until the fork schedule is known, so they aren't preloaded either.
+	_, err := gc.preloadDomains(5)
+	require.Error(t, err)
+	require.Empty(t, recorder.requests)
+
+	require.NoError(t, gc.updateForkSchedule(gc.ctx))
+	preloaded, err := gc.preloadDomains(5)
+	require.NoError(t, err)
+	require.Equal(t, 2*len(preloadedDomainTypes), preloaded)
+	// The next epoch is of the same fork, so its domains are served from the cache.
+	require.Len(t, recorder.requests, len(preloadedDomainTypes))
+
+	// The first signings are cache hits.
+	recorder.requests = nil
+	for _, domainType := range preloadedDomainTypes {
+		_, err := gc.DomainData(5, domainType)
+		require.NoError(t, err)
+		_, err = gc.DomainData(6, domainType)
+		require.NoError(t, err)
+	}
+	require.Empty(t, recorder.requests)
+}
@@ -0,0 +1,218 @@
+package goclient
+
+import (
+	"sync"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/operator/slotticker"
+)
+
+var (
+	metricsDutiesSubmitted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_beacon_duties_submitted",
+		Help: "Count of duties submitted to the beacon node, by role",
+	}, []string{"role"})
+	metricsDutiesMissed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_beacon_duties_missed",
+		Help: "Count of assigned attester and proposer duties which were never submitted, by role",
+	}, []string{"role"})
+	metricsDutiesLate = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_beacon_duties_late",
+		Help: "Count of assigned attester duties which were submitted after the end of their slot, by role",
+	}, []string{"role"})
+)
+
+func init() {
+	logger := zap.L()
+	for _, c := range []prometheus.Collector{metricsDutiesSubmitted, metricsDutiesMissed, metricsDutiesLate} {
+		if err := prometheus.Register(c); err != nil {
+			logger.Debug("could not register prometheus collector")
+		}
+	}
+}
+
+// dutyKey identifies a duty of a validator.
+type dutyKey struct {
+	role      spectypes.BeaconRole
+	slot      phase0.Slot
+	validator phase0.ValidatorIndex
+}
+
+// attesterKey identifies an attester duty by the position of the validator in its committee,
+// which is all that an unaggregated attestation tells about its validator.
+type attesterKey struct {
+	slot           phase0.Slot
+	committeeIndex phase0.CommitteeIndex
+	position       uint64
+}
+
+// attestationLateSlots is the number of slots after its slot in which an attestation may still be included,
+// and so in which an attester duty which wasn't submitted by the end of its slot is counted as late rather than missed.
+const attestationLateSlots = 32
+
+// dutyState is the submission state of a tracked duty.
+type dutyState struct {
+	submitted bool
+	overdue   bool // whether the duty's slot ended before it was submitted
+}
+
+// dutyTracker tracks the duties assigned by the beacon node until they're submitted,
+// so that duties which were never submitted are counted as missed.
+// Only attester and proposer duties are tracked, since their submissions identify the validator.
+// Proposer duties which weren't submitted by the end of their slot are missed, while attester duties
+// are tracked for attestationLateSlots more slots, and counted as late if submitted in the meantime.
+// Duties are fetched again e.g. on reorgs, so assigning an already tracked duty keeps whether it was submitted,
+// and duties of past slots aren't tracked, as they could only be counted as missed.
+type dutyTracker struct {
+	mu        sync.Mutex
+	duties    map[dutyKey]*dutyState
+	attesters map[attesterKey]phase0.ValidatorIndex
+}
+
+func newDutyTracker() *dutyTracker {
+	return &dutyTracker{
+		duties:    map[dutyKey]*dutyState{},
+		attesters: map[attesterKey]phase0.ValidatorIndex{},
+	}
+}
+
+// assign tracks the duty, unless it's of a past slot or already tracked.
+func (t *dutyTracker) assign(currentSlot phase0.Slot, key dutyKey) bool {
+	if key.slot < currentSlot {
+		return false
+	}
+	if _, ok := t.duties[key]; !ok {
+		t.duties[key] = &dutyState{}
+	}
+	return true
+}
+
+func (t *dutyTracker) assignAttesterDuties(currentSlot phase0.Slot, duties []*eth2apiv1.AttesterDuty) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, duty := range duties {
+		if !t.assign(currentSlot, dutyKey{role: spectypes.BNRoleAttester, slot: duty.Slot, validator: duty.ValidatorIndex}) {
+			continue
+		}
+		t.attesters[attesterKey{
+			slot:           duty.Slot,
+			committeeIndex: duty.CommitteeIndex,
+			position:       duty.ValidatorCommitteeIndex,
+		}] = duty.ValidatorIndex
+	}
+}
+
+func (t *dutyTracker) assignProposerDuties(currentSlot phase0.Slot, duties []*eth2apiv1.ProposerDuty) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, duty := range duties {
+		t.assign(currentSlot, dutyKey{role: spectypes.BNRoleProposer, slot: duty.Slot, validator: duty.ValidatorIndex})
+	}
+}
+
+// submit marks the duty as submitted, if it's tracked.
+func (t *dutyTracker) submit(key dutyKey) {
+	if state, ok := t.duties[key]; ok {
+		state.submitted = true
+	}
+}
+
+// submitAttestation marks the attester duties of the attestation's validators as submitted.
+func (t *dutyTracker) submitAttestation(attestation *phase0.Attestation) {
+	if attestation.Data == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, position := range attestation.AggregationBits.BitIndices() {
+		validator, ok := t.attesters[attesterKey{
+			slot:           attestation.Data.Slot,
+			committeeIndex: attestation.Data.Index,
+			position:       uint64(position),
+		}]
+		if ok {
+			t.submit(dutyKey{role: spectypes.BNRoleAttester, slot: attestation.Data.Slot, validator: validator})
+		}
+	}
+}
+
+// submitProposal marks the proposer duty of the given slot as submitted.
+func (t *dutyTracker) submitProposal(slot phase0.Slot, proposer phase0.ValidatorIndex) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.submit(dutyKey{role: spectypes.BNRoleProposer, slot: slot, validator: proposer})
+}
+
+// expire drops the duties of slots before the given slot which are settled, returning the number of those
+// which were never submitted and of those which were submitted after the end of their slot by role.
+// Attester duties which weren't submitted yet are kept until attestationLateSlots pass.
+func (t *dutyTracker) expire(slot phase0.Slot) (missed, late map[spectypes.BeaconRole]int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	missed = map[spectypes.BeaconRole]int{}
+	late = map[spectypes.BeaconRole]int{}
+	for key, state := range t.duties {
+		if key.slot >= slot {
+			continue
+		}
+		switch {
+		case state.submitted:
+			if state.overdue {
+				late[key.role]++
+			}
+		case key.role == spectypes.BNRoleAttester && slot <= key.slot+attestationLateSlots:
+			state.overdue = true
+			continue
+		default:
+			missed[key.role]++
+		}
+		delete(t.duties, key)
+	}
+	for key := range t.attesters {
+		if key.slot+attestationLateSlots < slot {
+			delete(t.attesters, key)
+		}
+	}
+	return missed, late
+}
+
+// dutySubmitted counts a successful submission of a duty of the given role.
+func dutySubmitted(role spectypes.BeaconRole) {
+	metricsDutiesSubmitted.WithLabelValues(role.String()).Inc()
+}
+
+// missedDutiesWatcher counts the duties which were never submitted as missed, and those submitted after their slot as late.
+func (gc *goClient) missedDutiesWatcher(slotTickerProvider slotticker.Provider) {
+	ticker := slotTickerProvider()
+	for {
+		select {
+		case <-gc.ctx.Done():
+			return
+		case <-ticker.Next():
+			missed, late := gc.duties.expire(ticker.Slot())
+			for role, count := range missed {
+				metricsDutiesMissed.WithLabelValues(role.String()).Add(float64(count))
+				gc.log.Debug("duties were never submitted",
+					fields.Role(role),
+					fields.Count(count),
+				)
+			}
+			for role, count := range late {
+				metricsDutiesLate.WithLabelValues(role.String()).Add(float64(count))
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package goclient
+
+import (
+	"testing"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDutyTracker(t *testing.T) {
+	tracker := newDutyTracker()
+	tracker.assignAttesterDuties(10, []*eth2apiv1.AttesterDuty{
+		{Slot: 10, ValidatorIndex: 1, CommitteeIndex: 2, ValidatorCommitteeIndex: 0},
+		{Slot: 10, ValidatorIndex: 2, CommitteeIndex: 2, ValidatorCommitteeIndex: 3},
+		{Slot: 11, ValidatorIndex: 3, CommitteeIndex: 0, ValidatorCommitteeIndex: 1},
+	})
+	tracker.assignProposerDuties(10, []*eth2apiv1.ProposerDuty{
+		{Slot: 10, ValidatorIndex: 1},
+		{Slot: 11, ValidatorIndex: 4},
+	})
+
+	aggregationBits := bitfield.NewBitlist(4)
+	aggregationBits.SetBitAt(3, true)
+	tracker.submitAttestation(&phase0.Attestation{
+		AggregationBits: aggregationBits,
+		Data:            &phase0.AttestationData{Slot: 10, Index: 2},
+	})
+	tracker.submitProposal(10, 1)
+
+	// Fetching the duties again keeps the submitted ones, and duties of past slots aren't tracked.
+	tracker.assignAttesterDuties(10, []*eth2apiv1.AttesterDuty{
+		{Slot: 9, ValidatorIndex: 5, CommitteeIndex: 1, ValidatorCommitteeIndex: 0},
+		{Slot: 10, ValidatorIndex: 2, CommitteeIndex: 2, ValidatorCommitteeIndex: 3},
+	})
+	tracker.assignProposerDuties(10, []*eth2apiv1.ProposerDuty{
+		{Slot: 10, ValidatorIndex: 1},
+	})
+
+	// Slot 10's unsubmitted attester duty of validator 1 isn't missed once slot 11 begins, as it may still be submitted late.
+	missed, late := tracker.expire(11)
+	require.Empty(t, missed)
+	require.Empty(t, late)
+
+	// Slot 11's proposal is submitted, and validator 1 submits its attestation of slot 10 late.
+	tracker.submitProposal(11, 4)
+	aggregationBits = bitfield.NewBitlist(4)
+	aggregationBits.SetBitAt(0, true)
+	tracker.submitAttestation(&phase0.Attestation{
+		AggregationBits: aggregationBits,
+		Data:            &phase0.AttestationData{Slot: 10, Index: 2},
+	})
+	missed, late = tracker.expire(12)
+	require.Empty(t, missed)
+	require.Equal(t, map[spectypes.BeaconRole]int{spectypes.BNRoleAttester: 1}, late)
+
+	// The attester duty of validator 3 is missed once it can't be submitted anymore.
+	missed, late = tracker.expire(11 + attestationLateSlots)
+	require.Empty(t, missed)
+	require.Empty(t, late)
+	missed, late = tracker.expire(12 + attestationLateSlots)
+	require.Equal(t, map[spectypes.BeaconRole]int{spectypes.BNRoleAttester: 1}, missed)
+	require.Empty(t, late)
+	require.Empty(t, tracker.duties)
+	require.Empty(t, tracker.attesters)
+
+	// Proposer duties which weren't submitted by the end of their slot are missed.
+	tracker.assignProposerDuties(100, []*eth2apiv1.ProposerDuty{{Slot: 100, ValidatorIndex: 1}})
+	missed, _ = tracker.expire(101)
+	require.Equal(t, map[spectypes.BeaconRole]int{spectypes.BNRoleProposer: 1}, missed)
+}
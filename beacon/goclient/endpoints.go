@@ -0,0 +1,103 @@
+package goclient
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var metricsDisabledEndpointCalls = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "ssv_beacon_disabled_endpoint_calls",
+	Help: "Count of calls to beacon node endpoints which are disabled by configuration, by endpoint class",
+}, []string{"class"})
+
+func init() {
+	logger := zap.L()
+	if err := prometheus.Register(metricsDisabledEndpointCalls); err != nil {
+		logger.Debug("could not register prometheus collector")
+	}
+}
+
+// ErrEndpointDisabled is returned by calls to beacon node endpoints whose class isn't enabled by configuration.
+var ErrEndpointDisabled = errors.New("endpoint disabled by configuration")
+
+// endpointClass groups beacon node endpoints which are enabled or disabled together.
+// Duties, validators, domains, events and the node's status are always enabled, as no duty can be performed without them.
+type endpointClass string
+
+const (
+	endpointAttestation     endpointClass = "attestation" // attestation data and attestations
+	endpointAggregation     endpointClass = "aggregation"
+	endpointProposal        endpointClass = "proposal" // local blocks and proposal preparations
+	endpointBlindedProposal endpointClass = "blinded_proposal"
+	endpointSyncCommittee   endpointClass = "sync_committee" // sync committee messages and contributions
+	endpointRegistration    endpointClass = "registration"   // validator registrations
+	endpointSubscription    endpointClass = "subscription"
+	endpointExit            endpointClass = "exit"
+)
+
+var endpointClasses = []endpointClass{
+	endpointAttestation,
+	endpointAggregation,
+	endpointProposal,
+	endpointBlindedProposal,
+	endpointSyncCommittee,
+	endpointRegistration,
+	endpointSubscription,
+	endpointExit,
+}
+
+// parseEnabledEndpoints parses the classes of endpoints enabled by the operator.
+// It returns nil if none are given, in which case all endpoints are enabled.
+func parseEnabledEndpoints(classes []string) (map[endpointClass]bool, error) {
+	if len(classes) == 0 {
+		return nil, nil
+	}
+	enabled := make(map[endpointClass]bool, len(classes))
+	for _, class := range classes {
+		class := endpointClass(strings.ToLower(strings.TrimSpace(class)))
+		if !isEndpointClass(class) {
+			return nil, fmt.Errorf("unknown endpoint class %q", class)
+		}
+		enabled[class] = true
+	}
+	return enabled, nil
+}
+
+func isEndpointClass(class endpointClass) bool {
+	for _, c := range endpointClasses {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// endpointEnabled returns whether the endpoints of the given class may be called.
+func (gc *goClient) endpointEnabled(class endpointClass) bool {
+	return gc.enabledEndpoints == nil || gc.enabledEndpoints[class]
+}
+
+// checkEndpoint fails calls to the endpoints of the given class unless they're enabled.
+func (gc *goClient) checkEndpoint(class endpointClass) error {
+	if gc.endpointEnabled(class) {
+		return nil
+	}
+	metricsDisabledEndpointCalls.WithLabelValues(string(class)).Inc()
+	return fmt.Errorf("%s: %w", class, ErrEndpointDisabled)
+}
+
+// disabledEndpoints returns the classes of endpoints which aren't enabled.
+func (gc *goClient) disabledEndpoints() []string {
+	var disabled []string
+	for _, class := range endpointClasses {
+		if !gc.endpointEnabled(class) {
+			disabled = append(disabled, string(class))
+		}
+	}
+	return disabled
+}
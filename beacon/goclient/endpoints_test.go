@@ -0,0 +1,49 @@
+package goclient
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEnabledEndpoints(t *testing.T) {
+	enabled, err := parseEnabledEndpoints(nil)
+	require.NoError(t, err)
+	require.Nil(t, enabled)
+
+	enabled, err = parseEnabledEndpoints([]string{"attestation", " Proposal "})
+	require.NoError(t, err)
+	require.Equal(t, map[endpointClass]bool{endpointAttestation: true, endpointProposal: true}, enabled)
+
+	_, err = parseEnabledEndpoints([]string{"attestation", "duties"})
+	require.ErrorContains(t, err, `unknown endpoint class "duties"`)
+}
+
+func TestCheckEndpoint(t *testing.T) {
+	// All endpoints are enabled by default.
+	gc := &goClient{}
+	for _, class := range endpointClasses {
+		require.NoError(t, gc.checkEndpoint(class))
+	}
+	require.Empty(t, gc.disabledEndpoints())
+
+	enabled, err := parseEnabledEndpoints([]string{"attestation", "proposal"})
+	require.NoError(t, err)
+	gc = &goClient{enabledEndpoints: enabled}
+	require.NoError(t, gc.checkEndpoint(endpointAttestation))
+	require.Equal(t, []string{"aggregation", "blinded_proposal", "sync_committee", "registration", "subscription", "exit"}, gc.disabledEndpoints())
+
+	// Disabled endpoints fail before reaching the beacon node.
+	calls := func() float64 {
+		return testutil.ToFloat64(metricsDisabledEndpointCalls.WithLabelValues(string(endpointExit)))
+	}
+	initial := calls()
+	require.ErrorIs(t, gc.SubmitVoluntaryExit(&phase0.SignedVoluntaryExit{}), ErrEndpointDisabled)
+	require.Equal(t, initial+1, calls())
+
+	_, _, err = gc.GetBlindedBeaconBlock(1, nil, nil)
+	require.ErrorIs(t, err, ErrEndpointDisabled)
+	require.ErrorIs(t, gc.SubmitValidatorRegistration(nil, [20]byte{}, phase0.BLSSignature{}), ErrEndpointDisabled)
+}
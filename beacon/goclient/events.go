@@ -0,0 +1,124 @@
+package goclient
+
+import (
+	"context"
+	"sync"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var metricsEventsDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "ssv_beacon_events_dropped",
+	Help: "Count of beacon node events dropped for consumers which fell behind, by topic",
+}, []string{"topic"})
+
+func init() {
+	logger := zap.L()
+	if err := prometheus.Register(metricsEventsDropped); err != nil {
+		logger.Debug("could not register prometheus collector")
+	}
+}
+
+// eventConsumerBuffer is the number of events buffered for each consumer.
+// Events for a consumer whose buffer is full are dropped rather than delaying the other consumers.
+const eventConsumerBuffer = 32
+
+// eventMultiplexer fans out the events of a single upstream subscription to the beacon node
+// to any number of consumers, each subscribed to a subset of its topics.
+type eventMultiplexer struct {
+	logger    *zap.Logger
+	topics    map[string]struct{}
+	mu        sync.RWMutex
+	consumers map[*eventConsumer]struct{}
+}
+
+type eventConsumer struct {
+	topics map[string]struct{}
+	events chan *eth2apiv1.Event
+}
+
+func newEventMultiplexer(logger *zap.Logger, topics []string) *eventMultiplexer {
+	m := &eventMultiplexer{
+		logger:    logger,
+		topics:    make(map[string]struct{}, len(topics)),
+		consumers: map[*eventConsumer]struct{}{},
+	}
+	for _, topic := range topics {
+		m.topics[topic] = struct{}{}
+	}
+	return m
+}
+
+// start subscribes to the multiplexed topics until the context is done.
+func (m *eventMultiplexer) start(ctx context.Context, client eth2client.EventsProvider) error {
+	topics := make([]string, 0, len(m.topics))
+	for topic := range m.topics {
+		topics = append(topics, topic)
+	}
+	return client.Events(ctx, topics, m.dispatch)
+}
+
+// covers returns whether all the given topics are multiplexed.
+func (m *eventMultiplexer) covers(topics []string) bool {
+	for _, topic := range topics {
+		if _, ok := m.topics[topic]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// subscribe calls the handler with the events of the given topics until the context is done.
+// The handler is called from a goroutine of its own, so a slow handler doesn't delay the other consumers.
+func (m *eventMultiplexer) subscribe(ctx context.Context, topics []string, handler eth2client.EventHandlerFunc) {
+	consumer := &eventConsumer{
+		topics: make(map[string]struct{}, len(topics)),
+		events: make(chan *eth2apiv1.Event, eventConsumerBuffer),
+	}
+	for _, topic := range topics {
+		consumer.topics[topic] = struct{}{}
+	}
+
+	m.mu.Lock()
+	m.consumers[consumer] = struct{}{}
+	m.mu.Unlock()
+
+	go func() {
+		defer func() {
+			m.mu.Lock()
+			delete(m.consumers, consumer)
+			m.mu.Unlock()
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-consumer.events:
+				handler(event)
+			}
+		}
+	}()
+}
+
+// dispatch delivers the event to the consumers of its topic without blocking.
+func (m *eventMultiplexer) dispatch(event *eth2apiv1.Event) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for consumer := range m.consumers {
+		if _, ok := consumer.topics[event.Topic]; !ok {
+			continue
+		}
+		select {
+		case consumer.events <- event:
+		default:
+			metricsEventsDropped.WithLabelValues(event.Topic).Inc()
+			m.logger.Debug("dropped event for a consumer which fell behind", zap.String("topic", event.Topic))
+		}
+	}
+}
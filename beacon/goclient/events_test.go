@@ -0,0 +1,90 @@
+package goclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	beacontesting "github.com/bloxapp/ssv/beacon/goclient/testing"
+)
+
+func TestEventMultiplexer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := beacontesting.NewEventStream()
+	gc := &goClient{
+		log:           zap.NewNop(),
+		client:        eventsClient{events: events},
+		subscriptions: map[string]int{},
+		events:        newEventMultiplexer(zap.NewNop(), []string{beacontesting.TopicHead, beacontesting.TopicChainReorg}),
+	}
+	require.NoError(t, gc.events.start(ctx, gc.client))
+
+	heads := make(chan *eth2apiv1.Event, 10)
+	require.NoError(t, gc.Events(ctx, []string{beacontesting.TopicHead}, func(event *eth2apiv1.Event) {
+		heads <- event
+	}))
+	all := make(chan *eth2apiv1.Event, 10)
+	require.NoError(t, gc.Events(ctx, []string{beacontesting.TopicHead, beacontesting.TopicChainReorg}, func(event *eth2apiv1.Event) {
+		all <- event
+	}))
+
+	// Topics which aren't multiplexed are subscribed to directly.
+	require.False(t, events.Subscribed(beacontesting.TopicFinalizedCheckpoint))
+	require.NoError(t, gc.Events(ctx, []string{beacontesting.TopicFinalizedCheckpoint}, func(*eth2apiv1.Event) {}))
+	require.True(t, events.Subscribed(beacontesting.TopicFinalizedCheckpoint))
+
+	// Both consumers share the single upstream subscription.
+	require.Equal(t, 1, events.PushHead(&eth2apiv1.HeadEvent{Slot: 1}))
+	require.Equal(t, 1, events.PushChainReorg(&eth2apiv1.ChainReorgEvent{Slot: 1}))
+	require.Equal(t, beacontesting.TopicHead, receiveEvent(t, heads).Topic)
+	require.Equal(t, beacontesting.TopicHead, receiveEvent(t, all).Topic)
+	require.Equal(t, beacontesting.TopicChainReorg, receiveEvent(t, all).Topic)
+	require.Empty(t, heads)
+}
+
+func TestEventMultiplexerBackpressure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := newEventMultiplexer(zap.NewNop(), []string{beacontesting.TopicHead})
+
+	// A blocked consumer neither blocks the dispatch nor the other consumers.
+	unblock := make(chan struct{})
+	m.subscribe(ctx, []string{beacontesting.TopicHead}, func(*eth2apiv1.Event) {
+		<-unblock
+	})
+	received := make(chan *eth2apiv1.Event, 2*eventConsumerBuffer)
+	m.subscribe(ctx, []string{beacontesting.TopicHead}, func(event *eth2apiv1.Event) {
+		received <- event
+	})
+
+	for i := 0; i < 2*eventConsumerBuffer; i++ {
+		m.dispatch(&eth2apiv1.Event{Topic: beacontesting.TopicHead})
+		receiveEvent(t, received)
+	}
+	close(unblock)
+
+	// Consumers are removed once their context is done.
+	cancel()
+	require.Eventually(t, func() bool {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+		return len(m.consumers) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func receiveEvent(t *testing.T, events <-chan *eth2apiv1.Event) *eth2apiv1.Event {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		require.FailNow(t, "event not received")
+		return nil
+	}
+}
@@ -4,25 +4,29 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"math/big"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
-	eth2clienthttp "github.com/attestantio/go-eth2-client/http"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
-	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 
 	"github.com/bloxapp/ssv/logging/fields"
 	operatordatastore "github.com/bloxapp/ssv/operator/datastore"
 	"github.com/bloxapp/ssv/operator/slotticker"
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/utils/commons"
 )
 
 const (
@@ -41,6 +45,13 @@ var (
 	allMetrics = []prometheus.Collector{
 		metricsBeaconNodeStatus,
 		metricsBeaconDataRequest,
+		metricsRegistrationsSkipped,
+		metricsRegistrationsOnDemand,
+		metricsRegistrationsStale,
+		metricsSyncCommitteeSubscriptionsMerged,
+		metricsRegistrationSubmitterBlocked,
+		metricsRegistrationCacheSize,
+		metricsRegistrationOldestSubmissionAge,
 	}
 	metricsBeaconNodeStatus = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "ssv_beacon_status",
@@ -54,6 +65,41 @@ var (
 		Buckets: []float64{0.02, 0.05, 0.1, 0.2, 0.5, 1, 5},
 	}, []string{"role"})
 
+	metricsRegistrationsSkipped = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv_beacon_validator_registrations_skipped",
+		Help: "Count of unchanged validator registrations skipped from resubmission",
+	})
+
+	metricsRegistrationsOnDemand = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv_beacon_validator_registrations_on_demand",
+		Help: "Count of validator registrations submitted on demand ahead of the regular submission",
+	})
+
+	metricsRegistrationsStale = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv_beacon_validator_registrations_stale",
+		Help: "Count of validator registrations rejected for being older than the cached ones",
+	})
+
+	metricsSyncCommitteeSubscriptionsMerged = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv_beacon_sync_committee_subscriptions_merged",
+		Help: "Count of sync committee subscriptions merged into the subscriptions of other validators of the same subnets",
+	})
+
+	metricsRegistrationSubmitterBlocked = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv_beacon_registration_submitter_blocked",
+		Help: "Whether the validator registration submitter is waiting for the beacon node to be ready (1) or not (0)",
+	})
+
+	metricsRegistrationCacheSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv_beacon_registration_cache_size",
+		Help: "Number of validator registrations in the registration cache",
+	})
+
+	metricsRegistrationOldestSubmissionAge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv_beacon_registration_oldest_submission_age_slots",
+		Help: "Number of slots since the least recent last submission among the cached validator registrations, or since entering the cache for registrations which were never submitted",
+	})
+
 	metricsAttesterDataRequest                  = metricsBeaconDataRequest.WithLabelValues(spectypes.BNRoleAttester.String())
 	metricsAggregatorDataRequest                = metricsBeaconDataRequest.WithLabelValues(spectypes.BNRoleAggregator.String())
 	metricsProposerDataRequest                  = metricsBeaconDataRequest.WithLabelValues(spectypes.BNRoleProposer.String())
@@ -99,6 +145,16 @@ func ParseNodeClient(version string) NodeClient {
 	}
 }
 
+// parseForcedNodeClient parses a client type given explicitly by the operator.
+func parseForcedNodeClient(client string) (NodeClient, error) {
+	switch nodeClient := NodeClient(strings.ToLower(strings.TrimSpace(client))); nodeClient {
+	case NodeLighthouse, NodePrysm, NodeNimbus:
+		return nodeClient, nil
+	default:
+		return "", fmt.Errorf("unknown consensus client type %q", client)
+	}
+}
+
 // Client defines all go-eth2-client interfaces used in ssv
 type Client interface {
 	eth2client.Service
@@ -121,6 +177,7 @@ type Client interface {
 	eth2client.ProposalSubmitter
 	eth2client.BlindedProposalSubmitter
 	eth2client.DomainProvider
+	eth2client.ForkScheduleProvider
 	eth2client.SyncCommitteeMessagesSubmitter
 	eth2client.BeaconBlockRootProvider
 	eth2client.SyncCommitteeContributionProvider
@@ -140,20 +197,57 @@ var _ NodeClientProvider = (*goClient)(nil)
 
 // goClient implementing Beacon struct
 type goClient struct {
-	log                  *zap.Logger
-	ctx                  context.Context
-	network              beaconprotocol.Network
-	client               Client
-	nodeVersion          string
-	nodeClient           NodeClient
-	graffiti             []byte
-	gasLimit             uint64
-	operatorDataStore    operatordatastore.OperatorDataStore
-	registrationMu       sync.Mutex
-	registrationLastSlot phase0.Slot
-	registrationCache    map[phase0.BLSPubKey]*api.VersionedSignedValidatorRegistration
-	commonTimeout        time.Duration
-	longTimeout          time.Duration
+	log                   *zap.Logger
+	ctx                   context.Context
+	network               beaconprotocol.Network
+	client                Client
+	nodeClientMu          sync.RWMutex
+	nodeVersion           string
+	nodeClient            NodeClient
+	forcedNodeClient      NodeClient // overrides the client type detected from nodeVersion, if set
+	graffiti              []byte
+	graffitiTemplate      *graffitiTemplate
+	gasLimit              uint64
+	operatorDataStore     operatordatastore.OperatorDataStore
+	registrationMu        sync.Mutex
+	registrationLastSlot  phase0.Slot
+	registrationCache     map[phase0.BLSPubKey]*api.VersionedSignedValidatorRegistration
+	registrationPending   map[phase0.BLSPubKey]struct{}
+	registrationSubmitted map[phase0.BLSPubKey]submittedRegistration
+	registrationCachedAt  map[phase0.BLSPubKey]phase0.Slot // the slot at which each cached registration entered the cache
+	registrationSucceeded map[phase0.BLSPubKey]phase0.Slot // the slot of each cached registration's last successful submission
+	registrationStore     *registrationStore               // persists registrationCache, if set
+	commonTimeout         time.Duration
+	longTimeout           time.Duration
+	timeouts              requestTimeouts
+	attestationDataSlack  time.Duration
+	attestationHeadCheck  bool // compares the head vote of attestation data with head events
+	attestationWarnOnly   bool // submit attestations with invalid data rather than rejecting them
+	head                  *headTracker
+	attestationBatcher    *attestationBatcher
+	readNode              *routedNode // serves duty and validator queries, if set
+	writeNode             *routedNode // serves submissions, if set
+	dutyRoutes            *dutyRoutes // tracks the nodes serving each duty within its slot, if set
+	submissionLimiter     *submissionLimiter
+	enabledEndpoints      map[endpointClass]bool // classes of endpoints which may be called, all if nil
+	inFlight              inFlightRequests
+	tracer                trace.Tracer // exports beacon node requests as OpenTelemetry spans, if set
+	dutyTraces            *dutyTraces  // ties the beacon node requests of each duty together, if set
+	validatorCache        *validatorCache
+	validatorsInvalidated chan struct{}
+	validatorIndices      *validatorIndexCache // caches the indices of fetched validators, if set
+	domainCache           *domainCache
+	aggregationCache      *aggregationCache // caches aggregation decisions within a slot, if set
+	duties                *dutyTracker
+	optimism              *optimismGuard      // suppresses submissions while the beacon node is optimistic, if set
+	warmUp                *warmUpGuard        // treats the beacon node's data with caution after it reconnects, if set
+	clockOffset           *clockOffsetTracker // estimates the offset from the beacon node's clock, if set
+	clockOffsetThreshold  time.Duration
+	relayHealth           *relayHealth      // prefers local blocks after failed blinded proposals, if set
+	relay                 string            // identifies the relays behind the beacon node in relayHealth
+	events                *eventMultiplexer // shares a single events subscription among consumers, if set
+	subscriptionsMu       sync.Mutex
+	subscriptions         map[string]int // active event subscriptions by topic
 }
 
 // New init new client and go-client instance
@@ -163,7 +257,7 @@ func New(
 	operatorDataStore operatordatastore.OperatorDataStore,
 	slotTickerProvider slotticker.Provider,
 ) (beaconprotocol.BeaconNode, error) {
-	logger.Info("consensus client: connecting", fields.Address(opt.BeaconNodeAddr), fields.Network(string(opt.Network.BeaconNetwork)))
+	logger.Info("consensus client: connecting", fields.Address(RedactAddress(opt.BeaconNodeAddr)), fields.Network(string(opt.Network.BeaconNetwork)))
 
 	commonTimeout := opt.CommonTimeout
 	if commonTimeout == 0 {
@@ -174,54 +268,276 @@ func New(
 		longTimeout = DefaultLongTimeout
 	}
 
-	httpClient, err := eth2clienthttp.New(opt.Context,
-		// WithAddress supplies the address of the beacon node, in host:port format.
-		eth2clienthttp.WithAddress(opt.BeaconNodeAddr),
-		// LogLevel supplies the level of logging to carry out.
-		eth2clienthttp.WithLogLevel(zerolog.DebugLevel),
-		eth2clienthttp.WithTimeout(commonTimeout),
-		eth2clienthttp.WithReducedMemoryUsage(true),
-	)
+	proposalTimeout, err := proposalTimeoutOrDefault(opt.ProposalTimeout, commonTimeout, opt.Network.SlotDurationSec())
 	if err != nil {
-		return nil, fmt.Errorf("failed to create http client: %w", err)
+		return nil, err
+	}
+
+	httpClient, err := newHTTPClient(opt.Context, opt.BeaconNodeAddr, commonTimeout)
+	if err != nil {
+		return nil, err
 	}
 
 	client := &goClient{
-		log:               logger,
-		ctx:               opt.Context,
-		network:           opt.Network,
-		client:            httpClient.(*eth2clienthttp.Service),
-		graffiti:          opt.Graffiti,
-		gasLimit:          opt.GasLimit,
-		operatorDataStore: operatorDataStore,
-		registrationCache: map[phase0.BLSPubKey]*api.VersionedSignedValidatorRegistration{},
-		commonTimeout:     commonTimeout,
-		longTimeout:       longTimeout,
-	}
-
-	nodeVersionResp, err := client.client.NodeVersion(opt.Context, &api.NodeVersionOpts{})
+		log:                   logger,
+		ctx:                   opt.Context,
+		network:               opt.Network,
+		client:                httpClient,
+		graffiti:              opt.Graffiti,
+		gasLimit:              opt.GasLimit,
+		operatorDataStore:     operatorDataStore,
+		registrationCache:     map[phase0.BLSPubKey]*api.VersionedSignedValidatorRegistration{},
+		registrationPending:   map[phase0.BLSPubKey]struct{}{},
+		registrationSubmitted: map[phase0.BLSPubKey]submittedRegistration{},
+		registrationCachedAt:  map[phase0.BLSPubKey]phase0.Slot{},
+		registrationSucceeded: map[phase0.BLSPubKey]phase0.Slot{},
+		commonTimeout:         commonTimeout,
+		longTimeout:           longTimeout,
+		timeouts: requestTimeouts{
+			attestationData:      timeoutOrDefault(opt.AttestationDataTimeout, commonTimeout),
+			aggregateAttestation: timeoutOrDefault(opt.AggregateAttestationTimeout, commonTimeout),
+			proposal:             proposalTimeout,
+			validators:           timeoutOrDefault(opt.ValidatorsTimeout, longTimeout),
+		},
+		attestationDataSlack: opt.AttestationDataSlack,
+		attestationHeadCheck: opt.AttestationDataHeadCheck,
+		attestationWarnOnly:  opt.AttestationSanityWarnOnly,
+		domainCache:          newDomainCache(),
+		validatorIndices:     newValidatorIndexCache(),
+		duties:               newDutyTracker(),
+		subscriptions:        map[string]int{},
+	}
+	if client.enabledEndpoints, err = parseEnabledEndpoints(opt.EnabledEndpoints); err != nil {
+		return nil, fmt.Errorf("invalid enabled endpoints: %w", err)
+	}
+	if disabled := client.disabledEndpoints(); len(disabled) > 0 {
+		logger.Info("beacon node endpoints disabled by configuration", zap.Strings("classes", disabled))
+	}
+	if opt.TracerProvider != nil {
+		client.tracer = opt.TracerProvider.Tracer(tracerName)
+	}
+	client.dutyTraces = newDutyTraces(client.tracer)
+
+	nodeVersion, err := client.fetchNodeVersion(opt.Context)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get node version: %w", err)
+		return nil, err
 	}
-	if nodeVersionResp == nil {
-		return nil, fmt.Errorf("node version response is nil")
+	if opt.ForceNodeClient != "" {
+		forced, err := parseForcedNodeClient(opt.ForceNodeClient)
+		if err != nil {
+			return nil, err
+		}
+		if detected := ParseNodeClient(nodeVersion); forced != detected {
+			logger.Warn("consensus client type is overridden and differs from the detected type",
+				zap.String("forced", string(forced)),
+				zap.String("detected", string(detected)),
+				zap.String("version", nodeVersion),
+			)
+		}
+		client.forcedNodeClient = forced
+	}
+	client.setNodeClient(nodeVersion)
+
+	if client.readNode, err = client.connectRoutedNode(nodeRoleRead, opt.ReadBeaconNodeAddr); err != nil {
+		return nil, err
+	}
+	if client.writeNode, err = client.connectRoutedNode(nodeRoleWrite, opt.WriteBeaconNodeAddr); err != nil {
+		return nil, err
+	}
+	client.dutyRoutes = newDutyRoutes(opt.SlotStickyRouting)
+
+	if opt.GraffitiTemplate != "" {
+		client.graffitiTemplate, err = parseGraffitiTemplate(opt.GraffitiTemplate, commons.GetNodeVersion())
+		if err != nil {
+			return nil, fmt.Errorf("invalid graffiti template: %w", err)
+		}
+	}
+
+	genesis, err := client.verifyNetwork(opt.Context)
+	if err != nil {
+		return nil, err
 	}
-	client.nodeVersion = nodeVersionResp.Data
-	client.nodeClient = ParseNodeClient(nodeVersionResp.Data)
 
 	logger.Info("consensus client connected",
 		fields.Name(httpClient.Name()),
-		fields.Address(httpClient.Address()),
+		fields.Address(RedactAddress(opt.BeaconNodeAddr)),
 		zap.String("client", string(client.nodeClient)),
 		zap.String("version", client.nodeVersion),
+		zap.String("genesis_validators_root", fmt.Sprintf("%#x", genesis.GenesisValidatorsRoot)),
 	)
 
-	go client.registrationSubmitter(slotTickerProvider)
+	if opt.SubmissionRateLimit > 0 {
+		burst := opt.SubmissionRateBurst
+		if burst <= 0 {
+			burst = int(math.Ceil(opt.SubmissionRateLimit))
+		}
+		client.submissionLimiter = newSubmissionLimiter(rate.Limit(opt.SubmissionRateLimit), burst)
+	}
+
+	if opt.AttestationBatchWindow > 0 {
+		client.attestationBatcher = newAttestationBatcher(
+			opt.AttestationBatchWindow,
+			client.attestationSubmissionDeadline,
+			client.submitAttestations,
+		)
+	}
+
+	if len(opt.EventTopics) > 0 {
+		client.events = newEventMultiplexer(logger, opt.EventTopics)
+		if err := client.events.start(opt.Context, client.client); err != nil {
+			// Consumers subscribe to the beacon node directly.
+			client.events = nil
+			logger.Warn("failed to subscribe to events", zap.Error(err), zap.Strings("topics", opt.EventTopics))
+		}
+	}
+
+	if client.attestationDataSlack > 0 || client.attestationHeadCheck {
+		client.head = newHeadTracker()
+		if err := client.subscribeToHeadEvents(opt.Context); err != nil {
+			// Attestation data is fetched without waiting for the head, nor checked against it.
+			client.head = nil
+			logger.Warn("failed to subscribe to head events", zap.Error(err))
+		}
+	}
+
+	watchdog := &tickerWatchdog{
+		ctx:          opt.Context,
+		logger:       logger,
+		stallTimeout: time.Duration(tickerStallSlots * float64(opt.Network.SlotDurationSec())),
+		fallback:     opt.SlotTickerFallback,
+		currentSlot:  opt.Network.EstimatedCurrentSlot,
+	}
+	slotTickerProvider = watchdog.wrap(slotTickerProvider)
+
+	if err := client.updateForkSchedule(opt.Context); err != nil {
+		// Domains aren't cached until the fork schedule is known.
+		logger.Warn("failed to get fork schedule", zap.Error(err))
+	}
+	go client.forkScheduleWatcher(slotTickerProvider)
+
+	if opt.PreloadDomains {
+		start := time.Now()
+		if preloaded, err := client.preloadDomains(opt.Network.EstimatedCurrentEpoch()); err != nil {
+			// The remaining domains are fetched on first use.
+			logger.Warn("failed to preload domains", zap.Error(err), fields.Count(preloaded), fields.Took(time.Since(start)))
+		} else {
+			logger.Info("preloaded domains", fields.Count(preloaded), fields.Took(time.Since(start)))
+		}
+	}
+
+	go client.nodeVersionWatcher(slotTickerProvider)
+
+	client.startRegistrations(opt.RegistrationsDB, opt.ValidatorsProvider, slotTickerProvider)
+
+	go client.missedDutiesWatcher(slotTickerProvider)
+
+	if opt.RelayFallbackProposals > 0 {
+		var minValue *big.Int
+		if opt.RelayMinValueGwei > 0 {
+			minValue = new(big.Int).Mul(new(big.Int).SetUint64(opt.RelayMinValueGwei), big.NewInt(1e9))
+		}
+		client.relayHealth = newRelayHealth(int(opt.RelayFallbackProposals), minValue)
+		client.relay = RedactAddress(opt.BeaconNodeAddr)
+	}
+
+	if opt.OptimisticSuppressionSlots > 0 {
+		client.optimism = newOptimismGuard(phase0.Slot(opt.OptimisticSuppressionSlots))
+		go client.optimismWatcher(slotTickerProvider)
+	}
+
+	if opt.WarmUpSlots > 0 {
+		client.warmUp = newWarmUpGuard(phase0.Slot(opt.WarmUpSlots), warmUpReconnectSlots*opt.Network.SlotDurationSec())
+		if err := client.subscribeToWarmUpEvents(opt.Context); err != nil {
+			// Reconnects aren't detected, so the beacon node never warms up.
+			client.warmUp = nil
+			logger.Warn("failed to subscribe to head events, beacon node doesn't warm up", zap.Error(err))
+		}
+	}
+
+	if opt.ClockOffsetThreshold > 0 {
+		client.clockOffset = &clockOffsetTracker{}
+		client.clockOffsetThreshold = opt.ClockOffsetThreshold
+		if err := client.subscribeToClockOffsetEvents(opt.Context); err != nil {
+			logger.Warn("failed to subscribe to head events, clock offset isn't checked", zap.Error(err))
+		}
+	}
+
+	if opt.AggregationCacheSize > 0 {
+		client.aggregationCache = newAggregationCache(opt.AggregationCacheSize)
+	}
+
+	if opt.PrefetchValidators && opt.ValidatorsProvider != nil {
+		client.validatorCache = newValidatorCache()
+		client.validatorsInvalidated = make(chan struct{}, 1)
+		go client.validatorPrefetcher(slotTickerProvider, opt.ValidatorsProvider)
+	}
 
 	return client, nil
 }
 
+// verifyNetwork fails if the beacon node's genesis doesn't match the configured network,
+// which happens when the node is pointed at a beacon node of another network.
+func (gc *goClient) verifyNetwork(ctx context.Context) (*eth2apiv1.Genesis, error) {
+	genesisResp, err := gc.client.Genesis(ctx, &api.GenesisOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get genesis: %w", err)
+	}
+	if genesisResp == nil || genesisResp.Data == nil {
+		return nil, fmt.Errorf("genesis response is nil")
+	}
+	genesis := genesisResp.Data
+
+	expectedForkVersion := phase0.Version(gc.network.ForkVersion())
+	if genesis.GenesisForkVersion != expectedForkVersion {
+		return nil, fmt.Errorf("beacon node is on a different network than %s: genesis fork version %#x, expected %#x",
+			gc.network.BeaconNetwork, genesis.GenesisForkVersion, expectedForkVersion)
+	}
+
+	// Local testnets have a fixed genesis time which doesn't match the beacon node's.
+	if !gc.network.LocalTestNet && uint64(genesis.GenesisTime.Unix()) != gc.network.MinGenesisTime() {
+		return nil, fmt.Errorf("beacon node is on a different network than %s: genesis time %d, expected %d",
+			gc.network.BeaconNetwork, genesis.GenesisTime.Unix(), gc.network.MinGenesisTime())
+	}
+
+	return genesis, nil
+}
+
+// requestTimeouts holds the timeouts of requests which may override the client's common timeout.
+type requestTimeouts struct {
+	attestationData      time.Duration
+	aggregateAttestation time.Duration
+	proposal             time.Duration
+	validators           time.Duration
+}
+
+func timeoutOrDefault(timeout, defaultTimeout time.Duration) time.Duration {
+	if timeout == 0 {
+		return defaultTimeout
+	}
+	return timeout
+}
+
+// proposalTimeoutOrDefault returns the timeout of proposal requests, which may take longer than other
+// requests when blocks are built by relays. By default it's half a slot, but no less than the common timeout.
+// A configured timeout must leave enough of the slot to submit the block within the common timeout.
+func proposalTimeoutOrDefault(timeout, commonTimeout, slotDuration time.Duration) (time.Duration, error) {
+	if timeout == 0 {
+		if slotDuration/2 > commonTimeout {
+			return slotDuration / 2, nil
+		}
+		return commonTimeout, nil
+	}
+	if timeout+commonTimeout > slotDuration {
+		return 0, fmt.Errorf("proposal timeout %v leaves less than the common timeout %v to submit the block within a slot of %v",
+			timeout, commonTimeout, slotDuration)
+	}
+	return timeout, nil
+}
+
 func (gc *goClient) NodeClient() NodeClient {
+	gc.nodeClientMu.RLock()
+	defer gc.nodeClientMu.RUnlock()
+
 	return gc.nodeClient
 }
 
@@ -249,6 +565,9 @@ func (gc *goClient) Healthy(ctx context.Context) error {
 	if syncState.IsSyncing {
 		return fmt.Errorf("syncing")
 	}
+	if gc.optimism != nil {
+		gc.observeOptimism(gc.network.EstimatedCurrentSlot(), syncState.IsOptimistic)
+	}
 	if syncState.IsOptimistic {
 		return fmt.Errorf("optimistic")
 	}
@@ -257,6 +576,27 @@ func (gc *goClient) Healthy(ctx context.Context) error {
 	return nil
 }
 
+// Live returns an error if the beacon node can't be reached at all,
+// regardless of whether it's able to serve duties.
+func (gc *goClient) Live(ctx context.Context) error {
+	if _, err := gc.client.NodeVersion(ctx, &api.NodeVersionOpts{}); err != nil {
+		return fmt.Errorf("failed to reach beacon node: %w", err)
+	}
+	return nil
+}
+
+// Ready returns an error if the beacon node can't serve duties: it must be healthy (see Healthy),
+// and the events subscription must be active if the client subscribed to events.
+func (gc *goClient) Ready(ctx context.Context) error {
+	if err := gc.Healthy(ctx); err != nil {
+		return err
+	}
+	if gc.head != nil && !gc.subscribed(headEventTopic) {
+		return fmt.Errorf("not subscribed to %s events", headEventTopic)
+	}
+	return nil
+}
+
 // GetBeaconNetwork returns the beacon network the node is on
 func (gc *goClient) GetBeaconNetwork() spectypes.BeaconNetwork {
 	return gc.network.BeaconNetwork
@@ -270,6 +610,52 @@ func (gc *goClient) slotStartTime(slot phase0.Slot) time.Time {
 	return startTime
 }
 
+// Events subscribes to the given event topics until the context is done.
+// Subscriptions to topics which the client subscribes to on startup share its subscription,
+// rather than opening another connection to the beacon node.
 func (gc *goClient) Events(ctx context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
-	return gc.client.Events(ctx, topics, handler)
+	if gc.events != nil && gc.events.covers(topics) {
+		gc.events.subscribe(ctx, topics, handler)
+	} else if err := gc.client.Events(ctx, topics, handler); err != nil {
+		return err
+	}
+
+	gc.updateSubscriptions(topics, 1)
+	go func() {
+		<-ctx.Done()
+		gc.updateSubscriptions(topics, -1)
+	}()
+	return nil
+}
+
+// SubscribedTopics returns the sorted event topics which currently have an active subscription.
+func (gc *goClient) SubscribedTopics() []string {
+	gc.subscriptionsMu.Lock()
+	defer gc.subscriptionsMu.Unlock()
+
+	topics := make([]string, 0, len(gc.subscriptions))
+	for topic := range gc.subscriptions {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+func (gc *goClient) subscribed(topic string) bool {
+	gc.subscriptionsMu.Lock()
+	defer gc.subscriptionsMu.Unlock()
+
+	return gc.subscriptions[topic] > 0
+}
+
+func (gc *goClient) updateSubscriptions(topics []string, delta int) {
+	gc.subscriptionsMu.Lock()
+	defer gc.subscriptionsMu.Unlock()
+
+	for _, topic := range topics {
+		gc.subscriptions[topic] += delta
+		if gc.subscriptions[topic] <= 0 {
+			delete(gc.subscriptions, topic)
+		}
+	}
 }
@@ -10,11 +10,15 @@ import (
 	"testing"
 	"time"
 
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/ssv-spec/types"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	beacontesting "github.com/bloxapp/ssv/beacon/goclient/testing"
 	operatordatastore "github.com/bloxapp/ssv/operator/datastore"
 	"github.com/bloxapp/ssv/operator/slotticker"
 	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
@@ -94,6 +98,76 @@ func TestTimeouts(t *testing.T) {
 	}
 }
 
+func TestNetworkVerification(t *testing.T) {
+	ctx := context.Background()
+	server := mockServer(t, delays{})
+
+	newClient := func(network beacon.Network) (beacon.BeaconNode, error) {
+		return New(
+			zap.NewNop(),
+			beacon.Options{
+				Context:        ctx,
+				Network:        network,
+				BeaconNodeAddr: server.URL,
+			},
+			operatordatastore.New(&registrystorage.OperatorData{ID: 1}),
+			func() slotticker.SlotTicker {
+				return slotticker.New(zap.NewNop(), slotticker.Config{
+					SlotDuration: 12 * time.Second,
+					GenesisTime:  time.Now(),
+				})
+			},
+		)
+	}
+
+	// The mock server serves mainnet's genesis.
+	_, err := newClient(beacon.NewNetwork(types.MainNetwork))
+	require.NoError(t, err)
+
+	_, err = newClient(beacon.NewNetwork(types.HoleskyNetwork))
+	require.ErrorContains(t, err, "beacon node is on a different network than holesky: genesis fork version 0x00000000, expected 0x01017000")
+
+	// Local testnets share mainnet's fork version but have their own genesis time.
+	_, err = newClient(beacon.NewLocalTestNetwork(types.MainNetwork))
+	require.NoError(t, err)
+}
+
+func TestForceNodeClient(t *testing.T) {
+	ctx := context.Background()
+	server := mockServer(t, delays{})
+
+	newClient := func(forceNodeClient string) (beacon.BeaconNode, error) {
+		return New(
+			zap.NewNop(),
+			beacon.Options{
+				Context:         ctx,
+				Network:         beacon.NewNetwork(types.MainNetwork),
+				BeaconNodeAddr:  server.URL,
+				ForceNodeClient: forceNodeClient,
+			},
+			operatordatastore.New(&registrystorage.OperatorData{ID: 1}),
+			func() slotticker.SlotTicker {
+				return slotticker.New(zap.NewNop(), slotticker.Config{
+					SlotDuration: 12 * time.Second,
+					GenesisTime:  time.Now(),
+				})
+			},
+		)
+	}
+
+	client, err := newClient("")
+	require.NoError(t, err)
+	detected := client.(NodeClientProvider).NodeClient()
+
+	client, err = newClient("Prysm")
+	require.NoError(t, err)
+	require.Equal(t, NodePrysm, client.(NodeClientProvider).NodeClient())
+	require.NotEqual(t, NodePrysm, detected)
+
+	_, err = newClient("teku-fork")
+	require.ErrorContains(t, err, `unknown consensus client type "teku-fork"`)
+}
+
 func mockClient(t *testing.T, ctx context.Context, serverURL string, commonTimeout, longTimeout time.Duration) (beacon.BeaconNode, error) {
 	return New(
 		zap.NewNop(),
@@ -157,3 +231,151 @@ func mockServer(t *testing.T, delays delays) *httptest.Server {
 		}
 	}))
 }
+
+func TestRequestTimeouts(t *testing.T) {
+	const (
+		commonTimeout = 100 * time.Millisecond
+		longTimeout   = 500 * time.Millisecond
+	)
+	recorder := &timeoutRecorder{
+		deadlines: map[string]time.Duration{},
+		timeouts:  map[string]time.Duration{},
+	}
+	gc := &goClient{
+		log:     zap.NewNop(),
+		ctx:     context.Background(),
+		network: beacon.NewNetwork(types.MainNetwork),
+		client:  recorder,
+		timeouts: requestTimeouts{
+			attestationData:      timeoutOrDefault(50*time.Millisecond, commonTimeout),
+			aggregateAttestation: timeoutOrDefault(0, commonTimeout),
+			proposal:             timeoutOrDefault(2*time.Second, commonTimeout),
+			validators:           timeoutOrDefault(0, longTimeout),
+		},
+	}
+
+	_, _, err := gc.SubmitAggregateSelectionProof(0, 0, 1, 0, make([]byte, 96)) // Also fetches attestation data.
+	require.ErrorContains(t, err, "aggregate attestation")
+	_, _, err = gc.GetBeaconBlock(0, nil, make([]byte, 96))
+	require.ErrorContains(t, err, "proposal")
+	_, err = gc.GetValidatorData([]phase0.BLSPubKey{{1}})
+	require.ErrorContains(t, err, "validators")
+
+	expected := map[string]time.Duration{
+		"attestation_data":      50 * time.Millisecond,
+		"aggregate_attestation": commonTimeout,
+		"proposal":              2 * time.Second,
+		"validators":            longTimeout,
+	}
+	require.Equal(t, expected, recorder.timeouts)
+	require.Len(t, recorder.deadlines, len(expected))
+	for path, timeout := range expected {
+		require.InDelta(t, timeout, recorder.deadlines[path], float64(20*time.Millisecond), path)
+	}
+}
+
+// timeoutRecorder records the context deadline and the timeout option of each request.
+type timeoutRecorder struct {
+	Client
+	deadlines map[string]time.Duration
+	timeouts  map[string]time.Duration
+}
+
+func (r *timeoutRecorder) record(ctx context.Context, path string, timeout time.Duration) {
+	if deadline, ok := ctx.Deadline(); ok {
+		r.deadlines[path] = time.Until(deadline)
+	}
+	r.timeouts[path] = timeout
+}
+
+func (r *timeoutRecorder) AttestationData(ctx context.Context, opts *api.AttestationDataOpts) (*api.Response[*phase0.AttestationData], error) {
+	r.record(ctx, "attestation_data", opts.Common.Timeout)
+	return &api.Response[*phase0.AttestationData]{
+		Data: &phase0.AttestationData{
+			Source: &phase0.Checkpoint{},
+			Target: &phase0.Checkpoint{},
+		},
+	}, nil
+}
+
+func (r *timeoutRecorder) AggregateAttestation(ctx context.Context, opts *api.AggregateAttestationOpts) (*api.Response[*phase0.Attestation], error) {
+	r.record(ctx, "aggregate_attestation", opts.Common.Timeout)
+	return nil, fmt.Errorf("aggregate attestation unavailable")
+}
+
+func (r *timeoutRecorder) Proposal(ctx context.Context, opts *api.ProposalOpts) (*api.Response[*api.VersionedProposal], error) {
+	r.record(ctx, "proposal", opts.Common.Timeout)
+	return nil, fmt.Errorf("proposal unavailable")
+}
+
+func (r *timeoutRecorder) Validators(ctx context.Context, opts *api.ValidatorsOpts) (*api.Response[map[phase0.ValidatorIndex]*eth2apiv1.Validator], error) {
+	r.record(ctx, "validators", opts.Common.Timeout)
+	return nil, fmt.Errorf("validators unavailable")
+}
+
+func TestProposalTimeout(t *testing.T) {
+	const slotDuration = 12 * time.Second
+
+	// By default, proposals may take half a slot, but no less than other requests.
+	timeout, err := proposalTimeoutOrDefault(0, 5*time.Second, slotDuration)
+	require.NoError(t, err)
+	require.Equal(t, 6*time.Second, timeout)
+	timeout, err = proposalTimeoutOrDefault(0, 8*time.Second, slotDuration)
+	require.NoError(t, err)
+	require.Equal(t, 8*time.Second, timeout)
+
+	timeout, err = proposalTimeoutOrDefault(7*time.Second, 5*time.Second, slotDuration)
+	require.NoError(t, err)
+	require.Equal(t, 7*time.Second, timeout)
+
+	// The block must still be submitted within the slot.
+	_, err = proposalTimeoutOrDefault(8*time.Second, 5*time.Second, slotDuration)
+	require.ErrorContains(t, err, "proposal timeout")
+}
+
+func TestSubscribedTopics(t *testing.T) {
+	events := beacontesting.NewEventStream()
+	gc := &goClient{
+		log:           zap.NewNop(),
+		client:        eventsClient{events: events},
+		subscriptions: map[string]int{},
+	}
+	require.Empty(t, gc.SubscribedTopics())
+
+	headCtx, cancelHead := context.WithCancel(context.Background())
+	require.NoError(t, gc.Events(headCtx, []string{beacontesting.TopicHead}, func(*eth2apiv1.Event) {}))
+
+	reorgCtx, cancelReorg := context.WithCancel(context.Background())
+	defer cancelReorg()
+	require.NoError(t, gc.Events(reorgCtx, []string{beacontesting.TopicHead, beacontesting.TopicChainReorg}, func(*eth2apiv1.Event) {}))
+	require.Equal(t, []string{beacontesting.TopicChainReorg, beacontesting.TopicHead}, gc.SubscribedTopics())
+
+	// Topics remain subscribed while any of their subscriptions is active.
+	cancelHead()
+	require.Eventually(t, func() bool {
+		gc.subscriptionsMu.Lock()
+		defer gc.subscriptionsMu.Unlock()
+		return gc.subscriptions[beacontesting.TopicHead] == 1
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{beacontesting.TopicChainReorg, beacontesting.TopicHead}, gc.SubscribedTopics())
+
+	cancelReorg()
+	require.Eventually(t, func() bool {
+		return len(gc.SubscribedTopics()) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	// Failed subscriptions aren't tracked.
+	events.FailSubscriptions(fmt.Errorf("unavailable"))
+	require.Error(t, gc.Events(context.Background(), []string{beacontesting.TopicHead}, func(*eth2apiv1.Event) {}))
+	require.Empty(t, gc.SubscribedTopics())
+}
+
+// eventsClient serves events from an EventStream.
+type eventsClient struct {
+	Client
+	events *beacontesting.EventStream
+}
+
+func (c eventsClient) Events(ctx context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
+	return c.events.Events(ctx, topics, handler)
+}
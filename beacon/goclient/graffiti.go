@@ -0,0 +1,83 @@
+package goclient
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+)
+
+// Variables of graffiti templates.
+const (
+	graffitiSlot       = "{slot}"
+	graffitiOperatorID = "{operator_id}"
+	graffitiVersion    = "{version}"
+)
+
+const (
+	graffitiSize = 32
+
+	// maxUint64Digits is the length of the longest decimal uint64, which slots and operator IDs expand to at most.
+	maxUint64Digits = 20
+)
+
+var graffitiVariable = regexp.MustCompile(`\{[^{}]*\}`)
+
+// graffitiTemplate renders the graffiti of block proposals from a template with variables.
+type graffitiTemplate struct {
+	template string
+	version  string
+}
+
+// parseGraffitiTemplate parses a graffiti template, failing if it has unknown variables
+// or if its longest expansion doesn't fit in the graffiti.
+func parseGraffitiTemplate(template, version string) (*graffitiTemplate, error) {
+	for _, variable := range graffitiVariable.FindAllString(template, -1) {
+		switch variable {
+		case graffitiSlot, graffitiOperatorID, graffitiVersion:
+		default:
+			return nil, fmt.Errorf("unknown graffiti template variable %s", variable)
+		}
+	}
+
+	t := &graffitiTemplate{
+		template: template,
+		version:  shortVersion(version),
+	}
+
+	longest := strings.Repeat("9", maxUint64Digits)
+	if expanded := t.expand(longest, longest); len(expanded) > graffitiSize {
+		return nil, fmt.Errorf("graffiti template expands to up to %d bytes, which exceeds %d bytes", len(expanded), graffitiSize)
+	}
+
+	return t, nil
+}
+
+// render returns the graffiti of a block proposal of the given slot.
+func (t *graffitiTemplate) render(slot phase0.Slot, operatorID spectypes.OperatorID) [graffitiSize]byte {
+	var graffiti [graffitiSize]byte
+	copy(graffiti[:], t.expand(
+		strconv.FormatUint(uint64(slot), 10),
+		strconv.FormatUint(operatorID, 10),
+	))
+	return graffiti
+}
+
+func (t *graffitiTemplate) expand(slot, operatorID string) string {
+	return strings.NewReplacer(
+		graffitiSlot, slot,
+		graffitiOperatorID, operatorID,
+		graffitiVersion, t.version,
+	).Replace(t.template)
+}
+
+// shortVersion strips the pre-release and build metadata from the version (e.g. v1.2.3-4-gabcdef becomes v1.2.3).
+func shortVersion(version string) string {
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		return version[:i]
+	}
+	return version
+}
@@ -0,0 +1,52 @@
+package goclient
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraffitiTemplate(t *testing.T) {
+	template, err := parseGraffitiTemplate("SSV {version} {operator_id}", "v1.3.4-12-gabcdef")
+	require.NoError(t, err)
+
+	require.Equal(t, "SSV v1.3.4 42", graffitiString(template.render(123, 42)))
+
+	template, err = parseGraffitiTemplate("SSV/{slot}", "")
+	require.NoError(t, err)
+	require.Equal(t, "SSV/8000000", graffitiString(template.render(8000000, 1)))
+
+	// Templates without variables are rendered as is.
+	template, err = parseGraffitiTemplate("SSV.Network", "v1.0.0")
+	require.NoError(t, err)
+	require.Equal(t, "SSV.Network", graffitiString(template.render(1, 1)))
+}
+
+func TestGraffitiTemplateOverflow(t *testing.T) {
+	// Slots and operator IDs may expand to 20 digits each.
+	_, err := parseGraffitiTemplate("{slot}{operator_id}", "")
+	require.ErrorContains(t, err, "expands to up to 40 bytes")
+
+	_, err = parseGraffitiTemplate("SSV.Network slot {slot}", "")
+	require.ErrorContains(t, err, "expands to up to 37 bytes")
+
+	_, err = parseGraffitiTemplate("SSV.Network {version}", "v1.3.4-very-long-pre-release-version")
+	require.NoError(t, err)
+
+	_, err = parseGraffitiTemplate("SSV.Network {version}", "v1.3.4.very.long.release.version")
+	require.ErrorContains(t, err, "exceeds 32 bytes")
+
+	// The longest expansion that fits.
+	_, err = parseGraffitiTemplate("SSV/op {operator_id}/1234", "")
+	require.NoError(t, err)
+}
+
+func TestGraffitiTemplateUnknownVariable(t *testing.T) {
+	_, err := parseGraffitiTemplate("SSV {epoch}", "")
+	require.ErrorContains(t, err, "unknown graffiti template variable {epoch}")
+}
+
+func graffitiString(graffiti [32]byte) string {
+	return string(bytes.TrimRight(graffiti[:], "\x00"))
+}
@@ -0,0 +1,176 @@
+package goclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+)
+
+var (
+	metricsAttestationDataHeadChanged = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv_beacon_attestation_data_head_changed",
+		Help: "Count of attestation data fetches where the head changed while waiting past 1/3 of the slot",
+	})
+	metricsAttestationDataHeadMismatch = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv_beacon_attestation_data_head_mismatch",
+		Help: "Count of attestation data fetches whose head vote differs from the head of their slot reported by head events",
+	})
+	metricsAttestationDataWait = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "ssv_beacon_attestation_data_wait_seconds",
+		Help:    "Time waited past 1/3 of the slot for a head event before fetching attestation data (seconds)",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.2, 0.5, 1, 2},
+	})
+)
+
+func init() {
+	logger := zap.L()
+	for _, c := range []prometheus.Collector{metricsAttestationDataHeadChanged, metricsAttestationDataHeadMismatch, metricsAttestationDataWait} {
+		if err := prometheus.Register(c); err != nil {
+			logger.Debug("could not register prometheus collector")
+		}
+	}
+}
+
+// headTracker keeps the latest head reported by the beacon node's head events.
+type headTracker struct {
+	mu      sync.Mutex
+	slot    phase0.Slot
+	root    phase0.Root
+	changed chan struct{} // closed and replaced on every head update
+}
+
+func newHeadTracker() *headTracker {
+	return &headTracker{
+		changed: make(chan struct{}),
+	}
+}
+
+func (ht *headTracker) update(slot phase0.Slot, root phase0.Root) {
+	ht.mu.Lock()
+	defer ht.mu.Unlock()
+
+	if slot < ht.slot {
+		return
+	}
+
+	ht.slot = slot
+	ht.root = root
+	close(ht.changed)
+	ht.changed = make(chan struct{})
+}
+
+func (ht *headTracker) head() (phase0.Slot, phase0.Root, <-chan struct{}) {
+	ht.mu.Lock()
+	defer ht.mu.Unlock()
+
+	return ht.slot, ht.root, ht.changed
+}
+
+const headEventTopic = "head"
+
+func (gc *goClient) subscribeToHeadEvents(ctx context.Context) error {
+	return gc.Events(ctx, []string{headEventTopic}, func(event *eth2apiv1.Event) {
+		data, ok := event.Data.(*eth2apiv1.HeadEvent)
+		if !ok || data == nil {
+			gc.log.Warn("unexpected head event data", zap.Any("data", event.Data))
+			return
+		}
+		gc.head.update(data.Slot, data.Block)
+	})
+}
+
+// waitForSlotHead waits until the beacon node reports a head for the given slot
+// or until the attestation data slack past 1/3 of the slot elapses, whichever comes first.
+func (gc *goClient) waitForSlotHead(ctx context.Context, slot phase0.Slot) {
+	if gc.attestationDataSlack == 0 || gc.head == nil {
+		return
+	}
+
+	expectedFetchTime := gc.slotStartTime(slot).Add(gc.network.SlotDurationSec() / time.Duration(IntervalsPerSlot))
+	timer := time.NewTimer(time.Until(expectedFetchTime.Add(gc.attestationDataSlack)))
+	defer timer.Stop()
+
+	headSlot, headRoot, changed := gc.head.head()
+	if headSlot >= slot {
+		return
+	}
+
+	// The wait starts past 1/3 of the slot, so the head changing before then isn't counted.
+	waitStart := expectedFetchTime
+	if now := time.Now(); now.After(waitStart) {
+		waitStart = now
+	}
+	startRoot := headRoot
+
+wait:
+	for headSlot < slot {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			gc.log.Debug("no head event for slot within attestation data slack",
+				fields.Slot(slot),
+				zap.Uint64("head_slot", uint64(headSlot)),
+			)
+			break wait
+		case <-changed:
+			headSlot, headRoot, changed = gc.head.head()
+			if time.Now().Before(waitStart) {
+				startRoot = headRoot
+			}
+		}
+	}
+
+	// The head of the slot arrived before 1/3 of the slot, so there was no wait.
+	if !time.Now().After(waitStart) {
+		return
+	}
+	metricsAttestationDataWait.Observe(time.Since(waitStart).Seconds())
+	if headRoot != startRoot {
+		metricsAttestationDataHeadChanged.Inc()
+	}
+}
+
+// attestationHeadWatch returns a channel which is closed once the head changes, to be passed to
+// checkAttestationDataHead after fetching attestation data, or nil if the head isn't checked.
+func (gc *goClient) attestationHeadWatch() <-chan struct{} {
+	if !gc.attestationHeadCheck || gc.head == nil {
+		return nil
+	}
+	_, _, changed := gc.head.head()
+	return changed
+}
+
+// checkAttestationDataHead compares the head vote of the fetched attestation data with the head of its slot
+// reported by head events, reporting a mismatch, which indicates that the beacon node produced the data for
+// a stale head (e.g. while lagging behind). Data is compared only once a head event of its slot was observed,
+// and not if the head changed while the data was being fetched (see attestationHeadWatch).
+func (gc *goClient) checkAttestationDataHead(data *phase0.AttestationData, headChanged <-chan struct{}) {
+	if headChanged == nil || data == nil {
+		return
+	}
+	select {
+	case <-headChanged:
+		return
+	default:
+	}
+
+	headSlot, headRoot, _ := gc.head.head()
+	if headSlot != data.Slot || data.BeaconBlockRoot == headRoot {
+		return
+	}
+	metricsAttestationDataHeadMismatch.Inc()
+	gc.log.Warn("attestation data votes for a different head than reported by head events, the beacon node may be lagging",
+		fields.Slot(data.Slot),
+		zap.String("data_head", data.BeaconBlockRoot.String()),
+		zap.String("event_head", headRoot.String()),
+	)
+}
@@ -0,0 +1,146 @@
+package goclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+func TestWaitForSlotHead(t *testing.T) {
+	ctx := context.Background()
+	network := beacon.NewNetwork(types.MainNetwork)
+
+	newClient := func() *goClient {
+		return &goClient{
+			log:                  zap.NewNop(),
+			network:              network,
+			attestationDataSlack: time.Second,
+			head:                 newHeadTracker(),
+		}
+	}
+
+	t.Run("head already at slot", func(t *testing.T) {
+		gc := newClient()
+		slot := network.EstimatedCurrentSlot() + 10
+		gc.head.update(slot, phase0.Root{1})
+
+		start := time.Now()
+		gc.waitForSlotHead(ctx, slot)
+		require.Less(t, time.Since(start), 100*time.Millisecond)
+	})
+
+	headChanged := func() float64 {
+		return testutil.ToFloat64(metricsAttestationDataHeadChanged)
+	}
+
+	t.Run("head event arrives", func(t *testing.T) {
+		gc := newClient()
+		slot := network.EstimatedCurrentSlot() + 10
+		gc.head.update(slot-1, phase0.Root{1})
+		initialHeadChanged := headChanged()
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			gc.head.update(slot-1, phase0.Root{2})
+			gc.head.update(slot, phase0.Root{3})
+		}()
+
+		start := time.Now()
+		gc.waitForSlotHead(ctx, slot)
+		require.Less(t, time.Since(start), time.Second)
+
+		headSlot, headRoot, _ := gc.head.head()
+		require.Equal(t, slot, headSlot)
+		require.Equal(t, phase0.Root{3}, headRoot)
+
+		// The head changed before 1/3 of the slot, so not while waiting.
+		require.Equal(t, initialHeadChanged, headChanged())
+	})
+
+	t.Run("head changes while waiting past 1/3 of the slot", func(t *testing.T) {
+		gc := newClient()
+		gc.attestationDataSlack = 2 * network.SlotDurationSec()
+		slot := network.EstimatedCurrentSlot() - 1
+		gc.head.update(slot-1, phase0.Root{1})
+		initialHeadChanged := headChanged()
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			gc.head.update(slot-1, phase0.Root{2})
+			gc.head.update(slot, phase0.Root{3})
+		}()
+
+		start := time.Now()
+		gc.waitForSlotHead(ctx, slot)
+		require.Less(t, time.Since(start), time.Second)
+		require.Equal(t, initialHeadChanged+1, headChanged())
+	})
+
+	t.Run("slack elapsed", func(t *testing.T) {
+		gc := newClient()
+		slot := network.EstimatedCurrentSlot() - 10
+
+		start := time.Now()
+		gc.waitForSlotHead(ctx, slot)
+		require.Less(t, time.Since(start), 100*time.Millisecond)
+	})
+
+	t.Run("stale head ignored", func(t *testing.T) {
+		gc := newClient()
+		gc.head.update(10, phase0.Root{1})
+		gc.head.update(9, phase0.Root{2})
+
+		headSlot, headRoot, _ := gc.head.head()
+		require.Equal(t, phase0.Slot(10), headSlot)
+		require.Equal(t, phase0.Root{1}, headRoot)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		gc := newClient()
+		gc.attestationDataSlack = 0
+		slot := network.EstimatedCurrentSlot() + 10
+
+		start := time.Now()
+		gc.waitForSlotHead(ctx, slot)
+		require.Less(t, time.Since(start), 100*time.Millisecond)
+	})
+}
+
+func TestCheckAttestationDataHead(t *testing.T) {
+	gc := &goClient{
+		log:                  zap.NewNop(),
+		attestationHeadCheck: true,
+		head:                 newHeadTracker(),
+	}
+	mismatches := func() float64 {
+		return testutil.ToFloat64(metricsAttestationDataHeadMismatch)
+	}
+	initial := mismatches()
+	gc.head.update(10, phase0.Root{1})
+
+	// Data of the observed head, or of a slot without an observed head, isn't reported.
+	gc.checkAttestationDataHead(&phase0.AttestationData{Slot: 10, BeaconBlockRoot: phase0.Root{1}}, gc.attestationHeadWatch())
+	gc.checkAttestationDataHead(&phase0.AttestationData{Slot: 11, BeaconBlockRoot: phase0.Root{1}}, gc.attestationHeadWatch())
+	require.Equal(t, initial, mismatches())
+
+	gc.checkAttestationDataHead(&phase0.AttestationData{Slot: 10, BeaconBlockRoot: phase0.Root{2}}, gc.attestationHeadWatch())
+	require.Equal(t, initial+1, mismatches())
+
+	// Data fetched while the head changed isn't compared.
+	headChanged := gc.attestationHeadWatch()
+	gc.head.update(10, phase0.Root{3})
+	gc.checkAttestationDataHead(&phase0.AttestationData{Slot: 10, BeaconBlockRoot: phase0.Root{1}}, headChanged)
+	require.Equal(t, initial+1, mismatches())
+
+	// The check is optional.
+	gc.attestationHeadCheck = false
+	require.Nil(t, gc.attestationHeadWatch())
+}
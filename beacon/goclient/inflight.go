@@ -0,0 +1,82 @@
+package goclient
+
+import (
+	"context"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var metricsRequestsInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "ssv_beacon_requests_in_flight",
+	Help: "Number of outstanding beacon node requests by endpoint class",
+}, []string{"class"})
+
+func init() {
+	logger := zap.L()
+	if err := prometheus.Register(metricsRequestsInFlight); err != nil {
+		logger.Debug("could not register prometheus collector")
+	}
+}
+
+// inFlightRequests counts the outstanding beacon node requests by endpoint class, so that a beacon node
+// saturated by concurrent requests can be told apart from one which is slow to serve each request.
+type inFlightRequests struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// start counts a request of the given class as outstanding until done is called.
+// It returns the number of outstanding requests of the class, including this one.
+func (r *inFlightRequests) start(class string) (count int, done func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.counts == nil {
+		r.counts = make(map[string]int)
+	}
+	r.counts[class]++
+	metricsRequestsInFlight.WithLabelValues(class).Inc()
+
+	var once sync.Once
+	return r.counts[class], func() {
+		once.Do(func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+
+			r.counts[class]--
+			metricsRequestsInFlight.WithLabelValues(class).Dec()
+		})
+	}
+}
+
+// submissionInFlightClass returns the endpoint class of submissions of the given class.
+func submissionInFlightClass(class submissionClass) string {
+	return "submit_" + string(class)
+}
+
+// submit issues a submission of the given class once it's allowed (see waitForSubmission),
+// counting it as outstanding while it's issued. It's issued to the write node, if one is set (see route).
+func (gc *goClient) submit(ctx context.Context, class submissionClass, submission func(client Client) error) error {
+	if err := gc.waitForSubmission(ctx, class); err != nil {
+		return err
+	}
+	_, done := gc.inFlight.start(submissionInFlightClass(class))
+	defer done()
+	return gc.route(gc.writeNode, string(class), submission)
+}
+
+// submitDuty issues a submission of the duties of the given role and slot like submit,
+// routing it along with the duty's other requests (see routeDuty).
+func (gc *goClient) submitDuty(ctx context.Context, class submissionClass, role spectypes.BeaconRole, slot phase0.Slot, submission func(client Client) error) error {
+	if err := gc.waitForSubmission(ctx, class); err != nil {
+		return err
+	}
+	_, done := gc.inFlight.start(submissionInFlightClass(class))
+	defer done()
+	return gc.routeDuty(gc.writeNode, role, slot, string(class), submission)
+}
@@ -0,0 +1,49 @@
+package goclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+func TestInFlightRequests(t *testing.T) {
+	var requests inFlightRequests
+	gauge := metricsRequestsInFlight.WithLabelValues("test_class")
+	initial := testutil.ToFloat64(gauge)
+
+	count, done1 := requests.start("test_class")
+	require.Equal(t, 1, count)
+	count, done2 := requests.start("test_class")
+	require.Equal(t, 2, count)
+	require.Equal(t, initial+2, testutil.ToFloat64(gauge))
+
+	// Requests are counted as done once, however many times done is called.
+	done1()
+	done1()
+	require.Equal(t, initial+1, testutil.ToFloat64(gauge))
+	done2()
+	require.Equal(t, initial, testutil.ToFloat64(gauge))
+}
+
+func TestSubmitInFlight(t *testing.T) {
+	gc := &goClient{
+		log:     zap.NewNop(),
+		ctx:     context.Background(),
+		network: beacon.NewNetwork(types.MainNetwork),
+	}
+	gauge := metricsRequestsInFlight.WithLabelValues(submissionInFlightClass(submissionExit))
+	initial := testutil.ToFloat64(gauge)
+
+	// Submissions are outstanding while they're issued.
+	require.NoError(t, gc.submit(gc.ctx, submissionExit, func(Client) error {
+		require.Equal(t, initial+1, testutil.ToFloat64(gauge))
+		return nil
+	}))
+	require.Equal(t, initial, testutil.ToFloat64(gauge))
+}
@@ -0,0 +1,116 @@
+package goclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/operator/slotticker"
+)
+
+var metricsNodeClient = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "ssv_beacon_node_client",
+	Help: "Type of the connected beacon node's client (1 for the current type, 0 for former ones)",
+}, []string{"client"})
+
+func init() {
+	logger := zap.L()
+	if err := prometheus.Register(metricsNodeClient); err != nil {
+		logger.Debug("could not register prometheus collector")
+	}
+}
+
+// fetchNodeVersion returns the beacon node's version string.
+func (gc *goClient) fetchNodeVersion(ctx context.Context) (string, error) {
+	nodeVersionResp, err := gc.client.NodeVersion(ctx, &api.NodeVersionOpts{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get node version: %w", err)
+	}
+	if nodeVersionResp == nil {
+		return "", fmt.Errorf("node version response is nil")
+	}
+	return nodeVersionResp.Data, nil
+}
+
+// setNodeClient records the beacon node's version and client type, returning the previous and current client types.
+// The client type isn't derived from the version if it's forced by the operator.
+func (gc *goClient) setNodeClient(version string) (previous, current NodeClient) {
+	gc.nodeClientMu.Lock()
+	defer gc.nodeClientMu.Unlock()
+
+	previous = gc.nodeClient
+	gc.nodeVersion = version
+	if gc.forcedNodeClient == "" {
+		gc.nodeClient = ParseNodeClient(version)
+	} else {
+		gc.nodeClient = gc.forcedNodeClient
+	}
+
+	if previous != gc.nodeClient {
+		if previous != "" {
+			metricsNodeClient.WithLabelValues(string(previous)).Set(0)
+		}
+		metricsNodeClient.WithLabelValues(string(gc.nodeClient)).Set(1)
+	}
+	return previous, gc.nodeClient
+}
+
+// nodeVersionWatcher re-detects the beacon node's client type every epoch, since the beacon node
+// behind the configured address may be replaced by another client, e.g. when the operator switches clients.
+func (gc *goClient) nodeVersionWatcher(slotTickerProvider slotticker.Provider) {
+	ticker := slotTickerProvider()
+	lastEpoch := gc.network.EstimatedCurrentEpoch()
+	for {
+		select {
+		case <-gc.ctx.Done():
+			return
+		case <-ticker.Next():
+			epoch := gc.network.EstimatedEpochAtSlot(ticker.Slot())
+			if epoch == lastEpoch {
+				continue
+			}
+			lastEpoch = epoch
+
+			if err := gc.redetectNodeClient(gc.ctx); err != nil {
+				gc.log.Debug("failed to re-detect consensus client", zap.Error(err))
+			}
+		}
+	}
+}
+
+// redetectNodeClient fetches the beacon node's version again, logging any change of its client type.
+func (gc *goClient) redetectNodeClient(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, gc.commonTimeout)
+	defer cancel()
+
+	version, err := gc.fetchNodeVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	gc.nodeClientMu.RLock()
+	previousVersion := gc.nodeVersion
+	gc.nodeClientMu.RUnlock()
+	if version == previousVersion {
+		return nil
+	}
+
+	previous, current := gc.setNodeClient(version)
+	if previous != current {
+		gc.log.Warn("consensus client changed",
+			zap.String("previous", string(previous)),
+			zap.String("client", string(current)),
+			zap.String("version", version),
+		)
+	} else {
+		gc.log.Info("consensus client version changed",
+			zap.String("previous_version", previousVersion),
+			zap.String("version", version),
+		)
+	}
+	return nil
+}
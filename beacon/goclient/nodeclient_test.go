@@ -0,0 +1,45 @@
+package goclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRedetectNodeClient(t *testing.T) {
+	client := &versionClient{version: "Lighthouse/v4.5.0-441fc16/x86_64-linux"}
+	gc := &goClient{
+		log:           zap.NewNop(),
+		client:        client,
+		commonTimeout: time.Second,
+	}
+	gc.setNodeClient(client.version)
+	require.Equal(t, NodeLighthouse, gc.NodeClient())
+
+	// The beacon node is replaced by another client behind the same address.
+	client.version = "Prysm/v4.2.1 (linux amd64)"
+	require.NoError(t, gc.redetectNodeClient(context.Background()))
+	require.Equal(t, NodePrysm, gc.NodeClient())
+	require.Equal(t, client.version, gc.nodeVersion)
+
+	// A forced client type survives upgrades.
+	gc.forcedNodeClient = NodeNimbus
+	client.version = "Lighthouse/v5.0.0-b5bae6e/x86_64-linux"
+	require.NoError(t, gc.redetectNodeClient(context.Background()))
+	require.Equal(t, NodeNimbus, gc.NodeClient())
+	require.Equal(t, client.version, gc.nodeVersion)
+}
+
+// versionClient serves the given node version.
+type versionClient struct {
+	Client
+	version string
+}
+
+func (c *versionClient) NodeVersion(context.Context, *api.NodeVersionOpts) (*api.Response[string], error) {
+	return &api.Response[string]{Data: c.version}, nil
+}
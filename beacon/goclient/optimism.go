@@ -0,0 +1,121 @@
+package goclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/operator/slotticker"
+)
+
+var metricsOptimisticSuppressed = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "ssv_beacon_submissions_suppressed_optimistic",
+	Help: "Count of submissions suppressed because the beacon node is, or recently was, optimistic, by endpoint class",
+}, []string{"class"})
+
+func init() {
+	logger := zap.L()
+	if err := prometheus.Register(metricsOptimisticSuppressed); err != nil {
+		logger.Debug("could not register prometheus collector")
+	}
+}
+
+// ErrOptimisticSuppression is returned for submissions suppressed by the optimism guard.
+var ErrOptimisticSuppression = fmt.Errorf("beacon node is optimistic")
+
+// optimismGuard suppresses submissions once the beacon node reports that it's optimistic, so that
+// validators don't attest to or build on blocks whose execution payload wasn't verified yet.
+// Suppression lasts for graceSlots since the node first reported optimistic, and beyond that
+// until the node confirms it's no longer optimistic.
+type optimismGuard struct {
+	graceSlots phase0.Slot
+
+	mu         sync.Mutex
+	active     bool
+	since      phase0.Slot
+	optimistic bool
+}
+
+func newOptimismGuard(graceSlots phase0.Slot) *optimismGuard {
+	return &optimismGuard{graceSlots: graceSlots}
+}
+
+// observe records the optimistic status reported by the beacon node at the given slot.
+// It returns whether suppression started.
+func (g *optimismGuard) observe(slot phase0.Slot, optimistic bool) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.optimistic = optimistic
+	if optimistic && !g.active {
+		g.active = true
+		g.since = slot
+		return true
+	}
+	return false
+}
+
+// suppressed returns whether submissions are suppressed at the given slot.
+func (g *optimismGuard) suppressed(slot phase0.Slot) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.active {
+		return false
+	}
+	if g.optimistic || slot < g.since+g.graceSlots {
+		return true
+	}
+	g.active = false
+	return false
+}
+
+// checkOptimism fails submissions of the given class while they're suppressed by the optimism guard.
+// Only attestations and proposals are suppressed, since these are the submissions which vote for blocks.
+func (gc *goClient) checkOptimism(class submissionClass) error {
+	if gc.optimism == nil || (class != submissionAttestation && class != submissionProposal) {
+		return nil
+	}
+	if !gc.optimism.suppressed(gc.network.EstimatedCurrentSlot()) {
+		return nil
+	}
+	metricsOptimisticSuppressed.WithLabelValues(string(class)).Inc()
+	return fmt.Errorf("%s submission suppressed: %w", class, ErrOptimisticSuppression)
+}
+
+// optimismWatcher polls the beacon node's optimistic status every slot.
+func (gc *goClient) optimismWatcher(slotTickerProvider slotticker.Provider) {
+	ticker := slotTickerProvider()
+	for {
+		select {
+		case <-gc.ctx.Done():
+			return
+		case <-ticker.Next():
+			ctx, cancel := context.WithTimeout(gc.ctx, gc.commonTimeout)
+			resp, err := gc.client.NodeSyncing(ctx, &api.NodeSyncingOpts{})
+			cancel()
+			if err != nil || resp == nil || resp.Data == nil {
+				// The last known status holds until the node responds.
+				gc.log.Debug("failed to poll optimistic status", zap.Error(err))
+				continue
+			}
+			gc.observeOptimism(ticker.Slot(), resp.Data.IsOptimistic)
+		}
+	}
+}
+
+func (gc *goClient) observeOptimism(slot phase0.Slot, optimistic bool) {
+	if gc.optimism.observe(slot, optimistic) {
+		gc.log.Warn("beacon node is optimistic, suppressing attestations and proposals",
+			fields.Slot(slot),
+			zap.Uint64("grace_slots", uint64(gc.optimism.graceSlots)),
+		)
+	}
+}
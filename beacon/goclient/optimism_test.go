@@ -0,0 +1,53 @@
+package goclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+func TestOptimismGuard(t *testing.T) {
+	guard := newOptimismGuard(3)
+	require.False(t, guard.suppressed(10))
+
+	// Suppression starts once the node reports optimistic.
+	require.True(t, guard.observe(10, true))
+	require.False(t, guard.observe(11, true))
+	require.True(t, guard.suppressed(11))
+
+	// The node recovers, but suppression lasts for the grace period.
+	guard.observe(12, false)
+	require.True(t, guard.suppressed(12))
+	require.False(t, guard.suppressed(13))
+
+	// Suppression outlasts the grace period while the node is still optimistic.
+	require.True(t, guard.observe(20, true))
+	require.True(t, guard.suppressed(30))
+	guard.observe(30, false)
+	require.False(t, guard.suppressed(30))
+	require.False(t, guard.suppressed(31))
+}
+
+func TestCheckOptimism(t *testing.T) {
+	network := beacon.NewNetwork(types.MainNetwork)
+	gc := &goClient{
+		log:     zap.NewNop(),
+		ctx:     context.Background(),
+		network: network,
+	}
+	require.NoError(t, gc.waitForSubmission(gc.ctx, submissionAttestation))
+
+	gc.optimism = newOptimismGuard(2)
+	gc.observeOptimism(network.EstimatedCurrentSlot(), true)
+	require.ErrorIs(t, gc.waitForSubmission(gc.ctx, submissionAttestation), ErrOptimisticSuppression)
+	require.ErrorIs(t, gc.waitForSubmission(gc.ctx, submissionProposal), ErrOptimisticSuppression)
+
+	// Submissions which don't vote for blocks aren't suppressed.
+	require.NoError(t, gc.waitForSubmission(gc.ctx, submissionRegistration))
+	require.NoError(t, gc.waitForSubmission(gc.ctx, submissionSubscription))
+}
@@ -2,6 +2,7 @@ package goclient
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -20,17 +21,30 @@ import (
 
 	"github.com/bloxapp/ssv/logging/fields"
 	"github.com/bloxapp/ssv/operator/slotticker"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 )
 
 const (
 	batchSize = 500
+
+	// registrationResubmitEpochs is the number of epochs after which unchanged
+	// registrations are resubmitted to keep them fresh in the relays.
+	registrationResubmitEpochs = 8
 )
 
+// ErrStaleRegistration is returned when a validator registration is older than its cached one,
+// which would regress the registration at the relays.
+var ErrStaleRegistration = errors.New("validator registration is older than the cached one")
+
 // ProposerDuties returns proposer duties for the given epoch.
 func (gc *goClient) ProposerDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*eth2apiv1.ProposerDuty, error) {
-	resp, err := gc.client.ProposerDuties(ctx, &api.ProposerDutiesOpts{
-		Epoch:   epoch,
-		Indices: validatorIndices,
+	var resp *api.Response[[]*eth2apiv1.ProposerDuty]
+	err := gc.route(gc.readNode, readRequestDuties, func(client Client) (err error) {
+		resp, err = client.ProposerDuties(ctx, &api.ProposerDutiesOpts{
+			Epoch:   epoch,
+			Indices: validatorIndices,
+		})
+		return err
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to obtain proposer duties: %w", err)
@@ -39,24 +53,60 @@ func (gc *goClient) ProposerDuties(ctx context.Context, epoch phase0.Epoch, vali
 		return nil, fmt.Errorf("proposer duties response is nil")
 	}
 
+	gc.duties.assignProposerDuties(gc.network.EstimatedCurrentSlot(), resp.Data)
+	gc.flagWarmUp("proposer_duties")
 	return resp.Data, nil
 }
 
 // GetBeaconBlock returns beacon block by the given slot, graffiti, and randao.
+// The graffiti is rendered from the graffiti template instead, if one is configured.
 func (gc *goClient) GetBeaconBlock(slot phase0.Slot, graffitiBytes, randao []byte) (ssz.Marshaler, spec.DataVersion, error) {
+	if err := gc.checkEndpoint(endpointProposal); err != nil {
+		return nil, DataVersionNil, err
+	}
+
+	return gc.getProposal(slot, graffitiBytes, randao)
+}
+
+// getProposal requests a proposal, which may be blinded unless blinded proposals are disabled.
+func (gc *goClient) getProposal(slot phase0.Slot, graffitiBytes, randao []byte) (ssz.Marshaler, spec.DataVersion, error) {
 	sig := phase0.BLSSignature{}
 	copy(sig[:], randao[:])
 
 	graffiti := [32]byte{}
 	copy(graffiti[:], graffitiBytes[:])
+	if gc.graffitiTemplate != nil {
+		var operatorID spectypes.OperatorID
+		if gc.operatorDataStore != nil {
+			operatorID = gc.operatorDataStore.GetOperatorID()
+		}
+		graffiti = gc.graffitiTemplate.render(slot, operatorID)
+	}
 
-	reqStart := time.Now()
-	proposalResp, err := gc.client.Proposal(gc.ctx, &api.ProposalOpts{
+	ctx, cancel := context.WithTimeout(gc.ctx, gc.timeouts.proposal)
+	defer cancel()
+
+	opts := &api.ProposalOpts{
 		Slot:                   slot,
 		RandaoReveal:           sig,
 		Graffiti:               graffiti,
 		SkipRandaoVerification: false,
+		Common:                 api.CommonOpts{Timeout: gc.timeouts.proposal},
+	}
+	preferLocal := gc.relayHealth != nil && gc.relayHealth.preferLocal(gc.relay)
+	if preferLocal || !gc.endpointEnabled(endpointBlindedProposal) {
+		// A zero boost factor makes the beacon node propose a local block unless no local block is available.
+		var noBuilderBoost uint64
+		opts.BuilderBoostFactor = &noBuilderBoost
+	}
+
+	var proposalResp *api.Response[*api.VersionedProposal]
+	span := gc.startRequest(ctx, spectypes.BNRoleProposer, slot, "proposal", metricsProposerDataRequest)
+	err := gc.fetchDutyData(spectypes.BNRoleProposer, slot, "proposal", func(client Client) (err error) {
+		proposalResp, err = client.Proposal(span.ctx, opts)
+		return err
 	})
+	span.end(err)
 	if err != nil {
 		return nil, DataVersionNil, fmt.Errorf("failed to get proposal: %w", err)
 	}
@@ -67,10 +117,16 @@ func (gc *goClient) GetBeaconBlock(slot phase0.Slot, graffitiBytes, randao []byt
 		return nil, DataVersionNil, fmt.Errorf("proposal data is nil")
 	}
 
-	metricsProposerDataRequest.Observe(time.Since(reqStart).Seconds())
 	beaconBlock := proposalResp.Data
+	if gc.relayHealth != nil {
+		gc.observeRelayProposal(slot, beaconBlock, preferLocal)
+	}
 
 	if beaconBlock.Blinded {
+		if err := gc.checkEndpoint(endpointBlindedProposal); err != nil {
+			// The beacon node had no local block to propose instead.
+			return nil, DataVersionNil, err
+		}
 		switch beaconBlock.Version {
 		case spec.DataVersionCapella:
 			if beaconBlock.CapellaBlinded == nil {
@@ -131,10 +187,18 @@ func (gc *goClient) GetBeaconBlock(slot phase0.Slot, graffitiBytes, randao []byt
 }
 
 func (gc *goClient) GetBlindedBeaconBlock(slot phase0.Slot, graffiti, randao []byte) (ssz.Marshaler, spec.DataVersion, error) {
-	return gc.GetBeaconBlock(slot, graffiti, randao)
+	if err := gc.checkEndpoint(endpointBlindedProposal); err != nil {
+		return nil, DataVersionNil, err
+	}
+
+	return gc.getProposal(slot, graffiti, randao)
 }
 
 func (gc *goClient) SubmitBlindedBeaconBlock(block *api.VersionedBlindedProposal, sig phase0.BLSSignature) error {
+	if err := gc.checkEndpoint(endpointBlindedProposal); err != nil {
+		return err
+	}
+
 	signedBlock := &api.VersionedSignedBlindedProposal{
 		Version: block.Version,
 	}
@@ -169,11 +233,36 @@ func (gc *goClient) SubmitBlindedBeaconBlock(block *api.VersionedBlindedProposal
 		Proposal: signedBlock,
 	}
 
-	return gc.client.SubmitBlindedProposal(gc.ctx, opts)
+	slot, err := block.Slot()
+	if err != nil {
+		return fmt.Errorf("failed to get blinded block slot: %w", err)
+	}
+
+	if err := gc.submitDuty(gc.ctx, submissionProposal, spectypes.BNRoleProposer, slot, func(client Client) error {
+		return client.SubmitBlindedProposal(gc.ctx, opts)
+	}); err != nil {
+		if gc.relayHealth != nil {
+			gc.relayHealth.recordBlinded(gc.relay, slot, true)
+		}
+		return err
+	}
+
+	dutySubmitted(spectypes.BNRoleProposer)
+	if gc.relayHealth != nil {
+		gc.relayHealth.recordBlinded(gc.relay, slot, false)
+	}
+	if proposer, err := block.ProposerIndex(); err == nil {
+		gc.duties.submitProposal(slot, proposer)
+	}
+	return nil
 }
 
 // SubmitBeaconBlock submit the block to the node
 func (gc *goClient) SubmitBeaconBlock(block *api.VersionedProposal, sig phase0.BLSSignature) error {
+	if err := gc.checkEndpoint(endpointProposal); err != nil {
+		return err
+	}
+
 	signedBlock := &api.VersionedSignedProposal{
 		Version: block.Version,
 	}
@@ -215,14 +304,47 @@ func (gc *goClient) SubmitBeaconBlock(block *api.VersionedProposal, sig phase0.B
 		Proposal: signedBlock,
 	}
 
-	return gc.client.SubmitProposal(gc.ctx, opts)
+	slot, err := block.Slot()
+	if err != nil {
+		return fmt.Errorf("failed to get block slot: %w", err)
+	}
+
+	if err := gc.submitDuty(gc.ctx, submissionProposal, spectypes.BNRoleProposer, slot, func(client Client) error {
+		return client.SubmitProposal(gc.ctx, opts)
+	}); err != nil {
+		return err
+	}
+
+	dutySubmitted(spectypes.BNRoleProposer)
+	if proposer, err := block.ProposerIndex(); err == nil {
+		gc.duties.submitProposal(slot, proposer)
+	}
+	return nil
 }
 
+var _ beaconprotocol.TimestampedRegistrationSubmitter = (*goClient)(nil)
+
+// SubmitValidatorRegistration caches the validator registration for submission,
+// assuming it was signed with the start of the current epoch as its timestamp.
 func (gc *goClient) SubmitValidatorRegistration(pubkey []byte, feeRecipient bellatrix.ExecutionAddress, sig phase0.BLSSignature) error {
-	return gc.updateBatchRegistrationCache(gc.createValidatorRegistration(pubkey, feeRecipient, sig))
+	return gc.SubmitTimestampedValidatorRegistration(pubkey, feeRecipient, gc.registrationTimestamp(), sig)
+}
+
+// SubmitTimestampedValidatorRegistration caches the validator registration which was signed with the given timestamp
+// for submission, unless a registration with a later timestamp is cached for the validator (see ErrStaleRegistration).
+func (gc *goClient) SubmitTimestampedValidatorRegistration(pubkey []byte, feeRecipient bellatrix.ExecutionAddress, timestamp time.Time, sig phase0.BLSSignature) error {
+	if err := gc.checkEndpoint(endpointRegistration); err != nil {
+		return err
+	}
+
+	return gc.updateBatchRegistrationCache(gc.createValidatorRegistration(pubkey, feeRecipient, timestamp, sig))
 }
 
 func (gc *goClient) SubmitProposalPreparation(feeRecipients map[phase0.ValidatorIndex]bellatrix.ExecutionAddress) error {
+	if err := gc.checkEndpoint(endpointProposal); err != nil {
+		return err
+	}
+
 	var preparations []*eth2apiv1.ProposalPreparation
 	for index, recipient := range feeRecipients {
 		preparations = append(preparations, &eth2apiv1.ProposalPreparation{
@@ -230,7 +352,9 @@ func (gc *goClient) SubmitProposalPreparation(feeRecipients map[phase0.Validator
 			FeeRecipient:   recipient,
 		})
 	}
-	return gc.client.SubmitProposalPreparations(gc.ctx, preparations)
+	return gc.submit(gc.ctx, submissionRegistration, func(client Client) error {
+		return client.SubmitProposalPreparations(gc.ctx, preparations)
+	})
 }
 
 func (gc *goClient) updateBatchRegistrationCache(registration *api.VersionedSignedValidatorRegistration) error {
@@ -239,14 +363,68 @@ func (gc *goClient) updateBatchRegistrationCache(registration *api.VersionedSign
 		return err
 	}
 
+	hash, err := registrationHash(registration)
+	if err != nil {
+		return fmt.Errorf("failed to hash registration: %w", err)
+	}
+
 	gc.registrationMu.Lock()
 	defer gc.registrationMu.Unlock()
 
+	cached, ok := gc.registrationCache[pk]
+	if ok && cached.V1 != nil && cached.V1.Message != nil &&
+		registration.V1.Message.Timestamp.Before(cached.V1.Message.Timestamp) {
+		metricsRegistrationsStale.Inc()
+		gc.log.Warn("rejected stale validator registration",
+			fields.PubKey(pk[:]),
+			zap.Time("timestamp", registration.V1.Message.Timestamp),
+			zap.Time("cached_timestamp", cached.V1.Message.Timestamp),
+		)
+		return fmt.Errorf("%w: timestamp %v is before %v", ErrStaleRegistration,
+			registration.V1.Message.Timestamp, cached.V1.Message.Timestamp)
+	}
+
+	if !ok {
+		gc.registrationCachedAt[pk] = gc.network.EstimatedCurrentSlot()
+	}
 	gc.registrationCache[pk] = registration
+	if submitted, ok := gc.registrationSubmitted[pk]; !ok || submitted.hash != hash {
+		gc.registrationPending[pk] = struct{}{}
+	}
+
+	if gc.registrationStore != nil {
+		if err := gc.registrationStore.save(pk, registration); err != nil {
+			// The registration remains cached in memory, and is persisted on its next update.
+			gc.log.Warn("failed to persist validator registration", zap.Error(err), fields.PubKey(pk[:]))
+		}
+	}
 	return nil
 }
 
-func (gc *goClient) createValidatorRegistration(pubkey []byte, feeRecipient bellatrix.ExecutionAddress, sig phase0.BLSSignature) *api.VersionedSignedValidatorRegistration {
+// submittedRegistration describes the last submission of a validator registration.
+type submittedRegistration struct {
+	hash [32]byte
+	slot phase0.Slot
+}
+
+// registrationHash hashes the registration's content, excluding the timestamp,
+// which changes every epoch without changing the registration's meaning.
+func registrationHash(registration *api.VersionedSignedValidatorRegistration) ([32]byte, error) {
+	if registration.V1 == nil || registration.V1.Message == nil {
+		return [32]byte{}, fmt.Errorf("registration message is nil")
+	}
+
+	message := *registration.V1.Message
+	message.Timestamp = time.Unix(0, 0)
+	return message.HashTreeRoot()
+}
+
+// registrationTimestamp returns the timestamp of registrations signed in the current epoch: the start of the epoch.
+func (gc *goClient) registrationTimestamp() time.Time {
+	return gc.network.GetSlotStartTime(gc.network.GetEpochFirstSlot(gc.network.EstimatedCurrentEpoch()))
+}
+
+func (gc *goClient) createValidatorRegistration(pubkey []byte, feeRecipient bellatrix.ExecutionAddress, timestamp time.Time, sig phase0.BLSSignature) *api.VersionedSignedValidatorRegistration {
 	pk := phase0.BLSPubKey{}
 	copy(pk[:], pubkey)
 
@@ -256,7 +434,7 @@ func (gc *goClient) createValidatorRegistration(pubkey []byte, feeRecipient bell
 			Message: &eth2apiv1.ValidatorRegistration{
 				FeeRecipient: feeRecipient,
 				GasLimit:     gc.gasLimit,
-				Timestamp:    gc.network.GetSlotStartTime(gc.network.GetEpochFirstSlot(gc.network.EstimatedCurrentEpoch())),
+				Timestamp:    timestamp,
 				Pubkey:       pk,
 			},
 			Signature: sig,
@@ -265,9 +443,25 @@ func (gc *goClient) createValidatorRegistration(pubkey []byte, feeRecipient bell
 	return signedReg
 }
 
+// Bounds of the backoff between readiness checks of the beacon node before the first submission of registrations.
+const (
+	registrationReadinessMinBackoff = time.Second
+	registrationReadinessMaxBackoff = time.Minute
+)
+
 func (gc *goClient) registrationSubmitter(slotTickerProvider slotticker.Provider) {
 	operatorID := gc.operatorDataStore.AwaitOperatorID()
 
+	// Registrations submitted to a syncing beacon node may not be associated with active validators.
+	ready := func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, gc.commonTimeout)
+		defer cancel()
+		return gc.Ready(ctx)
+	}
+	if !awaitRegistrationReady(gc.ctx, gc.log, ready, registrationReadinessMinBackoff, registrationReadinessMaxBackoff) {
+		return
+	}
+
 	ticker := slotTickerProvider()
 	for {
 		select {
@@ -279,6 +473,40 @@ func (gc *goClient) registrationSubmitter(slotTickerProvider slotticker.Provider
 	}
 }
 
+// awaitRegistrationReady blocks the registration submitter until ready succeeds, retrying with an exponential backoff
+// between minBackoff and maxBackoff. It returns false if the context is done first.
+func awaitRegistrationReady(ctx context.Context, logger *zap.Logger, ready func(ctx context.Context) error, minBackoff, maxBackoff time.Duration) bool {
+	// The submitter is no longer blocked once it stops waiting, whether ready or shutting down.
+	defer metricsRegistrationSubmitterBlocked.Set(0)
+
+	backoff := minBackoff
+	for attempt := 1; ; attempt++ {
+		err := ready(ctx)
+		if err == nil {
+			if attempt > 1 {
+				logger.Info("beacon node is ready, submitting validator registrations")
+			}
+			return true
+		}
+
+		metricsRegistrationSubmitterBlocked.Set(1)
+		if attempt == 1 {
+			logger.Info("waiting for beacon node to be ready before submitting validator registrations", zap.Error(err))
+		} else {
+			logger.Debug("beacon node is not ready yet", zap.Error(err), zap.Int("attempt", attempt), zap.Duration("backoff", backoff))
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
 func (gc *goClient) submitRegistrationsFromCache(currentSlot phase0.Slot, operatorID spectypes.OperatorID) {
 	slotsPerEpoch := gc.network.SlotsPerEpoch()
 
@@ -295,37 +523,209 @@ func (gc *goClient) submitRegistrationsFromCache(currentSlot phase0.Slot, operat
 	oneEpochPassed := slotsSinceLastRegistration >= phase0.Slot(slotsPerEpoch)
 	twoEpochsAndOperatorDelayPassed := uint64(slotsSinceLastRegistration) >= slotsPerEpoch*2+operatorSubmissionSlotModulo
 
-	if hasRegistrations && (oneEpochPassed && operatorSubmissionSlot || twoEpochsAndOperatorDelayPassed) {
+	regularSubmission := hasRegistrations && (oneEpochPassed && operatorSubmissionSlot || twoEpochsAndOperatorDelayPassed)
+	if regularSubmission {
 		gc.registrationLastSlot = currentSlot
-		registrations := gc.registrationList()
+	}
 
-		// Release lock after building a registrations list for submission.
-		gc.registrationMu.Unlock()
+	// Changed registrations are submitted immediately,
+	// unchanged ones only on a regular submission once they're due for a refresh.
+	registrations, skipped := gc.registrationList(currentSlot, regularSubmission)
+	gc.reportRegistrationCache(currentSlot)
 
-		if err := gc.submitBatchedRegistrations(currentSlot, registrations); err != nil {
-			gc.log.Error("Failed to submit validator registrations",
-				zap.Error(err),
-				fields.Slot(currentSlot))
-		}
+	// Release lock after building a registrations list for submission.
+	gc.registrationMu.Unlock()
 
+	if skipped != 0 {
+		metricsRegistrationsSkipped.Add(float64(skipped))
+	}
+	if len(registrations) == 0 {
 		return
 	}
 
-	gc.registrationMu.Unlock()
+	if err := gc.submitBatchedRegistrations(currentSlot, registrations); err != nil {
+		gc.log.Error("Failed to submit validator registrations",
+			zap.Error(err),
+			fields.Slot(currentSlot))
+
+		gc.resetSubmittedRegistrations(registrations)
+	}
 }
 
-// registrationList is not thread-safe
-func (gc *goClient) registrationList() []*api.VersionedSignedValidatorRegistration {
+// registrationList returns the registrations to submit at the given slot and marks them as submitted.
+// It also returns the number of unchanged registrations that were skipped.
+// registrationList is not thread-safe.
+func (gc *goClient) registrationList(currentSlot phase0.Slot, regularSubmission bool) ([]*api.VersionedSignedValidatorRegistration, int) {
+	resubmitSlots := phase0.Slot(registrationResubmitEpochs * gc.network.SlotsPerEpoch())
+
 	result := make([]*api.VersionedSignedValidatorRegistration, 0)
+	skipped := 0
+
+	for pk, registration := range gc.registrationCache {
+		_, pending := gc.registrationPending[pk]
+		submitted, ok := gc.registrationSubmitted[pk]
 
-	for _, registration := range gc.registrationCache {
+		// Registrations whose last submission failed are retried on the next regular submission.
+		if !pending {
+			if !regularSubmission {
+				continue
+			}
+			if ok && currentSlot-submitted.slot < resubmitSlots {
+				skipped++
+				continue
+			}
+		}
+
+		hash, err := registrationHash(registration)
+		if err != nil {
+			gc.log.Warn("failed to hash registration", zap.Error(err), fields.PubKey(pk[:]))
+			continue
+		}
+
+		gc.registrationSubmitted[pk] = submittedRegistration{hash: hash, slot: currentSlot}
+		delete(gc.registrationPending, pk)
 		result = append(result, registration)
 	}
 
-	return result
+	return result, skipped
+}
+
+// reportRegistrationCache reports the size of the registration cache and the age of its least recently
+// successfully submitted registration, which grows beyond registrationResubmitEpochs if the submitter falls behind
+// or its submissions fail. Registrations that were never submitted successfully are aged from when they entered the cache.
+// reportRegistrationCache is not thread-safe.
+func (gc *goClient) reportRegistrationCache(currentSlot phase0.Slot) {
+	var oldestAge phase0.Slot
+	for pk := range gc.registrationCache {
+		since, ok := gc.registrationSucceeded[pk]
+		if !ok {
+			since = gc.registrationCachedAt[pk]
+		}
+		if currentSlot > since && currentSlot-since > oldestAge {
+			oldestAge = currentSlot - since
+		}
+	}
+	metricsRegistrationCacheSize.Set(float64(len(gc.registrationCache)))
+	metricsRegistrationOldestSubmissionAge.Set(float64(oldestAge))
+}
+
+// ErrRegistrationNotAvailable is returned when a validator's registration can't be submitted to the beacon node yet.
+var ErrRegistrationNotAvailable = errors.New("validator registration not yet available")
+
+// EnsureValidatorRegistration makes sure the validator's registration was submitted to the beacon node.
+// A cached registration which is pending submission (e.g. of a validator added mid-epoch) is submitted
+// immediately rather than on the next registrationSubmitter tick.
+// It returns ErrRegistrationNotAvailable if the registration isn't cached yet or fails to be submitted.
+func (gc *goClient) EnsureValidatorRegistration(pubkey phase0.BLSPubKey) error {
+	if err := gc.checkEndpoint(endpointRegistration); err != nil {
+		return err
+	}
+
+	currentSlot := gc.network.EstimatedCurrentSlot()
+
+	gc.registrationMu.Lock()
+	registration, cached := gc.registrationCache[pubkey]
+	if !cached {
+		gc.registrationMu.Unlock()
+		return fmt.Errorf("%w: registration is not cached", ErrRegistrationNotAvailable)
+	}
+	_, pending := gc.registrationPending[pubkey]
+	if _, submitted := gc.registrationSubmitted[pubkey]; submitted && !pending {
+		gc.registrationMu.Unlock()
+		return nil
+	}
+	hash, err := registrationHash(registration)
+	if err != nil {
+		gc.registrationMu.Unlock()
+		return fmt.Errorf("%w: failed to hash registration: %v", ErrRegistrationNotAvailable, err)
+	}
+	gc.registrationSubmitted[pubkey] = submittedRegistration{hash: hash, slot: currentSlot}
+	delete(gc.registrationPending, pubkey)
+	gc.registrationMu.Unlock()
+
+	metricsRegistrationsOnDemand.Inc()
+
+	registrations := []*api.VersionedSignedValidatorRegistration{registration}
+	if err := gc.submitBatchedRegistrations(currentSlot, registrations); err != nil {
+		gc.resetSubmittedRegistrations(registrations)
+		return fmt.Errorf("%w: failed to submit registration: %v", ErrRegistrationNotAvailable, err)
+	}
+	return nil
+}
+
+// RegistrationInfo describes the state of a validator's registration in the registration cache.
+type RegistrationInfo struct {
+	FeeRecipient bellatrix.ExecutionAddress
+	GasLimit     uint64
+	Timestamp    time.Time
+
+	// Pending is whether the registration changed since it was last submitted, and awaits submission.
+	Pending bool
+	// Submitted is whether the registration was submitted, in which case LastSubmittedSlot is the slot of the last submission.
+	// A registration which is neither pending nor submitted failed to be submitted, and awaits the next regular submission.
+	Submitted         bool
+	LastSubmittedSlot phase0.Slot
+}
+
+// RegistrationStatus returns the state of the validator's cached registration,
+// or false if the validator's registration isn't cached.
+func (gc *goClient) RegistrationStatus(pubkey phase0.BLSPubKey) (*RegistrationInfo, bool) {
+	gc.registrationMu.Lock()
+	defer gc.registrationMu.Unlock()
+
+	registration, ok := gc.registrationCache[pubkey]
+	if !ok || registration.V1 == nil || registration.V1.Message == nil {
+		return nil, false
+	}
+
+	_, pending := gc.registrationPending[pubkey]
+	submitted, wasSubmitted := gc.registrationSubmitted[pubkey]
+	return &RegistrationInfo{
+		FeeRecipient:      registration.V1.Message.FeeRecipient,
+		GasLimit:          registration.V1.Message.GasLimit,
+		Timestamp:         registration.V1.Message.Timestamp,
+		Pending:           pending,
+		Submitted:         wasSubmitted,
+		LastSubmittedSlot: submitted.slot,
+	}, true
+}
+
+// resetSubmittedRegistrations marks the given registrations as unsubmitted after a failed submission,
+// so that they're retried on the next regular submission rather than on every slot until the beacon node recovers.
+func (gc *goClient) resetSubmittedRegistrations(registrations []*api.VersionedSignedValidatorRegistration) {
+	gc.registrationMu.Lock()
+	defer gc.registrationMu.Unlock()
+
+	for _, registration := range registrations {
+		pk, err := registration.PubKey()
+		if err != nil {
+			continue
+		}
+		delete(gc.registrationSubmitted, pk)
+	}
+}
+
+// markSubmittedRegistrations records the given slot as the last successful submission of the given registrations.
+func (gc *goClient) markSubmittedRegistrations(slot phase0.Slot, registrations []*api.VersionedSignedValidatorRegistration) {
+	gc.registrationMu.Lock()
+	defer gc.registrationMu.Unlock()
+
+	for _, registration := range registrations {
+		pk, err := registration.PubKey()
+		if err != nil {
+			continue
+		}
+		if _, ok := gc.registrationCache[pk]; ok {
+			gc.registrationSucceeded[pk] = slot
+		}
+	}
 }
 
 func (gc *goClient) submitBatchedRegistrations(slot phase0.Slot, registrations []*api.VersionedSignedValidatorRegistration) error {
+	if err := gc.checkWarmUp(); err != nil {
+		return err
+	}
+
 	gc.log.Info("going to submit batch validator registrations",
 		fields.Slot(slot),
 		fields.Count(len(registrations)))
@@ -336,9 +736,12 @@ func (gc *goClient) submitBatchedRegistrations(slot phase0.Slot, registrations [
 			bs = len(registrations)
 		}
 
-		if err := gc.client.SubmitValidatorRegistrations(gc.ctx, registrations[0:bs]); err != nil {
+		if err := gc.submit(gc.ctx, submissionRegistration, func(client Client) error {
+			return client.SubmitValidatorRegistrations(gc.ctx, registrations[0:bs])
+		}); err != nil {
 			return err
 		}
+		gc.markSubmittedRegistrations(slot, registrations[0:bs])
 
 		registrations = registrations[bs:]
 
@@ -0,0 +1,329 @@
+package goclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+func TestRegistrationList(t *testing.T) {
+	network := beacon.NewNetwork(types.MainNetwork)
+	gc := &goClient{
+		log:                   zap.NewNop(),
+		network:               network,
+		gasLimit:              types.DefaultGasLimit,
+		registrationCache:     map[phase0.BLSPubKey]*api.VersionedSignedValidatorRegistration{},
+		registrationPending:   map[phase0.BLSPubKey]struct{}{},
+		registrationSubmitted: map[phase0.BLSPubKey]submittedRegistration{},
+		registrationCachedAt:  map[phase0.BLSPubKey]phase0.Slot{},
+		registrationSucceeded: map[phase0.BLSPubKey]phase0.Slot{},
+	}
+
+	pubKey1 := []byte{1}
+	pubKey2 := []byte{2}
+	feeRecipient1 := bellatrix.ExecutionAddress{1}
+	feeRecipient2 := bellatrix.ExecutionAddress{2}
+
+	require.NoError(t, gc.updateBatchRegistrationCache(gc.createValidatorRegistration(pubKey1, feeRecipient1, gc.registrationTimestamp(), phase0.BLSSignature{})))
+	require.NoError(t, gc.updateBatchRegistrationCache(gc.createValidatorRegistration(pubKey2, feeRecipient1, gc.registrationTimestamp(), phase0.BLSSignature{})))
+
+	// New registrations are submitted immediately.
+	slot := phase0.Slot(100)
+	registrations, skipped := gc.registrationList(slot, false)
+	require.Len(t, registrations, 2)
+	require.Zero(t, skipped)
+
+	// Unchanged registrations are neither submitted nor counted as skipped outside of regular submissions.
+	slot++
+	registrations, skipped = gc.registrationList(slot, false)
+	require.Empty(t, registrations)
+	require.Zero(t, skipped)
+
+	// Unchanged registrations are skipped on regular submissions until they're due for a refresh.
+	registrations, skipped = gc.registrationList(slot, true)
+	require.Empty(t, registrations)
+	require.Equal(t, 2, skipped)
+
+	// Re-signing with a new timestamp but the same content doesn't make the registration pending.
+	registration := gc.createValidatorRegistration(pubKey1, feeRecipient1, gc.registrationTimestamp(), phase0.BLSSignature{1})
+	registration.V1.Message.Timestamp = registration.V1.Message.Timestamp.Add(network.SlotDurationSec())
+	require.NoError(t, gc.updateBatchRegistrationCache(registration))
+	registrations, _ = gc.registrationList(slot, false)
+	require.Empty(t, registrations)
+
+	// Changed registrations are submitted immediately.
+	require.NoError(t, gc.updateBatchRegistrationCache(gc.createValidatorRegistration(pubKey2, feeRecipient2, gc.registrationTimestamp(), phase0.BLSSignature{})))
+	slot++
+	registrations, skipped = gc.registrationList(slot, false)
+	require.Len(t, registrations, 1)
+	require.Equal(t, feeRecipient2, registrations[0].V1.Message.FeeRecipient)
+	require.Zero(t, skipped)
+
+	// Failed submissions are retried on the next regular submission, regardless of when they're due for a refresh.
+	gc.resetSubmittedRegistrations(registrations)
+	slot++
+	registrations, _ = gc.registrationList(slot, false)
+	require.Empty(t, registrations)
+	registrations, skipped = gc.registrationList(slot, true)
+	require.Len(t, registrations, 1)
+	require.Equal(t, 1, skipped)
+
+	// All registrations are resubmitted once they're due for a refresh.
+	slot += phase0.Slot(registrationResubmitEpochs * network.SlotsPerEpoch())
+	registrations, skipped = gc.registrationList(slot, true)
+	require.Len(t, registrations, 2)
+	require.Zero(t, skipped)
+}
+
+func TestReportRegistrationCache(t *testing.T) {
+	network := beacon.NewNetwork(types.MainNetwork)
+	recorder := &registrationsRecorder{}
+	gc := &goClient{
+		log:                   zap.NewNop(),
+		ctx:                   context.Background(),
+		network:               network,
+		client:                recorder,
+		gasLimit:              types.DefaultGasLimit,
+		registrationCache:     map[phase0.BLSPubKey]*api.VersionedSignedValidatorRegistration{},
+		registrationPending:   map[phase0.BLSPubKey]struct{}{},
+		registrationSubmitted: map[phase0.BLSPubKey]submittedRegistration{},
+		registrationCachedAt:  map[phase0.BLSPubKey]phase0.Slot{},
+		registrationSucceeded: map[phase0.BLSPubKey]phase0.Slot{},
+	}
+	cacheSize := func() float64 {
+		return testutil.ToFloat64(metricsRegistrationCacheSize)
+	}
+	oldestAge := func() float64 {
+		return testutil.ToFloat64(metricsRegistrationOldestSubmissionAge)
+	}
+
+	gc.reportRegistrationCache(100)
+	require.Zero(t, cacheSize())
+	require.Zero(t, oldestAge())
+
+	// Registrations pending their first submission are aged from when they entered the cache.
+	registration := gc.createValidatorRegistration([]byte{1}, bellatrix.ExecutionAddress{1}, gc.registrationTimestamp(), phase0.BLSSignature{})
+	require.NoError(t, gc.updateBatchRegistrationCache(registration))
+	cachedAt := gc.registrationCachedAt[registration.V1.Message.Pubkey]
+	require.GreaterOrEqual(t, cachedAt, network.EstimatedCurrentSlot()-1)
+	gc.reportRegistrationCache(cachedAt)
+	require.Equal(t, 1.0, cacheSize())
+	require.Zero(t, oldestAge())
+	gc.reportRegistrationCache(cachedAt + 5)
+	require.Equal(t, 5.0, oldestAge())
+
+	// Updating a cached registration doesn't reset its age.
+	require.NoError(t, gc.updateBatchRegistrationCache(gc.createValidatorRegistration([]byte{1}, bellatrix.ExecutionAddress{2}, gc.registrationTimestamp(), phase0.BLSSignature{})))
+	require.Equal(t, cachedAt, gc.registrationCachedAt[registration.V1.Message.Pubkey])
+
+	// Failed submissions don't count as submissions.
+	require.NoError(t, gc.updateBatchRegistrationCache(gc.createValidatorRegistration([]byte{2}, bellatrix.ExecutionAddress{1}, gc.registrationTimestamp(), phase0.BLSSignature{})))
+	gc.registrationCachedAt[phase0.BLSPubKey{2}] = cachedAt
+	recorder.err = errors.New("test error")
+	registrations, _ := gc.registrationList(cachedAt+10, false)
+	require.Len(t, registrations, 2)
+	require.Error(t, gc.submitBatchedRegistrations(cachedAt+10, registrations))
+	gc.resetSubmittedRegistrations(registrations)
+	gc.reportRegistrationCache(cachedAt + 15)
+	require.Equal(t, 2.0, cacheSize())
+	require.Equal(t, 15.0, oldestAge())
+
+	// Once submitted successfully, registrations are aged from their last successful submission.
+	recorder.err = nil
+	registrations, _ = gc.registrationList(cachedAt+20, true)
+	require.Len(t, registrations, 2)
+	require.NoError(t, gc.submitBatchedRegistrations(cachedAt+20, registrations))
+	gc.reportRegistrationCache(cachedAt + 30)
+	require.Equal(t, 10.0, oldestAge())
+
+	// A failed resubmission keeps aging registrations from their last successful submission.
+	require.NoError(t, gc.updateBatchRegistrationCache(gc.createValidatorRegistration([]byte{1}, bellatrix.ExecutionAddress{3}, gc.registrationTimestamp(), phase0.BLSSignature{})))
+	recorder.err = errors.New("test error")
+	registrations, _ = gc.registrationList(cachedAt+40, false)
+	require.Len(t, registrations, 1)
+	require.Error(t, gc.submitBatchedRegistrations(cachedAt+40, registrations))
+	gc.resetSubmittedRegistrations(registrations)
+	gc.reportRegistrationCache(cachedAt + 50)
+	require.Equal(t, 30.0, oldestAge())
+
+	// Removed registrations are forgotten.
+	require.NoError(t, gc.RemoveValidatorRegistration(registration.V1.Message.Pubkey))
+	require.NotContains(t, gc.registrationCachedAt, registration.V1.Message.Pubkey)
+	require.NotContains(t, gc.registrationSucceeded, registration.V1.Message.Pubkey)
+}
+
+type registrationsRecorder struct {
+	Client
+	submitted []*api.VersionedSignedValidatorRegistration
+	err       error
+}
+
+func (r *registrationsRecorder) SubmitValidatorRegistrations(ctx context.Context, registrations []*api.VersionedSignedValidatorRegistration) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.submitted = append(r.submitted, registrations...)
+	return nil
+}
+
+func TestStaleRegistration(t *testing.T) {
+	network := beacon.NewNetwork(types.MainNetwork)
+	gc := &goClient{
+		log:                   zap.NewNop(),
+		network:               network,
+		gasLimit:              types.DefaultGasLimit,
+		registrationCache:     map[phase0.BLSPubKey]*api.VersionedSignedValidatorRegistration{},
+		registrationPending:   map[phase0.BLSPubKey]struct{}{},
+		registrationSubmitted: map[phase0.BLSPubKey]submittedRegistration{},
+		registrationCachedAt:  map[phase0.BLSPubKey]phase0.Slot{},
+		registrationSucceeded: map[phase0.BLSPubKey]phase0.Slot{},
+	}
+	pubKey := phase0.BLSPubKey{1}
+
+	registration := gc.createValidatorRegistration(pubKey[:], bellatrix.ExecutionAddress{1}, gc.registrationTimestamp(), phase0.BLSSignature{})
+	require.NoError(t, gc.updateBatchRegistrationCache(registration))
+
+	// A registration older than the cached one is rejected, leaving the cached one in place.
+	stale := gc.createValidatorRegistration(pubKey[:], bellatrix.ExecutionAddress{2}, gc.registrationTimestamp(), phase0.BLSSignature{})
+	stale.V1.Message.Timestamp = stale.V1.Message.Timestamp.Add(-network.SlotDurationSec())
+	require.ErrorIs(t, gc.updateBatchRegistrationCache(stale), ErrStaleRegistration)
+	require.Same(t, registration, gc.registrationCache[pubKey])
+
+	// Registrations of the same or a later timestamp replace the cached one.
+	same := gc.createValidatorRegistration(pubKey[:], bellatrix.ExecutionAddress{2}, gc.registrationTimestamp(), phase0.BLSSignature{})
+	require.NoError(t, gc.updateBatchRegistrationCache(same))
+	require.Same(t, same, gc.registrationCache[pubKey])
+	later := gc.createValidatorRegistration(pubKey[:], bellatrix.ExecutionAddress{3}, gc.registrationTimestamp(), phase0.BLSSignature{})
+	later.V1.Message.Timestamp = later.V1.Message.Timestamp.Add(network.SlotDurationSec())
+	require.NoError(t, gc.updateBatchRegistrationCache(later))
+	require.Same(t, later, gc.registrationCache[pubKey])
+
+	// Submitted registrations are compared by the timestamp they were signed with.
+	err := gc.SubmitTimestampedValidatorRegistration(pubKey[:], bellatrix.ExecutionAddress{4}, same.V1.Message.Timestamp, phase0.BLSSignature{})
+	require.ErrorIs(t, err, ErrStaleRegistration)
+	require.Same(t, later, gc.registrationCache[pubKey])
+	require.NoError(t, gc.SubmitTimestampedValidatorRegistration(pubKey[:], bellatrix.ExecutionAddress{4}, later.V1.Message.Timestamp, phase0.BLSSignature{}))
+	require.Equal(t, bellatrix.ExecutionAddress{4}, gc.registrationCache[pubKey].V1.Message.FeeRecipient)
+}
+
+func TestEnsureValidatorRegistration(t *testing.T) {
+	recorder := &registrationsRecorder{}
+	gc := &goClient{
+		log:                   zap.NewNop(),
+		ctx:                   context.Background(),
+		network:               beacon.NewNetwork(types.MainNetwork),
+		client:                recorder,
+		gasLimit:              types.DefaultGasLimit,
+		registrationCache:     map[phase0.BLSPubKey]*api.VersionedSignedValidatorRegistration{},
+		registrationPending:   map[phase0.BLSPubKey]struct{}{},
+		registrationSubmitted: map[phase0.BLSPubKey]submittedRegistration{},
+		registrationCachedAt:  map[phase0.BLSPubKey]phase0.Slot{},
+		registrationSucceeded: map[phase0.BLSPubKey]phase0.Slot{},
+	}
+	pubKey := phase0.BLSPubKey{1}
+
+	// Registrations which aren't cached yet can't be submitted.
+	require.ErrorIs(t, gc.EnsureValidatorRegistration(pubKey), ErrRegistrationNotAvailable)
+
+	// Pending registrations are submitted on demand.
+	require.NoError(t, gc.updateBatchRegistrationCache(gc.createValidatorRegistration(pubKey[:], bellatrix.ExecutionAddress{1}, gc.registrationTimestamp(), phase0.BLSSignature{})))
+	require.NoError(t, gc.EnsureValidatorRegistration(pubKey))
+	require.Len(t, recorder.submitted, 1)
+
+	// Submitted registrations aren't submitted again, neither on demand nor by the next tick.
+	require.NoError(t, gc.EnsureValidatorRegistration(pubKey))
+	require.Len(t, recorder.submitted, 1)
+	registrations, _ := gc.registrationList(gc.network.EstimatedCurrentSlot(), false)
+	require.Empty(t, registrations)
+
+	// Failed submissions are left for the next regular submission.
+	require.NoError(t, gc.updateBatchRegistrationCache(gc.createValidatorRegistration(pubKey[:], bellatrix.ExecutionAddress{2}, gc.registrationTimestamp(), phase0.BLSSignature{})))
+	recorder.err = errors.New("test error")
+	require.ErrorIs(t, gc.EnsureValidatorRegistration(pubKey), ErrRegistrationNotAvailable)
+	registrations, _ = gc.registrationList(gc.network.EstimatedCurrentSlot(), false)
+	require.Empty(t, registrations)
+	registrations, _ = gc.registrationList(gc.network.EstimatedCurrentSlot(), true)
+	require.Len(t, registrations, 1)
+}
+
+func TestRegistrationStatus(t *testing.T) {
+	recorder := &registrationsRecorder{}
+	gc := &goClient{
+		log:                   zap.NewNop(),
+		ctx:                   context.Background(),
+		network:               beacon.NewNetwork(types.MainNetwork),
+		client:                recorder,
+		gasLimit:              types.DefaultGasLimit,
+		registrationCache:     map[phase0.BLSPubKey]*api.VersionedSignedValidatorRegistration{},
+		registrationPending:   map[phase0.BLSPubKey]struct{}{},
+		registrationSubmitted: map[phase0.BLSPubKey]submittedRegistration{},
+		registrationCachedAt:  map[phase0.BLSPubKey]phase0.Slot{},
+		registrationSucceeded: map[phase0.BLSPubKey]phase0.Slot{},
+	}
+	pubKey := phase0.BLSPubKey{1}
+
+	_, ok := gc.RegistrationStatus(pubKey)
+	require.False(t, ok)
+
+	// Cached registrations are pending until submitted.
+	require.NoError(t, gc.updateBatchRegistrationCache(gc.createValidatorRegistration(pubKey[:], bellatrix.ExecutionAddress{1}, gc.registrationTimestamp(), phase0.BLSSignature{})))
+	info, ok := gc.RegistrationStatus(pubKey)
+	require.True(t, ok)
+	require.Equal(t, bellatrix.ExecutionAddress{1}, info.FeeRecipient)
+	require.EqualValues(t, types.DefaultGasLimit, info.GasLimit)
+	require.True(t, info.Pending)
+	require.False(t, info.Submitted)
+
+	currentSlot := gc.network.EstimatedCurrentSlot()
+	require.NoError(t, gc.EnsureValidatorRegistration(pubKey))
+	info, ok = gc.RegistrationStatus(pubKey)
+	require.True(t, ok)
+	require.False(t, info.Pending)
+	require.True(t, info.Submitted)
+	require.GreaterOrEqual(t, info.LastSubmittedSlot, currentSlot)
+}
+
+func TestAwaitRegistrationReady(t *testing.T) {
+	blocked := func() float64 {
+		return testutil.ToFloat64(metricsRegistrationSubmitterBlocked)
+	}
+
+	var calls []time.Time
+	ready := func(ctx context.Context) error {
+		calls = append(calls, time.Now())
+		if len(calls) < 4 {
+			return errors.New("syncing")
+		}
+		// The submitter is reported as blocked while waiting.
+		require.Equal(t, 1.0, blocked())
+		return nil
+	}
+	require.True(t, awaitRegistrationReady(context.Background(), zap.NewNop(), ready, 10*time.Millisecond, 20*time.Millisecond))
+	require.Len(t, calls, 4)
+	require.Zero(t, blocked())
+
+	// The backoff doubles up to the maximum.
+	require.GreaterOrEqual(t, calls[1].Sub(calls[0]), 10*time.Millisecond)
+	require.GreaterOrEqual(t, calls[2].Sub(calls[1]), 20*time.Millisecond)
+	require.GreaterOrEqual(t, calls[3].Sub(calls[2]), 20*time.Millisecond)
+
+	// Waiting stops once the context is done.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	notReady := func(ctx context.Context) error {
+		return errors.New("syncing")
+	}
+	require.False(t, awaitRegistrationReady(ctx, zap.NewNop(), notReady, time.Minute, time.Minute))
+	require.Zero(t, blocked())
+}
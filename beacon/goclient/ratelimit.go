@@ -0,0 +1,86 @@
+package goclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+var metricsSubmissionRateLimitUtilization = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "ssv_beacon_submission_rate_limit_utilization",
+	Help: "Utilization of the submission rate limit by endpoint class (above 1 when submissions are queued)",
+}, []string{"class"})
+
+func init() {
+	logger := zap.L()
+	if err := prometheus.Register(metricsSubmissionRateLimitUtilization); err != nil {
+		logger.Debug("could not register prometheus collector")
+	}
+}
+
+// submissionClass groups submission endpoints which share a rate limit bucket.
+type submissionClass string
+
+const (
+	submissionAttestation  submissionClass = "attestation" // attestations, aggregates and sync committee messages
+	submissionProposal     submissionClass = "proposal"
+	submissionRegistration submissionClass = "registration" // validator registrations and proposal preparations
+	submissionSubscription submissionClass = "subscription"
+	submissionExit         submissionClass = "exit"
+)
+
+var submissionClasses = []submissionClass{
+	submissionAttestation,
+	submissionProposal,
+	submissionRegistration,
+	submissionSubscription,
+	submissionExit,
+}
+
+// submissionLimiter limits the rate of submissions to the beacon node
+// with a token bucket per submission class.
+type submissionLimiter struct {
+	limiters map[submissionClass]*rate.Limiter
+}
+
+func newSubmissionLimiter(limit rate.Limit, burst int) *submissionLimiter {
+	limiters := make(map[submissionClass]*rate.Limiter, len(submissionClasses))
+	for _, class := range submissionClasses {
+		limiters[class] = rate.NewLimiter(limit, burst)
+	}
+	return &submissionLimiter{limiters: limiters}
+}
+
+// Wait blocks until a submission of the given class is allowed.
+// It fails immediately if the submission wouldn't be allowed before the deadline.
+func (l *submissionLimiter) Wait(ctx context.Context, class submissionClass, deadline time.Time) error {
+	limiter := l.limiters[class]
+
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	err := limiter.Wait(ctx)
+	metricsSubmissionRateLimitUtilization.WithLabelValues(string(class)).Set(1 - limiter.Tokens()/float64(limiter.Burst()))
+	if err != nil {
+		return fmt.Errorf("%s submission rate limit exceeded: %w", class, err)
+	}
+	return nil
+}
+
+// waitForSubmission blocks until a submission of the given class is allowed by the rate limit,
+// failing if it isn't allowed by the end of the current slot, or if it's suppressed by the optimism guard.
+func (gc *goClient) waitForSubmission(ctx context.Context, class submissionClass) error {
+	if err := gc.checkOptimism(class); err != nil {
+		return err
+	}
+	if gc.submissionLimiter == nil {
+		return nil
+	}
+	deadline := gc.slotStartTime(gc.network.EstimatedCurrentSlot() + 1)
+	return gc.submissionLimiter.Wait(ctx, class, deadline)
+}
@@ -0,0 +1,35 @@
+package goclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestSubmissionLimiter(t *testing.T) {
+	ctx := context.Background()
+	limiter := newSubmissionLimiter(rate.Every(time.Minute), 2)
+	deadline := time.Now().Add(time.Second)
+
+	// Submissions within the burst are allowed immediately.
+	require.NoError(t, limiter.Wait(ctx, submissionAttestation, deadline))
+	require.NoError(t, limiter.Wait(ctx, submissionAttestation, deadline))
+
+	// Submissions which wouldn't be allowed before the deadline fail fast.
+	start := time.Now()
+	require.ErrorContains(t, limiter.Wait(ctx, submissionAttestation, deadline), "attestation submission rate limit exceeded")
+	require.Less(t, time.Since(start), deadline.Sub(start))
+
+	// Each class has its own bucket.
+	require.NoError(t, limiter.Wait(ctx, submissionProposal, deadline))
+
+	// Submissions are queued until allowed.
+	limiter = newSubmissionLimiter(rate.Every(50*time.Millisecond), 1)
+	require.NoError(t, limiter.Wait(ctx, submissionRegistration, deadline))
+	start = time.Now()
+	require.NoError(t, limiter.Wait(ctx, submissionRegistration, deadline))
+	require.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
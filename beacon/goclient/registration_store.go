@@ -0,0 +1,152 @@
+package goclient
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/api"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/operator/slotticker"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+var registrationsPrefix = []byte("beacon/registrations/")
+
+// registrationStore persists the registration cache, so that after a restart the cached registrations
+// are submitted without waiting for each validator's registration duty to come around again.
+type registrationStore struct {
+	db basedb.Database
+}
+
+func newRegistrationStore(db basedb.Database) *registrationStore {
+	return &registrationStore{db: db}
+}
+
+// save persists the validator's registration, replacing its previous one.
+func (s *registrationStore) save(pubKey phase0.BLSPubKey, registration *api.VersionedSignedValidatorRegistration) error {
+	if registration.V1 == nil {
+		return fmt.Errorf("unsupported registration version %s", registration.Version)
+	}
+	data, err := registration.V1.MarshalSSZ()
+	if err != nil {
+		return fmt.Errorf("failed to encode registration: %w", err)
+	}
+	return s.db.Set(registrationsPrefix, pubKey[:], data)
+}
+
+// delete removes the validator's persisted registration.
+func (s *registrationStore) delete(pubKey phase0.BLSPubKey) error {
+	return s.db.Delete(registrationsPrefix, pubKey[:])
+}
+
+// load returns the persisted registrations.
+func (s *registrationStore) load() ([]*api.VersionedSignedValidatorRegistration, error) {
+	var registrations []*api.VersionedSignedValidatorRegistration
+	err := s.db.GetAll(registrationsPrefix, func(i int, obj basedb.Obj) error {
+		registration := &eth2apiv1.SignedValidatorRegistration{}
+		if err := registration.UnmarshalSSZ(obj.Value); err != nil {
+			return fmt.Errorf("failed to decode registration of %x: %w", obj.Key, err)
+		}
+		registrations = append(registrations, &api.VersionedSignedValidatorRegistration{
+			Version: spec.BuilderVersionV1,
+			V1:      registration,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return registrations, nil
+}
+
+// loadRegistrations fills the registration cache with the persisted registrations,
+// pending submission until they're submitted for the first time since the start.
+// If validatorsProvider is set, the registrations of validators which it doesn't return,
+// e.g. validators removed while the node was down, are deleted instead.
+func (gc *goClient) loadRegistrations(validatorsProvider func() []phase0.BLSPubKey) (loaded, pruned int, err error) {
+	registrations, err := gc.registrationStore.load()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var current map[phase0.BLSPubKey]struct{}
+	if validatorsProvider != nil {
+		validators := validatorsProvider()
+		current = make(map[phase0.BLSPubKey]struct{}, len(validators))
+		for _, pubKey := range validators {
+			current[pubKey] = struct{}{}
+		}
+	}
+
+	gc.registrationMu.Lock()
+	defer gc.registrationMu.Unlock()
+
+	for _, registration := range registrations {
+		pk, err := registration.PubKey()
+		if err != nil {
+			return 0, 0, err
+		}
+		if _, ok := current[pk]; current != nil && !ok {
+			if err := gc.registrationStore.delete(pk); err != nil {
+				return 0, 0, fmt.Errorf("failed to delete registration of %x: %w", pk, err)
+			}
+			pruned++
+			continue
+		}
+		gc.registrationCache[pk] = registration
+		gc.registrationPending[pk] = struct{}{}
+		gc.registrationCachedAt[pk] = gc.network.EstimatedCurrentSlot()
+		loaded++
+	}
+	return loaded, pruned, nil
+}
+
+var _ beaconprotocol.RegistrationRemover = (*goClient)(nil)
+
+// startRegistrations loads the persisted validator registrations if a database is given,
+// and starts submitting the cached registrations. Neither is done if the registration endpoints are disabled,
+// so that persisted registrations aren't submitted either.
+func (gc *goClient) startRegistrations(db basedb.Database, validatorsProvider func() []phase0.BLSPubKey, slotTickerProvider slotticker.Provider) {
+	if !gc.endpointEnabled(endpointRegistration) {
+		gc.log.Info("validator registrations are disabled, not submitting them")
+		return
+	}
+
+	if db != nil {
+		gc.registrationStore = newRegistrationStore(db)
+		loaded, pruned, err := gc.loadRegistrations(validatorsProvider)
+		if err != nil {
+			// Registrations are cached again as their duties come around.
+			gc.log.Warn("failed to load persisted validator registrations", zap.Error(err))
+		} else {
+			gc.log.Info("loaded persisted validator registrations", fields.Count(loaded), zap.Int("pruned", pruned))
+		}
+	}
+
+	go gc.registrationSubmitter(slotTickerProvider)
+}
+
+// RemoveValidatorRegistration removes the validator's registration from the registration cache
+// and from the store, so that it's no longer submitted.
+func (gc *goClient) RemoveValidatorRegistration(pubKey phase0.BLSPubKey) error {
+	gc.registrationMu.Lock()
+	delete(gc.registrationCache, pubKey)
+	delete(gc.registrationPending, pubKey)
+	delete(gc.registrationSubmitted, pubKey)
+	delete(gc.registrationCachedAt, pubKey)
+	delete(gc.registrationSucceeded, pubKey)
+	gc.registrationMu.Unlock()
+
+	if gc.registrationStore == nil {
+		return nil
+	}
+	if err := gc.registrationStore.delete(pubKey); err != nil {
+		return fmt.Errorf("failed to delete persisted registration: %w", err)
+	}
+	return nil
+}
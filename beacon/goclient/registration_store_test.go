@@ -0,0 +1,128 @@
+package goclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestRegistrationStore(t *testing.T) {
+	db, err := kv.NewInMemory(zap.NewNop(), basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	newClient := func() *goClient {
+		return &goClient{
+			log:                   zap.NewNop(),
+			network:               beacon.NewNetwork(types.MainNetwork),
+			gasLimit:              types.DefaultGasLimit,
+			registrationCache:     map[phase0.BLSPubKey]*api.VersionedSignedValidatorRegistration{},
+			registrationPending:   map[phase0.BLSPubKey]struct{}{},
+			registrationSubmitted: map[phase0.BLSPubKey]submittedRegistration{},
+			registrationCachedAt:  map[phase0.BLSPubKey]phase0.Slot{},
+			registrationSucceeded: map[phase0.BLSPubKey]phase0.Slot{},
+			registrationStore:     newRegistrationStore(db),
+		}
+	}
+
+	// Registrations are written through to the store on update.
+	gc := newClient()
+	require.NoError(t, gc.updateBatchRegistrationCache(gc.createValidatorRegistration([]byte{1}, bellatrix.ExecutionAddress{1}, gc.registrationTimestamp(), phase0.BLSSignature{})))
+	require.NoError(t, gc.updateBatchRegistrationCache(gc.createValidatorRegistration([]byte{2}, bellatrix.ExecutionAddress{1}, gc.registrationTimestamp(), phase0.BLSSignature{})))
+	require.NoError(t, gc.updateBatchRegistrationCache(gc.createValidatorRegistration([]byte{1}, bellatrix.ExecutionAddress{2}, gc.registrationTimestamp(), phase0.BLSSignature{})))
+	registrations, _ := gc.registrationList(100, false)
+	require.Len(t, registrations, 2)
+
+	// After a restart, the persisted registrations are pending submission.
+	restarted := newClient()
+	loaded, pruned, err := restarted.loadRegistrations(nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, loaded)
+	require.Zero(t, pruned)
+	require.Len(t, restarted.registrationCache, 2)
+	for pk, registration := range gc.registrationCache {
+		require.Contains(t, restarted.registrationCache, pk)
+		require.True(t, registration.V1.Message.Timestamp.Equal(restarted.registrationCache[pk].V1.Message.Timestamp))
+		require.Equal(t, registration.V1.Message.FeeRecipient, restarted.registrationCache[pk].V1.Message.FeeRecipient)
+	}
+	require.Len(t, restarted.registrationPending, 2)
+	require.Empty(t, restarted.registrationSubmitted)
+
+	registrations, _ = restarted.registrationList(200, false)
+	require.Len(t, registrations, 2)
+	info, ok := restarted.RegistrationStatus(phase0.BLSPubKey{1})
+	require.True(t, ok)
+	require.Equal(t, bellatrix.ExecutionAddress{2}, info.FeeRecipient)
+
+	// Registrations of validators removed while the node was down are deleted.
+	restarted = newClient()
+	loaded, pruned, err = restarted.loadRegistrations(func() []phase0.BLSPubKey {
+		return []phase0.BLSPubKey{{2}}
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, loaded)
+	require.Equal(t, 1, pruned)
+	require.NotContains(t, restarted.registrationCache, phase0.BLSPubKey{1})
+
+	// Registrations of removed validators are deleted.
+	require.NoError(t, restarted.RemoveValidatorRegistration(phase0.BLSPubKey{2}))
+	require.Empty(t, restarted.registrationCache)
+	require.Empty(t, restarted.registrationPending)
+
+	restarted = newClient()
+	loaded, _, err = restarted.loadRegistrations(nil)
+	require.NoError(t, err)
+	require.Zero(t, loaded)
+}
+
+func TestDisabledRegistrationsAreNotLoaded(t *testing.T) {
+	db, err := kv.NewInMemory(zap.NewNop(), basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	network := beacon.NewNetwork(types.MainNetwork)
+	newClient := func(recorder *registrationsRecorder) *goClient {
+		return &goClient{
+			log:                   zap.NewNop(),
+			ctx:                   context.Background(),
+			network:               network,
+			client:                recorder,
+			gasLimit:              types.DefaultGasLimit,
+			registrationCache:     map[phase0.BLSPubKey]*api.VersionedSignedValidatorRegistration{},
+			registrationPending:   map[phase0.BLSPubKey]struct{}{},
+			registrationSubmitted: map[phase0.BLSPubKey]submittedRegistration{},
+			registrationCachedAt:  map[phase0.BLSPubKey]phase0.Slot{},
+			registrationSucceeded: map[phase0.BLSPubKey]phase0.Slot{},
+		}
+	}
+
+	// Persist a registration.
+	gc := newClient(&registrationsRecorder{})
+	gc.registrationStore = newRegistrationStore(db)
+	require.NoError(t, gc.updateBatchRegistrationCache(gc.createValidatorRegistration([]byte{1}, bellatrix.ExecutionAddress{1}, gc.registrationTimestamp(), phase0.BLSSignature{})))
+
+	// With the registration endpoints disabled, the persisted registration is neither loaded nor submitted.
+	recorder := &registrationsRecorder{}
+	restarted := newClient(recorder)
+	restarted.enabledEndpoints, err = parseEnabledEndpoints([]string{string(endpointAttestation)})
+	require.NoError(t, err)
+	restarted.startRegistrations(db, nil, nil)
+	require.Nil(t, restarted.registrationStore)
+	require.Empty(t, restarted.registrationCache)
+
+	slot := network.EstimatedCurrentSlot()
+	for i := phase0.Slot(0); i < phase0.Slot(network.SlotsPerEpoch()); i++ {
+		restarted.submitRegistrationsFromCache(slot+i, 1)
+	}
+	require.Empty(t, recorder.submitted)
+}
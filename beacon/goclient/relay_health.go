@@ -0,0 +1,172 @@
+package goclient
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+)
+
+var (
+	metricsRelayBlindedPreferred = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv_beacon_relay_blinded_preferred",
+		Help: "Whether blinded proposals are currently preferred from the relay (1) or local blocks are (0)",
+	}, []string{"relay"})
+	metricsRelayErrorRate = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv_beacon_relay_error_rate",
+		Help: "Rate of failed or low-value blinded proposals among the relay's recent blinded proposals",
+	}, []string{"relay"})
+)
+
+func init() {
+	logger := zap.L()
+	allMetrics := []prometheus.Collector{
+		metricsRelayBlindedPreferred,
+		metricsRelayErrorRate,
+	}
+	for _, c := range allMetrics {
+		if err := prometheus.Register(c); err != nil {
+			logger.Debug("could not register prometheus collector")
+		}
+	}
+}
+
+// relayHealthWindow is the number of recent blinded proposals the error rate of a relay is computed over.
+const relayHealthWindow = 16
+
+// relayHealth tracks the outcome of recent blinded proposals by relay. Once a blinded proposal
+// of a relay fails or pays less than minValue, local blocks are preferred for the relay's
+// next fallbackProposals proposals.
+type relayHealth struct {
+	fallbackProposals int
+	minValue          *big.Int // in wei, unchecked if nil
+
+	mu     sync.Mutex
+	relays map[string]*relayStats
+}
+
+type relayStats struct {
+	outcomes []bool      // whether each of the recent blinded proposals failed, oldest first
+	lastSlot phase0.Slot // slot of the last outcome
+	local    int         // remaining proposals to prefer local blocks for
+}
+
+func newRelayHealth(fallbackProposals int, minValue *big.Int) *relayHealth {
+	return &relayHealth{
+		fallbackProposals: fallbackProposals,
+		minValue:          minValue,
+		relays:            make(map[string]*relayStats),
+	}
+}
+
+// preferLocal returns whether local blocks are preferred over the relay's blinded blocks.
+func (h *relayHealth) preferLocal(relay string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	stats, ok := h.relays[relay]
+	return ok && stats.local > 0
+}
+
+// proposedLocal records a proposal whose block was requested while local blocks were preferred.
+func (h *relayHealth) proposedLocal(relay string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	stats := h.stats(relay)
+	if stats.local > 0 {
+		stats.local--
+	}
+	h.report(relay, stats)
+}
+
+// lowValue returns whether the value of a blinded proposal is too low to be worth proposing.
+func (h *relayHealth) lowValue(value *big.Int) bool {
+	return h.minValue != nil && value != nil && value.Cmp(h.minValue) < 0
+}
+
+// recordBlinded records the outcome of the relay's blinded proposal at the given slot.
+// Outcomes recorded for the same slot are merged, so that a proposal which was
+// already found to pay too little isn't counted again once it's submitted.
+func (h *relayHealth) recordBlinded(relay string, slot phase0.Slot, failed bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	stats := h.stats(relay)
+	if n := len(stats.outcomes); n > 0 && stats.lastSlot == slot {
+		stats.outcomes[n-1] = stats.outcomes[n-1] || failed
+	} else {
+		stats.outcomes = append(stats.outcomes, failed)
+		if len(stats.outcomes) > relayHealthWindow {
+			stats.outcomes = stats.outcomes[1:]
+		}
+	}
+	stats.lastSlot = slot
+	if failed {
+		stats.local = h.fallbackProposals
+	}
+	h.report(relay, stats)
+}
+
+// errorRate returns the rate of failures among the relay's recent blinded proposals.
+func (h *relayHealth) errorRate(relay string) float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.stats(relay).errorRate()
+}
+
+func (h *relayHealth) stats(relay string) *relayStats {
+	stats, ok := h.relays[relay]
+	if !ok {
+		stats = &relayStats{}
+		h.relays[relay] = stats
+	}
+	return stats
+}
+
+func (h *relayHealth) report(relay string, stats *relayStats) {
+	preferred := 1.0
+	if stats.local > 0 {
+		preferred = 0
+	}
+	metricsRelayBlindedPreferred.WithLabelValues(relay).Set(preferred)
+	metricsRelayErrorRate.WithLabelValues(relay).Set(stats.errorRate())
+}
+
+func (s *relayStats) errorRate() float64 {
+	if len(s.outcomes) == 0 {
+		return 0
+	}
+	failures := 0
+	for _, failed := range s.outcomes {
+		if failed {
+			failures++
+		}
+	}
+	return float64(failures) / float64(len(s.outcomes))
+}
+
+// observeRelayProposal records the proposal fetched for the given slot in the relay health tracker.
+func (gc *goClient) observeRelayProposal(slot phase0.Slot, proposal *api.VersionedProposal, preferredLocal bool) {
+	if preferredLocal {
+		gc.relayHealth.proposedLocal(gc.relay)
+	}
+	if !proposal.Blinded || !gc.relayHealth.lowValue(proposal.ExecutionValue) {
+		return
+	}
+	gc.relayHealth.recordBlinded(gc.relay, slot, true)
+	gc.log.Warn("blinded proposal pays less than the minimum value, preferring local blocks",
+		fields.Slot(slot),
+		zap.String("relay", gc.relay),
+		zap.Stringer("value", proposal.ExecutionValue),
+		zap.Stringer("min_value", gc.relayHealth.minValue),
+		zap.Int("proposals", gc.relayHealth.fallbackProposals),
+	)
+}
@@ -0,0 +1,102 @@
+package goclient
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+func TestRelayHealth(t *testing.T) {
+	health := newRelayHealth(2, big.NewInt(100))
+	require.False(t, health.preferLocal("relay"))
+	require.True(t, health.lowValue(big.NewInt(99)))
+	require.False(t, health.lowValue(big.NewInt(100)))
+
+	// Successful blinded proposals don't affect the preference.
+	health.recordBlinded("relay", 1, false)
+	require.False(t, health.preferLocal("relay"))
+
+	// A failed blinded proposal prefers local blocks for the next proposals.
+	health.recordBlinded("relay", 2, true)
+	require.True(t, health.preferLocal("relay"))
+	require.False(t, health.preferLocal("other"))
+	require.Equal(t, 0.5, health.errorRate("relay"))
+
+	// Outcomes of the same slot are counted once.
+	health.recordBlinded("relay", 2, false)
+	require.Equal(t, 0.5, health.errorRate("relay"))
+
+	health.proposedLocal("relay")
+	require.True(t, health.preferLocal("relay"))
+	health.proposedLocal("relay")
+	require.False(t, health.preferLocal("relay"))
+
+	// The error rate is computed over the recent proposals only.
+	for slot := 3; slot < 3+relayHealthWindow; slot++ {
+		health.recordBlinded("relay", phase0.Slot(slot), false)
+	}
+	require.Equal(t, 0.0, health.errorRate("relay"))
+}
+
+func TestRelayHealthProposal(t *testing.T) {
+	recorder := &proposalRecorder{
+		proposal: &api.VersionedProposal{
+			Version: spec.DataVersionCapella,
+			Blinded: true,
+			CapellaBlinded: &apiv1capella.BlindedBeaconBlock{
+				Body: &apiv1capella.BlindedBeaconBlockBody{
+					ExecutionPayloadHeader: &capella.ExecutionPayloadHeader{},
+				},
+			},
+			ExecutionValue: big.NewInt(1),
+		},
+	}
+	gc := &goClient{
+		log:         zap.NewNop(),
+		ctx:         context.Background(),
+		network:     beacon.NewNetwork(types.MainNetwork),
+		client:      recorder,
+		timeouts:    requestTimeouts{proposal: time.Second},
+		relayHealth: newRelayHealth(1, big.NewInt(1e9)),
+		relay:       "relay",
+	}
+
+	// A low-value blinded proposal prefers a local block for the next proposal.
+	_, _, err := gc.GetBeaconBlock(1, nil, make([]byte, 96))
+	require.NoError(t, err)
+	require.Nil(t, recorder.opts.BuilderBoostFactor)
+
+	recorder.proposal.ExecutionValue = big.NewInt(2e9)
+	_, _, err = gc.GetBeaconBlock(2, nil, make([]byte, 96))
+	require.NoError(t, err)
+	require.NotNil(t, recorder.opts.BuilderBoostFactor)
+	require.Zero(t, *recorder.opts.BuilderBoostFactor)
+
+	// Blinded blocks are allowed again once the preference is exhausted.
+	_, _, err = gc.GetBeaconBlock(3, nil, make([]byte, 96))
+	require.NoError(t, err)
+	require.Nil(t, recorder.opts.BuilderBoostFactor)
+}
+
+type proposalRecorder struct {
+	Client
+	proposal *api.VersionedProposal
+	opts     *api.ProposalOpts
+}
+
+func (r *proposalRecorder) Proposal(ctx context.Context, opts *api.ProposalOpts) (*api.Response[*api.VersionedProposal], error) {
+	r.opts = opts
+	return &api.Response[*api.VersionedProposal]{Data: r.proposal}, nil
+}
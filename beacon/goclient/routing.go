@@ -0,0 +1,275 @@
+package goclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	eth2clienthttp "github.com/attestantio/go-eth2-client/http"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+)
+
+var (
+	metricsNodeRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_beacon_node_requests",
+		Help: "Count of beacon node requests by request class and the role of the node which served them",
+	}, []string{"class", "node"})
+	metricsNodeMidSlotSwitches = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_beacon_node_mid_slot_switches",
+		Help: "Count of duty requests served by a different beacon node than the previous request of the same duty within its slot",
+	}, []string{"role"})
+)
+
+func init() {
+	logger := zap.L()
+	for _, c := range []prometheus.Collector{metricsNodeRequests, metricsNodeMidSlotSwitches} {
+		if err := prometheus.Register(c); err != nil {
+			logger.Debug("could not register prometheus collector")
+		}
+	}
+}
+
+// nodeRole is the role of a beacon node in routing requests.
+type nodeRole string
+
+const (
+	nodeRolePrimary nodeRole = "primary" // serves any request, and requests which the other nodes failed to
+	nodeRoleRead    nodeRole = "read"    // serves duty and validator queries
+	nodeRoleWrite   nodeRole = "write"   // serves submissions, and with slot stickiness the data of duties too
+)
+
+const (
+	readRequestDuties     = "duties"
+	readRequestValidators = "validators"
+)
+
+// routedNode is a beacon node dedicated to a class of requests. Once a request to it fails,
+// its requests are routed to the primary node until fallbackPeriod passes.
+type routedNode struct {
+	role           nodeRole
+	client         Client
+	fallbackPeriod time.Duration
+
+	mu             sync.Mutex
+	unhealthyUntil time.Time
+}
+
+func newRoutedNode(role nodeRole, client Client, fallbackPeriod time.Duration) *routedNode {
+	return &routedNode{
+		role:           role,
+		client:         client,
+		fallbackPeriod: fallbackPeriod,
+	}
+}
+
+func (n *routedNode) healthy(now time.Time) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return !now.Before(n.unhealthyUntil)
+}
+
+func (n *routedNode) markUnhealthy(now time.Time) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.unhealthyUntil = now.Add(n.fallbackPeriod)
+}
+
+// dutyRouteRetention is the number of slots after a duty's slot for which the node serving it is remembered,
+// so that late submissions of the duty are still routed to it.
+const dutyRouteRetention = 2
+
+// dutyRoute identifies the requests of the duties of a role within a slot.
+type dutyRoute struct {
+	role spectypes.BeaconRole
+	slot phase0.Slot
+}
+
+// dutyRoutes records the node which served the latest request of each duty, in order to count
+// the duties whose requests were served by different nodes. If it's sticky, the node which served
+// a duty's first request keeps serving the duty's requests unless it fails, so that the data of a duty
+// and its submission aren't served by nodes on different heads.
+type dutyRoutes struct {
+	sticky bool
+
+	mu     sync.Mutex
+	latest phase0.Slot // the latest slot of a served duty
+	routes map[dutyRoute]nodeRole
+}
+
+func newDutyRoutes(sticky bool) *dutyRoutes {
+	return &dutyRoutes{
+		sticky: sticky,
+		routes: make(map[dutyRoute]nodeRole),
+	}
+}
+
+// pinned returns the node which the requests of the duty are pinned to, if any.
+func (r *dutyRoutes) pinned(duty dutyRoute) (nodeRole, bool) {
+	if !r.sticky {
+		return "", false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	server, ok := r.routes[duty]
+	return server, ok
+}
+
+// serve records that a request of the duty was served by the node of the given role,
+// and returns whether the previous request of the duty was served by another node.
+func (r *dutyRoutes) serve(duty dutyRoute, server nodeRole) (switched bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if duty.slot > r.latest {
+		r.latest = duty.slot
+		for d := range r.routes {
+			if d.slot+dutyRouteRetention < duty.slot {
+				delete(r.routes, d)
+			}
+		}
+	}
+	previous, ok := r.routes[duty]
+	r.routes[duty] = server
+	return ok && previous != server
+}
+
+// route issues the request to the given node if it's set and healthy, and to the primary node otherwise
+// or if the request to the given node fails.
+func (gc *goClient) route(node *routedNode, class string, request func(client Client) error) error {
+	_, err := gc.issue(node, node != nil && node.healthy(time.Now()), class, request)
+	return err
+}
+
+// routeDuty issues a request of the duties of the given role and slot like route. With slot stickiness,
+// it's issued to the node which served the previous request of the duty, unless that node failed since.
+// Requests of a duty which are served by different nodes are counted as switches.
+func (gc *goClient) routeDuty(node *routedNode, role spectypes.BeaconRole, slot phase0.Slot, class string, request func(client Client) error) error {
+	duty := dutyRoute{role: role, slot: slot}
+	useNode := node != nil && node.healthy(time.Now())
+	if gc.dutyRoutes != nil && useNode {
+		if pinned, ok := gc.dutyRoutes.pinned(duty); ok {
+			useNode = pinned == node.role
+		}
+	}
+
+	server, err := gc.issue(node, useNode, class, request)
+	if err != nil {
+		return err
+	}
+	if gc.dutyRoutes != nil && gc.dutyRoutes.serve(duty, server) {
+		metricsNodeMidSlotSwitches.WithLabelValues(role.String()).Inc()
+		gc.log.Debug("beacon node switched within duty",
+			fields.Role(role),
+			zap.String("class", class),
+			zap.String("node", string(server)),
+			fields.Slot(slot),
+		)
+	}
+	return nil
+}
+
+// fetchDutyData issues a request for the data of a duty of the given role and slot. With slot stickiness,
+// it's routed to the write node, so that the data of a duty is fetched from the node which it's submitted to.
+// Otherwise, it's issued to the primary node.
+func (gc *goClient) fetchDutyData(role spectypes.BeaconRole, slot phase0.Slot, class string, request func(client Client) error) error {
+	var node *routedNode
+	if gc.dutyRoutes != nil && gc.dutyRoutes.sticky {
+		node = gc.writeNode
+	}
+	return gc.routeDuty(node, role, slot, class, request)
+}
+
+// issue issues the request to the given node if useNode is set, and to the primary node otherwise
+// or if the given node fails to serve the request (see nodeFailed). Requests which the given node rejects
+// are failed without falling back, as the primary node would reject them as well.
+// It returns the role of the node which served the request.
+func (gc *goClient) issue(node *routedNode, useNode bool, class string, request func(client Client) error) (nodeRole, error) {
+	if useNode {
+		err := request(node.client)
+		if err == nil {
+			metricsNodeRequests.WithLabelValues(class, string(node.role)).Inc()
+			return node.role, nil
+		}
+		if !nodeFailed(err) {
+			return node.role, err
+		}
+		node.markUnhealthy(time.Now())
+		gc.log.Warn("beacon node request failed, falling back to the primary node",
+			zap.String("node", string(node.role)),
+			zap.String("class", class),
+			zap.Duration("fallback_period", node.fallbackPeriod),
+			zap.Error(err),
+		)
+	}
+
+	if err := request(gc.client); err != nil {
+		return "", err
+	}
+	metricsNodeRequests.WithLabelValues(class, string(nodeRolePrimary)).Inc()
+	return nodeRolePrimary, nil
+}
+
+// nodeFailed returns whether the error of a beacon node request is a failure of the beacon node,
+// such as a transport error, a timeout, rate limiting or a server error, rather than a rejection of the request.
+func nodeFailed(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var apiErr *api.Error
+	if !errors.As(err, &apiErr) {
+		return true
+	}
+	switch apiErr.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return true
+	}
+	return apiErr.StatusCode >= 500
+}
+
+// newHTTPClient connects to the beacon node at the given address.
+func newHTTPClient(ctx context.Context, address string, timeout time.Duration) (Client, error) {
+	httpClient, err := eth2clienthttp.New(ctx,
+		// WithAddress supplies the address of the beacon node, in host:port format.
+		eth2clienthttp.WithAddress(address),
+		// LogLevel supplies the level of logging to carry out.
+		eth2clienthttp.WithLogLevel(zerolog.DebugLevel),
+		eth2clienthttp.WithTimeout(timeout),
+		eth2clienthttp.WithReducedMemoryUsage(true),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create http client: %w", err)
+	}
+	return httpClient.(*eth2clienthttp.Service), nil
+}
+
+// connectRoutedNode connects to the beacon node of the given role, if its address is set.
+func (gc *goClient) connectRoutedNode(role nodeRole, address string) (*routedNode, error) {
+	if address == "" {
+		return nil, nil
+	}
+	client, err := newHTTPClient(gc.ctx, address, gc.commonTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s beacon node: %w", role, err)
+	}
+	gc.log.Info("consensus client: connected to dedicated node",
+		zap.String("role", string(role)),
+		fields.Address(RedactAddress(address)),
+	)
+	fallbackPeriod := gc.network.SlotDurationSec() * time.Duration(gc.network.SlotsPerEpoch())
+	return newRoutedNode(role, client, fallbackPeriod), nil
+}
@@ -0,0 +1,186 @@
+package goclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+func TestRouting(t *testing.T) {
+	primary := &routingRecorder{}
+	read := &routingRecorder{}
+	write := &routingRecorder{}
+	gc := &goClient{
+		log:       zap.NewNop(),
+		ctx:       context.Background(),
+		network:   beacon.NewNetwork(types.MainNetwork),
+		client:    primary,
+		readNode:  newRoutedNode(nodeRoleRead, read, time.Hour),
+		writeNode: newRoutedNode(nodeRoleWrite, write, time.Hour),
+		duties:    newDutyTracker(),
+	}
+
+	// Queries are served by the read node, and submissions by the write node.
+	_, err := gc.AttesterDuties(gc.ctx, 1, nil)
+	require.NoError(t, err)
+	require.NoError(t, gc.SubmitVoluntaryExit(&phase0.SignedVoluntaryExit{}))
+	require.Equal(t, 1, read.duties)
+	require.Equal(t, 1, write.exits)
+	require.Zero(t, primary.duties+primary.exits)
+
+	// Once the read node fails, its request and the following ones fall back to the primary node.
+	read.err = errors.New("test error")
+	_, err = gc.AttesterDuties(gc.ctx, 1, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, primary.duties)
+
+	read.err = nil
+	_, err = gc.AttesterDuties(gc.ctx, 1, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, primary.duties)
+	require.Equal(t, 1, read.duties)
+
+	// The write node isn't affected by the failure of the read node.
+	require.NoError(t, gc.SubmitVoluntaryExit(&phase0.SignedVoluntaryExit{}))
+	require.Equal(t, 2, write.exits)
+
+	// The read node serves requests again once the fallback period passes.
+	gc.readNode.fallbackPeriod = 0
+	gc.readNode.markUnhealthy(time.Now())
+	_, err = gc.AttesterDuties(gc.ctx, 1, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, read.duties)
+
+	// Requests which the read node rejects fail without falling back, and the read node keeps serving requests.
+	read.err = &api.Error{StatusCode: http.StatusBadRequest}
+	_, err = gc.AttesterDuties(gc.ctx, 1, nil)
+	require.ErrorIs(t, err, read.err)
+	require.Equal(t, 2, primary.duties)
+	require.True(t, gc.readNode.healthy(time.Now()))
+
+	// Server errors, timeouts and rate limiting are failures of the read node.
+	for i, statusCode := range []int{http.StatusServiceUnavailable, http.StatusRequestTimeout, http.StatusTooManyRequests} {
+		gc.readNode.fallbackPeriod = 0
+		gc.readNode.markUnhealthy(time.Now())
+		gc.readNode.fallbackPeriod = time.Hour
+		read.err = &api.Error{StatusCode: statusCode}
+		_, err = gc.AttesterDuties(gc.ctx, 1, nil)
+		require.NoError(t, err, statusCode)
+		require.Equal(t, 3+i, primary.duties, statusCode)
+		require.False(t, gc.readNode.healthy(time.Now()), statusCode)
+	}
+	read.err = nil
+
+	// Requests which fail on the primary node too fail.
+	primary.err = errors.New("test error")
+	gc.writeNode = nil
+	require.Error(t, gc.SubmitVoluntaryExit(&phase0.SignedVoluntaryExit{}))
+}
+
+type routingRecorder struct {
+	Client
+	err    error
+	duties int
+	exits  int
+}
+
+func (r *routingRecorder) AttesterDuties(ctx context.Context, opts *api.AttesterDutiesOpts) (*api.Response[[]*eth2apiv1.AttesterDuty], error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	r.duties++
+	return &api.Response[[]*eth2apiv1.AttesterDuty]{}, nil
+}
+
+func (r *routingRecorder) SubmitVoluntaryExit(ctx context.Context, voluntaryExit *phase0.SignedVoluntaryExit) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.exits++
+	return nil
+}
+
+func TestSlotStickyRouting(t *testing.T) {
+	primary := &routingRecorder{}
+	write := &routingRecorder{}
+	gc := &goClient{
+		log:        zap.NewNop(),
+		ctx:        context.Background(),
+		network:    beacon.NewNetwork(types.MainNetwork),
+		client:     primary,
+		writeNode:  newRoutedNode(nodeRoleWrite, write, 0),
+		dutyRoutes: newDutyRoutes(true),
+	}
+	switches := func(role types.BeaconRole) float64 {
+		return testutil.ToFloat64(metricsNodeMidSlotSwitches.WithLabelValues(role.String()))
+	}
+	initial := switches(types.BNRoleAttester)
+
+	var served Client
+	var failing Client
+	request := func(client Client) error {
+		if client == failing {
+			return errors.New("test error")
+		}
+		served = client
+		return nil
+	}
+	fetch := func(role types.BeaconRole, slot phase0.Slot) Client {
+		require.NoError(t, gc.fetchDutyData(role, slot, "test", request))
+		return served
+	}
+	submit := func(role types.BeaconRole, slot phase0.Slot) Client {
+		require.NoError(t, gc.submitDuty(gc.ctx, submissionExit, role, slot, request))
+		return served
+	}
+
+	// The data of a duty is fetched from the node which it's submitted to.
+	slot := phase0.Slot(100)
+	require.Equal(t, write, fetch(types.BNRoleAttester, slot))
+	require.Equal(t, write, submit(types.BNRoleAttester, slot))
+	require.Equal(t, initial, switches(types.BNRoleAttester))
+
+	// The node is only abandoned within the duty's slot once it fails, which is counted as a switch.
+	failing = write
+	require.Equal(t, primary, fetch(types.BNRoleAttester, slot+1))
+	failing = nil
+	require.Equal(t, write, fetch(types.BNRoleAttester, slot))
+	failing = write
+	require.Equal(t, primary, submit(types.BNRoleAttester, slot))
+	require.Equal(t, initial+1, switches(types.BNRoleAttester))
+
+	// Although the write node is healthy again right away (no fallback period),
+	// the primary node keeps serving the duty within its slot.
+	failing = nil
+	require.True(t, gc.writeNode.healthy(time.Now()))
+	require.Equal(t, primary, submit(types.BNRoleAttester, slot))
+	require.Equal(t, primary, submit(types.BNRoleAttester, slot+1))
+	require.Equal(t, initial+1, switches(types.BNRoleAttester))
+
+	// Other duties and slots are routed to the write node.
+	require.Equal(t, write, fetch(types.BNRoleProposer, slot))
+	require.Equal(t, write, fetch(types.BNRoleAttester, slot+2))
+
+	// Routes of past slots are forgotten.
+	require.Equal(t, write, fetch(types.BNRoleAttester, slot+dutyRouteRetention+2))
+	require.NotContains(t, gc.dutyRoutes.routes, dutyRoute{role: types.BNRoleAttester, slot: slot})
+
+	// Without stickiness, the data of duties is fetched from the primary node,
+	// so submitting a duty to the write node is counted as a switch.
+	gc.dutyRoutes = newDutyRoutes(false)
+	require.Equal(t, primary, fetch(types.BNRoleAttester, slot))
+	require.Equal(t, write, submit(types.BNRoleAttester, slot))
+	require.Equal(t, initial+2, switches(types.BNRoleAttester))
+}
@@ -0,0 +1,194 @@
+package goclient
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+)
+
+// Endpoints probed by SelfTest.
+const (
+	EndpointAttesterDuties      = "attester_duties"
+	EndpointProposerDuties      = "proposer_duties"
+	EndpointSyncCommitteeDuties = "sync_committee_duties"
+	EndpointAttestationData     = "attestation_data"
+	EndpointProposal            = "proposal"
+	EndpointBlindedProposal     = "blinded_proposal"
+	EndpointValidators          = "validators"
+	EndpointEvents              = "events"
+	EndpointDomain              = "domain"
+	EndpointRegistrations       = "validator_registrations"
+)
+
+// selfTestEndpointClasses are the classes of the probed endpoints which may be disabled by configuration.
+var selfTestEndpointClasses = map[string]endpointClass{
+	EndpointAttestationData: endpointAttestation,
+	EndpointProposal:        endpointProposal,
+	EndpointBlindedProposal: endpointBlindedProposal,
+	EndpointRegistrations:   endpointRegistration,
+}
+
+// selfTestEventSlots is the number of slots to wait for the first head event.
+const selfTestEventSlots = 2
+
+var (
+	// infinityRandaoReveal is the point at infinity, passed as the RANDAO reveal of proposals
+	// which skip its verification.
+	infinityRandaoReveal = phase0.BLSSignature{0xc0}
+
+	// errSelfTestSkipped is returned by probes which can't be run without side effects.
+	errSelfTestSkipped = fmt.Errorf("skipped")
+)
+
+// SelfTester probes the beacon node's endpoints.
+type SelfTester interface {
+	SelfTest(ctx context.Context) []EndpointResult
+}
+
+var _ SelfTester = (*goClient)(nil)
+
+// EndpointResult is the outcome of probing a single beacon node endpoint.
+type EndpointResult struct {
+	Endpoint string
+	Latency  time.Duration
+	Err      error
+	Skipped  bool // the endpoint is disabled or couldn't be probed without side effects, so it wasn't
+}
+
+// SelfTest probes every beacon node endpoint which SSV depends on, one at a time, with requests
+// that have no side effects, and returns the result of each probe. It's meant to be run before
+// going live, to catch unsupported or misconfigured endpoints before the first duty needs them.
+func (gc *goClient) SelfTest(ctx context.Context) []EndpointResult {
+	slot := gc.network.EstimatedCurrentSlot()
+	epoch := gc.network.EstimatedEpochAtSlot(slot)
+	indices := []phase0.ValidatorIndex{0}
+
+	probes := []struct {
+		endpoint string
+		timeout  time.Duration
+		probe    func(ctx context.Context) error
+	}{
+		{EndpointAttesterDuties, gc.commonTimeout, func(ctx context.Context) error {
+			_, err := gc.client.AttesterDuties(ctx, &api.AttesterDutiesOpts{Epoch: epoch, Indices: indices})
+			return err
+		}},
+		{EndpointProposerDuties, gc.commonTimeout, func(ctx context.Context) error {
+			_, err := gc.client.ProposerDuties(ctx, &api.ProposerDutiesOpts{Epoch: epoch, Indices: indices})
+			return err
+		}},
+		{EndpointSyncCommitteeDuties, gc.commonTimeout, func(ctx context.Context) error {
+			_, err := gc.client.SyncCommitteeDuties(ctx, &api.SyncCommitteeDutiesOpts{Epoch: epoch, Indices: indices})
+			return err
+		}},
+		{EndpointAttestationData, gc.timeouts.attestationData, func(ctx context.Context) error {
+			_, err := gc.client.AttestationData(ctx, &api.AttestationDataOpts{Slot: slot})
+			return err
+		}},
+		{EndpointProposal, gc.timeouts.proposal, func(ctx context.Context) error {
+			return gc.probeProposal(ctx, slot+1, 0)
+		}},
+		{EndpointBlindedProposal, gc.timeouts.proposal, func(ctx context.Context) error {
+			return gc.probeProposal(ctx, slot+1, math.MaxUint64)
+		}},
+		{EndpointValidators, gc.timeouts.validators, func(ctx context.Context) error {
+			_, err := gc.client.Validators(ctx, &api.ValidatorsOpts{State: "head", Indices: indices})
+			return err
+		}},
+		{EndpointEvents, selfTestEventSlots * gc.network.SlotDurationSec(), gc.probeEvents},
+		{EndpointDomain, gc.commonTimeout, func(ctx context.Context) error {
+			_, err := gc.client.Domain(ctx, phase0.DomainType(spectypes.DomainAttester), epoch)
+			return err
+		}},
+		{EndpointRegistrations, gc.commonTimeout, gc.probeRegistrations},
+	}
+
+	results := make([]EndpointResult, 0, len(probes))
+	for _, p := range probes {
+		if class, ok := selfTestEndpointClasses[p.endpoint]; ok && !gc.endpointEnabled(class) {
+			results = append(results, EndpointResult{Endpoint: p.endpoint, Skipped: true})
+			continue
+		}
+
+		probeCtx, cancel := context.WithTimeout(ctx, p.timeout)
+		start := time.Now()
+		err := p.probe(probeCtx)
+		latency := time.Since(start)
+		cancel()
+
+		result := EndpointResult{Endpoint: p.endpoint, Latency: latency, Err: err}
+		if err == errSelfTestSkipped {
+			result = EndpointResult{Endpoint: p.endpoint, Skipped: true}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// probeProposal requests a proposal without verifying its RANDAO reveal. A builder boost factor
+// of math.MaxUint64 requests a blinded proposal whenever the beacon node has a builder configured.
+func (gc *goClient) probeProposal(ctx context.Context, slot phase0.Slot, builderBoostFactor uint64) error {
+	opts := &api.ProposalOpts{
+		Slot:                   slot,
+		RandaoReveal:           infinityRandaoReveal,
+		SkipRandaoVerification: true,
+	}
+	if builderBoostFactor != 0 {
+		opts.BuilderBoostFactor = &builderBoostFactor
+	}
+	resp, err := gc.client.Proposal(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if resp == nil || resp.Data == nil {
+		return fmt.Errorf("proposal response is nil")
+	}
+	return nil
+}
+
+// probeEvents waits for the first head event of a fresh subscription,
+// since the subscription itself succeeds before the stream is connected.
+func (gc *goClient) probeEvents(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	received := make(chan struct{}, 1)
+	err := gc.client.Events(ctx, []string{"head"}, func(*eth2apiv1.Event) {
+		select {
+		case received <- struct{}{}:
+		default:
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-received:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("no head event received: %w", ctx.Err())
+	}
+}
+
+// probeRegistrations resubmits a cached registration, which the beacon node has already accepted.
+// It's skipped if no registration is cached yet.
+func (gc *goClient) probeRegistrations(ctx context.Context) error {
+	gc.registrationMu.Lock()
+	var registration *api.VersionedSignedValidatorRegistration
+	for _, r := range gc.registrationCache {
+		registration = r
+		break
+	}
+	gc.registrationMu.Unlock()
+
+	if registration == nil {
+		return errSelfTestSkipped
+	}
+	return gc.client.SubmitValidatorRegistrations(ctx, []*api.VersionedSignedValidatorRegistration{registration})
+}
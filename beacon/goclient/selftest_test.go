@@ -0,0 +1,124 @@
+package goclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+func TestSelfTest(t *testing.T) {
+	client := &selfTestClient{}
+	gc := &goClient{
+		log:               zap.NewNop(),
+		network:           beacon.NewNetwork(types.MainNetwork),
+		client:            client,
+		commonTimeout:     time.Second,
+		registrationCache: map[phase0.BLSPubKey]*api.VersionedSignedValidatorRegistration{},
+		timeouts: requestTimeouts{
+			attestationData: time.Second,
+			proposal:        time.Second,
+			validators:      time.Second,
+		},
+	}
+
+	results := gc.SelfTest(context.Background())
+	endpoints := make([]string, 0, len(results))
+	for _, result := range results {
+		endpoints = append(endpoints, result.Endpoint)
+		switch result.Endpoint {
+		case EndpointBlindedProposal:
+			require.ErrorContains(t, result.Err, "builder is not configured")
+		case EndpointRegistrations:
+			// Nothing to resubmit yet.
+			require.True(t, result.Skipped)
+		default:
+			require.NoError(t, result.Err, result.Endpoint)
+			require.False(t, result.Skipped)
+		}
+	}
+	require.Equal(t, []string{
+		EndpointAttesterDuties,
+		EndpointProposerDuties,
+		EndpointSyncCommitteeDuties,
+		EndpointAttestationData,
+		EndpointProposal,
+		EndpointBlindedProposal,
+		EndpointValidators,
+		EndpointEvents,
+		EndpointDomain,
+		EndpointRegistrations,
+	}, endpoints)
+	require.Equal(t, 0, client.registrations)
+
+	// A cached registration is resubmitted.
+	gc.registrationCache[phase0.BLSPubKey{1}] = &api.VersionedSignedValidatorRegistration{
+		Version: spec.BuilderVersionV1,
+		V1:      &eth2apiv1.SignedValidatorRegistration{Message: &eth2apiv1.ValidatorRegistration{Pubkey: phase0.BLSPubKey{1}}},
+	}
+	results = gc.SelfTest(context.Background())
+	require.NoError(t, results[len(results)-1].Err)
+	require.False(t, results[len(results)-1].Skipped)
+	require.Equal(t, 1, client.registrations)
+}
+
+// selfTestClient serves every request probed by SelfTest, except for blinded proposals.
+type selfTestClient struct {
+	Client
+	registrations int
+}
+
+func (c *selfTestClient) AttesterDuties(context.Context, *api.AttesterDutiesOpts) (*api.Response[[]*eth2apiv1.AttesterDuty], error) {
+	return &api.Response[[]*eth2apiv1.AttesterDuty]{}, nil
+}
+
+func (c *selfTestClient) ProposerDuties(context.Context, *api.ProposerDutiesOpts) (*api.Response[[]*eth2apiv1.ProposerDuty], error) {
+	return &api.Response[[]*eth2apiv1.ProposerDuty]{}, nil
+}
+
+func (c *selfTestClient) SyncCommitteeDuties(context.Context, *api.SyncCommitteeDutiesOpts) (*api.Response[[]*eth2apiv1.SyncCommitteeDuty], error) {
+	return &api.Response[[]*eth2apiv1.SyncCommitteeDuty]{}, nil
+}
+
+func (c *selfTestClient) AttestationData(context.Context, *api.AttestationDataOpts) (*api.Response[*phase0.AttestationData], error) {
+	return &api.Response[*phase0.AttestationData]{Data: &phase0.AttestationData{}}, nil
+}
+
+func (c *selfTestClient) Proposal(_ context.Context, opts *api.ProposalOpts) (*api.Response[*api.VersionedProposal], error) {
+	if opts.BuilderBoostFactor != nil {
+		return nil, fmt.Errorf("builder is not configured")
+	}
+	if !opts.SkipRandaoVerification {
+		return nil, fmt.Errorf("invalid randao reveal")
+	}
+	return &api.Response[*api.VersionedProposal]{Data: &api.VersionedProposal{}}, nil
+}
+
+func (c *selfTestClient) Validators(context.Context, *api.ValidatorsOpts) (*api.Response[map[phase0.ValidatorIndex]*eth2apiv1.Validator], error) {
+	return &api.Response[map[phase0.ValidatorIndex]*eth2apiv1.Validator]{}, nil
+}
+
+func (c *selfTestClient) Events(_ context.Context, _ []string, handler eth2client.EventHandlerFunc) error {
+	go handler(&eth2apiv1.Event{Topic: "head", Data: &eth2apiv1.HeadEvent{}})
+	return nil
+}
+
+func (c *selfTestClient) Domain(context.Context, phase0.DomainType, phase0.Epoch) (phase0.Domain, error) {
+	return phase0.Domain{}, nil
+}
+
+func (c *selfTestClient) SubmitValidatorRegistrations(context.Context, []*api.VersionedSignedValidatorRegistration) error {
+	c.registrations++
+	return nil
+}
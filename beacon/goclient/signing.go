@@ -84,10 +84,19 @@ func (gc *goClient) DomainData(epoch phase0.Epoch, domain phase0.DomainType) (ph
 		return gc.computeVoluntaryExitDomain(gc.ctx)
 	}
 
+	if gc.domainCache != nil {
+		if data, ok := gc.domainCache.get(epoch, domain); ok {
+			return data, nil
+		}
+	}
+
 	data, err := gc.client.Domain(gc.ctx, domain, epoch)
 	if err != nil {
 		return phase0.Domain{}, err
 	}
+	if gc.domainCache != nil {
+		gc.domainCache.set(epoch, domain, data)
+	}
 	return data, nil
 }
 
@@ -3,20 +3,24 @@ package goclient
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/attestantio/go-eth2-client/api"
 	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
 )
 
 // SyncCommitteeDuties returns sync committee duties for a given epoch
 func (gc *goClient) SyncCommitteeDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*eth2apiv1.SyncCommitteeDuty, error) {
-	resp, err := gc.client.SyncCommitteeDuties(ctx, &api.SyncCommitteeDutiesOpts{
-		Epoch:   epoch,
-		Indices: validatorIndices,
+	var resp *api.Response[[]*eth2apiv1.SyncCommitteeDuty]
+	err := gc.route(gc.readNode, readRequestDuties, func(client Client) (err error) {
+		resp, err = client.SyncCommitteeDuties(ctx, &api.SyncCommitteeDutiesOpts{
+			Epoch:   epoch,
+			Indices: validatorIndices,
+		})
+		return err
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to obtain sync committee duties: %w", err)
@@ -25,15 +29,25 @@ func (gc *goClient) SyncCommitteeDuties(ctx context.Context, epoch phase0.Epoch,
 		return nil, fmt.Errorf("sync committee duties response is nil")
 	}
 
+	gc.flagWarmUp("sync_committee_duties")
 	return resp.Data, nil
 }
 
 // GetSyncMessageBlockRoot returns beacon block root for sync committee
 func (gc *goClient) GetSyncMessageBlockRoot(slot phase0.Slot) (phase0.Root, spec.DataVersion, error) {
-	reqStart := time.Now()
-	resp, err := gc.client.BeaconBlockRoot(gc.ctx, &api.BeaconBlockRootOpts{
-		Block: "head",
+	if err := gc.checkEndpoint(endpointSyncCommittee); err != nil {
+		return phase0.Root{}, DataVersionNil, err
+	}
+
+	var resp *api.Response[*phase0.Root]
+	span := gc.startRequest(gc.ctx, spectypes.BNRoleSyncCommittee, slot, "beacon_block_root", metricsSyncCommitteeDataRequest)
+	err := gc.fetchDutyData(spectypes.BNRoleSyncCommittee, slot, "beacon_block_root", func(client Client) (err error) {
+		resp, err = client.BeaconBlockRoot(span.ctx, &api.BeaconBlockRootOpts{
+			Block: "head",
+		})
+		return err
 	})
+	span.end(err)
 	if err != nil {
 		return phase0.Root{}, DataVersionNil, fmt.Errorf("failed to obtain beacon block root: %w", err)
 	}
@@ -43,15 +57,22 @@ func (gc *goClient) GetSyncMessageBlockRoot(slot phase0.Slot) (phase0.Root, spec
 	if resp.Data == nil {
 		return phase0.Root{}, DataVersionNil, fmt.Errorf("beacon block root data is nil")
 	}
-	metricsSyncCommitteeDataRequest.Observe(time.Since(reqStart).Seconds())
 
 	return *resp.Data, spec.DataVersionAltair, nil
 }
 
 // SubmitSyncMessage submits a signed sync committee msg
 func (gc *goClient) SubmitSyncMessage(msg *altair.SyncCommitteeMessage) error {
-	if err := gc.client.SubmitSyncCommitteeMessages(gc.ctx, []*altair.SyncCommitteeMessage{msg}); err != nil {
+	if err := gc.checkEndpoint(endpointSyncCommittee); err != nil {
 		return err
 	}
+
+	if err := gc.submitDuty(gc.ctx, submissionAttestation, spectypes.BNRoleSyncCommittee, msg.Slot, func(client Client) error {
+		return client.SubmitSyncCommitteeMessages(gc.ctx, []*altair.SyncCommitteeMessage{msg})
+	}); err != nil {
+		return err
+	}
+
+	dutySubmitted(spectypes.BNRoleSyncCommittee)
 	return nil
 }
@@ -3,6 +3,7 @@ package goclient
 import (
 	"crypto/sha256"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"time"
 
@@ -12,9 +13,24 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	ssz "github.com/ferranbt/fastssz"
+	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+
+	"github.com/bloxapp/ssv/logging/fields"
 )
 
+// maxConcurrentContributionRequests bounds the number of sync committee contributions requested at once.
+// A validator is in at most SyncCommitteeSubnetCount subcommittees, so normally all are requested at once.
+var maxConcurrentContributionRequests = int(SyncCommitteeSubnetCount)
+
+// ErrInvalidSyncCommitteeContribution is returned when the beacon node returns a contribution
+// which doesn't match its request or aggregates no sync committee messages.
+var ErrInvalidSyncCommitteeContribution = errors.New("invalid sync committee contribution")
+
+// ErrEmptySyncCommitteeContribution is returned when the beacon node returns a contribution
+// which aggregates no sync committee messages, e.g. since none were received for its subnet yet.
+var ErrEmptySyncCommitteeContribution = fmt.Errorf("%w: no aggregation bits are set", ErrInvalidSyncCommitteeContribution)
+
 // IsSyncCommitteeAggregator returns tru if aggregator
 func (gc *goClient) IsSyncCommitteeAggregator(proof []byte) (bool, error) {
 	// Hash the signature.
@@ -36,16 +52,25 @@ func (gc *goClient) SyncCommitteeSubnetID(index phase0.CommitteeIndex) (uint64,
 
 // GetSyncCommitteeContribution returns
 func (gc *goClient) GetSyncCommitteeContribution(slot phase0.Slot, selectionProofs []phase0.BLSSignature, subnetIDs []uint64) (ssz.Marshaler, spec.DataVersion, error) {
+	if err := gc.checkEndpoint(endpointSyncCommittee); err != nil {
+		return nil, DataVersionNil, err
+	}
+
 	if len(selectionProofs) != len(subnetIDs) {
 		return nil, DataVersionNil, fmt.Errorf("mismatching number of selection proofs and subnet IDs")
 	}
 
 	gc.waitForOneThirdSlotDuration(slot)
 
-	scDataReqStart := time.Now()
-	beaconBlockRootResp, err := gc.client.BeaconBlockRoot(gc.ctx, &api.BeaconBlockRootOpts{
-		Block: fmt.Sprint(slot),
+	var beaconBlockRootResp *api.Response[*phase0.Root]
+	span := gc.startRequest(gc.ctx, spectypes.BNRoleSyncCommitteeContribution, slot, "beacon_block_root", metricsSyncCommitteeDataRequest)
+	err := gc.fetchDutyData(spectypes.BNRoleSyncCommitteeContribution, slot, "beacon_block_root", func(client Client) (err error) {
+		beaconBlockRootResp, err = client.BeaconBlockRoot(span.ctx, &api.BeaconBlockRootOpts{
+			Block: fmt.Sprint(slot),
+		})
+		return err
 	})
+	span.end(err)
 	if err != nil {
 		return nil, DataVersionNil, fmt.Errorf("failed to obtain beacon block root: %w", err)
 	}
@@ -55,55 +80,141 @@ func (gc *goClient) GetSyncCommitteeContribution(slot phase0.Slot, selectionProo
 	if beaconBlockRootResp.Data == nil {
 		return nil, DataVersionNil, fmt.Errorf("beacon block root data is nil")
 	}
-
-	metricsSyncCommitteeDataRequest.Observe(time.Since(scDataReqStart).Seconds())
 	blockRoot := beaconBlockRootResp.Data
 
 	gc.waitToSlotTwoThirds(slot)
 
-	// Fetch sync committee contributions for each subnet in parallel.
+	contributions, err := gc.fetchSyncCommitteeContributions(slot, *blockRoot, selectionProofs, subnetIDs)
+	if err != nil {
+		return nil, DataVersionNil, err
+	}
+
+	return &contributions, spec.DataVersionAltair, nil
+}
+
+// fetchSyncCommitteeContributions requests the contributions of the given subnets concurrently,
+// pairing each with its selection proof once it's validated.
+// Empty contributions are skipped, so that the other subnets' contributions are still submitted,
+// unless all of them are empty.
+func (gc *goClient) fetchSyncCommitteeContributions(
+	slot phase0.Slot,
+	blockRoot phase0.Root,
+	selectionProofs []phase0.BLSSignature,
+	subnetIDs []uint64,
+) (spectypes.Contributions, error) {
 	var (
-		sccDataReqStart = time.Now()
-		contributions   = make(spectypes.Contributions, 0, len(subnetIDs))
-		g               errgroup.Group
+		contributions = make(spectypes.Contributions, len(subnetIDs))
+		g             errgroup.Group
 	)
+	g.SetLimit(maxConcurrentContributionRequests)
 	for i := range subnetIDs {
 		index := i
 		g.Go(func() error {
-			syncCommitteeContrResp, err := gc.client.SyncCommitteeContribution(gc.ctx, &api.SyncCommitteeContributionOpts{
-				Slot:              slot,
-				SubcommitteeIndex: subnetIDs[index],
-				BeaconBlockRoot:   *blockRoot,
-			})
-			if err != nil {
-				return fmt.Errorf("failed to obtain sync committee contribution: %w", err)
-			}
-			if syncCommitteeContrResp == nil {
-				return fmt.Errorf("sync committee contribution response is nil")
+			contribution, err := gc.fetchSyncCommitteeContribution(slot, blockRoot, subnetIDs[index])
+			if errors.Is(err, ErrEmptySyncCommitteeContribution) {
+				gc.log.Debug("skipping empty sync committee contribution",
+					fields.Slot(slot),
+					zap.Uint64("subnet_id", subnetIDs[index]),
+				)
+				return nil
 			}
-			if syncCommitteeContrResp.Data == nil {
-				return fmt.Errorf("sync committee contribution data is nil")
+			if err != nil {
+				return err
 			}
-			contribution := syncCommitteeContrResp.Data
-			contributions = append(contributions, &spectypes.Contribution{
+			contributions[index] = &spectypes.Contribution{
 				SelectionProofSig: selectionProofs[index],
 				Contribution:      *contribution,
-			})
+			}
 			return nil
 		})
 	}
 	if err := g.Wait(); err != nil {
-		return nil, DataVersionNil, err
+		return nil, err
 	}
 
-	metricsSyncCommitteeContributionDataRequest.Observe(time.Since(sccDataReqStart).Seconds())
+	nonEmpty := contributions[:0]
+	for _, contribution := range contributions {
+		if contribution != nil {
+			nonEmpty = append(nonEmpty, contribution)
+		}
+	}
+	if len(nonEmpty) == 0 {
+		return nil, fmt.Errorf("all %d sync committee contributions: %w", len(subnetIDs), ErrEmptySyncCommitteeContribution)
+	}
+	return nonEmpty, nil
+}
 
-	return &contributions, spec.DataVersionAltair, nil
+func (gc *goClient) fetchSyncCommitteeContribution(slot phase0.Slot, blockRoot phase0.Root, subnetID uint64) (*altair.SyncCommitteeContribution, error) {
+	span := gc.startRequest(gc.ctx, spectypes.BNRoleSyncCommitteeContribution, slot, "sync_committee_contribution", metricsSyncCommitteeContributionDataRequest,
+		zap.Uint64("subnet_id", subnetID),
+	)
+	var syncCommitteeContrResp *api.Response[*altair.SyncCommitteeContribution]
+	err := gc.fetchDutyData(spectypes.BNRoleSyncCommitteeContribution, slot, "sync_committee_contribution", func(client Client) (err error) {
+		syncCommitteeContrResp, err = client.SyncCommitteeContribution(span.ctx, &api.SyncCommitteeContributionOpts{
+			Slot:              slot,
+			SubcommitteeIndex: subnetID,
+			BeaconBlockRoot:   blockRoot,
+		})
+		return err
+	})
+	span.end(err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain sync committee contribution: %w", err)
+	}
+	if syncCommitteeContrResp == nil {
+		return nil, fmt.Errorf("sync committee contribution response is nil")
+	}
+	if syncCommitteeContrResp.Data == nil {
+		return nil, fmt.Errorf("sync committee contribution data is nil")
+	}
+
+	contribution := syncCommitteeContrResp.Data
+	if err := validateSyncCommitteeContribution(contribution, slot, blockRoot, subnetID); err != nil {
+		return nil, err
+	}
+	return contribution, nil
+}
+
+// validateSyncCommitteeContribution checks that the contribution is of the requested slot, block root and subnet,
+// and that its aggregation bits fit the subcommittee and include at least one participant.
+func validateSyncCommitteeContribution(contribution *altair.SyncCommitteeContribution, slot phase0.Slot, blockRoot phase0.Root, subnetID uint64) error {
+	if contribution.Slot != slot {
+		return fmt.Errorf("%w: slot %d doesn't match requested slot %d", ErrInvalidSyncCommitteeContribution, contribution.Slot, slot)
+	}
+	if contribution.BeaconBlockRoot != blockRoot {
+		return fmt.Errorf("%w: beacon block root %x doesn't match requested root %x", ErrInvalidSyncCommitteeContribution, contribution.BeaconBlockRoot, blockRoot)
+	}
+	if contribution.SubcommitteeIndex != subnetID {
+		return fmt.Errorf("%w: subcommittee %d doesn't match requested subnet %d", ErrInvalidSyncCommitteeContribution, contribution.SubcommitteeIndex, subnetID)
+	}
+	if size := uint64(len(contribution.AggregationBits)) * 8; size != SyncCommitteeSize/SyncCommitteeSubnetCount {
+		return fmt.Errorf("%w: %d aggregation bits for a subcommittee of %d", ErrInvalidSyncCommitteeContribution, size, SyncCommitteeSize/SyncCommitteeSubnetCount)
+	}
+	if contribution.AggregationBits.Count() == 0 {
+		return ErrEmptySyncCommitteeContribution
+	}
+	return nil
 }
 
 // SubmitSignedContributionAndProof broadcasts to the network
 func (gc *goClient) SubmitSignedContributionAndProof(contribution *altair.SignedContributionAndProof) error {
-	return gc.client.SubmitSyncCommitteeContributions(gc.ctx, []*altair.SignedContributionAndProof{contribution})
+	if err := gc.checkEndpoint(endpointSyncCommittee); err != nil {
+		return err
+	}
+
+	if contribution.Message == nil || contribution.Message.Contribution == nil {
+		return fmt.Errorf("contribution and proof is incomplete")
+	}
+
+	slot := contribution.Message.Contribution.Slot
+	if err := gc.submitDuty(gc.ctx, submissionAttestation, spectypes.BNRoleSyncCommitteeContribution, slot, func(client Client) error {
+		return client.SubmitSyncCommitteeContributions(gc.ctx, []*altair.SignedContributionAndProof{contribution})
+	}); err != nil {
+		return err
+	}
+
+	dutySubmitted(spectypes.BNRoleSyncCommitteeContribution)
+	return nil
 }
 
 // waitForOneThirdSlotDuration waits until one-third of the slot has transpired (SECONDS_PER_SLOT / 3 seconds after the start of slot)
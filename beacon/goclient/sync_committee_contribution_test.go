@@ -0,0 +1,129 @@
+package goclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFetchSyncCommitteeContributions(t *testing.T) {
+	client := &contributionsClient{}
+	gc := &goClient{
+		log:    zap.NewNop(),
+		ctx:    context.Background(),
+		client: client,
+	}
+
+	blockRoot := phase0.Root{1, 2, 3}
+	subnetIDs := []uint64{3, 0, 2, 1, 0, 3}
+	selectionProofs := make([]phase0.BLSSignature, len(subnetIDs))
+	for i := range selectionProofs {
+		selectionProofs[i][0] = byte(i)
+	}
+
+	contributions, err := gc.fetchSyncCommitteeContributions(100, blockRoot, selectionProofs, subnetIDs)
+	require.NoError(t, err)
+	require.Len(t, contributions, len(subnetIDs))
+	for i, contribution := range contributions {
+		require.Equal(t, [96]byte(selectionProofs[i]), contribution.SelectionProofSig)
+		require.Equal(t, subnetIDs[i], contribution.Contribution.SubcommitteeIndex)
+	}
+	require.LessOrEqual(t, client.peak, maxConcurrentContributionRequests)
+
+	// Empty contributions are skipped, and the others are still returned.
+	client.emptySubnets = map[uint64]bool{3: true}
+	contributions, err = gc.fetchSyncCommitteeContributions(100, blockRoot, selectionProofs, subnetIDs)
+	require.NoError(t, err)
+	require.Len(t, contributions, 4)
+	for _, contribution := range contributions {
+		require.NotEqual(t, uint64(3), contribution.Contribution.SubcommitteeIndex)
+		require.True(t, contribution.Contribution.AggregationBits.BitAt(contribution.Contribution.SubcommitteeIndex))
+	}
+
+	// If all contributions are empty, there's nothing to submit.
+	client.emptySubnets = map[uint64]bool{0: true, 1: true, 2: true, 3: true}
+	_, err = gc.fetchSyncCommitteeContributions(100, blockRoot, selectionProofs, subnetIDs)
+	require.ErrorIs(t, err, ErrEmptySyncCommitteeContribution)
+	require.ErrorIs(t, err, ErrInvalidSyncCommitteeContribution)
+	require.ErrorContains(t, err, "no aggregation bits are set")
+}
+
+func TestValidateSyncCommitteeContribution(t *testing.T) {
+	blockRoot := phase0.Root{1}
+	valid := func() *altair.SyncCommitteeContribution {
+		bits := bitfield.NewBitvector128()
+		bits.SetBitAt(5, true)
+		return &altair.SyncCommitteeContribution{
+			Slot:              10,
+			BeaconBlockRoot:   blockRoot,
+			SubcommitteeIndex: 2,
+			AggregationBits:   bits,
+		}
+	}
+	require.NoError(t, validateSyncCommitteeContribution(valid(), 10, blockRoot, 2))
+
+	tests := map[string]func(*altair.SyncCommitteeContribution){
+		"doesn't match requested slot":   func(c *altair.SyncCommitteeContribution) { c.Slot = 11 },
+		"doesn't match requested root":   func(c *altair.SyncCommitteeContribution) { c.BeaconBlockRoot = phase0.Root{2} },
+		"doesn't match requested subnet": func(c *altair.SyncCommitteeContribution) { c.SubcommitteeIndex = 1 },
+		"64 aggregation bits":            func(c *altair.SyncCommitteeContribution) { c.AggregationBits = c.AggregationBits[:8] },
+		"no aggregation bits are set":    func(c *altair.SyncCommitteeContribution) { c.AggregationBits = bitfield.NewBitvector128() },
+	}
+	for want, modify := range tests {
+		contribution := valid()
+		modify(contribution)
+		err := validateSyncCommitteeContribution(contribution, 10, blockRoot, 2)
+		require.ErrorIs(t, err, ErrInvalidSyncCommitteeContribution, want)
+		require.ErrorContains(t, err, want)
+	}
+}
+
+// contributionsClient returns contributions of the requested subnet, recording the peak number of concurrent requests.
+type contributionsClient struct {
+	Client
+	emptySubnets map[uint64]bool
+
+	mu     sync.Mutex
+	active int
+	peak   int
+}
+
+func (c *contributionsClient) SyncCommitteeContribution(ctx context.Context, opts *api.SyncCommitteeContributionOpts) (*api.Response[*altair.SyncCommitteeContribution], error) {
+	c.mu.Lock()
+	c.active++
+	if c.active > c.peak {
+		c.peak = c.active
+	}
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.active--
+		c.mu.Unlock()
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	if opts.SubcommitteeIndex >= SyncCommitteeSubnetCount {
+		return nil, fmt.Errorf("unknown subcommittee %d", opts.SubcommitteeIndex)
+	}
+	bits := bitfield.NewBitvector128()
+	if !c.emptySubnets[opts.SubcommitteeIndex] {
+		bits.SetBitAt(opts.SubcommitteeIndex, true)
+	}
+	return &api.Response[*altair.SyncCommitteeContribution]{
+		Data: &altair.SyncCommitteeContribution{
+			Slot:              opts.Slot,
+			BeaconBlockRoot:   opts.BeaconBlockRoot,
+			SubcommitteeIndex: opts.SubcommitteeIndex,
+			AggregationBits:   bits,
+		},
+	}, nil
+}
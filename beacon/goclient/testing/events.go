@@ -0,0 +1,113 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+)
+
+// Event topics supported by EventStream.
+const (
+	TopicHead                = "head"
+	TopicFinalizedCheckpoint = "finalized_checkpoint"
+	TopicChainReorg          = "chain_reorg"
+)
+
+var _ eth2client.EventsProvider = (*EventStream)(nil)
+
+// EventStream is a test double for the beacon node events stream.
+// It records handlers registered via Events and lets tests push synthetic events
+// through them synchronously, so that event consumers can be tested deterministically.
+type EventStream struct {
+	mu       sync.Mutex
+	handlers map[string][]eth2client.EventHandlerFunc
+	err      error
+}
+
+// NewEventStream creates a new EventStream with no registered handlers.
+func NewEventStream() *EventStream {
+	return &EventStream{
+		handlers: map[string][]eth2client.EventHandlerFunc{},
+	}
+}
+
+// FailSubscriptions makes subsequent calls to Events return the given error.
+// Passing nil restores normal behavior.
+func (s *EventStream) FailSubscriptions(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.err = err
+}
+
+// Events registers the handler for the given topics.
+func (s *EventStream) Events(_ context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	if len(topics) == 0 {
+		return fmt.Errorf("no topics supplied")
+	}
+	if handler == nil {
+		return fmt.Errorf("no handler supplied")
+	}
+
+	for _, topic := range topics {
+		s.handlers[topic] = append(s.handlers[topic], handler)
+	}
+
+	return nil
+}
+
+// Subscribed returns whether at least one handler is registered for the given topic.
+func (s *EventStream) Subscribed(topic string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.handlers[topic]) != 0
+}
+
+// PushHead delivers a head event to all handlers subscribed to the head topic.
+// It returns the number of handlers the event was delivered to.
+func (s *EventStream) PushHead(data *eth2apiv1.HeadEvent) int {
+	return s.Push(TopicHead, data)
+}
+
+// PushFinalizedCheckpoint delivers a finalized checkpoint event to all handlers
+// subscribed to the finalized_checkpoint topic.
+// It returns the number of handlers the event was delivered to.
+func (s *EventStream) PushFinalizedCheckpoint(data *eth2apiv1.FinalizedCheckpointEvent) int {
+	return s.Push(TopicFinalizedCheckpoint, data)
+}
+
+// PushChainReorg delivers a chain reorg event to all handlers subscribed to the chain_reorg topic.
+// It returns the number of handlers the event was delivered to.
+func (s *EventStream) PushChainReorg(data *eth2apiv1.ChainReorgEvent) int {
+	return s.Push(TopicChainReorg, data)
+}
+
+// Push delivers an event with arbitrary data to all handlers subscribed to the given topic.
+// Handlers are called synchronously in the order they were registered.
+// It returns the number of handlers the event was delivered to.
+func (s *EventStream) Push(topic string, data any) int {
+	// Copy handlers so that they may subscribe or push events themselves without deadlocking.
+	s.mu.Lock()
+	handlers := make([]eth2client.EventHandlerFunc, len(s.handlers[topic]))
+	copy(handlers, s.handlers[topic])
+	s.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(&eth2apiv1.Event{
+			Topic: topic,
+			Data:  data,
+		})
+	}
+
+	return len(handlers)
+}
@@ -0,0 +1,51 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventStream(t *testing.T) {
+	ctx := context.Background()
+	stream := NewEventStream()
+
+	var heads []*eth2apiv1.HeadEvent
+	var reorgs []*eth2apiv1.ChainReorgEvent
+	var topics []string
+	require.NoError(t, stream.Events(ctx, []string{TopicHead, TopicChainReorg}, func(e *eth2apiv1.Event) {
+		topics = append(topics, e.Topic)
+		switch data := e.Data.(type) {
+		case *eth2apiv1.HeadEvent:
+			heads = append(heads, data)
+		case *eth2apiv1.ChainReorgEvent:
+			reorgs = append(reorgs, data)
+		}
+	}))
+
+	require.True(t, stream.Subscribed(TopicHead))
+	require.True(t, stream.Subscribed(TopicChainReorg))
+	require.False(t, stream.Subscribed(TopicFinalizedCheckpoint))
+
+	require.Equal(t, 1, stream.PushHead(&eth2apiv1.HeadEvent{Slot: 10}))
+	require.Equal(t, 1, stream.PushChainReorg(&eth2apiv1.ChainReorgEvent{Slot: 11, Depth: 2}))
+	require.Equal(t, 0, stream.PushFinalizedCheckpoint(&eth2apiv1.FinalizedCheckpointEvent{Epoch: 1}))
+
+	require.Equal(t, []string{TopicHead, TopicChainReorg}, topics)
+	require.Len(t, heads, 1)
+	require.Equal(t, phase0.Slot(10), heads[0].Slot)
+	require.Len(t, reorgs, 1)
+	require.Equal(t, uint64(2), reorgs[0].Depth)
+
+	subscriptionErr := errors.New("connection refused")
+	stream.FailSubscriptions(subscriptionErr)
+	require.ErrorIs(t, stream.Events(ctx, []string{TopicFinalizedCheckpoint}, func(*eth2apiv1.Event) {}), subscriptionErr)
+
+	stream.FailSubscriptions(nil)
+	require.Error(t, stream.Events(ctx, nil, func(*eth2apiv1.Event) {}))
+	require.Error(t, stream.Events(ctx, []string{TopicHead}, nil))
+}
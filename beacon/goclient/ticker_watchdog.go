@@ -0,0 +1,109 @@
+package goclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/operator/slotticker"
+)
+
+var metricsSlotTickerStalls = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "ssv_beacon_slot_ticker_stalls",
+	Help: "Count of slot ticks which didn't arrive in time",
+})
+
+func init() {
+	logger := zap.L()
+	if err := prometheus.Register(metricsSlotTickerStalls); err != nil {
+		logger.Debug("could not register prometheus collector")
+	}
+}
+
+// tickerStallSlots is the number of slot durations to wait for a tick before it's considered stalled.
+const tickerStallSlots = 1.5
+
+// tickerWatchdog detects stalls of the slot tickers which drive the client's periodic work,
+// such as the submission of validator registrations, which would otherwise silently freeze.
+// With fallback, a stalled tick is replaced by a tick of the slot derived from the wall clock.
+type tickerWatchdog struct {
+	ctx          context.Context
+	logger       *zap.Logger
+	stallTimeout time.Duration
+	fallback     bool
+	currentSlot  func() phase0.Slot
+}
+
+// wrap returns a provider of the given provider's tickers, watched by the watchdog.
+func (w *tickerWatchdog) wrap(provider slotticker.Provider) slotticker.Provider {
+	return func() slotticker.SlotTicker {
+		return &watchedTicker{watchdog: w, ticker: provider()}
+	}
+}
+
+type watchedTicker struct {
+	watchdog *tickerWatchdog
+	ticker   slotticker.SlotTicker
+
+	mu   sync.Mutex
+	slot phase0.Slot
+}
+
+// Next returns a channel that signals when the next slot starts, by the underlying ticker,
+// or by the wall clock if the underlying ticker stalls and fallback is enabled.
+// Like the underlying ticker, it must not be called concurrently.
+func (t *watchedTicker) Next() <-chan time.Time {
+	next := t.ticker.Next()
+	ch := make(chan time.Time, 1)
+	go func() {
+		timer := time.NewTimer(t.watchdog.stallTimeout)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-t.watchdog.ctx.Done():
+				return
+			case tick := <-next:
+				t.setSlot(t.ticker.Slot())
+				ch <- tick
+				return
+			case <-timer.C:
+				slot := t.watchdog.currentSlot()
+				metricsSlotTickerStalls.Inc()
+				t.watchdog.logger.Warn("slot ticker stalled",
+					fields.Slot(slot),
+					zap.Duration("timeout", t.watchdog.stallTimeout),
+					zap.Bool("fallback", t.watchdog.fallback),
+				)
+				if t.watchdog.fallback {
+					t.setSlot(slot)
+					ch <- time.Now()
+					return
+				}
+				timer.Reset(t.watchdog.stallTimeout)
+			}
+		}
+	}()
+	return ch
+}
+
+// Slot returns the slot of the last tick.
+func (t *watchedTicker) Slot() phase0.Slot {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.slot
+}
+
+func (t *watchedTicker) setSlot(slot phase0.Slot) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.slot = slot
+}
@@ -0,0 +1,72 @@
+package goclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/operator/slotticker"
+)
+
+func TestTickerWatchdog(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stalled := &stalledTicker{ticks: make(chan time.Time, 1)}
+	newWatchdog := func(fallback bool) *tickerWatchdog {
+		return &tickerWatchdog{
+			ctx:          ctx,
+			logger:       zap.NewNop(),
+			stallTimeout: 50 * time.Millisecond,
+			fallback:     fallback,
+			currentSlot:  func() phase0.Slot { return 100 },
+		}
+	}
+	provider := func() slotticker.SlotTicker { return stalled }
+
+	// Ticks of the underlying ticker are passed through.
+	ticker := newWatchdog(true).wrap(provider)()
+	stalled.slot = 5
+	stalled.ticks <- time.Now()
+	<-ticker.Next()
+	require.Equal(t, phase0.Slot(5), ticker.Slot())
+
+	// A stalled tick is replaced by the wall clock's with fallback.
+	select {
+	case <-ticker.Next():
+		require.Equal(t, phase0.Slot(100), ticker.Slot())
+	case <-time.After(time.Second):
+		require.Fail(t, "stalled tick wasn't replaced")
+	}
+
+	// Without fallback, the stalled tick is awaited.
+	ticker = newWatchdog(false).wrap(provider)()
+	next := ticker.Next()
+	select {
+	case <-next:
+		require.Fail(t, "stalled tick was replaced without fallback")
+	case <-time.After(200 * time.Millisecond):
+	}
+	stalled.slot = 6
+	stalled.ticks <- time.Now()
+	<-next
+	require.Equal(t, phase0.Slot(6), ticker.Slot())
+}
+
+// stalledTicker ticks only when a tick is pushed to it.
+type stalledTicker struct {
+	ticks chan time.Time
+	slot  phase0.Slot
+}
+
+func (s *stalledTicker) Next() <-chan time.Time {
+	return s.ticks
+}
+
+func (s *stalledTicker) Slot() phase0.Slot {
+	return s.slot
+}
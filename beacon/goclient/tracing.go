@@ -0,0 +1,252 @@
+package goclient
+
+import (
+	"context"
+	"crypto/rand"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+)
+
+// spanIDLabel is the exemplar label of the span ID of a request.
+const spanIDLabel = "span_id"
+
+// tracerName is the name of the OpenTelemetry tracer of beacon node requests.
+const tracerName = "github.com/bloxapp/ssv/beacon/goclient"
+
+// Attributes of the OpenTelemetry spans of beacon node requests.
+const (
+	roleAttribute     = attribute.Key("beacon.role")
+	slotAttribute     = attribute.Key("beacon.slot")
+	requestAttribute  = attribute.Key("beacon.request")
+	outcomeAttribute  = attribute.Key("beacon.outcome")
+	requestOutcomeOK  = "success"
+	requestOutcomeErr = "failure"
+)
+
+// requestSpan traces a beacon node request of a duty, so that a slow request in the logs can be
+// correlated with the beacon node's own logs by its time, and with the request histogram by its exemplar.
+type requestSpan struct {
+	// ctx carries the span context of the request, and should be passed to the request.
+	ctx       context.Context
+	logger    *zap.Logger
+	logFields []zap.Field
+	observer  prometheus.Observer
+	id        string
+	start     time.Time
+	done      func()     // ends counting the request as outstanding
+	otelSpan  trace.Span // nil unless a tracer provider is configured
+	duty      *dutyTrace // nil unless duty traces are set
+}
+
+// startRequest logs the issue of a beacon node request with a newly generated span ID,
+// and counts it as outstanding under its request name until the span ends.
+// The request's duration is observed by the given observer once the span ends.
+// If a tracer provider is configured, the request is also exported as an OpenTelemetry span, whose ID is used
+// if the span is recorded and has an ID of its own (see ownSpan).
+// Either way, the span context is carried by the span's ctx, as a child of the span of the duty of the given role and slot,
+// see dutyTraces, or of the span in the given context if duty traces aren't set.
+func (gc *goClient) startRequest(ctx context.Context, role spectypes.BeaconRole, slot phase0.Slot, request string, observer prometheus.Observer, logFields ...zap.Field) *requestSpan {
+	var duty *dutyTrace
+	if gc.dutyTraces != nil {
+		duty = gc.dutyTraces.start(ctx, dutyRoute{role: role, slot: slot})
+		ctx = trace.ContextWithSpan(ctx, duty.span)
+	}
+
+	parent := trace.SpanContextFromContext(ctx)
+	var otelSpan trace.Span
+	if gc.tracer != nil {
+		spanCtx, span := gc.tracer.Start(ctx, "beacon."+request,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				roleAttribute.String(role.String()),
+				requestAttribute.String(request),
+			),
+		)
+		if ownSpan(span, parent) {
+			ctx, otelSpan = spanCtx, span
+		}
+	}
+
+	var spanContext trace.SpanContext
+	if otelSpan != nil {
+		spanContext = otelSpan.SpanContext()
+	} else {
+		spanContext = newSpanContext(parent)
+		ctx = trace.ContextWithSpanContext(ctx, spanContext)
+	}
+
+	inFlight, done := gc.inFlight.start(request)
+	span := &requestSpan{
+		ctx:      ctx,
+		logger:   gc.log,
+		observer: observer,
+		id:       spanContext.SpanID().String(),
+		start:    time.Now(),
+		done:     done,
+		otelSpan: otelSpan,
+		duty:     duty,
+	}
+	span.logFields = append([]zap.Field{
+		zap.String(spanIDLabel, span.id),
+		zap.String("trace_id", spanContext.TraceID().String()),
+		fields.Role(role),
+		fields.Slot(slot),
+		zap.String("request", request),
+	}, logFields...)
+	span.log("beacon request issued", zap.Int("in_flight", inFlight))
+	return span
+}
+
+// log logs a debug message with the fields of the request, without encoding them unless debug logs are enabled.
+func (s *requestSpan) log(msg string, fields ...zap.Field) {
+	if ce := s.logger.Check(zap.DebugLevel, msg); ce != nil {
+		ce.Write(append(s.logFields[:len(s.logFields):len(s.logFields)], fields...)...)
+	}
+}
+
+// end logs the completion of the request, and observes its duration with the span ID as an exemplar if it succeeded.
+func (s *requestSpan) end(err error) {
+	s.done()
+	took := time.Since(s.start)
+	if s.duty != nil {
+		s.duty.ended(s.start.Add(took))
+	}
+	if s.otelSpan != nil {
+		if err != nil {
+			s.otelSpan.SetAttributes(outcomeAttribute.String(requestOutcomeErr))
+			s.otelSpan.RecordError(err)
+			s.otelSpan.SetStatus(codes.Error, err.Error())
+		} else {
+			s.otelSpan.SetAttributes(outcomeAttribute.String(requestOutcomeOK))
+		}
+		s.otelSpan.End()
+	}
+	if err != nil {
+		s.log("beacon request failed", fields.Took(took), zap.Error(err))
+		return
+	}
+	s.log("beacon request completed", fields.Took(took))
+
+	if exemplarObserver, ok := s.observer.(prometheus.ExemplarObserver); ok {
+		exemplarObserver.ObserveWithExemplar(took.Seconds(), prometheus.Labels{spanIDLabel: s.id})
+	} else {
+		s.observer.Observe(took.Seconds())
+	}
+}
+
+// ownSpan returns whether the span was started as a recorded span of its own, rather than e.g. by a no-op tracer,
+// which returns a span carrying the parent's span context. Otherwise, its ID can't identify the request.
+func ownSpan(span trace.Span, parent trace.SpanContext) bool {
+	return span.IsRecording() && span.SpanContext().HasSpanID() && span.SpanContext().SpanID() != parent.SpanID()
+}
+
+// newSpanContext returns the context of a new span with a random span ID,
+// in the trace of the given parent span, or in a new trace with a random ID if there's no parent span.
+func newSpanContext(parent trace.SpanContext) trace.SpanContext {
+	config := trace.SpanContextConfig{
+		TraceID:    parent.TraceID(),
+		TraceFlags: parent.TraceFlags(),
+	}
+	if !parent.HasTraceID() {
+		_, _ = rand.Read(config.TraceID[:])
+	}
+	_, _ = rand.Read(config.SpanID[:])
+	return trace.NewSpanContext(config)
+}
+
+// dutyTraces holds a span for each duty, as the parent of the spans of the duty's beacon node requests,
+// so that the requests of a duty are in one trace. A duty's span starts with its first request,
+// and ends with its latest request, once the duty is older than dutyRouteRetention slots.
+// Without a tracer, the span isn't exported, but its context still ties the duty's requests together.
+type dutyTraces struct {
+	tracer trace.Tracer // nil unless a tracer provider is configured
+
+	mu     sync.Mutex
+	latest phase0.Slot // the latest slot of a traced duty
+	traces map[dutyRoute]*dutyTrace
+}
+
+func newDutyTraces(tracer trace.Tracer) *dutyTraces {
+	return &dutyTraces{
+		tracer: tracer,
+		traces: make(map[dutyRoute]*dutyTrace),
+	}
+}
+
+// start returns the trace of the duty, starting it as a child of the span in the given context, if any,
+// and ends the traces of the duties which are too old to have further requests.
+func (t *dutyTraces) start(ctx context.Context, duty dutyRoute) *dutyTrace {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if dt, ok := t.traces[duty]; ok {
+		return dt
+	}
+	if duty.slot > t.latest {
+		t.latest = duty.slot
+		for d, dt := range t.traces {
+			if d.slot+dutyRouteRetention < duty.slot {
+				dt.finish()
+				delete(t.traces, d)
+			}
+		}
+	}
+
+	parent := trace.SpanContextFromContext(ctx)
+	var span trace.Span
+	if t.tracer != nil {
+		_, span = t.tracer.Start(ctx, "beacon.duty",
+			trace.WithAttributes(
+				roleAttribute.String(duty.role.String()),
+				slotAttribute.Int64(int64(duty.slot)),
+			),
+		)
+	}
+	if span == nil || !ownSpan(span, parent) {
+		span = trace.SpanFromContext(trace.ContextWithSpanContext(ctx, newSpanContext(parent)))
+	}
+
+	dt := &dutyTrace{span: span}
+	t.traces[duty] = dt
+	return dt
+}
+
+// dutyTrace is the span of a duty, see dutyTraces.
+type dutyTrace struct {
+	span trace.Span
+
+	mu  sync.Mutex
+	end time.Time // the end of the duty's latest request
+}
+
+// ended records the end of a request of the duty.
+func (dt *dutyTrace) ended(at time.Time) {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	if at.After(dt.end) {
+		dt.end = at
+	}
+}
+
+// finish ends the duty's span at the end of its latest request.
+func (dt *dutyTrace) finish() {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	if dt.end.IsZero() {
+		dt.span.End()
+		return
+	}
+	dt.span.End(trace.WithTimestamp(dt.end))
+}
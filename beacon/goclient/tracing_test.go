@@ -0,0 +1,225 @@
+package goclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type exemplarRecorder struct {
+	prometheus.Observer
+	exemplars []prometheus.Labels
+}
+
+func (r *exemplarRecorder) ObserveWithExemplar(_ float64, exemplar prometheus.Labels) {
+	r.exemplars = append(r.exemplars, exemplar)
+}
+
+func TestRequestSpan(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	gc := &goClient{log: zap.New(core)}
+	histogram := &exemplarRecorder{}
+
+	span := gc.startRequest(context.Background(), spectypes.BNRoleAttester, 1, "attestation_data", histogram)
+	require.Len(t, span.id, 16)
+	span.end(nil)
+
+	// The span context is carried by the request's context.
+	spanContext := trace.SpanContextFromContext(span.ctx)
+	require.True(t, spanContext.IsValid())
+	require.Equal(t, span.id, spanContext.SpanID().String())
+
+	// Both the issue and the completion are logged with the span ID.
+	entries := logs.TakeAll()
+	require.Len(t, entries, 2)
+	require.Equal(t, "beacon request issued", entries[0].Message)
+	require.Equal(t, "beacon request completed", entries[1].Message)
+	for _, entry := range entries {
+		require.Equal(t, span.id, entry.ContextMap()[spanIDLabel])
+		require.Equal(t, "attestation_data", entry.ContextMap()["request"])
+	}
+
+	// The duration is observed with the span ID as an exemplar.
+	require.Equal(t, []prometheus.Labels{{spanIDLabel: span.id}}, histogram.exemplars)
+
+	// Failed requests are logged, but not observed.
+	failed := gc.startRequest(context.Background(), spectypes.BNRoleAttester, 1, "attestation_data", histogram)
+	require.NotEqual(t, span.id, failed.id)
+	require.NotEqual(t, spanContext.TraceID(), trace.SpanContextFromContext(failed.ctx).TraceID())
+	failed.end(errors.New("test error"))
+	entries = logs.TakeAll()
+	require.Len(t, entries, 2)
+	require.Equal(t, "beacon request failed", entries[1].Message)
+	require.Len(t, histogram.exemplars, 1)
+
+	// Requests in the context of another span are in its trace.
+	parent := trace.ContextWithSpanContext(context.Background(), spanContext)
+	child := gc.startRequest(parent, spectypes.BNRoleAttester, 1, "attestation_data", histogram)
+	childContext := trace.SpanContextFromContext(child.ctx)
+	require.Equal(t, spanContext.TraceID(), childContext.TraceID())
+	require.NotEqual(t, spanContext.SpanID(), childContext.SpanID())
+	child.end(nil)
+
+	// Nothing is logged unless debug logs are enabled.
+	infoCore, infoLogs := observer.New(zapcore.InfoLevel)
+	gc.log = zap.New(infoCore)
+	gc.startRequest(context.Background(), spectypes.BNRoleAttester, 1, "attestation_data", histogram).end(nil)
+	require.Zero(t, infoLogs.Len())
+}
+
+type recordingTracer struct {
+	trace.Tracer
+	spans []*recordingSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	cfg := trace.NewSpanStartConfig(opts...)
+	parent, _ := trace.SpanFromContext(ctx).(*recordingSpan)
+	span := &recordingSpan{
+		Span:   trace.SpanFromContext(ctx),
+		parent: parent,
+		name:   name,
+		kind:   cfg.SpanKind(),
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{1},
+			SpanID:  trace.SpanID{byte(len(t.spans) + 1)},
+		}),
+	}
+	span.SetAttributes(cfg.Attributes()...)
+	t.spans = append(t.spans, span)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type recordingSpan struct {
+	trace.Span
+	parent      *recordingSpan
+	name        string
+	kind        trace.SpanKind
+	spanContext trace.SpanContext
+	attributes  map[attribute.Key]string
+	status      codes.Code
+	errs        []error
+	ended       bool
+}
+
+func (s *recordingSpan) SpanContext() trace.SpanContext { return s.spanContext }
+
+func (s *recordingSpan) IsRecording() bool { return true }
+
+func (s *recordingSpan) SetAttributes(kv ...attribute.KeyValue) {
+	if s.attributes == nil {
+		s.attributes = map[attribute.Key]string{}
+	}
+	for _, attr := range kv {
+		s.attributes[attr.Key] = attr.Value.AsString()
+	}
+}
+
+func (s *recordingSpan) SetStatus(code codes.Code, _ string) { s.status = code }
+
+func (s *recordingSpan) RecordError(err error, _ ...trace.EventOption) { s.errs = append(s.errs, err) }
+
+func (s *recordingSpan) End(...trace.SpanEndOption) { s.ended = true }
+
+func TestRequestOpenTelemetrySpan(t *testing.T) {
+	tracer := &recordingTracer{}
+	gc := &goClient{log: zap.NewNop(), ctx: context.Background(), tracer: tracer}
+	histogram := &exemplarRecorder{}
+
+	span := gc.startRequest(context.Background(), spectypes.BNRoleAttester, 1, "attestation_data", histogram)
+	span.end(nil)
+
+	require.Len(t, tracer.spans, 1)
+	otelSpan := tracer.spans[0]
+	require.True(t, otelSpan.ended)
+	require.Equal(t, "beacon.attestation_data", otelSpan.name)
+	require.Equal(t, trace.SpanKindClient, otelSpan.kind)
+	require.Equal(t, map[attribute.Key]string{
+		roleAttribute:    spectypes.BNRoleAttester.String(),
+		requestAttribute: "attestation_data",
+		outcomeAttribute: requestOutcomeOK,
+	}, otelSpan.attributes)
+	require.Equal(t, codes.Unset, otelSpan.status)
+
+	// The OpenTelemetry span ID is used in the logs and exemplars.
+	require.Equal(t, otelSpan.spanContext.SpanID().String(), span.id)
+	require.Equal(t, []prometheus.Labels{{spanIDLabel: span.id}}, histogram.exemplars)
+
+	testErr := errors.New("test error")
+	gc.startRequest(context.Background(), spectypes.BNRoleProposer, 1, "proposal", histogram).end(testErr)
+	require.Len(t, tracer.spans, 2)
+	failed := tracer.spans[1]
+	require.True(t, failed.ended)
+	require.Equal(t, requestOutcomeErr, failed.attributes[outcomeAttribute])
+	require.Equal(t, codes.Error, failed.status)
+	require.Equal(t, []error{testErr}, failed.errs)
+}
+
+func TestDutyTraces(t *testing.T) {
+	tracer := &recordingTracer{}
+	gc := &goClient{log: zap.NewNop(), ctx: context.Background(), tracer: tracer, dutyTraces: newDutyTraces(tracer)}
+	histogram := &exemplarRecorder{}
+
+	request := func(role spectypes.BeaconRole, slot phase0.Slot) trace.SpanContext {
+		span := gc.startRequest(context.Background(), role, slot, "attestation_data", histogram)
+		span.end(nil)
+		return trace.SpanContextFromContext(span.ctx)
+	}
+
+	// The requests of a duty are children of the duty's span.
+	first := request(spectypes.BNRoleAttester, 10)
+	second := request(spectypes.BNRoleAttester, 10)
+	require.NotEqual(t, first.SpanID(), second.SpanID())
+	require.Len(t, tracer.spans, 3)
+	dutySpan := tracer.spans[0]
+	require.Equal(t, "beacon.duty", dutySpan.name)
+	require.Equal(t, spectypes.BNRoleAttester.String(), dutySpan.attributes[roleAttribute])
+	require.Same(t, dutySpan, tracer.spans[1].parent)
+	require.Same(t, dutySpan, tracer.spans[2].parent)
+	require.False(t, dutySpan.ended)
+
+	// Other duties have their own spans.
+	request(spectypes.BNRoleProposer, 10)
+	require.Len(t, tracer.spans, 5)
+	require.Equal(t, "beacon.duty", tracer.spans[3].name)
+	require.Same(t, tracer.spans[3], tracer.spans[4].parent)
+
+	// The span of a duty ends once the duty is too old to have further requests.
+	request(spectypes.BNRoleAttester, 10+dutyRouteRetention)
+	require.False(t, dutySpan.ended)
+	request(spectypes.BNRoleAttester, 11+dutyRouteRetention)
+	require.True(t, dutySpan.ended)
+	require.True(t, tracer.spans[3].ended)
+
+	// Without a tracer, the requests of a duty are still in one trace.
+	gc = &goClient{log: zap.NewNop(), ctx: context.Background(), dutyTraces: newDutyTraces(nil)}
+	first = request(spectypes.BNRoleAttester, 10)
+	second = request(spectypes.BNRoleAttester, 10)
+	other := request(spectypes.BNRoleAttester, 11)
+	require.True(t, first.IsValid())
+	require.Equal(t, first.TraceID(), second.TraceID())
+	require.NotEqual(t, first.SpanID(), second.SpanID())
+	require.NotEqual(t, first.TraceID(), other.TraceID())
+
+	// With a no-op tracer, whose spans carry their parent's span context, each request still has an ID of its own.
+	noopTracer := trace.NewNoopTracerProvider().Tracer(tracerName)
+	gc = &goClient{log: zap.NewNop(), ctx: context.Background(), tracer: noopTracer, dutyTraces: newDutyTraces(noopTracer)}
+	first = request(spectypes.BNRoleAttester, 10)
+	second = request(spectypes.BNRoleAttester, 10)
+	dutyContext := gc.dutyTraces.traces[dutyRoute{role: spectypes.BNRoleAttester, slot: 10}].span.SpanContext()
+	require.True(t, dutyContext.IsValid())
+	require.Equal(t, dutyContext.TraceID(), first.TraceID())
+	require.NotEqual(t, dutyContext.SpanID(), first.SpanID())
+	require.NotEqual(t, first.SpanID(), second.SpanID())
+}
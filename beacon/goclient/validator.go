@@ -1,19 +1,203 @@
 package goclient
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"sync"
 
 	"github.com/attestantio/go-eth2-client/api"
 	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/operator/slotticker"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+var (
+	metricsValidatorCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_beacon_validator_cache_lookups",
+		Help: "Count of validator data lookups by whether they were served from the prefetched cache",
+	}, []string{"result"})
+	metricsValidatorFetchFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv_beacon_validator_fetch_failures",
+		Help: "Count of validators which failed to be fetched by partial fetches",
+	})
 )
 
-// GetValidatorData returns metadata (balance, index, status, more) for each pubkey from the node
+func init() {
+	logger := zap.L()
+	allMetrics := []prometheus.Collector{
+		metricsValidatorCacheLookups,
+		metricsValidatorFetchFailures,
+	}
+	for _, c := range allMetrics {
+		if err := prometheus.Register(c); err != nil {
+			logger.Debug("could not register prometheus collector")
+		}
+	}
+}
+
+// maxPartialValidatorRequests is the maximum number of requests of a partial fetch, which bounds
+// the requests spent on isolating failing validators, e.g. when the beacon node is down.
+const maxPartialValidatorRequests = 64
+
+var _ beaconprotocol.PartialValidatorsProvider = (*goClient)(nil)
+
+// GetValidatorData returns metadata (balance, index, status, more) for each pubkey from the node.
+// Validators prefetched in the current epoch are served from the cache.
 func (gc *goClient) GetValidatorData(validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, error) {
-	resp, err := gc.client.Validators(gc.ctx, &api.ValidatorsOpts{
-		State:   "head", // TODO maybe need to get the chainId (head) as var
-		PubKeys: validatorPubKeys,
-		Common:  api.CommonOpts{Timeout: gc.longTimeout},
+	if gc.validatorCache == nil || len(validatorPubKeys) == 0 {
+		return gc.fetchValidators(validatorPubKeys)
+	}
+
+	epoch := gc.network.EstimatedCurrentEpoch()
+	cached, missing := gc.validatorCache.get(epoch, validatorPubKeys)
+	if len(missing) == 0 {
+		metricsValidatorCacheLookups.WithLabelValues("hit").Inc()
+		return cached, nil
+	}
+	metricsValidatorCacheLookups.WithLabelValues("miss").Inc()
+
+	fetched, err := gc.fetchValidators(missing)
+	if err != nil {
+		return nil, err
+	}
+	gc.validatorCache.add(epoch, fetched)
+
+	for index, validator := range fetched {
+		cached[index] = validator
+	}
+	return cached, nil
+}
+
+// GetValidatorDataPartial returns metadata for each pubkey like GetValidatorData, but when a fetch fails,
+// it's split to isolate the failing validators, returning the validators which were fetched successfully
+// along with the public keys of the failed ones.
+func (gc *goClient) GetValidatorDataPartial(validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, []phase0.BLSPubKey, error) {
+	if len(validatorPubKeys) == 0 {
+		validators, err := gc.GetValidatorData(validatorPubKeys)
+		return validators, nil, err
+	}
+
+	epoch := gc.network.EstimatedCurrentEpoch()
+	validators := make(map[phase0.ValidatorIndex]*eth2apiv1.Validator, len(validatorPubKeys))
+	missing := validatorPubKeys
+	if gc.validatorCache != nil {
+		var cached map[phase0.ValidatorIndex]*eth2apiv1.Validator
+		cached, missing = gc.validatorCache.get(epoch, validatorPubKeys)
+		if len(missing) == 0 {
+			metricsValidatorCacheLookups.WithLabelValues("hit").Inc()
+			return cached, nil, nil
+		}
+		metricsValidatorCacheLookups.WithLabelValues("miss").Inc()
+		validators = cached
+	}
+
+	fetch := &partialValidatorsFetch{gc: gc, budget: maxPartialValidatorRequests}
+	fetched := fetch.run(missing)
+	if len(fetch.failed) == len(validatorPubKeys) {
+		return nil, nil, fmt.Errorf("failed to obtain any validators: %w", fetch.lastErr)
+	}
+	if gc.validatorCache != nil {
+		gc.validatorCache.add(epoch, fetched)
+	}
+	for index, validator := range fetched {
+		validators[index] = validator
+	}
+
+	if len(fetch.failed) > 0 {
+		metricsValidatorFetchFailures.Add(float64(len(fetch.failed)))
+		gc.log.Warn("failed to fetch some validators",
+			zap.Int("failed", len(fetch.failed)),
+			zap.Int("fetched", len(fetched)),
+			zap.Int("requests", maxPartialValidatorRequests-fetch.budget),
+			zap.Error(fetch.lastErr),
+		)
+	}
+	return validators, fetch.failed, nil
+}
+
+// partialValidatorsFetch fetches validators by splitting fetches which the beacon node rejected in halves,
+// until the rejected validators are isolated or the request budget runs out.
+// Once a fetch fails otherwise, e.g. the beacon node is unreachable, the remaining fetches are aborted.
+type partialValidatorsFetch struct {
+	gc      *goClient
+	budget  int
+	aborted bool
+	failed  []phase0.BLSPubKey
+	lastErr error
+}
+
+func (f *partialValidatorsFetch) run(pubKeys []phase0.BLSPubKey) map[phase0.ValidatorIndex]*eth2apiv1.Validator {
+	if f.budget == 0 || f.aborted {
+		f.failed = append(f.failed, pubKeys...)
+		return nil
+	}
+	f.budget--
+
+	validators, err := f.gc.fetchValidators(pubKeys)
+	if err == nil {
+		return validators
+	}
+	f.lastErr = err
+	if !validatorsRejected(err) {
+		f.aborted = true
+	}
+	if len(pubKeys) == 1 || f.aborted {
+		f.failed = append(f.failed, pubKeys...)
+		return nil
+	}
+
+	half := len(pubKeys) / 2
+	validators = f.run(pubKeys[:half])
+	if validators == nil {
+		validators = make(map[phase0.ValidatorIndex]*eth2apiv1.Validator)
+	}
+	for index, validator := range f.run(pubKeys[half:]) {
+		validators[index] = validator
+	}
+	return validators
+}
+
+// validatorsRejected returns whether the beacon node rejected a fetch of validators with a client error,
+// which may be caused by some of the fetched validators, e.g. an invalid public key.
+// Timeouts and rate limiting aren't caused by the validators.
+func validatorsRejected(err error) bool {
+	var apiErr *api.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return false
+	}
+	return apiErr.StatusCode >= 400 && apiErr.StatusCode < 500
+}
+
+func (gc *goClient) fetchValidators(validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, error) {
+	var resp *api.Response[map[phase0.ValidatorIndex]*eth2apiv1.Validator]
+	err := gc.route(gc.readNode, readRequestValidators, func(client Client) (err error) {
+		// Fetching validators by public keys is a single request, bounded by its own context (for either node).
+		// Fetching all of them requires several requests (such as the beacon state), each bounded by the timeout option.
+		ctx := gc.ctx
+		if len(validatorPubKeys) > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(gc.ctx, gc.timeouts.validators)
+			defer cancel()
+		}
+
+		resp, err = client.Validators(ctx, &api.ValidatorsOpts{
+			State:   "head", // TODO maybe need to get the chainId (head) as var
+			PubKeys: validatorPubKeys,
+			Common:  api.CommonOpts{Timeout: gc.timeouts.validators},
+		})
+		return err
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to obtain validators: %w", err)
@@ -21,6 +205,123 @@ func (gc *goClient) GetValidatorData(validatorPubKeys []phase0.BLSPubKey) (map[p
 	if resp == nil {
 		return nil, fmt.Errorf("validators response is nil")
 	}
+	if gc.validatorIndices != nil {
+		gc.validatorIndices.add(resp.Data)
+	}
 
 	return resp.Data, nil
 }
+
+// validatorPrefetcher loads the validators given by validatorsProvider into the cache at every epoch boundary,
+// and again within the epoch whenever the operator's validators change, see InvalidateValidators.
+func (gc *goClient) validatorPrefetcher(slotTickerProvider slotticker.Provider, validatorsProvider func() []phase0.BLSPubKey) {
+	ticker := slotTickerProvider()
+	var prefetched bool
+	var prefetchedEpoch phase0.Epoch
+	for {
+		var epoch phase0.Epoch
+		select {
+		case <-gc.ctx.Done():
+			return
+		case <-gc.validatorsInvalidated:
+			epoch = gc.network.EstimatedCurrentEpoch()
+		case <-ticker.Next():
+			epoch = gc.network.EstimatedEpochAtSlot(ticker.Slot())
+			if prefetched && epoch == prefetchedEpoch {
+				continue
+			}
+		}
+
+		// A failed prefetch is retried on the next slot.
+		prefetched = gc.prefetchValidators(epoch, validatorsProvider())
+		prefetchedEpoch = epoch
+	}
+}
+
+// prefetchValidators replaces the cache with the given validators, returning whether they were fetched.
+func (gc *goClient) prefetchValidators(epoch phase0.Epoch, pubKeys []phase0.BLSPubKey) bool {
+	if len(pubKeys) == 0 {
+		// Fetching without public keys would fetch all validators.
+		gc.validatorCache.reset(epoch, nil)
+		return true
+	}
+
+	validators, err := gc.fetchValidators(pubKeys)
+	if err != nil {
+		gc.log.Warn("failed to prefetch validators", zap.Error(err), fields.Count(len(pubKeys)))
+		return false
+	}
+	gc.validatorCache.reset(epoch, validators)
+	gc.log.Debug("prefetched validators", fields.Count(len(validators)), zap.Uint64("epoch", uint64(epoch)))
+	return true
+}
+
+var _ beaconprotocol.ValidatorsInvalidator = (*goClient)(nil)
+
+// InvalidateValidators prefetches the operator's validators again, as they changed. It doesn't block.
+func (gc *goClient) InvalidateValidators() {
+	if gc.validatorCache == nil {
+		return
+	}
+	select {
+	case gc.validatorsInvalidated <- struct{}{}:
+	default:
+		// A prefetch is already due.
+	}
+}
+
+// validatorCache holds the validators fetched in the current epoch.
+type validatorCache struct {
+	mu         sync.Mutex
+	epoch      phase0.Epoch
+	validators map[phase0.BLSPubKey]*eth2apiv1.Validator
+}
+
+func newValidatorCache() *validatorCache {
+	return &validatorCache{
+		validators: map[phase0.BLSPubKey]*eth2apiv1.Validator{},
+	}
+}
+
+// get returns the cached validators of the given epoch, and the public keys of the ones that aren't cached.
+func (c *validatorCache) get(epoch phase0.Epoch, pubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, []phase0.BLSPubKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached := make(map[phase0.ValidatorIndex]*eth2apiv1.Validator, len(pubKeys))
+	var missing []phase0.BLSPubKey
+	for _, pubKey := range pubKeys {
+		validator, ok := c.validators[pubKey]
+		if !ok || epoch != c.epoch {
+			missing = append(missing, pubKey)
+			continue
+		}
+		cached[validator.Index] = validator
+	}
+	return cached, missing
+}
+
+// add caches the given validators, if they were fetched in the cached epoch.
+func (c *validatorCache) add(epoch phase0.Epoch, validators map[phase0.ValidatorIndex]*eth2apiv1.Validator) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if epoch != c.epoch {
+		return
+	}
+	for _, validator := range validators {
+		c.validators[validator.Validator.PublicKey] = validator
+	}
+}
+
+// reset replaces the cache with the given prefetched validators.
+func (c *validatorCache) reset(epoch phase0.Epoch, validators map[phase0.ValidatorIndex]*eth2apiv1.Validator) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.epoch = epoch
+	c.validators = make(map[phase0.BLSPubKey]*eth2apiv1.Validator, len(validators))
+	for _, validator := range validators {
+		c.validators[validator.Validator.PublicKey] = validator
+	}
+}
@@ -0,0 +1,159 @@
+package goclient
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/api"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var (
+	metricsValidatorIndexCacheSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv_beacon_validator_index_cache_size",
+		Help: "Number of validators whose index is cached by public key",
+	})
+	metricsValidatorIndexCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_beacon_validator_index_cache_lookups",
+		Help: "Count of validator index lookups by whether they were served from the cache",
+	}, []string{"result"})
+)
+
+func init() {
+	logger := zap.L()
+	for _, c := range []prometheus.Collector{metricsValidatorIndexCacheSize, metricsValidatorIndexCacheLookups} {
+		if err := prometheus.Register(c); err != nil {
+			logger.Debug("could not register prometheus collector")
+		}
+	}
+}
+
+// ValidatorIndexProvider translates between the public keys and the indices of validators.
+type ValidatorIndexProvider interface {
+	// ValidatorIndices returns the indices of the given validators, leaving out the ones unknown to the beacon node.
+	ValidatorIndices(pubKeys []phase0.BLSPubKey) (map[phase0.BLSPubKey]phase0.ValidatorIndex, error)
+	// ValidatorPubKey returns the public key of the validator with the given index, if it's known to the beacon node.
+	ValidatorPubKey(index phase0.ValidatorIndex) (phase0.BLSPubKey, bool, error)
+}
+
+var _ ValidatorIndexProvider = (*goClient)(nil)
+
+// ValidatorIndices returns the indices of the given validators, fetching the ones which aren't cached.
+func (gc *goClient) ValidatorIndices(pubKeys []phase0.BLSPubKey) (map[phase0.BLSPubKey]phase0.ValidatorIndex, error) {
+	indices, missing := gc.validatorIndices.indices(pubKeys)
+	metricsValidatorIndexCacheLookups.WithLabelValues("hit").Add(float64(len(pubKeys) - len(missing)))
+	if len(missing) == 0 {
+		return indices, nil
+	}
+	metricsValidatorIndexCacheLookups.WithLabelValues("miss").Add(float64(len(missing)))
+
+	// fetchValidators caches the fetched indices.
+	fetched, err := gc.fetchValidators(missing)
+	if err != nil {
+		return nil, err
+	}
+	for index, validator := range fetched {
+		if validator != nil && validator.Validator != nil {
+			indices[validator.Validator.PublicKey] = index
+		}
+	}
+	return indices, nil
+}
+
+// ValidatorPubKey returns the public key of the validator with the given index, fetching it if it isn't cached.
+func (gc *goClient) ValidatorPubKey(index phase0.ValidatorIndex) (phase0.BLSPubKey, bool, error) {
+	if pubKey, ok := gc.validatorIndices.pubKey(index); ok {
+		metricsValidatorIndexCacheLookups.WithLabelValues("hit").Inc()
+		return pubKey, true, nil
+	}
+	metricsValidatorIndexCacheLookups.WithLabelValues("miss").Inc()
+
+	var resp *api.Response[map[phase0.ValidatorIndex]*eth2apiv1.Validator]
+	err := gc.route(gc.readNode, readRequestValidators, func(client Client) (err error) {
+		resp, err = client.Validators(gc.ctx, &api.ValidatorsOpts{
+			State:   "head",
+			Indices: []phase0.ValidatorIndex{index},
+			Common:  api.CommonOpts{Timeout: gc.timeouts.validators},
+		})
+		return err
+	})
+	if err != nil {
+		return phase0.BLSPubKey{}, false, fmt.Errorf("failed to obtain validator: %w", err)
+	}
+	if resp == nil {
+		return phase0.BLSPubKey{}, false, fmt.Errorf("validators response is nil")
+	}
+	gc.validatorIndices.add(resp.Data)
+
+	validator, ok := resp.Data[index]
+	if !ok || validator.Validator == nil {
+		return phase0.BLSPubKey{}, false, nil
+	}
+	return validator.Validator.PublicKey, true, nil
+}
+
+// validatorIndexCache maps the public keys of validators to their indices and back. Since a validator's index
+// never changes, entries don't expire, but exited validators are left out, as they're no longer of interest.
+type validatorIndexCache struct {
+	mu       sync.RWMutex
+	byPubKey map[phase0.BLSPubKey]phase0.ValidatorIndex
+	byIndex  map[phase0.ValidatorIndex]phase0.BLSPubKey
+}
+
+func newValidatorIndexCache() *validatorIndexCache {
+	return &validatorIndexCache{
+		byPubKey: map[phase0.BLSPubKey]phase0.ValidatorIndex{},
+		byIndex:  map[phase0.ValidatorIndex]phase0.BLSPubKey{},
+	}
+}
+
+// indices returns the cached indices of the given validators, and the public keys of the ones that aren't cached.
+func (c *validatorIndexCache) indices(pubKeys []phase0.BLSPubKey) (map[phase0.BLSPubKey]phase0.ValidatorIndex, []phase0.BLSPubKey) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	indices := make(map[phase0.BLSPubKey]phase0.ValidatorIndex, len(pubKeys))
+	var missing []phase0.BLSPubKey
+	for _, pubKey := range pubKeys {
+		index, ok := c.byPubKey[pubKey]
+		if !ok {
+			missing = append(missing, pubKey)
+			continue
+		}
+		indices[pubKey] = index
+	}
+	return indices, missing
+}
+
+// pubKey returns the cached public key of the validator with the given index.
+func (c *validatorIndexCache) pubKey(index phase0.ValidatorIndex) (phase0.BLSPubKey, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	pubKey, ok := c.byIndex[index]
+	return pubKey, ok
+}
+
+// add caches the indices of the given validators, evicting the ones that exited.
+func (c *validatorIndexCache) add(validators map[phase0.ValidatorIndex]*eth2apiv1.Validator) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for index, validator := range validators {
+		if validator == nil || validator.Validator == nil {
+			continue
+		}
+		if validator.Status.HasExited() {
+			delete(c.byPubKey, validator.Validator.PublicKey)
+			delete(c.byIndex, index)
+			continue
+		}
+		c.byPubKey[validator.Validator.PublicKey] = index
+		c.byIndex[index] = validator.Validator.PublicKey
+	}
+	metricsValidatorIndexCacheSize.Set(float64(len(c.byPubKey)))
+}
@@ -0,0 +1,81 @@
+package goclient
+
+import (
+	"context"
+	"testing"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestValidatorIndexCache(t *testing.T) {
+	pubKey1 := phase0.BLSPubKey{1}
+	pubKey2 := phase0.BLSPubKey{2}
+	cache := newValidatorIndexCache()
+
+	cache.add(map[phase0.ValidatorIndex]*eth2apiv1.Validator{
+		1: testValidator(1, pubKey1),
+		2: testValidator(2, pubKey2),
+	})
+	require.Equal(t, 2.0, testutil.ToFloat64(metricsValidatorIndexCacheSize))
+
+	indices, missing := cache.indices([]phase0.BLSPubKey{pubKey1, {3}})
+	require.Equal(t, map[phase0.BLSPubKey]phase0.ValidatorIndex{pubKey1: 1}, indices)
+	require.Equal(t, []phase0.BLSPubKey{{3}}, missing)
+
+	pubKey, ok := cache.pubKey(2)
+	require.True(t, ok)
+	require.Equal(t, pubKey2, pubKey)
+
+	// Exited validators are evicted.
+	exited := testValidator(2, pubKey2)
+	exited.Status = eth2apiv1.ValidatorStateExitedUnslashed
+	cache.add(map[phase0.ValidatorIndex]*eth2apiv1.Validator{2: exited})
+	_, ok = cache.pubKey(2)
+	require.False(t, ok)
+	_, missing = cache.indices([]phase0.BLSPubKey{pubKey2})
+	require.Equal(t, []phase0.BLSPubKey{pubKey2}, missing)
+	require.Equal(t, 1.0, testutil.ToFloat64(metricsValidatorIndexCacheSize))
+}
+
+func TestValidatorIndices(t *testing.T) {
+	recorder := &validatorsRecorder{}
+	gc := &goClient{
+		log:              zap.NewNop(),
+		ctx:              context.Background(),
+		client:           recorder,
+		validatorIndices: newValidatorIndexCache(),
+	}
+	pubKey1 := phase0.BLSPubKey{1}
+	pubKey2 := phase0.BLSPubKey{2}
+	lookups := func(result string) float64 {
+		return testutil.ToFloat64(metricsValidatorIndexCacheLookups.WithLabelValues(result))
+	}
+	hits, misses := lookups("hit"), lookups("miss")
+
+	// Validators fetched for any purpose are cached.
+	_, err := gc.fetchValidators([]phase0.BLSPubKey{pubKey1})
+	require.NoError(t, err)
+	recorder.requests = nil
+
+	indices, err := gc.ValidatorIndices([]phase0.BLSPubKey{pubKey1, pubKey2})
+	require.NoError(t, err)
+	require.Equal(t, map[phase0.BLSPubKey]phase0.ValidatorIndex{pubKey1: 1, pubKey2: 2}, indices)
+	require.Equal(t, [][]phase0.BLSPubKey{{pubKey2}}, recorder.requests)
+	require.Equal(t, hits+1, lookups("hit"))
+	require.Equal(t, misses+1, lookups("miss"))
+
+	// Once resolved, indices and public keys are served from the cache.
+	indices, err = gc.ValidatorIndices([]phase0.BLSPubKey{pubKey2})
+	require.NoError(t, err)
+	require.Equal(t, map[phase0.BLSPubKey]phase0.ValidatorIndex{pubKey2: 2}, indices)
+	pubKey, ok, err := gc.ValidatorPubKey(2)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, pubKey2, pubKey)
+	require.Len(t, recorder.requests, 1)
+	require.Equal(t, hits+3, lookups("hit"))
+}
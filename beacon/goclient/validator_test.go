@@ -0,0 +1,174 @@
+package goclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+type validatorsRecorder struct {
+	Client
+	requests [][]phase0.BLSPubKey
+	failing  map[phase0.BLSPubKey]bool // reject requests of these validators
+	err      error                     // fail all requests, if set
+}
+
+func (r *validatorsRecorder) Validators(ctx context.Context, opts *api.ValidatorsOpts) (*api.Response[map[phase0.ValidatorIndex]*eth2apiv1.Validator], error) {
+	r.requests = append(r.requests, opts.PubKeys)
+	if r.err != nil {
+		return nil, r.err
+	}
+	data := make(map[phase0.ValidatorIndex]*eth2apiv1.Validator, len(opts.PubKeys))
+	for _, pubKey := range opts.PubKeys {
+		if r.failing[pubKey] {
+			return nil, &api.Error{StatusCode: http.StatusBadRequest}
+		}
+		index := phase0.ValidatorIndex(pubKey[0])
+		data[index] = testValidator(index, pubKey)
+	}
+	return &api.Response[map[phase0.ValidatorIndex]*eth2apiv1.Validator]{Data: data}, nil
+}
+
+func testValidator(index phase0.ValidatorIndex, pubKey phase0.BLSPubKey) *eth2apiv1.Validator {
+	return &eth2apiv1.Validator{
+		Index:     index,
+		Validator: &phase0.Validator{PublicKey: pubKey},
+	}
+}
+
+func TestValidatorCache(t *testing.T) {
+	pubKey1 := phase0.BLSPubKey{1}
+	pubKey2 := phase0.BLSPubKey{2}
+	cache := newValidatorCache()
+
+	cache.reset(1, map[phase0.ValidatorIndex]*eth2apiv1.Validator{
+		1: testValidator(1, pubKey1),
+	})
+
+	cached, missing := cache.get(1, []phase0.BLSPubKey{pubKey1, pubKey2})
+	require.Len(t, cached, 1)
+	require.Equal(t, []phase0.BLSPubKey{pubKey2}, missing)
+
+	// Validators of a past epoch aren't served.
+	cached, missing = cache.get(2, []phase0.BLSPubKey{pubKey1})
+	require.Empty(t, cached)
+	require.Equal(t, []phase0.BLSPubKey{pubKey1}, missing)
+
+	cache.add(1, map[phase0.ValidatorIndex]*eth2apiv1.Validator{2: testValidator(2, pubKey2)})
+	cached, missing = cache.get(1, []phase0.BLSPubKey{pubKey1, pubKey2})
+	require.Len(t, cached, 2)
+	require.Empty(t, missing)
+}
+
+func TestGetValidatorDataFromCache(t *testing.T) {
+	recorder := &validatorsRecorder{}
+	gc := &goClient{
+		log:            zap.NewNop(),
+		ctx:            context.Background(),
+		network:        beacon.NewNetwork(types.MainNetwork),
+		client:         recorder,
+		validatorCache: newValidatorCache(),
+	}
+	epoch := gc.network.EstimatedCurrentEpoch()
+	pubKey1 := phase0.BLSPubKey{1}
+	pubKey2 := phase0.BLSPubKey{2}
+
+	validators, err := gc.fetchValidators([]phase0.BLSPubKey{pubKey1})
+	require.NoError(t, err)
+	gc.validatorCache.reset(epoch, validators)
+	recorder.requests = nil
+
+	// Prefetched validators are served from the cache.
+	validators, err = gc.GetValidatorData([]phase0.BLSPubKey{pubKey1})
+	require.NoError(t, err)
+	require.Len(t, validators, 1)
+	require.Empty(t, recorder.requests)
+
+	// Only validators missing from the cache are fetched, and then cached.
+	validators, err = gc.GetValidatorData([]phase0.BLSPubKey{pubKey1, pubKey2})
+	require.NoError(t, err)
+	require.Len(t, validators, 2)
+	require.Equal(t, [][]phase0.BLSPubKey{{pubKey2}}, recorder.requests)
+
+	_, err = gc.GetValidatorData([]phase0.BLSPubKey{pubKey2})
+	require.NoError(t, err)
+	require.Len(t, recorder.requests, 1)
+
+	// A prefetch replaces the cache, dropping validators which were removed.
+	require.True(t, gc.prefetchValidators(epoch, []phase0.BLSPubKey{pubKey2}))
+	cached, missing := gc.validatorCache.get(epoch, []phase0.BLSPubKey{pubKey1, pubKey2})
+	require.Len(t, cached, 1)
+	require.Equal(t, []phase0.BLSPubKey{pubKey1}, missing)
+}
+
+func TestInvalidateValidators(t *testing.T) {
+	// Without prefetching, invalidation does nothing.
+	gc := &goClient{}
+	gc.InvalidateValidators()
+
+	gc = &goClient{
+		validatorCache:        newValidatorCache(),
+		validatorsInvalidated: make(chan struct{}, 1),
+	}
+	gc.InvalidateValidators()
+	gc.InvalidateValidators() // doesn't block while a prefetch is due
+	require.Len(t, gc.validatorsInvalidated, 1)
+}
+
+func TestGetValidatorDataPartial(t *testing.T) {
+	recorder := &validatorsRecorder{failing: map[phase0.BLSPubKey]bool{{3}: true}}
+	gc := &goClient{
+		log:     zap.NewNop(),
+		ctx:     context.Background(),
+		network: beacon.NewNetwork(types.MainNetwork),
+		client:  recorder,
+	}
+	pubKeys := []phase0.BLSPubKey{{1}, {2}, {3}, {4}, {5}}
+
+	// The failing validator is isolated, and the rest are returned.
+	validators, failed, err := gc.GetValidatorDataPartial(pubKeys)
+	require.NoError(t, err)
+	require.Len(t, validators, 4)
+	require.NotContains(t, validators, phase0.ValidatorIndex(3))
+	require.Equal(t, []phase0.BLSPubKey{{3}}, failed)
+
+	// The whole fetch fails if no validator could be fetched.
+	_, _, err = gc.GetValidatorDataPartial([]phase0.BLSPubKey{{3}})
+	require.Error(t, err)
+
+	// The requests spent on isolating failures are bounded.
+	recorder.requests = nil
+	many := make([]phase0.BLSPubKey, 1000)
+	for i := range many {
+		many[i] = phase0.BLSPubKey{byte(i), byte(i >> 8)}
+		recorder.failing[many[i]] = true
+	}
+	fetch := &partialValidatorsFetch{gc: gc, budget: maxPartialValidatorRequests}
+	require.Empty(t, fetch.run(many))
+	require.Len(t, recorder.requests, maxPartialValidatorRequests)
+	require.ElementsMatch(t, many, fetch.failed)
+
+	// Failures which aren't caused by the validators abort the fetch rather than split it.
+	for _, err := range []error{
+		errors.New("connection refused"),
+		context.DeadlineExceeded,
+		&api.Error{StatusCode: http.StatusInternalServerError},
+		&api.Error{StatusCode: http.StatusTooManyRequests},
+	} {
+		recorder.requests = nil
+		recorder.err = err
+		_, _, err = gc.GetValidatorDataPartial(pubKeys)
+		require.Error(t, err)
+		require.Len(t, recorder.requests, 1)
+	}
+}
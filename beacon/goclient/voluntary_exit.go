@@ -2,8 +2,20 @@ package goclient
 
 import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
 )
 
 func (gc *goClient) SubmitVoluntaryExit(voluntaryExit *phase0.SignedVoluntaryExit) error {
-	return gc.client.SubmitVoluntaryExit(gc.ctx, voluntaryExit)
+	if err := gc.checkEndpoint(endpointExit); err != nil {
+		return err
+	}
+
+	if err := gc.submit(gc.ctx, submissionExit, func(client Client) error {
+		return client.SubmitVoluntaryExit(gc.ctx, voluntaryExit)
+	}); err != nil {
+		return err
+	}
+
+	dutySubmitted(spectypes.BNRoleVoluntaryExit)
+	return nil
 }
@@ -0,0 +1,152 @@
+package goclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+var (
+	metricsWarmingUp = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv_beacon_warming_up",
+		Help: "Whether the beacon node is warming up after reconnecting (1) or not (0)",
+	})
+	metricsWarmUpStaleData = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_beacon_warm_up_stale_data",
+		Help: "Count of beacon node responses flagged as potentially stale during warm-up, by request",
+	}, []string{"request"})
+	metricsWarmUpDelayed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_beacon_submissions_delayed_warm_up",
+		Help: "Count of submissions delayed because the beacon node is warming up, by endpoint class",
+	}, []string{"class"})
+)
+
+func init() {
+	logger := zap.L()
+	allMetrics := []prometheus.Collector{
+		metricsWarmingUp,
+		metricsWarmUpStaleData,
+		metricsWarmUpDelayed,
+	}
+	for _, c := range allMetrics {
+		if err := prometheus.Register(c); err != nil {
+			logger.Debug("could not register prometheus collector")
+		}
+	}
+}
+
+// ErrWarmingUp is returned for submissions delayed while the beacon node is warming up.
+var ErrWarmingUp = fmt.Errorf("beacon node is warming up")
+
+// warmUpReconnectSlots is the number of slots without head events after which the next head event is taken
+// as a reconnect of the events stream (e.g. after the beacon node restarted), rather than as missed blocks.
+const warmUpReconnectSlots = 3
+
+// warmUpGuard tracks whether the beacon node is warming up: once the events stream reconnects
+// after being silent (e.g. after a restart), the node may briefly serve stale head data,
+// so its data is treated with caution for the following slots.
+type warmUpGuard struct {
+	slots   phase0.Slot
+	silence time.Duration
+
+	mu        sync.Mutex
+	lastEvent time.Time
+	active    bool
+	until     phase0.Slot
+}
+
+func newWarmUpGuard(slots phase0.Slot, silence time.Duration) *warmUpGuard {
+	return &warmUpGuard{slots: slots, silence: silence}
+}
+
+// observe records a head event of the given slot received at the given time.
+// It returns whether the warm-up started or ended.
+func (g *warmUpGuard) observe(slot phase0.Slot, receivedAt time.Time) (started, ended bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	reconnected := !g.lastEvent.IsZero() && receivedAt.Sub(g.lastEvent) >= g.silence
+	g.lastEvent = receivedAt
+
+	switch {
+	case reconnected:
+		started = !g.active
+		g.active = true
+		g.until = slot + g.slots
+	case g.active && slot >= g.until:
+		g.active = false
+		ended = true
+	}
+	return started, ended
+}
+
+func (g *warmUpGuard) warmingUp() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.active
+}
+
+var _ beaconprotocol.WarmUpReporter = (*goClient)(nil)
+
+// WarmingUp returns whether the beacon node's events stream recently reconnected, in which case
+// the data it serves may be stale.
+func (gc *goClient) WarmingUp() bool {
+	return gc.warmUp != nil && gc.warmUp.warmingUp()
+}
+
+// flagWarmUp flags the response to the given request as potentially stale if the beacon node is warming up.
+func (gc *goClient) flagWarmUp(request string) {
+	if !gc.WarmingUp() {
+		return
+	}
+	metricsWarmUpStaleData.WithLabelValues(request).Inc()
+	gc.log.Warn("beacon node is warming up, response may be stale", zap.String("request", request))
+}
+
+// checkWarmUp fails validator registrations while the beacon node is warming up.
+// Registrations are the only submissions delayed, since the ones which fail are submitted again
+// in the following slots, unlike subscriptions, proposal preparations and the submissions of duties.
+func (gc *goClient) checkWarmUp() error {
+	if !gc.WarmingUp() {
+		return nil
+	}
+	metricsWarmUpDelayed.WithLabelValues(string(submissionRegistration)).Inc()
+	return fmt.Errorf("%s submission delayed: %w", submissionRegistration, ErrWarmingUp)
+}
+
+// subscribeToWarmUpEvents starts the warm-up whenever head events resume after the events stream was silent.
+func (gc *goClient) subscribeToWarmUpEvents(ctx context.Context) error {
+	return gc.Events(ctx, []string{headEventTopic}, func(event *eth2apiv1.Event) {
+		data, ok := event.Data.(*eth2apiv1.HeadEvent)
+		if !ok || data == nil {
+			return
+		}
+		gc.observeWarmUp(data.Slot, time.Now())
+	})
+}
+
+func (gc *goClient) observeWarmUp(slot phase0.Slot, receivedAt time.Time) {
+	started, ended := gc.warmUp.observe(slot, receivedAt)
+	if started {
+		metricsWarmingUp.Set(1)
+		gc.log.Warn("beacon node events stream reconnected, warming up",
+			fields.Slot(slot),
+			zap.Uint64("warm_up_slots", uint64(gc.warmUp.slots)),
+		)
+	}
+	if ended {
+		metricsWarmingUp.Set(0)
+		gc.log.Info("beacon node warmed up", fields.Slot(slot))
+	}
+}
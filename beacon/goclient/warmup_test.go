@@ -0,0 +1,100 @@
+package goclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	beacontesting "github.com/bloxapp/ssv/beacon/goclient/testing"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+func TestWarmUpGuard(t *testing.T) {
+	const silence = 3 * time.Second
+	guard := newWarmUpGuard(2, silence)
+	start := time.Now()
+
+	// Warm-up doesn't start on the first event, nor while events keep arriving.
+	started, ended := guard.observe(10, start)
+	require.False(t, started || ended)
+	started, ended = guard.observe(11, start.Add(silence-time.Millisecond))
+	require.False(t, started || ended)
+	require.False(t, guard.warmingUp())
+
+	// Warm-up starts once events resume after the stream was silent.
+	reconnectedAt := start.Add(2 * silence)
+	started, _ = guard.observe(12, reconnectedAt)
+	require.True(t, started)
+	require.True(t, guard.warmingUp())
+
+	_, ended = guard.observe(13, reconnectedAt.Add(time.Second))
+	require.False(t, ended)
+	require.True(t, guard.warmingUp())
+	_, ended = guard.observe(14, reconnectedAt.Add(2*time.Second))
+	require.True(t, ended)
+	require.False(t, guard.warmingUp())
+
+	// Another reconnect starts another warm-up.
+	started, _ = guard.observe(20, reconnectedAt.Add(2*time.Second+silence))
+	require.True(t, started)
+	require.True(t, guard.warmingUp())
+}
+
+func TestWarmUpEvents(t *testing.T) {
+	network := beacon.NewNetwork(types.MainNetwork)
+	events := beacontesting.NewEventStream()
+	gc := &goClient{
+		log:           zap.NewNop(),
+		ctx:           context.Background(),
+		network:       network,
+		client:        eventsClient{events: events},
+		subscriptions: map[string]int{},
+		warmUp:        newWarmUpGuard(2, 0),
+	}
+	var reporter beacon.WarmUpReporter = gc
+	require.False(t, reporter.WarmingUp())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, gc.subscribeToWarmUpEvents(ctx))
+
+	// With no silence required, any head event after the first is a reconnect.
+	events.PushHead(&eth2apiv1.HeadEvent{Slot: 10})
+	require.False(t, reporter.WarmingUp())
+	events.PushHead(&eth2apiv1.HeadEvent{Slot: 11})
+	require.Eventually(t, reporter.WarmingUp, time.Second, 10*time.Millisecond)
+}
+
+func TestCheckWarmUp(t *testing.T) {
+	network := beacon.NewNetwork(types.MainNetwork)
+	gc := &goClient{
+		log:     zap.NewNop(),
+		ctx:     context.Background(),
+		network: network,
+	}
+	require.False(t, gc.WarmingUp())
+	require.NoError(t, gc.checkWarmUp())
+
+	gc.warmUp = newWarmUpGuard(2, time.Minute)
+	now := time.Now()
+	slot := network.EstimatedCurrentSlot()
+	gc.observeWarmUp(slot, now)
+	gc.observeWarmUp(slot+1, now.Add(time.Minute))
+	require.True(t, gc.WarmingUp())
+	require.ErrorIs(t, gc.checkWarmUp(), ErrWarmingUp)
+
+	// Validator registrations are delayed, other submissions aren't.
+	require.ErrorIs(t, gc.submitBatchedRegistrations(slot+1, nil), ErrWarmingUp)
+	require.NoError(t, gc.waitForSubmission(gc.ctx, submissionRegistration))
+	require.NoError(t, gc.waitForSubmission(gc.ctx, submissionSubscription))
+	require.NoError(t, gc.waitForSubmission(gc.ctx, submissionAttestation))
+
+	gc.observeWarmUp(slot+3, now.Add(time.Minute+time.Second))
+	require.False(t, gc.WarmingUp())
+	require.NoError(t, gc.checkWarmUp())
+}
@@ -9,6 +9,7 @@ import (
 	"math/big"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,11 +17,18 @@ import (
 
 	"github.com/bloxapp/ssv/network"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/api/handlers"
@@ -43,12 +51,14 @@ import (
 	"github.com/bloxapp/ssv/migrations"
 	"github.com/bloxapp/ssv/monitoring/metrics"
 	"github.com/bloxapp/ssv/monitoring/metricsreporter"
+	p2pcommons "github.com/bloxapp/ssv/network/commons"
 	p2pv1 "github.com/bloxapp/ssv/network/p2p"
 	"github.com/bloxapp/ssv/networkconfig"
 	"github.com/bloxapp/ssv/nodeprobe"
 	"github.com/bloxapp/ssv/operator"
 	operatordatastore "github.com/bloxapp/ssv/operator/datastore"
 	"github.com/bloxapp/ssv/operator/duties/dutystore"
+	"github.com/bloxapp/ssv/operator/fee_recipient"
 	"github.com/bloxapp/ssv/operator/keys"
 	"github.com/bloxapp/ssv/operator/slotticker"
 	operatorstorage "github.com/bloxapp/ssv/operator/storage"
@@ -84,8 +94,27 @@ type config struct {
 	NetworkPrivateKey          string                           `yaml:"NetworkPrivateKey" env:"NETWORK_PRIVATE_KEY" env-description:"private key for network identity"`
 	WsAPIPort                  int                              `yaml:"WebSocketAPIPort" env:"WS_API_PORT" env-description:"Port to listen on for the websocket API."`
 	WithPing                   bool                             `yaml:"WithPing" env:"WITH_PING" env-description:"Whether to send websocket ping messages'"`
+	WsReplaySize               int                              `yaml:"WebSocketReplaySize" env:"WS_REPLAY_SIZE" env-default:"32" env-description:"Number of recent decided messages to replay to newly connected stream clients (at most 256)"`
+	WsMaxStreamSubscribers     int                              `yaml:"WebSocketMaxStreamSubscribers" env:"WS_MAX_STREAM_SUBSCRIBERS" env-description:"Maximum number of concurrent stream clients, beyond which connections are rejected (0 for unlimited)"`
+	WsStreamHeartbeat          time.Duration                    `yaml:"WebSocketStreamHeartbeat" env:"WS_STREAM_HEARTBEAT" env-description:"Interval without stream messages after which a heartbeat message is sent to stream clients, so that proxies keep idle connections open (0 disables heartbeats)"`
+	DecidedRetentionSlots      uint64                           `yaml:"DecidedRetentionSlots" env:"DECIDED_RETENTION_SLOTS" env-description:"Number of recent slots whose decided instances are kept for the decided history (0 keeps all)"`
+	DecidedPruneInterval       time.Duration                    `yaml:"DecidedPruneInterval" env:"DECIDED_PRUNE_INTERVAL" env-default:"10m" env-description:"Interval between prunings of decided instances older than the retention window"`
 	SSVAPIPort                 int                              `yaml:"SSVAPIPort" env:"SSV_API_PORT" env-description:"Port to listen on for the SSV API."`
+	SSVAdminAPIPort            int                              `yaml:"SSVAdminAPIPort" env:"SSV_ADMIN_API_PORT" env-description:"Port to listen on localhost for the SSV admin API, which changes the node's state at runtime and exposes its internals, independently of SSV_API_PORT (disabled if 0)"`
 	LocalEventsPath            string                           `yaml:"LocalEventsPath" env:"EVENTS_PATH" env-description:"path to local events"`
+	FeeRecipientPolicy         fee_recipient.PolicyOptions      `yaml:"FeeRecipientPolicy"`
+	MaxMessageSize             int                              `yaml:"MaxMessageSize" env:"MAX_MESSAGE_SIZE" env-description:"Maximum size of incoming pubsub messages, rejected before decoding (defaults to the largest legitimate message)"`
+	PartialSignatureBatchSize  int                              `yaml:"PartialSignatureBatchSize" env:"PARTIAL_SIGNATURE_BATCH_SIZE" env-description:"Number of partial signatures of messages of the same slot verified together during message validation (0 disables verification)"`
+	StrictSpecValidation       bool                             `yaml:"StrictSpecValidation" env:"STRICT_SPEC_VALIDATION" env-description:"Reject messages which message validation otherwise handles leniently, for conformance testing"`
+	ValidationObserverMode     bool                             `yaml:"ValidationObserverMode" env:"VALIDATION_OBSERVER_MODE" env-description:"Validate and report messages without affecting their propagation, e.g. for exporters. Messages are processed locally but never forwarded"`
+	CommitRootValidation       bool                             `yaml:"CommitRootValidation" env:"COMMIT_ROOT_VALIDATION" env-description:"Reject commit messages whose root doesn't match the proposal of their slot and round"`
+	SlotSkewValidation         bool                             `yaml:"SlotSkewValidation" env:"SLOT_SKEW_VALIDATION" env-description:"Reject consensus messages whose full data is of a duty slot more than MaxSlotSkew slots away from their height"`
+	MaxSlotSkew                uint64                           `yaml:"MaxSlotSkew" env:"MAX_SLOT_SKEW" env-description:"Maximum distance in slots between the height of a consensus message and the duty slot of its full data, beyond which it's rejected if SlotSkewValidation is enabled"`
+	ReconfigurationWindow      string                           `yaml:"ReconfigurationWindow" env:"RECONFIGURATION_WINDOW" env-description:"Number of slots following a change of a validator's committee during which decided messages of its previous committee are allowed as well, 0 for none (default 64 if unset)"`
+	MaxPlausibleRound          uint64                           `yaml:"MaxPlausibleRound" env:"MAX_PLAUSIBLE_ROUND" env-description:"Highest round of consensus messages beyond which they're rejected (defaults to the round reachable by the round timeouts before messages expire)"`
+	CommitteeValidatorsOnly    bool                             `yaml:"CommitteeValidatorsOnly" env:"COMMITTEE_VALIDATORS_ONLY" env-description:"Ignore partial signature messages of validators whose committee doesn't include this operator before verifying them. Such messages aren't relayed either"`
+	BeaconSelfTest             bool                             `yaml:"BeaconSelfTest" env:"BEACON_SELF_TEST" env-description:"Probe every beacon node endpoint SSV depends on at startup and report the unsupported ones"`
+	StartupSyncTimeout         time.Duration                    `yaml:"StartupSyncTimeout" env:"STARTUP_SYNC_TIMEOUT" env-description:"Time to wait at startup for registry events to be synced and the consensus client to be ready before starting duties, failing if exceeded (0 disables waiting)"`
 }
 
 var cfg config
@@ -193,13 +222,44 @@ var StartNodeCmd = &cobra.Command{
 		cfg.ConsensusClient.Graffiti = []byte(cfg.Graffiti)
 		cfg.ConsensusClient.GasLimit = spectypes.DefaultGasLimit
 		cfg.ConsensusClient.Network = networkConfig.Beacon.GetNetwork()
+		cfg.ConsensusClient.RegistrationsDB = db
+		if cfg.ConsensusClient.TracingEndpoint != "" && cfg.ConsensusClient.TracerProvider == nil {
+			cfg.ConsensusClient.TracerProvider = setupTracerProvider(cmd.Context(), logger, cfg.ConsensusClient.TracingEndpoint)
+		}
+		cfg.ConsensusClient.ValidatorsProvider = func() []phase0.BLSPubKey {
+			if !operatorDataStore.OperatorIDReady() {
+				return nil
+			}
+			shares := nodeStorage.Shares().List(nil,
+				registrystorage.ByOperatorID(operatorDataStore.GetOperatorID()),
+				registrystorage.ByNotLiquidated(),
+			)
+			pubKeys := make([]phase0.BLSPubKey, len(shares))
+			for i, share := range shares {
+				copy(pubKeys[i][:], share.ValidatorPubKey)
+			}
+			return pubKeys
+		}
 
 		consensusClient := setupConsensusClient(logger, operatorDataStore, slotTickerProvider)
 
+		additionalRegistryContracts, err := cfg.ExecutionClient.ParseAdditionalRegistryContracts()
+		if err != nil {
+			logger.Fatal("invalid additional registry contracts", zap.Error(err))
+		}
+		var additionalContracts []ethcommon.Address
+		for _, c := range additionalRegistryContracts {
+			additionalContracts = append(additionalContracts, c.Address)
+		}
+		if len(additionalContracts) > 0 {
+			logger.Info("processing events of additional registry contracts", zap.Stringers("contracts", additionalContracts))
+		}
+
 		executionClient, err := executionclient.New(
 			cmd.Context(),
 			cfg.ExecutionClient.Addr,
 			ethcommon.HexToAddress(networkConfig.RegistryContractAddr),
+			executionclient.WithAdditionalContracts(additionalContracts...),
 			executionclient.WithLogger(logger),
 			executionclient.WithMetrics(metricsReporter),
 			executionclient.WithFollowDistance(executionclient.DefaultFollowDistance),
@@ -223,20 +283,55 @@ var StartNodeCmd = &cobra.Command{
 		dutyStore := dutystore.New()
 		cfg.SSVOptions.DutyStore = dutyStore
 
-		messageValidator := validation.NewMessageValidator(
-			networkConfig,
+		selfPID := setupNetworkKey(logger, db)
+
+		messageValidatorOpts := []validation.Option{
 			validation.WithNodeStorage(nodeStorage),
+			validation.WithSelfPeerID(selfPID),
 			validation.WithLogger(logger),
 			validation.WithMetrics(metricsReporter),
 			validation.WithDutyStore(dutyStore),
 			validation.WithOwnOperatorID(operatorDataStore),
-		)
+		}
+		if cfg.MaxMessageSize > 0 {
+			messageValidatorOpts = append(messageValidatorOpts, validation.WithMaxMessageSize(cfg.MaxMessageSize))
+		}
+		if cfg.PartialSignatureBatchSize > 0 {
+			messageValidatorOpts = append(messageValidatorOpts, validation.WithPartialSignatureVerification(cfg.PartialSignatureBatchSize))
+		}
+		if cfg.StrictSpecValidation {
+			messageValidatorOpts = append(messageValidatorOpts, validation.WithStrictSpec())
+		}
+		if cfg.ValidationObserverMode {
+			messageValidatorOpts = append(messageValidatorOpts, validation.WithObserverMode())
+		}
+		if cfg.CommitRootValidation {
+			messageValidatorOpts = append(messageValidatorOpts, validation.WithCommitRootValidation())
+		}
+		if cfg.SlotSkewValidation {
+			messageValidatorOpts = append(messageValidatorOpts, validation.WithMaxSlotSkew(phase0.Slot(cfg.MaxSlotSkew)))
+		}
+		// The reconfiguration window is a string so that 0 can be told apart from unset.
+		if cfg.ReconfigurationWindow != "" {
+			window, err := strconv.ParseUint(cfg.ReconfigurationWindow, 10, 64)
+			if err != nil {
+				logger.Fatal("invalid reconfiguration window", zap.String("window", cfg.ReconfigurationWindow), zap.Error(err))
+			}
+			messageValidatorOpts = append(messageValidatorOpts, validation.WithReconfigurationWindow(phase0.Slot(window)))
+		}
+		if cfg.MaxPlausibleRound > 0 {
+			messageValidatorOpts = append(messageValidatorOpts, validation.WithMaxPlausibleRound(specqbft.Round(cfg.MaxPlausibleRound)))
+		}
+		if cfg.CommitteeValidatorsOnly {
+			messageValidatorOpts = append(messageValidatorOpts, validation.WithCommitteeValidatorsOnly())
+		}
+		messageValidator := validation.NewMessageValidator(networkConfig, messageValidatorOpts...)
 
 		cfg.P2pNetworkConfig.Metrics = metricsReporter
 		cfg.P2pNetworkConfig.MessageValidator = messageValidator
 		cfg.SSVOptions.ValidatorOptions.MessageValidator = messageValidator
 
-		p2pNetwork := setupP2P(logger, db, metricsReporter)
+		p2pNetwork := setupP2P(logger, metricsReporter)
 
 		cfg.SSVOptions.Context = cmd.Context()
 		cfg.SSVOptions.DB = db
@@ -259,9 +354,13 @@ var StartNodeCmd = &cobra.Command{
 
 		if cfg.WsAPIPort != 0 {
 			ws := exporterapi.NewWsServer(cmd.Context(), nil, http.NewServeMux(), cfg.WithPing)
+			ws.UseMaxStreamSubscribers(cfg.WsMaxStreamSubscribers)
+			ws.UseStreamHeartbeat(cfg.WsStreamHeartbeat)
 			cfg.SSVOptions.WS = ws
 			cfg.SSVOptions.WsAPIPort = cfg.WsAPIPort
-			cfg.SSVOptions.ValidatorOptions.NewDecidedHandler = decided.NewStreamPublisher(logger, ws)
+			decidedPublisher := decided.NewStreamPublisher(logger, ws, networkConfig.Beacon, cfg.WsReplaySize)
+			decidedPublisher.UseOperatorDataStore(operatorDataStore)
+			cfg.SSVOptions.ValidatorOptions.NewDecidedHandler = decidedPublisher.Handler()
 		}
 
 		cfg.SSVOptions.ValidatorOptions.DutyRoles = []spectypes.BeaconRole{spectypes.BNRoleAttester} // TODO could be better to set in other place
@@ -282,10 +381,26 @@ var StartNodeCmd = &cobra.Command{
 		}
 
 		cfg.SSVOptions.ValidatorOptions.StorageMap = storageMap
+		if cfg.DecidedRetentionSlots > 0 {
+			go storageMap.RetainInstances(
+				cmd.Context(),
+				logger,
+				networkConfig.Beacon.EstimatedCurrentSlot,
+				phase0.Slot(cfg.DecidedRetentionSlots),
+				cfg.DecidedPruneInterval,
+			)
+		}
 		cfg.SSVOptions.ValidatorOptions.Metrics = metricsReporter
 		cfg.SSVOptions.ValidatorOptions.Graffiti = []byte(cfg.Graffiti)
 		cfg.SSVOptions.Metrics = metricsReporter
 
+		feeRecipientPolicy, err := fee_recipient.NewPolicy(cfg.FeeRecipientPolicy)
+		if err != nil {
+			logger.Fatal("could not setup fee recipient policy", zap.Error(err))
+		}
+		cfg.SSVOptions.FeeRecipientPolicy = feeRecipientPolicy
+		cfg.SSVOptions.ValidatorOptions.FeeRecipientPolicy = feeRecipientPolicy
+
 		validatorCtrl := validator.NewController(logger, cfg.SSVOptions.ValidatorOptions)
 		cfg.SSVOptions.ValidatorController = validatorCtrl
 
@@ -310,23 +425,49 @@ var StartNodeCmd = &cobra.Command{
 			},
 		)
 
+		eventSyncer, eventHandler := setupEventHandling(
+			logger,
+			executionClient,
+			additionalRegistryContracts,
+			validatorCtrl,
+			storageMap,
+			metricsReporter,
+			networkConfig,
+			nodeStorage,
+			operatorDataStore,
+			operatorPrivKey,
+		)
+
+		// Gate the node's start on the registry sync and the consensus client's readiness,
+		// with the timeout covering the wait for healthy nodes and the historical registry sync.
+		fullyUp := make(chan struct{})
+		if cfg.StartupSyncTimeout > 0 {
+			logger.Info("waiting for registry sync and consensus client readiness")
+			go func() {
+				if err := nodeprobe.WaitForSync(cmd.Context(), cfg.StartupSyncTimeout, eventSyncer, consensusClient.(nodeprobe.ReadyChecker)); err != nil {
+					logger.Fatal("node isn't fully up", zap.Error(err))
+				}
+				logger.Info("node is fully up")
+				close(fullyUp)
+			}()
+		} else {
+			close(fullyUp)
+		}
+
 		nodeProber.Start(cmd.Context())
 		nodeProber.Wait()
 		logger.Info("ethereum node(s) are healthy")
 
 		metricsReporter.SSVNodeHealthy()
 
-		eventSyncer := setupEventHandling(
+		syncRegistryEvents(
 			cmd.Context(),
 			logger,
-			executionClient,
-			validatorCtrl,
-			storageMap,
-			metricsReporter,
+			eventSyncer,
+			eventHandler,
 			networkConfig,
 			nodeStorage,
 			operatorDataStore,
-			operatorPrivKey,
 		)
 		nodeProber.AddNode("event syncer", eventSyncer)
 
@@ -340,10 +481,11 @@ var StartNodeCmd = &cobra.Command{
 			logger.Fatal("failed to start network", zap.Error(err))
 		}
 
-		if cfg.SSVAPIPort > 0 {
+		if cfg.SSVAPIPort > 0 || cfg.SSVAdminAPIPort > 0 {
 			apiServer := apiserver.New(
 				logger,
 				fmt.Sprintf(":%d", cfg.SSVAPIPort),
+				fmt.Sprintf("127.0.0.1:%d", cfg.SSVAdminAPIPort),
 				&handlers.Node{
 					// TODO: replace with narrower interface! (instead of accessing the entire PeersIndex)
 					ListenAddresses: []string{fmt.Sprintf("tcp://%s:%d", cfg.P2pNetworkConfig.HostAddress, cfg.P2pNetworkConfig.TCPPort), fmt.Sprintf("udp://%s:%d", cfg.P2pNetworkConfig.HostAddress, cfg.P2pNetworkConfig.UDPPort)},
@@ -351,19 +493,38 @@ var StartNodeCmd = &cobra.Command{
 					Network:         p2pNetwork.(p2pv1.HostProvider).Host().Network(),
 					TopicIndex:      p2pNetwork.(handlers.TopicIndex),
 					NodeProber:      nodeProber,
+					EventTopics:     consensusClient.(handlers.EventTopicsProvider),
+					ConsensusClient: consensusClient.(handlers.ConsensusClientProbe),
+					ScoreParams:     p2pNetwork.(handlers.TopicScoreParamsProvider),
+					RoleValidation:  messageValidator,
+					ValidationPause: messageValidator,
+					TraceLog:        p2pNetwork.(handlers.PubsubTraceLogSwitch),
 				},
 				&handlers.Validators{
-					Shares: nodeStorage.Shares(),
+					Shares:        nodeStorage.Shares(),
+					Registrations: consensusClient.(handlers.RegistrationStatusProvider),
 				},
 			)
-			go func() {
-				err := apiServer.Run()
-				if err != nil {
-					logger.Fatal("failed to start API server", zap.Error(err))
-				}
-			}()
+			if cfg.SSVAPIPort > 0 {
+				go func() {
+					err := apiServer.Run()
+					if err != nil {
+						logger.Fatal("failed to start API server", zap.Error(err))
+					}
+				}()
+			}
+			if cfg.SSVAdminAPIPort > 0 {
+				go func() {
+					err := apiServer.RunAdmin()
+					if err != nil {
+						logger.Fatal("failed to start admin API server", zap.Error(err))
+					}
+				}()
+			}
 		}
 
+		<-fullyUp
+
 		if err := operatorNode.Start(logger); err != nil {
 			logger.Fatal("failed to start SSV node", zap.Error(err))
 		}
@@ -579,7 +740,8 @@ func setupSSVNetwork(logger *zap.Logger) (networkconfig.NetworkConfig, error) {
 	return networkConfig, nil
 }
 
-func setupP2P(logger *zap.Logger, db basedb.Database, mr metricsreporter.MetricsReporter) network.P2PNetwork {
+// setupNetworkKey sets up the network private key of the p2p network, returning the peer ID of its host.
+func setupNetworkKey(logger *zap.Logger, db basedb.Database) peer.ID {
 	istore := ssv_identity.NewIdentityStore(db)
 	netPrivKey, err := istore.SetupNetworkKey(logger, cfg.NetworkPrivateKey)
 	if err != nil {
@@ -587,6 +749,14 @@ func setupP2P(logger *zap.Logger, db basedb.Database, mr metricsreporter.Metrics
 	}
 	cfg.P2pNetworkConfig.NetworkPrivateKey = netPrivKey
 
+	selfPID, err := p2pcommons.PeerIDFromNetworkKey(netPrivKey)
+	if err != nil {
+		logger.Fatal("failed to derive peer ID from network private key", zap.Error(err))
+	}
+	return selfPID
+}
+
+func setupP2P(logger *zap.Logger, mr metricsreporter.MetricsReporter) network.P2PNetwork {
 	p2pNetwork, err := p2pv1.New(logger, &cfg.P2pNetworkConfig, mr)
 	if err != nil {
 		logger.Fatal("failed to setup p2p network", zap.Error(err))
@@ -602,16 +772,80 @@ func setupConsensusClient(
 	cl, err := goclient.New(logger, cfg.ConsensusClient, operatorDataStore, slotTickerProvider)
 	if err != nil {
 		logger.Fatal("failed to create beacon go-client", zap.Error(err),
-			fields.Address(cfg.ConsensusClient.BeaconNodeAddr))
+			fields.Address(goclient.RedactAddress(cfg.ConsensusClient.BeaconNodeAddr)))
+	}
+
+	if cfg.BeaconSelfTest {
+		reportBeaconSelfTest(logger, cl.(goclient.SelfTester))
 	}
 
 	return cl
 }
 
+// setupTracerProvider returns a tracer provider which exports spans in batches to the OTLP/HTTP collector
+// at the given endpoint, flushing the remaining spans once the context is done.
+func setupTracerProvider(ctx context.Context, logger *zap.Logger, endpoint string) *sdktrace.TracerProvider {
+	exporter, err := otlptracehttp.New(ctx,
+		otlptracehttp.WithEndpoint(endpoint),
+		otlptracehttp.WithInsecure(),
+	)
+	if err != nil {
+		logger.Fatal("failed to create tracing exporter", zap.Error(err), zap.String("endpoint", endpoint))
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName("ssv-node"),
+		semconv.ServiceVersion(commons.GetNodeVersion()),
+	))
+	if err != nil {
+		logger.Fatal("failed to create tracing resource", zap.Error(err))
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := provider.Shutdown(shutdownCtx); err != nil {
+			logger.Warn("failed to flush spans", zap.Error(err))
+		}
+	}()
+	logger.Info("exporting beacon node requests as OpenTelemetry spans", zap.String("endpoint", endpoint))
+	return provider
+}
+
+// reportBeaconSelfTest logs the result of probing each of the beacon node's endpoints.
+func reportBeaconSelfTest(logger *zap.Logger, selfTester goclient.SelfTester) {
+	failed := 0
+	for _, result := range selfTester.SelfTest(cfg.ConsensusClient.Context) {
+		switch {
+		case result.Skipped:
+			logger.Info("beacon self-test: endpoint skipped", zap.String("endpoint", result.Endpoint))
+		case result.Err != nil:
+			failed++
+			logger.Error("beacon self-test: endpoint failed",
+				zap.String("endpoint", result.Endpoint),
+				fields.Took(result.Latency),
+				zap.Error(result.Err),
+			)
+		default:
+			logger.Info("beacon self-test: endpoint passed",
+				zap.String("endpoint", result.Endpoint),
+				fields.Took(result.Latency),
+			)
+		}
+	}
+	if failed != 0 {
+		logger.Warn("beacon self-test: some endpoints failed, duties depending on them may fail", fields.Count(failed))
+	}
+}
+
 func setupEventHandling(
-	ctx context.Context,
 	logger *zap.Logger,
 	executionClient *executionclient.ExecutionClient,
+	additionalContracts []executionclient.RegistryContract,
 	validatorCtrl validator.Controller,
 	storageMap *ibftstorage.QBFTStores,
 	metricsReporter metricsreporter.MetricsReporter,
@@ -619,13 +853,29 @@ func setupEventHandling(
 	nodeStorage operatorstorage.Storage,
 	operatorDataStore operatordatastore.OperatorDataStore,
 	operatorDecrypter keys.OperatorDecrypter,
-) *eventsyncer.EventSyncer {
+) (*eventsyncer.EventSyncer, *eventhandler.EventHandler) {
 	eventFilterer, err := executionClient.Filterer()
 	if err != nil {
 		logger.Fatal("failed to set up event filterer", zap.Error(err))
 	}
 
-	eventParser := eventparser.New(eventFilterer)
+	abiVersion := eventparser.AbiVersion(cfg.ExecutionClient.AbiVersion)
+	eventParser, err := eventparser.NewForVersion(abiVersion, eventFilterer)
+	if err != nil {
+		logger.Fatal("failed to set up event parser", zap.Error(err))
+	}
+	logger.Debug("decoding registry contract events", fields.ABIVersion(string(abiVersion)))
+
+	contractParsers := make(map[ethcommon.Address]eventparser.Parser, len(additionalContracts))
+	for _, c := range additionalContracts {
+		abiVersion := eventparser.AbiVersion(c.AbiVersion)
+		contractParser, err := eventparser.NewForVersion(abiVersion, eventFilterer)
+		if err != nil {
+			logger.Fatal("failed to set up event parser of additional registry contract", fields.Contract(c.Address), zap.Error(err))
+		}
+		contractParsers[c.Address] = contractParser
+		logger.Debug("decoding additional registry contract events", fields.Contract(c.Address), fields.ABIVersion(string(abiVersion)))
+	}
 
 	eventHandler, err := eventhandler.New(
 		nodeStorage,
@@ -640,6 +890,7 @@ func setupEventHandling(
 		eventhandler.WithFullNode(),
 		eventhandler.WithLogger(logger),
 		eventhandler.WithMetrics(metricsReporter),
+		eventhandler.WithAdditionalContracts(contractParsers),
 	)
 	if err != nil {
 		logger.Fatal("failed to setup event data handler", zap.Error(err))
@@ -653,17 +904,23 @@ func setupEventHandling(
 		eventsyncer.WithMetrics(metricsReporter),
 	)
 
-	fromBlock, found, err := nodeStorage.GetLastProcessedBlock(nil)
+	return eventSyncer, eventHandler
+}
+
+// syncRegistryEvents syncs the historical registry events, or handles the local events if configured,
+// and then syncs the ongoing registry events in the background.
+func syncRegistryEvents(
+	ctx context.Context,
+	logger *zap.Logger,
+	eventSyncer *eventsyncer.EventSyncer,
+	eventHandler *eventhandler.EventHandler,
+	networkConfig networkconfig.NetworkConfig,
+	nodeStorage operatorstorage.Storage,
+	operatorDataStore operatordatastore.OperatorDataStore,
+) {
+	fromBlock, err := registrySyncStart(logger, nodeStorage, networkConfig, cfg.ExecutionClient.SyncOffset)
 	if err != nil {
-		logger.Fatal("syncing registry contract events failed, could not get last processed block", zap.Error(err))
-	}
-	if !found {
-		fromBlock = networkConfig.RegistrySyncOffset
-	} else if fromBlock == nil {
-		logger.Fatal("syncing registry contract events failed, last processed block is nil")
-	} else {
-		// Start syncing from the next block.
-		fromBlock = new(big.Int).SetUint64(fromBlock.Uint64() + 1)
+		logger.Fatal("syncing registry contract events failed, could not determine the start block", zap.Error(err))
 	}
 
 	// load & parse local events yaml if exists, otherwise sync from contract
@@ -676,6 +933,7 @@ func setupEventHandling(
 		if err := eventHandler.HandleLocalEvents(localEvents); err != nil {
 			logger.Fatal("error occurred while running event data handler", zap.Error(err))
 		}
+		eventSyncer.MarkHistorySynced()
 	} else {
 		// Sync historical registry events.
 		logger.Debug("syncing historical registry events", zap.Uint64("fromBlock", fromBlock.Uint64()))
@@ -728,8 +986,49 @@ func setupEventHandling(
 				zap.Error(err))
 		}()
 	}
+}
+
+// registrySyncStart returns the block to sync registry events from: the block after the last processed one,
+// or on a fresh database, the configured sync offset, defaulting to the network's registry contract deploy block.
+// A configured sync offset after the last processed block is rejected, since the blocks in between would be skipped.
+func registrySyncStart(logger *zap.Logger, nodeStorage operatorstorage.Storage, networkConfig networkconfig.NetworkConfig, syncOffset string) (*big.Int, error) {
+	offset := networkConfig.RegistrySyncOffset
+	if syncOffset != "" {
+		configured, ok := new(big.Int).SetString(syncOffset, 0)
+		if !ok || configured.Sign() < 0 {
+			return nil, fmt.Errorf("invalid sync offset %q", syncOffset)
+		}
+		if offset != nil && configured.Cmp(offset) > 0 {
+			logger.Warn("sync offset is after the network's registry contract deploy block, earlier registry events are missed on a fresh database",
+				fields.SyncOffset(configured),
+				zap.Stringer("deploy_block", offset),
+			)
+		}
+		offset = configured
+	}
+
+	lastProcessedBlock, found, err := nodeStorage.GetLastProcessedBlock(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get last processed block")
+	}
+	if !found {
+		if offset == nil {
+			return nil, errors.New("no sync offset is configured and the network's registry contract deploy block is unknown")
+		}
+		logger.Info("syncing registry events from the sync offset", fields.SyncOffset(offset), zap.Bool("configured", syncOffset != ""))
+		return offset, nil
+	}
+	if lastProcessedBlock == nil {
+		return nil, errors.New("last processed block is nil")
+	}
 
-	return eventSyncer
+	// Start syncing from the next block.
+	fromBlock := new(big.Int).SetUint64(lastProcessedBlock.Uint64() + 1)
+	if syncOffset != "" && offset.Cmp(fromBlock) > 0 {
+		return nil, fmt.Errorf("sync offset %s is after the next block to sync %s, registry events in between would be missed", offset, fromBlock)
+	}
+	logger.Info("resuming registry events sync from the last processed block", zap.Stringer("from_block", fromBlock), fields.SyncOffset(offset))
+	return fromBlock, nil
 }
 
 func startMetricsHandler(ctx context.Context, logger *zap.Logger, db basedb.Database, metricsReporter metricsreporter.MetricsReporter, port int, enableProf bool) {
@@ -1,6 +1,7 @@
 package operator
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -136,3 +137,54 @@ func Test_verifyConfig(t *testing.T) {
 		require.NoError(t, nodeStorage.DeleteConfig(nil))
 	})
 }
+
+func Test_registrySyncStart(t *testing.T) {
+	logger := zap.NewNop()
+
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	require.NoError(t, err)
+
+	network := networkconfig.TestNetwork
+
+	t.Run("fresh database", func(t *testing.T) {
+		// The network's registry contract deploy block is the default.
+		fromBlock, err := registrySyncStart(logger, nodeStorage, network, "")
+		require.NoError(t, err)
+		require.Equal(t, network.RegistrySyncOffset, fromBlock)
+
+		fromBlock, err = registrySyncStart(logger, nodeStorage, network, "1000")
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(1000), fromBlock)
+
+		fromBlock, err = registrySyncStart(logger, nodeStorage, network, "0x10")
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(16), fromBlock)
+
+		_, err = registrySyncStart(logger, nodeStorage, network, "latest")
+		require.ErrorContains(t, err, "invalid sync offset")
+
+		unknownNetwork := network
+		unknownNetwork.RegistrySyncOffset = nil
+		_, err = registrySyncStart(logger, nodeStorage, unknownNetwork, "")
+		require.Error(t, err)
+	})
+
+	t.Run("persisted progress", func(t *testing.T) {
+		require.NoError(t, nodeStorage.SaveLastProcessedBlock(nil, big.NewInt(20000000)))
+
+		fromBlock, err := registrySyncStart(logger, nodeStorage, network, "")
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(20000001), fromBlock)
+
+		// An earlier sync offset doesn't override the progress.
+		fromBlock, err = registrySyncStart(logger, nodeStorage, network, "1000")
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(20000001), fromBlock)
+
+		_, err = registrySyncStart(logger, nodeStorage, network, "20000002")
+		require.ErrorContains(t, err, "registry events in between would be missed")
+	})
+}
@@ -3,6 +3,7 @@
 package eventhandler
 
 import (
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"math/big"
@@ -12,6 +13,7 @@ import (
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	lru "github.com/hashicorp/golang-lru/v2"
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/eth/contract"
@@ -41,6 +43,10 @@ const (
 	ValidatorExited            = "ValidatorExited"
 )
 
+// contractEventsCacheSize is the number of recently processed events whose emitting contract is remembered
+// to skip their duplicates emitted by additional registry contracts.
+const contractEventsCacheSize = 10000
+
 var (
 	// ErrInferiorBlock is returned when trying to process a block that is
 	// not higher than the last processed block.
@@ -67,6 +73,11 @@ type EventHandler struct {
 	beacon            beaconprotocol.BeaconNode
 	storageMap        *qbftstorage.QBFTStores
 
+	// contractParsers parse the events of additional registry contracts by contract, such as during contract migrations.
+	// contractEvents remembers the contract which emitted each recently processed event, by its identity (see eventIdentity).
+	contractParsers map[ethcommon.Address]eventparser.Parser
+	contractEvents  *lru.Cache[[32]byte, ethcommon.Address]
+
 	fullNode bool
 	logger   *zap.Logger
 	metrics  metrics
@@ -102,6 +113,14 @@ func New(
 		opt(eh)
 	}
 
+	if len(eh.contractParsers) != 0 {
+		contractEvents, err := lru.New[[32]byte, ethcommon.Address](contractEventsCacheSize)
+		if err != nil {
+			return nil, fmt.Errorf("create contract events cache: %w", err)
+		}
+		eh.contractEvents = contractEvents
+	}
+
 	return eh, nil
 }
 
@@ -137,11 +156,28 @@ func (eh *EventHandler) HandleBlockEventsStream(logs <-chan executionclient.Bloc
 				logger.Debug("executed task")
 			}
 		}
+		eh.invalidateValidators(tasks)
 	}
 
 	return
 }
 
+// invalidateValidators notifies the beacon node when the tasks changed the operator's validators,
+// so that it prefetches them again.
+func (eh *EventHandler) invalidateValidators(tasks []Task) {
+	invalidator, ok := eh.beacon.(beaconprotocol.ValidatorsInvalidator)
+	if !ok {
+		return
+	}
+	for _, task := range tasks {
+		switch task.(type) {
+		case *StartValidatorTask, *StopValidatorTask, *LiquidateClusterTask, *ReactivateClusterTask:
+			invalidator.InvalidateValidators()
+			return
+		}
+	}
+}
+
 func (eh *EventHandler) processBlockEvents(block executionclient.BlockLogs) ([]Task, error) {
 	txn := eh.nodeStorage.Begin()
 	defer txn.Discard()
@@ -188,17 +224,32 @@ func (eh *EventHandler) processBlockEvents(block executionclient.BlockLogs) ([]T
 }
 
 func (eh *EventHandler) processEvent(txn basedb.Txn, event ethtypes.Log) (Task, error) {
-	abiEvent, err := eh.eventParser.EventByID(event.Topics[0])
+	// The registry contract which emitted the event, as there may be several during contract migrations.
+	logger := eh.logger.With(fields.Contract(event.Address))
+
+	if eh.duplicateContractEvent(event) {
+		logger.Debug("skipping event already processed from another registry contract",
+			fields.TxHash(event.TxHash),
+			zap.String("hash", event.Topics[0].String()))
+		return nil, nil
+	}
+
+	parser := eh.eventParser
+	if contractParser, ok := eh.contractParsers[event.Address]; ok {
+		parser = contractParser
+	}
+
+	abiEvent, err := parser.EventByID(event.Topics[0])
 	if err != nil {
-		eh.logger.Error("failed to find event by ID", zap.String("hash", event.Topics[0].String()))
+		logger.Error("failed to find event by ID", zap.String("hash", event.Topics[0].String()))
 		return nil, nil
 	}
 
 	switch abiEvent.Name {
 	case OperatorAdded:
-		operatorAddedEvent, err := eh.eventParser.ParseOperatorAdded(event)
+		operatorAddedEvent, err := parser.ParseOperatorAdded(event)
 		if err != nil {
-			eh.logger.Warn("could not parse event",
+			logger.Warn("could not parse event",
 				fields.EventName(abiEvent.Name),
 				zap.Error(err))
 			eh.metrics.EventProcessingFailed(abiEvent.Name)
@@ -219,9 +270,9 @@ func (eh *EventHandler) processEvent(txn basedb.Txn, event ethtypes.Log) (Task,
 		return nil, nil
 
 	case OperatorRemoved:
-		operatorRemovedEvent, err := eh.eventParser.ParseOperatorRemoved(event)
+		operatorRemovedEvent, err := parser.ParseOperatorRemoved(event)
 		if err != nil {
-			eh.logger.Warn("could not parse event",
+			logger.Warn("could not parse event",
 				fields.EventName(abiEvent.Name),
 				zap.Error(err))
 			eh.metrics.EventProcessingFailed(abiEvent.Name)
@@ -242,9 +293,9 @@ func (eh *EventHandler) processEvent(txn basedb.Txn, event ethtypes.Log) (Task,
 		return nil, nil
 
 	case ValidatorAdded:
-		validatorAddedEvent, err := eh.eventParser.ParseValidatorAdded(event)
+		validatorAddedEvent, err := parser.ParseValidatorAdded(event)
 		if err != nil {
-			eh.logger.Warn("could not parse event",
+			logger.Warn("could not parse event",
 				fields.EventName(abiEvent.Name),
 				zap.Error(err))
 			eh.metrics.EventProcessingFailed(abiEvent.Name)
@@ -273,9 +324,9 @@ func (eh *EventHandler) processEvent(txn basedb.Txn, event ethtypes.Log) (Task,
 		return task, nil
 
 	case ValidatorRemoved:
-		validatorRemovedEvent, err := eh.eventParser.ParseValidatorRemoved(event)
+		validatorRemovedEvent, err := parser.ParseValidatorRemoved(event)
 		if err != nil {
-			eh.logger.Warn("could not parse event",
+			logger.Warn("could not parse event",
 				fields.EventName(abiEvent.Name),
 				zap.Error(err))
 			eh.metrics.EventProcessingFailed(abiEvent.Name)
@@ -302,9 +353,9 @@ func (eh *EventHandler) processEvent(txn basedb.Txn, event ethtypes.Log) (Task,
 		return nil, nil
 
 	case ClusterLiquidated:
-		clusterLiquidatedEvent, err := eh.eventParser.ParseClusterLiquidated(event)
+		clusterLiquidatedEvent, err := parser.ParseClusterLiquidated(event)
 		if err != nil {
-			eh.logger.Warn("could not parse event",
+			logger.Warn("could not parse event",
 				fields.EventName(abiEvent.Name),
 				zap.Error(err))
 			eh.metrics.EventProcessingFailed(abiEvent.Name)
@@ -333,9 +384,9 @@ func (eh *EventHandler) processEvent(txn basedb.Txn, event ethtypes.Log) (Task,
 		return task, nil
 
 	case ClusterReactivated:
-		clusterReactivatedEvent, err := eh.eventParser.ParseClusterReactivated(event)
+		clusterReactivatedEvent, err := parser.ParseClusterReactivated(event)
 		if err != nil {
-			eh.logger.Warn("could not parse event",
+			logger.Warn("could not parse event",
 				fields.EventName(abiEvent.Name),
 				zap.Error(err))
 			eh.metrics.EventProcessingFailed(abiEvent.Name)
@@ -364,9 +415,9 @@ func (eh *EventHandler) processEvent(txn basedb.Txn, event ethtypes.Log) (Task,
 		return task, nil
 
 	case FeeRecipientAddressUpdated:
-		feeRecipientAddressUpdatedEvent, err := eh.eventParser.ParseFeeRecipientAddressUpdated(event)
+		feeRecipientAddressUpdatedEvent, err := parser.ParseFeeRecipientAddressUpdated(event)
 		if err != nil {
-			eh.logger.Warn("could not parse event",
+			logger.Warn("could not parse event",
 				fields.EventName(abiEvent.Name),
 				zap.Error(err))
 			eh.metrics.EventProcessingFailed(abiEvent.Name)
@@ -394,9 +445,9 @@ func (eh *EventHandler) processEvent(txn basedb.Txn, event ethtypes.Log) (Task,
 		return task, nil
 
 	case ValidatorExited:
-		validatorExitedEvent, err := eh.eventParser.ParseValidatorExited(event)
+		validatorExitedEvent, err := parser.ParseValidatorExited(event)
 		if err != nil {
-			eh.logger.Warn("could not parse event",
+			logger.Warn("could not parse event",
 				fields.EventName(abiEvent.Name),
 				zap.Error(err))
 			eh.metrics.EventProcessingFailed(abiEvent.Name)
@@ -424,11 +475,39 @@ func (eh *EventHandler) processEvent(txn basedb.Txn, event ethtypes.Log) (Task,
 		return task, nil
 
 	default:
-		eh.logger.Warn("unknown event name", fields.Name(abiEvent.Name))
+		logger.Warn("unknown event name", fields.Name(abiEvent.Name))
 		return nil, nil
 	}
 }
 
+// duplicateContractEvent returns whether an identical event was already processed from another registry contract,
+// which happens during contract migrations if both the old and the new contract emit it, and otherwise remembers
+// the event's contract. Events repeated by the same contract aren't duplicates.
+func (eh *EventHandler) duplicateContractEvent(event ethtypes.Log) bool {
+	if eh.contractEvents == nil {
+		return false
+	}
+	id := eventIdentity(event)
+	if emitter, ok := eh.contractEvents.Get(id); ok && emitter != event.Address {
+		return true
+	}
+	eh.contractEvents.Add(id, event.Address)
+	return false
+}
+
+// eventIdentity identifies an event by its topics and data, regardless of the contract and the transaction which emitted it.
+func eventIdentity(event ethtypes.Log) [32]byte {
+	h := sha256.New()
+	for _, topic := range event.Topics {
+		h.Write(topic[:])
+	}
+	h.Write(event.Data)
+
+	var id [32]byte
+	copy(id[:], h.Sum(nil))
+	return id
+}
+
 func (eh *EventHandler) HandleLocalEvents(localEvents []localevents.Event) error {
 	txn := eh.nodeStorage.Begin()
 	defer txn.Discard()
@@ -19,6 +19,7 @@ import (
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/golang/mock/gomock"
+	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
@@ -1273,6 +1274,79 @@ func TestHandleBlockEventsStream(t *testing.T) {
 	})
 }
 
+// feeRecipientParser counts the FeeRecipientAddressUpdated events it parses.
+type feeRecipientParser struct {
+	eventparser.Parser
+	parsed int
+}
+
+func (p *feeRecipientParser) ParseFeeRecipientAddressUpdated(log ethtypes.Log) (*contract.ContractFeeRecipientAddressUpdated, error) {
+	p.parsed++
+	return p.Parser.ParseFeeRecipientAddressUpdated(log)
+}
+
+func TestAdditionalContractEvents(t *testing.T) {
+	ops, err := createOperators(1, 0)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := zaptest.NewLogger(t)
+	eh, _, err := setupEventHandler(t, ctx, logger, nil, ops[0], true)
+	require.NoError(t, err)
+
+	newContract := ethcommon.Address{1}
+	oldContract := ethcommon.Address{2}
+	newParser := &feeRecipientParser{Parser: eh.eventParser}
+	oldParser := &feeRecipientParser{Parser: eh.eventParser}
+	eh.eventParser = newParser
+	WithAdditionalContracts(map[ethcommon.Address]eventparser.Parser{oldContract: oldParser})(eh)
+	eh.contractEvents, err = lru.New[[32]byte, ethcommon.Address](contractEventsCacheSize)
+	require.NoError(t, err)
+
+	contractABI, err := contract.ContractMetaData.GetAbi()
+	require.NoError(t, err)
+	feeRecipientLog := func(contract, recipient ethcommon.Address) ethtypes.Log {
+		return ethtypes.Log{
+			Address: contract,
+			Topics:  []ethcommon.Hash{contractABI.Events[FeeRecipientAddressUpdated].ID, ethcommon.BytesToHash(testAddr.Bytes())},
+			Data:    ethcommon.LeftPadBytes(recipient.Bytes(), 32),
+		}
+	}
+
+	// Events of the additional contract are parsed with its own parser, and its duplicates of events
+	// already processed from the other contract are skipped.
+	_, err = eh.processBlockEvents(executionclient.BlockLogs{
+		BlockNumber: 1,
+		Logs: []ethtypes.Log{
+			feeRecipientLog(newContract, ethcommon.Address{3}),
+			feeRecipientLog(oldContract, ethcommon.Address{3}),
+			feeRecipientLog(oldContract, ethcommon.Address{4}),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, newParser.parsed)
+	require.Equal(t, 1, oldParser.parsed)
+
+	recipientData, found, err := eh.nodeStorage.GetRecipientData(nil, testAddr)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, ethcommon.Address{4}.Bytes(), recipientData.FeeRecipient[:])
+
+	// Events repeated by the same contract aren't duplicates.
+	_, err = eh.processBlockEvents(executionclient.BlockLogs{
+		BlockNumber: 2,
+		Logs: []ethtypes.Log{
+			feeRecipientLog(newContract, ethcommon.Address{3}),
+			feeRecipientLog(oldContract, ethcommon.Address{4}),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, newParser.parsed)
+	require.Equal(t, 2, oldParser.parsed)
+}
+
 func setupEventHandler(t *testing.T, ctx context.Context, logger *zap.Logger, network *networkconfig.NetworkConfig, operator *testOperator, useMockCtrl bool) (*EventHandler, *mocks.MockController, error) {
 	db, err := kv.NewInMemory(logger, basedb.Options{
 		Ctx: ctx,
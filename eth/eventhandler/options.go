@@ -1,8 +1,11 @@
 package eventhandler
 
 import (
-	"github.com/bloxapp/ssv/logging"
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/eth/eventparser"
+	"github.com/bloxapp/ssv/logging"
 )
 
 // Option defines EventHandler configuration option.
@@ -28,3 +31,12 @@ func WithFullNode() Option {
 		eh.fullNode = true
 	}
 }
+
+// WithAdditionalContracts parses the events of additional registry contracts with the given parsers by contract,
+// such as during a contract migration, in which the contracts may decode their events with different ABI versions.
+// Events already processed from another contract, e.g. emitted by both the old and the new contract, are skipped.
+func WithAdditionalContracts(parsers map[ethcommon.Address]eventparser.Parser) Option {
+	return func(eh *EventHandler) {
+		eh.contractParsers = parsers
+	}
+}
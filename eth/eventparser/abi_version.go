@@ -0,0 +1,61 @@
+package eventparser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/bloxapp/ssv/eth/contract"
+)
+
+// AbiVersion is a version of the registry contract's ABI.
+type AbiVersion string
+
+// DefaultAbiVersion is the version of the registry contract's ABI which the generated contract bindings implement.
+// Its logs are decoded by EventParser.
+const DefaultAbiVersion AbiVersion = "v1"
+
+// Decoder creates the parser of the registry contract's logs of an ABI version from the contract's filterer.
+type Decoder func(filterer *contract.ContractFilterer) (Parser, error)
+
+var (
+	decodersMu sync.RWMutex
+	decoders   = map[AbiVersion]Decoder{
+		DefaultAbiVersion: func(filterer *contract.ContractFilterer) (Parser, error) {
+			return New(filterer), nil
+		},
+	}
+)
+
+// RegisterDecoder registers the decoder of the logs of the given ABI version,
+// replacing the decoder registered for it, if any.
+func RegisterDecoder(version AbiVersion, decoder Decoder) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+
+	decoders[version] = decoder
+}
+
+// NewForVersion creates the parser of the logs of the given ABI version with the decoder registered for it.
+func NewForVersion(version AbiVersion, filterer *contract.ContractFilterer) (Parser, error) {
+	decodersMu.RLock()
+	decoder, ok := decoders[version]
+	registered := make([]string, 0, len(decoders))
+	for v := range decoders {
+		registered = append(registered, string(v))
+	}
+	decodersMu.RUnlock()
+
+	if !ok {
+		sort.Strings(registered)
+		return nil, fmt.Errorf("no event decoder is registered for registry contract ABI version %q (registered versions: %s)",
+			version, strings.Join(registered, ", "))
+	}
+
+	parser, err := decoder(filterer)
+	if err != nil {
+		return nil, fmt.Errorf("create event decoder for ABI version %q: %w", version, err)
+	}
+	return parser, nil
+}
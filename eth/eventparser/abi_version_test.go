@@ -0,0 +1,68 @@
+package eventparser_test
+
+import (
+	"math/big"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/eth/contract"
+	"github.com/bloxapp/ssv/eth/eventparser"
+)
+
+// dataOperatorRemovedParser decodes a hypothetical ABI version whose OperatorRemoved event
+// carries the operator ID in its data rather than in an indexed topic.
+type dataOperatorRemovedParser struct {
+	eventparser.Parser
+}
+
+func (p *dataOperatorRemovedParser) ParseOperatorRemoved(log ethtypes.Log) (*contract.ContractOperatorRemoved, error) {
+	return &contract.ContractOperatorRemoved{
+		OperatorId: new(big.Int).SetBytes(log.Data).Uint64(),
+		Raw:        log,
+	}, nil
+}
+
+func TestAbiVersionDecoders(t *testing.T) {
+	contractFilterer, err := contract.NewContractFilterer(ethcommon.Address{}, nil)
+	require.NoError(t, err)
+
+	operatorRemovedTopic := ethcommon.HexToHash("0x0e0ba6c2b04de36d6d509ec5bd155c43a9fe862f8052096dd54f3902a74cca3e")
+
+	t.Run("default version", func(t *testing.T) {
+		parser, err := eventparser.NewForVersion(eventparser.DefaultAbiVersion, contractFilterer)
+		require.NoError(t, err)
+		require.IsType(t, &eventparser.EventParser{}, parser)
+	})
+
+	t.Run("custom decoder", func(t *testing.T) {
+		const version eventparser.AbiVersion = "test-data-operator-removed"
+		eventparser.RegisterDecoder(version, func(filterer *contract.ContractFilterer) (eventparser.Parser, error) {
+			return &dataOperatorRemovedParser{Parser: eventparser.New(filterer)}, nil
+		})
+
+		parser, err := eventparser.NewForVersion(version, contractFilterer)
+		require.NoError(t, err)
+
+		log := ethtypes.Log{
+			Topics: []ethcommon.Hash{operatorRemovedTopic},
+			Data:   ethcommon.LeftPadBytes([]byte{0x12, 0x34}, 32),
+		}
+
+		abiEvent, err := parser.EventByID(log.Topics[0])
+		require.NoError(t, err)
+		require.Equal(t, "OperatorRemoved", abiEvent.Name)
+
+		parsedEvent, err := parser.ParseOperatorRemoved(log)
+		require.NoError(t, err)
+		require.Equal(t, uint64(0x1234), parsedEvent.OperatorId)
+	})
+
+	t.Run("unregistered version", func(t *testing.T) {
+		_, err := eventparser.NewForVersion("unregistered", contractFilterer)
+		require.ErrorContains(t, err, `no event decoder is registered for registry contract ABI version "unregistered"`)
+		require.ErrorContains(t, err, string(eventparser.DefaultAbiVersion))
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -45,6 +46,9 @@ type EventSyncer struct {
 
 	lastProcessedBlock       uint64
 	lastProcessedBlockChange time.Time
+
+	historySynced     chan struct{} // closed once historical events are synced
+	historySyncedOnce sync.Once
 }
 
 func New(nodeStorage nodestorage.Storage, executionClient ExecutionClient, eventHandler EventHandler, opts ...Option) *EventSyncer {
@@ -56,6 +60,7 @@ func New(nodeStorage nodestorage.Storage, executionClient ExecutionClient, event
 		logger:             zap.NewNop(),
 		metrics:            nopMetrics{},
 		stalenessThreshold: 150 * time.Second,
+		historySynced:      make(chan struct{}),
 	}
 
 	for _, opt := range opts {
@@ -90,6 +95,7 @@ func (es *EventSyncer) SyncHistory(ctx context.Context, fromBlock uint64) (lastP
 	fetchLogs, fetchError, err := es.executionClient.FetchHistoricalLogs(ctx, fromBlock)
 	if errors.Is(err, executionclient.ErrNothingToSync) {
 		// Nothing to sync, should keep ongoing sync from the given fromBlock.
+		es.MarkHistorySynced()
 		return 0, executionclient.ErrNothingToSync
 	}
 	if err != nil {
@@ -116,9 +122,28 @@ func (es *EventSyncer) SyncHistory(ctx context.Context, fromBlock uint64) (lastP
 	es.logger.Info("finished syncing historical events",
 		zap.Uint64("from_block", fromBlock),
 		zap.Uint64("last_processed_block", lastProcessedBlock))
+	es.MarkHistorySynced()
 	return lastProcessedBlock, nil
 }
 
+// MarkHistorySynced marks historical events as synced, for when they're handled
+// other than by SyncHistory, such as from local events.
+func (es *EventSyncer) MarkHistorySynced() {
+	es.historySyncedOnce.Do(func() {
+		close(es.historySynced)
+	})
+}
+
+// WaitHistorySynced blocks until historical events are synced successfully, or until the context is done.
+func (es *EventSyncer) WaitHistorySynced(ctx context.Context) error {
+	select {
+	case <-es.historySynced:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // SyncOngoing streams and processes ongoing events as they come since the given fromBlock.
 func (es *EventSyncer) SyncOngoing(ctx context.Context, fromBlock uint64) error {
 	es.logger.Info("subscribing to ongoing registry events", fields.FromBlock(fromBlock))
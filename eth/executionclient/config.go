@@ -1,7 +1,11 @@
 package executionclient
 
 import (
+	"fmt"
+	"strings"
 	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
 )
 
 // TODO: rename eth1, consider combining with consensus client options
@@ -10,4 +14,44 @@ import (
 type ExecutionOptions struct {
 	Addr              string        `yaml:"ETH1Addr" env:"ETH_1_ADDR" env-required:"true" env-description:"Execution client WebSocket address"`
 	ConnectionTimeout time.Duration `yaml:"ETH1ConnectionTimeout" env:"ETH_1_CONNECTION_TIMEOUT" env-default:"10s" env-description:"Execution client connection timeout"`
+
+	// AdditionalRegistryContracts are registry contracts whose events are processed along with
+	// the network's registry contract, to bridge contract migrations (see ParseAdditionalRegistryContracts). Optional.
+	AdditionalRegistryContracts []string `yaml:"ETH1AdditionalRegistryContracts" env:"ETH_1_ADDITIONAL_REGISTRY_CONTRACTS" env-description:"Addresses of registry contracts whose events are processed along with the network's registry contract, each optionally followed by the version of its ABI (e.g. 0x...:v2), defaulting to ETH1AbiVersion"`
+
+	// SyncOffset is the block from which registry events are synced on a fresh database,
+	// the network's registry contract deploy block by default. Optional.
+	SyncOffset string `yaml:"ETH1SyncOffset" env:"ETH_1_SYNC_OFFSET" env-description:"Block to sync registry events from on a fresh database, defaults to the network's registry contract deploy block"`
+
+	// AbiVersion is the version of the registry contract's ABI whose registered decoder parses the contract's events.
+	AbiVersion string `yaml:"ETH1AbiVersion" env:"ETH_1_ABI_VERSION" env-default:"v1" env-description:"Version of the registry contract's ABI to decode its events with"`
+}
+
+// RegistryContract is an additional registry contract and the version of the ABI to decode its events with.
+type RegistryContract struct {
+	Address    ethcommon.Address
+	AbiVersion string
+}
+
+// ParseAdditionalRegistryContracts parses AdditionalRegistryContracts, each given as an address optionally followed
+// by the version of the contract's ABI after a colon. Contracts without an ABI version are decoded with AbiVersion.
+func (o ExecutionOptions) ParseAdditionalRegistryContracts() ([]RegistryContract, error) {
+	var contracts []RegistryContract
+	for _, spec := range o.AdditionalRegistryContracts {
+		addr, abiVersion, found := strings.Cut(spec, ":")
+		if !found {
+			abiVersion = o.AbiVersion
+		}
+		if !ethcommon.IsHexAddress(addr) {
+			return nil, fmt.Errorf("invalid registry contract address %q", addr)
+		}
+		if abiVersion == "" {
+			return nil, fmt.Errorf("missing ABI version of registry contract %s", addr)
+		}
+		contracts = append(contracts, RegistryContract{
+			Address:    ethcommon.HexToAddress(addr),
+			AbiVersion: abiVersion,
+		})
+	}
+	return contracts, nil
 }
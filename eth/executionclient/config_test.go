@@ -0,0 +1,32 @@
+package executionclient
+
+import (
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAdditionalRegistryContracts(t *testing.T) {
+	opts := ExecutionOptions{
+		AbiVersion: "v1",
+		AdditionalRegistryContracts: []string{
+			"0x0000000000000000000000000000000000000001",
+			"0x0000000000000000000000000000000000000002:v2",
+		},
+	}
+	contracts, err := opts.ParseAdditionalRegistryContracts()
+	require.NoError(t, err)
+	require.Equal(t, []RegistryContract{
+		{Address: ethcommon.HexToAddress("0x01"), AbiVersion: "v1"},
+		{Address: ethcommon.HexToAddress("0x02"), AbiVersion: "v2"},
+	}, contracts)
+
+	opts.AdditionalRegistryContracts = []string{"0x01:v2"}
+	_, err = opts.ParseAdditionalRegistryContracts()
+	require.ErrorContains(t, err, "invalid registry contract address")
+
+	opts.AdditionalRegistryContracts = []string{"0x0000000000000000000000000000000000000001:"}
+	_, err = opts.ParseAdditionalRegistryContracts()
+	require.ErrorContains(t, err, "missing ABI version")
+}
@@ -33,6 +33,7 @@ type ExecutionClient struct {
 	contractAddress ethcommon.Address
 
 	// optional
+	additionalContracts         []ethcommon.Address // bridged during contract migrations
 	logger                      *zap.Logger
 	metrics                     metrics
 	followDistance              uint64 // TODO: consider reading the finalized checkpoint from consensus layer
@@ -117,7 +118,7 @@ func (ec *ExecutionClient) fetchLogsInBatches(ctx context.Context, startBlock, e
 
 			start := time.Now()
 			results, err := ec.client.FilterLogs(ctx, ethereum.FilterQuery{
-				Addresses: []ethcommon.Address{ec.contractAddress},
+				Addresses: ec.contractAddresses(),
 				FromBlock: new(big.Int).SetUint64(fromBlock),
 				ToBlock:   new(big.Int).SetUint64(toBlock),
 			})
@@ -157,6 +158,16 @@ func (ec *ExecutionClient) fetchLogsInBatches(ctx context.Context, startBlock, e
 					}
 					validLogs = append(validLogs, log)
 				}
+				if err := VerifyLogs(validLogs, fromBlock, toBlock); err != nil {
+					// Rather than process partial data, fail so that the blocks are fetched again.
+					ec.metrics.ExecutionClientLogsGap()
+					ec.logger.Error("fetched inconsistent registry events",
+						fields.FromBlock(fromBlock),
+						fields.ToBlock(toBlock),
+						zap.Error(err))
+					errors <- fmt.Errorf("blocks %d-%d: %w", fromBlock, toBlock, err)
+					return
+				}
 				if len(validLogs) == 0 {
 					// Emit empty block logs to indicate that we have advanced to this block.
 					logs <- BlockLogs{BlockNumber: toBlock}
@@ -352,6 +363,13 @@ func (ec *ExecutionClient) reconnect(ctx context.Context) {
 	logger.Info("reconnected to execution client", zap.Duration("took", time.Since(start)))
 }
 
+// contractAddresses returns the addresses of the registry contracts whose logs are fetched.
+func (ec *ExecutionClient) contractAddresses() []ethcommon.Address {
+	return append([]ethcommon.Address{ec.contractAddress}, ec.additionalContracts...)
+}
+
+// Filterer returns a filterer of the registry contract's events. Since it decodes logs regardless of
+// the contract which emitted them, it decodes the logs of the additional contracts as well.
 func (ec *ExecutionClient) Filterer() (*contract.ContractFilterer, error) {
 	return contract.NewContractFilterer(ec.contractAddress, ec.client)
 }
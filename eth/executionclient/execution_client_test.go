@@ -117,6 +117,66 @@ func TestFetchHistoricalLogs(t *testing.T) {
 	require.NoError(t, sim.Close())
 }
 
+func TestFetchHistoricalLogsAdditionalContracts(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	const testTimeout = 1 * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	sim := simTestBackend(testAddr)
+
+	rpcServer, _ := sim.Node.RPCHandler()
+	httpsrv := httptest.NewServer(rpcServer.WebsocketHandler([]string{"*"}))
+	defer rpcServer.Stop()
+	defer httpsrv.Close()
+	addr := httpToWebSocketURL(httpsrv.URL)
+
+	// Deploy an old and a new contract, both emitting events.
+	parsed, _ := abi.JSON(strings.NewReader(callableAbi))
+	auth, _ := bind.NewKeyedTransactorWithChainID(testKey, big.NewInt(1337))
+	oldContractAddr, _, oldContract, err := bind.DeployContract(auth, parsed, ethcommon.FromHex(callableBin), sim)
+	require.NoError(t, err)
+	sim.Commit()
+	newContractAddr, _, newContract, err := bind.DeployContract(auth, parsed, ethcommon.FromHex(callableBin), sim)
+	require.NoError(t, err)
+	sim.Commit()
+
+	client, err := New(ctx, addr, newContractAddr,
+		WithAdditionalContracts(oldContractAddr),
+		WithLogger(logger),
+		WithFollowDistance(0),
+	)
+	require.NoError(t, err)
+
+	const blocks = 5
+	for i := 0; i < blocks; i++ {
+		_, err := oldContract.Transact(auth, "Call")
+		require.NoError(t, err)
+		_, err = newContract.Transact(auth, "Call")
+		require.NoError(t, err)
+		sim.Commit()
+	}
+
+	logs, fetchErrCh, err := client.FetchHistoricalLogs(ctx, 0)
+	require.NoError(t, err)
+
+	// Logs of both contracts are merged in the order they were emitted, tagged with their contract.
+	seen := map[ethcommon.Address]int{}
+	for block := range logs {
+		for i, log := range block.Logs {
+			seen[log.Address]++
+			if i > 0 {
+				require.Less(t, block.Logs[i-1].Index, log.Index)
+			}
+		}
+	}
+	require.NoError(t, <-fetchErrCh)
+	require.Equal(t, map[ethcommon.Address]int{oldContractAddr: blocks, newContractAddr: blocks}, seen)
+
+	require.NoError(t, client.Close())
+	require.NoError(t, sim.Close())
+}
+
 func TestStreamLogs(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	const testTimeout = 2 * time.Second
@@ -1,11 +1,21 @@
 package executionclient
 
 import (
+	"errors"
+	"fmt"
 	"sort"
 
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
+var (
+	// ErrLogsOutOfOrder is returned when fetched logs aren't in block and log index order, or are duplicated.
+	ErrLogsOutOfOrder = errors.New("logs are out of order")
+	// ErrLogsGap is returned when fetched logs don't cover the requested blocks consistently,
+	// which indicates that the execution client returned partial results.
+	ErrLogsGap = errors.New("logs have a gap")
+)
+
 // BlockLogs holds a block's number and it's logs.
 type BlockLogs struct {
 	BlockNumber uint64
@@ -14,9 +24,13 @@ type BlockLogs struct {
 
 // PackLogs packs logs into []BlockLogs by their block number.
 func PackLogs(logs []ethtypes.Log) []BlockLogs {
-	// Sort the logs by block number.
+	// Sort the logs by block number, and by the order they were emitted within the block,
+	// which interleaves the logs of different contracts.
 	sort.Slice(logs, func(i, j int) bool {
 		if logs[i].BlockNumber == logs[j].BlockNumber {
+			if logs[i].TxIndex == logs[j].TxIndex {
+				return logs[i].Index < logs[j].Index
+			}
 			return logs[i].TxIndex < logs[j].TxIndex
 		}
 		return logs[i].BlockNumber < logs[j].BlockNumber
@@ -37,3 +51,34 @@ func PackLogs(logs []ethtypes.Log) []BlockLogs {
 
 	return all
 }
+
+// VerifyLogs verifies that logs fetched for the blocks from fromBlock to toBlock are as eth_getLogs
+// orders them: by block number and then by log index, which increases with the transaction index.
+// Since the logs are filtered by contract, log indices within a block aren't necessarily contiguous,
+// so gaps are detected by logs of blocks outside the requested range or of different block hashes.
+func VerifyLogs(logs []ethtypes.Log, fromBlock, toBlock uint64) error {
+	for i, log := range logs {
+		if log.BlockNumber < fromBlock || log.BlockNumber > toBlock {
+			return fmt.Errorf("%w: log of block %d is outside of the requested blocks %d-%d", ErrLogsGap, log.BlockNumber, fromBlock, toBlock)
+		}
+		if i == 0 {
+			continue
+		}
+
+		prev := logs[i-1]
+		if log.BlockNumber < prev.BlockNumber {
+			return fmt.Errorf("%w: log of block %d follows block %d", ErrLogsOutOfOrder, log.BlockNumber, prev.BlockNumber)
+		}
+		if log.BlockNumber > prev.BlockNumber {
+			continue
+		}
+		if log.BlockHash != prev.BlockHash {
+			return fmt.Errorf("%w: logs of block %d have different block hashes %s and %s", ErrLogsGap, log.BlockNumber, prev.BlockHash.Hex(), log.BlockHash.Hex())
+		}
+		if log.Index <= prev.Index || log.TxIndex < prev.TxIndex {
+			return fmt.Errorf("%w: log index %d (tx index %d) follows log index %d (tx index %d) in block %d",
+				ErrLogsOutOfOrder, log.Index, log.TxIndex, prev.Index, prev.TxIndex, log.BlockNumber)
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package executionclient
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -82,4 +83,67 @@ func TestPackLogs(t *testing.T) {
 	assert.Len(t, result[0].Logs, 2)
 	assert.Equal(t, uint(0), result[0].Logs[0].TxIndex) // should be sorted
 	assert.Equal(t, uint(1), result[0].Logs[1].TxIndex)
+
+	// Logs of different contracts within a transaction, interleaved by their index
+	logs = []types.Log{
+		{BlockNumber: 1, TxIndex: 0, Index: 2, Address: common.Address{2}},
+		{BlockNumber: 1, TxIndex: 0, Index: 1, Address: common.Address{1}},
+		{BlockNumber: 1, TxIndex: 0, Index: 3, Address: common.Address{1}},
+	}
+	result = PackLogs(logs)
+	assert.Len(t, result, 1)
+	assert.Len(t, result[0].Logs, 3)
+	assert.Equal(t, common.Address{1}, result[0].Logs[0].Address)
+	assert.Equal(t, common.Address{2}, result[0].Logs[1].Address)
+	assert.Equal(t, common.Address{1}, result[0].Logs[2].Address)
+}
+
+func TestVerifyLogs(t *testing.T) {
+	hash := common.HexToHash("0x1")
+
+	// Logs in block and log index order are valid, with gaps between the log indices of the contract.
+	logs := []types.Log{
+		{BlockNumber: 1, BlockHash: hash, TxIndex: 0, Index: 0},
+		{BlockNumber: 1, BlockHash: hash, TxIndex: 2, Index: 5},
+		{BlockNumber: 3, TxIndex: 0, Index: 1},
+	}
+	assert.NoError(t, VerifyLogs(logs, 1, 3))
+	assert.NoError(t, VerifyLogs(nil, 1, 3))
+
+	// Logs outside of the requested blocks.
+	assert.ErrorIs(t, VerifyLogs(logs, 2, 3), ErrLogsGap)
+	assert.ErrorIs(t, VerifyLogs(logs, 1, 2), ErrLogsGap)
+
+	// Logs of the same block from different block hashes.
+	logs = []types.Log{
+		{BlockNumber: 1, BlockHash: hash, Index: 0},
+		{BlockNumber: 1, BlockHash: common.HexToHash("0x2"), Index: 1},
+	}
+	assert.ErrorIs(t, VerifyLogs(logs, 1, 1), ErrLogsGap)
+
+	// Blocks out of order.
+	logs = []types.Log{
+		{BlockNumber: 2, Index: 0},
+		{BlockNumber: 1, Index: 1},
+	}
+	assert.ErrorIs(t, VerifyLogs(logs, 1, 2), ErrLogsOutOfOrder)
+
+	// Log indices out of order, or duplicated.
+	logs = []types.Log{
+		{BlockNumber: 1, Index: 1},
+		{BlockNumber: 1, Index: 0},
+	}
+	assert.ErrorIs(t, VerifyLogs(logs, 1, 1), ErrLogsOutOfOrder)
+	logs = []types.Log{
+		{BlockNumber: 1, Index: 1},
+		{BlockNumber: 1, Index: 1},
+	}
+	assert.ErrorIs(t, VerifyLogs(logs, 1, 1), ErrLogsOutOfOrder)
+
+	// Log indices which don't increase with the transaction index.
+	logs = []types.Log{
+		{BlockNumber: 1, TxIndex: 1, Index: 0},
+		{BlockNumber: 1, TxIndex: 0, Index: 1},
+	}
+	assert.ErrorIs(t, VerifyLogs(logs, 1, 1), ErrLogsOutOfOrder)
 }
@@ -5,6 +5,7 @@ type metrics interface {
 	ExecutionClientSyncing()
 	ExecutionClientFailure()
 	ExecutionClientLastFetchedBlock(block uint64)
+	ExecutionClientLogsGap()
 }
 
 // nopMetrics is no-op metrics.
@@ -14,3 +15,4 @@ func (nopMetrics) ExecutionClientReady()                    {}
 func (nopMetrics) ExecutionClientSyncing()                  {}
 func (nopMetrics) ExecutionClientFailure()                  {}
 func (nopMetrics) ExecutionClientLastFetchedBlock(_ uint64) {}
+func (nopMetrics) ExecutionClientLogsGap()                  {}
@@ -3,6 +3,7 @@ package executionclient
 import (
 	"time"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"go.uber.org/zap"
 )
 
@@ -52,6 +53,16 @@ func WithReconnectionMaxInterval(interval time.Duration) Option {
 	}
 }
 
+// WithAdditionalContracts fetches the logs of the given registry contracts as well, merged with the
+// logs of the main contract in the order they were emitted. Each log is tagged with the contract
+// which emitted it by its Address. It's meant to bridge contract migrations, during which
+// events may be emitted by both the old and the new contract.
+func WithAdditionalContracts(addresses ...ethcommon.Address) Option {
+	return func(s *ExecutionClient) {
+		s.additionalContracts = append(s.additionalContracts, addresses...)
+	}
+}
+
 // WithLogBatchSize sets log batch size.
 func WithLogBatchSize(size uint64) Option {
 	return func(s *ExecutionClient) {
@@ -1,7 +1,6 @@
 package api
 
 import (
-	"encoding/json"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -19,6 +18,7 @@ type Broadcaster interface {
 
 type broadcasted interface {
 	ID() string
+	Encoding() Encoding
 	Send([]byte)
 }
 
@@ -56,13 +56,9 @@ func (b *broadcaster) FromFeed(logger *zap.Logger, msgFeed *event.Feed) error {
 	}
 }
 
-// Broadcast broadcasts a message to all available connections
+// Broadcast broadcasts a message to all available connections,
+// encoding it once for each of the encodings the connections negotiated
 func (b *broadcaster) Broadcast(msg Message) error {
-	data, err := json.Marshal(&msg)
-	if err != nil {
-		return errors.Wrap(err, "could not marshal msg")
-	}
-
 	// lock is applied only when reading from the connections map
 	// therefore a new temp slice is created to hold all current connections and avoid concurrency issues
 	b.mut.Lock()
@@ -72,7 +68,17 @@ func (b *broadcaster) Broadcast(msg Message) error {
 	}
 	b.mut.Unlock()
 	// send to all connections
+	encoded := make(map[Encoding][]byte)
 	for _, c := range conns {
+		data, ok := encoded[c.Encoding()]
+		if !ok {
+			var err error
+			data, err = EncodeMessage(&msg, c.Encoding())
+			if err != nil {
+				return errors.Wrap(err, "could not marshal msg")
+			}
+			encoded[c.Encoding()] = data
+		}
 		c.Send(data)
 	}
 
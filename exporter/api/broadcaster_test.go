@@ -13,7 +13,7 @@ import (
 )
 
 func TestConn_Send_FullQueue(t *testing.T) {
-	c := newConn(context.Background(), nil, "test", 0, false)
+	c := newConn(context.Background(), nil, "test", 0, EncodingJSON, false)
 
 	for i := 0; i < chanSize+2; i++ {
 		c.Send([]byte(fmt.Sprintf("test-%d", i)))
@@ -69,6 +69,10 @@ func (b *broadcastedMock) ID() string {
 	return b.id
 }
 
+func (b *broadcastedMock) Encoding() Encoding {
+	return EncodingJSON
+}
+
 func (b *broadcastedMock) Send(msg []byte) {
 	b.mut.Lock()
 	defer b.mut.Unlock()
@@ -14,6 +14,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// chanSize is the capacity of a connection's read and send queues.
+const chanSize = 256
+
 var (
 	// pingTimeout time allowed to read the next pong message from the peer.
 	pingTimeout = 60 * time.Second
@@ -24,8 +27,6 @@ var (
 	// maxMessageSize max msg size allowed from peer.
 	maxMessageSize = int64(1024)
 
-	chanSize = 256
-
 	newline = []byte{'\n'}
 	space   = []byte{' '}
 )
@@ -41,6 +42,7 @@ var upgrader = websocket.Upgrader{
 // Conn is a wrapper interface for websocket connections
 type Conn interface {
 	ID() string
+	Encoding() Encoding
 	ReadNext() []byte
 	Send(msg []byte)
 	WriteLoop(logger *zap.Logger)
@@ -55,6 +57,7 @@ type conn struct {
 	ws  *websocket.Conn
 
 	writeTimeout time.Duration
+	encoding     Encoding
 
 	read chan []byte
 	send chan []byte
@@ -64,12 +67,13 @@ type conn struct {
 	withPing bool
 }
 
-func newConn(ctx context.Context, ws *websocket.Conn, id string, writeTimeout time.Duration, withPing bool) Conn {
+func newConn(ctx context.Context, ws *websocket.Conn, id string, writeTimeout time.Duration, encoding Encoding, withPing bool) Conn {
 	return &conn{
 		ctx:          ctx,
 		id:           id,
 		ws:           ws,
 		writeTimeout: writeTimeout,
+		encoding:     encoding,
 		read:         make(chan []byte, chanSize),
 		send:         make(chan []byte, chanSize),
 		writeLock:    &sync.Mutex{},
@@ -82,6 +86,11 @@ func (c *conn) ID() string {
 	return c.id
 }
 
+// Encoding returns the encoding of messages sent on the connection
+func (c *conn) Encoding() Encoding {
+	return c.encoding
+}
+
 // RemoteAddr returns the remote address of the socket
 func (c *conn) RemoteAddr() net.Addr {
 	return c.ws.RemoteAddr()
@@ -210,7 +219,11 @@ func (c *conn) pingLoop(ctx context.Context, logger *zap.Logger) {
 // sendMsg sends the given message and returns the number of bytes that were written, plus the error
 func (c *conn) sendMsg(msg []byte) (int, error) {
 	_ = c.ws.SetWriteDeadline(time.Now().Add(pingTimeout))
-	w, err := c.ws.NextWriter(websocket.TextMessage)
+	messageType := websocket.TextMessage
+	if c.encoding == EncodingProtobuf {
+		messageType = websocket.BinaryMessage
+	}
+	w, err := c.ws.NextWriter(messageType)
 	if err != nil {
 		return 0, errors.Wrap(err, "could not create ws writer")
 	}
@@ -229,6 +242,10 @@ func (c *conn) logMsg(logger *zap.Logger, message []byte, byteWritten int) {
 	if byteWritten == 0 {
 		return
 	}
+	if c.encoding == EncodingProtobuf {
+		logger.Debug("ws msg was sent", zap.Int("bytes", byteWritten), zap.String("encoding", string(c.encoding)))
+		return
+	}
 	j := make(map[string]json.RawMessage)
 	if err := json.Unmarshal(message, &j); err != nil {
 		logger.Error("could not parse msg", zap.Error(err))
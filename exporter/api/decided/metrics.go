@@ -0,0 +1,11 @@
+package decided
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var metricStreamDeduped = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "ssv:exporter:stream_deduped",
+	Help: "count the decided messages dropped from the stream as duplicates of the current slot",
+}, []string{"role"})
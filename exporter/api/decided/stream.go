@@ -3,33 +3,205 @@ package decided
 import (
 	"encoding/hex"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
-	"github.com/patrickmn/go-cache"
+	"github.com/prysmaticlabs/prysm/v4/async/event"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	"github.com/bloxapp/ssv/exporter/api"
 	"github.com/bloxapp/ssv/logging/fields"
+	operatordatastore "github.com/bloxapp/ssv/operator/datastore"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v2/qbft/controller"
 )
 
+// maxDedupEntries bounds the memory of the dedup scope of a single slot.
+const maxDedupEntries = 10000
+
+// dedupLogSampling bounds the debug logs of deduplicated messages to the first dedupLogFirst
+// per dedupLogTick, and every dedupLogThereafter-th thereafter.
+const (
+	dedupLogTick       = time.Second
+	dedupLogFirst      = 10
+	dedupLogThereafter = 100
+)
+
+// dedupResult is the outcome of deduplicating a decided message.
+type dedupResult int
+
+const (
+	dedupNew dedupResult = iota
+	dedupDuplicate
+)
+
+func (r dedupResult) String() string {
+	switch r {
+	case dedupNew:
+		return "new"
+	case dedupDuplicate:
+		return "duplicate"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(r))
+	}
+}
+
+// StreamPublisher forwards newly decided messages to the websocket stream.
+type StreamPublisher struct {
+	logger        *zap.Logger
+	dedupLogger   *zap.Logger
+	beaconNetwork beacon.BeaconNetwork
+	feed          *event.Feed
+	dedup         *slotDedup
+	recent        *recentMessages
+	operatorData  operatordatastore.OperatorDataStore
+}
+
 // NewStreamPublisher handles incoming newly decided messages.
-// it forward messages to websocket stream, where messages are cached (1m TTL) to avoid flooding
-func NewStreamPublisher(logger *zap.Logger, ws api.WebSocketServer) controller.NewDecidedHandler {
-	c := cache.New(time.Minute, time.Minute*3/2)
-	feed := ws.BroadcastFeed()
+// it forward messages to websocket stream, where messages are deduplicated within the current slot to avoid flooding.
+// the last replaySize messages are kept in memory and replayed to newly connected stream clients.
+func NewStreamPublisher(logger *zap.Logger, ws api.WebSocketServer, beaconNetwork beacon.BeaconNetwork, replaySize int) *StreamPublisher {
+	p := &StreamPublisher{
+		logger: logger,
+		dedupLogger: logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewSamplerWithOptions(core, dedupLogTick, dedupLogFirst, dedupLogThereafter)
+		})),
+		beaconNetwork: beaconNetwork,
+		feed:          ws.BroadcastFeed(),
+		dedup:         newSlotDedup(maxDedupEntries),
+	}
+	if replaySize > api.MaxStreamReplaySize {
+		// replayed messages beyond the connection's send buffer would be dropped
+		logger.Warn("capping the stream replay size", zap.Int("replay_size", replaySize), zap.Int("max", api.MaxStreamReplaySize))
+		replaySize = api.MaxStreamReplaySize
+	}
+	if replaySize > 0 {
+		p.recent = newRecentMessages(replaySize)
+		ws.UseStreamReplay(p.recent.List)
+	}
+	return p
+}
+
+// UseOperatorDataStore attributes the published messages to the local operator, once its ID is known.
+func (p *StreamPublisher) UseOperatorDataStore(ods operatordatastore.OperatorDataStore) {
+	p.operatorData = ods
+}
+
+// Handler returns the handler of live decided messages, which are deduplicated.
+func (p *StreamPublisher) Handler() controller.NewDecidedHandler {
 	return func(msg *specqbft.SignedMessage) {
-		identifier := hex.EncodeToString(msg.Message.Identifier)
-		key := fmt.Sprintf("%s:%d:%d", identifier, msg.Message.Height, len(msg.Signers))
-		_, ok := c.Get(key)
-		if ok {
-			return
+		p.publish(msg)
+	}
+}
+
+// publish forwards the decided message to the stream, unless it's a duplicate of the current slot.
+func (p *StreamPublisher) publish(msg *specqbft.SignedMessage) {
+	identifier := hex.EncodeToString(msg.Message.Identifier)
+	key := fmt.Sprintf("%s:%d:%d", identifier, msg.Message.Height, len(msg.Signers))
+	slot := p.beaconNetwork.EstimatedCurrentSlot()
+	if !p.dedup.Add(slot, key) {
+		role := specqbft.ControllerIdToMessageID(msg.Message.Identifier).GetRoleType()
+		metricStreamDeduped.WithLabelValues(role.String()).Inc()
+		// Check avoids building the fields when debug logs are disabled.
+		if ce := p.dedupLogger.Check(zap.DebugLevel, "deduplicated decided stream message"); ce != nil {
+			ce.Write(
+				zap.String("identifier", identifier),
+				fields.Role(role),
+				fields.Height(msg.Message.Height),
+				zap.Int("signers", len(msg.Signers)),
+				fields.Slot(slot),
+				zap.Stringer("dedup", dedupDuplicate),
+			)
 		}
-		c.SetDefault(key, true)
+		return
+	}
+
+	p.logger.Debug("broadcast decided stream",
+		zap.String("identifier", identifier),
+		fields.Height(msg.Message.Height),
+		zap.Stringer("dedup", dedupNew),
+	)
 
-		logger.Debug("broadcast decided stream", zap.String("identifier", identifier), fields.Height(msg.Message.Height))
+	apiMsg := api.NewDecidedAPIMsg(msg)
+	if p.operatorData != nil && p.operatorData.OperatorIDReady() {
+		apiMsg.OperatorID = p.operatorData.GetOperatorID()
+	}
+	if p.recent != nil {
+		p.recent.Add(apiMsg)
+	}
+	p.feed.Send(apiMsg)
+}
+
+// recentMessages is a fixed size ring buffer of the most recent messages
+type recentMessages struct {
+	mu   sync.Mutex
+	msgs []api.Message
+	next int
+	full bool
+}
+
+func newRecentMessages(size int) *recentMessages {
+	return &recentMessages{
+		msgs: make([]api.Message, size),
+	}
+}
+
+// Add adds the given message, overwriting the oldest message if the buffer is full
+func (r *recentMessages) Add(msg api.Message) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.msgs[r.next] = msg
+	r.next = (r.next + 1) % len(r.msgs)
+	if r.next == 0 {
+		r.full = true
+	}
+}
 
-		feed.Send(api.NewDecidedAPIMsg(msg))
+// List returns the buffered messages, from oldest to newest
+func (r *recentMessages) List() []api.Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.full {
+		return append([]api.Message(nil), r.msgs[:r.next]...)
+	}
+	list := make([]api.Message, 0, len(r.msgs))
+	list = append(list, r.msgs[r.next:]...)
+	return append(list, r.msgs[:r.next]...)
+}
+
+// slotDedup tracks the keys seen within the current slot,
+// starting with a clean scope at each slot boundary.
+type slotDedup struct {
+	mu         sync.Mutex
+	slot       phase0.Slot
+	seen       map[string]struct{}
+	maxEntries int
+}
+
+func newSlotDedup(maxEntries int) *slotDedup {
+	return &slotDedup{
+		seen:       make(map[string]struct{}),
+		maxEntries: maxEntries,
+	}
+}
+
+// Add returns false if the key was already seen in the given slot.
+func (d *slotDedup) Add(slot phase0.Slot, key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if slot != d.slot || len(d.seen) >= d.maxEntries {
+		d.slot = slot
+		d.seen = make(map[string]struct{})
+	}
+	if _, ok := d.seen[key]; ok {
+		return false
 	}
+	d.seen[key] = struct{}{}
+	return true
 }
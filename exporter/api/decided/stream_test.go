@@ -0,0 +1,133 @@
+package decided
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prysmaticlabs/prysm/v4/async/event"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/bloxapp/ssv/exporter/api"
+	"github.com/bloxapp/ssv/networkconfig"
+	operatordatastore "github.com/bloxapp/ssv/operator/datastore"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+)
+
+func TestRecentMessages(t *testing.T) {
+	newMsg := func(height uint64) api.Message {
+		return api.Message{Type: api.TypeDecided, Filter: api.MessageFilter{From: height, To: height}}
+	}
+	heights := func(msgs []api.Message) []uint64 {
+		var res []uint64
+		for _, msg := range msgs {
+			res = append(res, msg.Filter.From)
+		}
+		return res
+	}
+
+	recent := newRecentMessages(3)
+	require.Empty(t, recent.List())
+
+	recent.Add(newMsg(1))
+	recent.Add(newMsg(2))
+	require.Equal(t, []uint64{1, 2}, heights(recent.List()))
+
+	recent.Add(newMsg(3))
+	require.Equal(t, []uint64{1, 2, 3}, heights(recent.List()))
+
+	recent.Add(newMsg(4))
+	recent.Add(newMsg(5))
+	require.Equal(t, []uint64{3, 4, 5}, heights(recent.List()))
+}
+
+func TestSlotDedup(t *testing.T) {
+	dedup := newSlotDedup(3)
+
+	require.True(t, dedup.Add(phase0.Slot(1), "a"))
+	require.False(t, dedup.Add(phase0.Slot(1), "a"))
+	require.True(t, dedup.Add(phase0.Slot(1), "b"))
+
+	// a new slot starts with a clean scope
+	require.True(t, dedup.Add(phase0.Slot(2), "a"))
+	require.False(t, dedup.Add(phase0.Slot(2), "a"))
+	require.True(t, dedup.Add(phase0.Slot(2), "b"))
+	require.True(t, dedup.Add(phase0.Slot(2), "c"))
+
+	// exceeding the cap resets the scope
+	require.True(t, dedup.Add(phase0.Slot(2), "d"))
+	require.True(t, dedup.Add(phase0.Slot(2), "a"))
+}
+
+func TestStreamPublisherDedup(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	ws := &streamServer{feed: new(event.Feed)}
+	publish := NewStreamPublisher(zap.New(core), ws, beacon.NewNetwork(spectypes.MainNetwork), 0).Handler()
+
+	identifier := spectypes.NewMsgID(networkconfig.TestNetwork.Domain, []byte("pk"), spectypes.BNRoleAttester)
+	msg := &specqbft.SignedMessage{
+		Signers: []spectypes.OperatorID{1, 2, 3},
+		Message: specqbft.Message{Identifier: identifier[:], Height: 1},
+	}
+	deduped := metricStreamDeduped.WithLabelValues(spectypes.BNRoleAttester.String())
+	before := testutil.ToFloat64(deduped)
+
+	publish(msg)
+	require.Zero(t, logs.FilterMessage("deduplicated decided stream message").Len())
+
+	publish(msg)
+	require.Equal(t, before+1, testutil.ToFloat64(deduped))
+	dedupLogs := logs.FilterMessage("deduplicated decided stream message").All()
+	require.Len(t, dedupLogs, 1)
+	require.EqualValues(t, 3, dedupLogs[0].ContextMap()["signers"])
+
+	// Messages with more signers aren't duplicates.
+	msg.Signers = append(msg.Signers, 4)
+	publish(msg)
+	require.Equal(t, before+1, testutil.ToFloat64(deduped))
+}
+
+func TestStreamPublisherOperatorID(t *testing.T) {
+	ws := &streamServer{feed: new(event.Feed)}
+	publisher := NewStreamPublisher(zap.NewNop(), ws, beacon.NewNetwork(spectypes.MainNetwork), 0)
+	received := make(chan api.Message, 10)
+	sub := ws.feed.Subscribe(received)
+	defer sub.Unsubscribe()
+
+	identifier := spectypes.NewMsgID(networkconfig.TestNetwork.Domain, []byte("pk"), spectypes.BNRoleAttester)
+	msg := &specqbft.SignedMessage{
+		Signers: []spectypes.OperatorID{1, 2, 3},
+		Message: specqbft.Message{Identifier: identifier[:], Height: 1},
+	}
+
+	// Messages aren't attributed without an operator data store.
+	publisher.Handler()(msg)
+	require.Zero(t, (<-received).OperatorID)
+
+	// Nor until the operator is registered.
+	ods := operatordatastore.New(&registrystorage.OperatorData{})
+	publisher.UseOperatorDataStore(ods)
+	msg.Message.Height = 2
+	publisher.Handler()(msg)
+	require.Zero(t, (<-received).OperatorID)
+
+	ods.SetOperatorData(&registrystorage.OperatorData{ID: 5})
+	msg.Message.Height = 3
+	publisher.Handler()(msg)
+	require.Equal(t, spectypes.OperatorID(5), (<-received).OperatorID)
+}
+
+type streamServer struct {
+	api.WebSocketServer
+	feed *event.Feed
+}
+
+func (s *streamServer) BroadcastFeed() *event.Feed {
+	return s.feed
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Encoding is the wire encoding of stream messages
+type Encoding string
+
+const (
+	// EncodingJSON encodes messages as JSON text frames, the default
+	EncodingJSON Encoding = "json"
+	// EncodingProtobuf encodes messages as protobuf binary frames, following stream.proto
+	EncodingProtobuf Encoding = "protobuf"
+)
+
+const (
+	contentTypeJSON     = "application/json"
+	contentTypeProtobuf = "application/x-protobuf"
+)
+
+// StreamSchemaVersion is the version of the protobuf schema of stream messages (stream.proto),
+// which is bumped on any incompatible change
+const StreamSchemaVersion = 1
+
+// encodingQueryParam is the query parameter a client may request an encoding with,
+// for clients which can't set the Accept header of the websocket handshake
+const encodingQueryParam = "encoding"
+
+// negotiateEncoding returns the encoding requested by the client, either with the encoding query parameter
+// or with the Accept header, defaulting to JSON. Only an unknown encoding in the query parameter is an error,
+// as the Accept header may list any media types.
+func negotiateEncoding(r *http.Request) (Encoding, error) {
+	if param := r.URL.Query().Get(encodingQueryParam); param != "" {
+		switch enc := Encoding(param); enc {
+		case EncodingJSON, EncodingProtobuf:
+			return enc, nil
+		default:
+			return "", fmt.Errorf("unsupported encoding %q", param)
+		}
+	}
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case contentTypeJSON:
+			return EncodingJSON, nil
+		case contentTypeProtobuf, "application/protobuf":
+			return EncodingProtobuf, nil
+		}
+	}
+	return EncodingJSON, nil
+}
+
+// EncodeMessage encodes the given message with the given encoding
+func EncodeMessage(msg *Message, enc Encoding) ([]byte, error) {
+	switch enc {
+	case EncodingJSON, "":
+		return json.Marshal(msg)
+	case EncodingProtobuf:
+		return marshalProtobuf(msg)
+	default:
+		return nil, fmt.Errorf("unsupported encoding %q", enc)
+	}
+}
+
+// marshalProtobuf encodes the given message as a StreamMessage of stream.proto
+func marshalProtobuf(msg *Message) ([]byte, error) {
+	var b []byte
+	b = appendVarintField(b, 1, StreamSchemaVersion)
+	b = appendBytesField(b, 2, []byte(msg.Type))
+
+	var filter []byte
+	filter = appendVarintField(filter, 1, msg.Filter.From)
+	filter = appendVarintField(filter, 2, msg.Filter.To)
+	filter = appendBytesField(filter, 3, []byte(msg.Filter.Role))
+	filter = appendBytesField(filter, 4, []byte(msg.Filter.PublicKey))
+	b = appendMessageField(b, 3, filter)
+
+	switch data := msg.Data.(type) {
+	case nil:
+	case []*SignedMessageAPI:
+		for _, signed := range data {
+			encoded, err := marshalSignedMessageProtobuf(signed)
+			if err != nil {
+				return nil, err
+			}
+			b = appendMessageField(b, 4, encoded)
+		}
+	default:
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not marshal data")
+		}
+		b = appendBytesField(b, 5, encoded)
+	}
+	b = appendVarintField(b, 6, uint64(msg.OperatorID))
+	return b, nil
+}
+
+func marshalSignedMessageProtobuf(signed *SignedMessageAPI) ([]byte, error) {
+	var b []byte
+	b = appendBytesField(b, 1, signed.Signature)
+	if len(signed.Signers) > 0 {
+		var signers []byte
+		for _, signer := range signed.Signers {
+			signers = protowire.AppendVarint(signers, uint64(signer))
+		}
+		b = appendMessageField(b, 2, signers)
+	}
+
+	var qbftMsg []byte
+	qbftMsg = appendVarintField(qbftMsg, 1, uint64(signed.Message.MsgType))
+	qbftMsg = appendVarintField(qbftMsg, 2, uint64(signed.Message.Height))
+	qbftMsg = appendVarintField(qbftMsg, 3, uint64(signed.Message.Round))
+	qbftMsg = appendBytesField(qbftMsg, 4, signed.Message.Identifier)
+	qbftMsg = appendBytesField(qbftMsg, 5, signed.Message.Root[:])
+	qbftMsg = appendVarintField(qbftMsg, 6, uint64(signed.Message.DataRound))
+	for _, justification := range signed.Message.RoundChangeJustification {
+		qbftMsg = appendMessageField(qbftMsg, 7, justification)
+	}
+	for _, justification := range signed.Message.PrepareJustification {
+		qbftMsg = appendMessageField(qbftMsg, 8, justification)
+	}
+	b = appendMessageField(b, 3, qbftMsg)
+
+	if signed.FullData != nil {
+		fullData, err := signed.FullData.MarshalSSZ()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not marshal consensus data")
+		}
+		b = appendBytesField(b, 4, fullData)
+	}
+	return b, nil
+}
+
+// appendVarintField appends a varint field, omitting the zero value as proto3 does
+func appendVarintField(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+// appendBytesField appends a bytes or string field, omitting the empty value as proto3 does
+func appendBytesField(b []byte, num protowire.Number, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	return appendMessageField(b, num, v)
+}
+
+// appendMessageField appends a length-delimited field even if it's empty,
+// as embedded messages and elements of repeated fields are
+func appendMessageField(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		accept   string
+		expected Encoding
+		err      bool
+	}{
+		{name: "default", target: "/stream", expected: EncodingJSON},
+		{name: "query param", target: "/stream?encoding=protobuf", expected: EncodingProtobuf},
+		{name: "query param overrides accept", target: "/stream?encoding=json", accept: contentTypeProtobuf, expected: EncodingJSON},
+		{name: "unknown query param", target: "/stream?encoding=xml", err: true},
+		{name: "accept", target: "/stream", accept: "text/html, application/x-protobuf;q=0.9", expected: EncodingProtobuf},
+		{name: "unknown accept", target: "/stream", accept: "text/html", expected: EncodingJSON},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", test.target, nil)
+			if test.accept != "" {
+				r.Header.Set("Accept", test.accept)
+			}
+			enc, err := negotiateEncoding(r)
+			if test.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, enc)
+		})
+	}
+}
+
+func TestEncodeMessageProtobuf(t *testing.T) {
+	identifier := spectypes.NewMsgID(spectypes.GenesisMainnet, []byte("pk"), spectypes.BNRoleAttester)
+	msg := NewDecidedAPIMsg(&specqbft.SignedMessage{
+		Signature: []byte{1, 2, 3},
+		Signers:   []spectypes.OperatorID{1, 2, 300},
+		Message: specqbft.Message{
+			MsgType:              specqbft.CommitMsgType,
+			Height:               5,
+			Round:                1,
+			Identifier:           identifier[:],
+			Root:                 [32]byte{7},
+			PrepareJustification: [][]byte{{}, {9}},
+		},
+	})
+	msg.OperatorID = 4
+
+	encoded, err := EncodeMessage(&msg, EncodingProtobuf)
+	require.NoError(t, err)
+	fields := consumeFields(t, encoded)
+	require.Equal(t, []uint64{StreamSchemaVersion}, varints(fields[1]))
+	require.Equal(t, "decided", string(fields[2][0]))
+	require.Equal(t, []uint64{4}, varints(fields[6]))
+
+	filter := consumeFields(t, fields[3][0])
+	require.Equal(t, []uint64{5}, varints(filter[1]))
+	require.Equal(t, []uint64{5}, varints(filter[2]))
+	require.Equal(t, spectypes.BNRoleAttester.String(), string(filter[3][0]))
+	require.Equal(t, msg.Filter.PublicKey, string(filter[4][0]))
+
+	require.Len(t, fields[4], 1)
+	signed := consumeFields(t, fields[4][0])
+	require.Equal(t, []byte{1, 2, 3}, signed[1][0])
+	var signers []uint64
+	for b := signed[2][0]; len(b) > 0; {
+		v, n := protowire.ConsumeVarint(b)
+		require.Positive(t, n)
+		signers = append(signers, v)
+		b = b[n:]
+	}
+	require.Equal(t, []uint64{1, 2, 300}, signers)
+	require.Empty(t, signed[4])
+
+	qbftMsg := consumeFields(t, signed[3][0])
+	require.Equal(t, []uint64{uint64(specqbft.CommitMsgType)}, varints(qbftMsg[1]))
+	require.Equal(t, []uint64{5}, varints(qbftMsg[2]))
+	require.Equal(t, []uint64{1}, varints(qbftMsg[3]))
+	require.Equal(t, identifier[:], qbftMsg[4][0])
+	require.Equal(t, []byte{7}, qbftMsg[5][0][:1])
+	require.Empty(t, qbftMsg[6])
+	require.Equal(t, [][]byte{{}, {9}}, qbftMsg[8])
+
+	// Data of other message types is carried as JSON.
+	other := newTestMessage()
+	encoded, err = EncodeMessage(&other, EncodingProtobuf)
+	require.NoError(t, err)
+	fields = consumeFields(t, encoded)
+	expected, err := json.Marshal(other.Data)
+	require.NoError(t, err)
+	require.Equal(t, expected, fields[5][0])
+	require.Empty(t, fields[4])
+	require.Empty(t, fields[6])
+}
+
+// consumeFields parses the given protobuf message into the raw values of its fields by number,
+// where varints are kept in their encoded form
+func consumeFields(t *testing.T, b []byte) map[protowire.Number][][]byte {
+	fields := make(map[protowire.Number][][]byte)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.Positive(t, n)
+		b = b[n:]
+		switch typ {
+		case protowire.VarintType:
+			_, n = protowire.ConsumeVarint(b)
+			require.Positive(t, n)
+			fields[num] = append(fields[num], b[:n])
+		case protowire.BytesType:
+			var v []byte
+			v, n = protowire.ConsumeBytes(b)
+			require.Positive(t, n)
+			fields[num] = append(fields[num], v)
+		default:
+			t.Fatalf("unexpected wire type %d", typ)
+		}
+		b = b[n:]
+	}
+	return fields
+}
+
+func varints(values [][]byte) []uint64 {
+	var res []uint64
+	for _, b := range values {
+		v, _ := protowire.ConsumeVarint(b)
+		res = append(res, v)
+	}
+	return res
+}
@@ -14,6 +14,18 @@ var (
 		Name: "ssv:exporter:stream_outbound_errors",
 		Help: "count the outbound messages failures on stream channel",
 	}, []string{"cid"})
+	metricStreamSubscribers = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv:exporter:stream_subscribers",
+		Help: "current number of connections to the stream",
+	})
+	metricStreamSubscribersPeak = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv:exporter:stream_subscribers_peak",
+		Help: "peak number of concurrent connections to the stream",
+	})
+	metricStreamSubscribersRejected = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv:exporter:stream_subscribers_rejected",
+		Help: "count the connections to the stream rejected due to the subscribers limit",
+	})
 )
 
 func reportStreamOutbound(cid string, err error) {
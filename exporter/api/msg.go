@@ -17,6 +17,9 @@ type Message struct {
 	Filter MessageFilter `json:"filter"`
 	// Values holds the results, optional as it's relevant for response
 	Data interface{} `json:"data,omitempty"`
+	// OperatorID is the ID of the operator whose node broadcast the message (as opposed to its signers),
+	// optional as it's relevant for decided stream messages of registered operators
+	OperatorID types.OperatorID `json:"operatorId,omitempty"`
 }
 
 type SignedMessageAPI struct {
@@ -109,4 +112,6 @@ const (
 	TypeDecided MessageType = "decided"
 	// TypeError is an enum for error type messages
 	TypeError MessageType = "error"
+	// TypeHeartbeat is an enum for stream messages sent during quiet periods to keep connections alive
+	TypeHeartbeat MessageType = "heartbeat"
 )
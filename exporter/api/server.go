@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -23,13 +24,23 @@ type WebSocketServer interface {
 	Start(logger *zap.Logger, addr string) error
 	BroadcastFeed() *event.Feed
 	UseQueryHandler(handler QueryMessageHandler)
+	UseStreamReplay(replay StreamReplayFunc)
+	UseMaxStreamSubscribers(max int)
+	UseStreamHeartbeat(interval time.Duration)
 }
 
+// StreamReplayFunc returns recent stream messages to send to newly connected stream clients
+type StreamReplayFunc func() []Message
+
+// MaxStreamReplaySize is the maximum number of replayed messages, as they're all queued on a connection at once
+const MaxStreamReplaySize = chanSize
+
 // wsServer is an implementation of WebSocketServer
 type wsServer struct {
 	ctx context.Context
 
 	handler QueryMessageHandler
+	replay  StreamReplayFunc
 
 	broadcaster Broadcaster
 
@@ -37,6 +48,15 @@ type wsServer struct {
 	// out is a subject for writing messages
 	out      *event.Feed
 	withPing bool
+
+	// maxSubscribers is the maximum number of concurrent stream connections, zero for unlimited
+	maxSubscribers  int
+	subscribersLock sync.Mutex
+	subscribers     int
+	peakSubscribers int
+
+	// heartbeatInterval is the quiet period after which a heartbeat is broadcast to stream connections, zero for none
+	heartbeatInterval time.Duration
 }
 
 // NewWsServer creates a new instance
@@ -56,6 +76,22 @@ func (ws *wsServer) UseQueryHandler(handler QueryMessageHandler) {
 	ws.handler = handler
 }
 
+func (ws *wsServer) UseStreamReplay(replay StreamReplayFunc) {
+	ws.replay = replay
+}
+
+// UseMaxStreamSubscribers limits the number of concurrent stream connections,
+// rejecting new connections beyond the limit. Zero means unlimited.
+func (ws *wsServer) UseMaxStreamSubscribers(max int) {
+	ws.maxSubscribers = max
+}
+
+// UseStreamHeartbeat broadcasts a heartbeat message to stream connections whenever no message was broadcast
+// for the given interval, so that intermediaries don't close idle connections. Zero disables heartbeats.
+func (ws *wsServer) UseStreamHeartbeat(interval time.Duration) {
+	ws.heartbeatInterval = interval
+}
+
 // Start starts the websocket server and the broadcaster
 func (ws *wsServer) Start(logger *zap.Logger, addr string) error {
 	logger = logger.Named(logging.NameWSServer)
@@ -69,6 +105,10 @@ func (ws *wsServer) Start(logger *zap.Logger, addr string) error {
 		}
 	}()
 
+	if ws.heartbeatInterval > 0 {
+		go ws.heartbeatLoop(logger, ws.heartbeatInterval)
+	}
+
 	logger.Info("starting", fields.Address(addr), zap.Strings("endPoints", []string{"/query", "/stream"}))
 
 	const timeout = 3 * time.Second
@@ -92,8 +132,39 @@ func (ws *wsServer) BroadcastFeed() *event.Feed {
 	return ws.out
 }
 
+// heartbeatLoop broadcasts a heartbeat message to stream connections whenever no message
+// was broadcast for the given interval
+func (ws *wsServer) heartbeatLoop(logger *zap.Logger, interval time.Duration) {
+	msgs := make(chan Message, 32)
+	sub := ws.out.Subscribe(msgs)
+	defer sub.Unsubscribe()
+
+	lastSent := time.Now()
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ws.ctx.Done():
+			return
+		case <-sub.Err():
+			return
+		case <-msgs:
+			lastSent = time.Now()
+		case now := <-timer.C:
+			if now.Sub(lastSent) >= interval {
+				// heartbeats bypass the feed, so that they don't reach its other subscribers
+				if err := ws.broadcaster.Broadcast(Message{Type: TypeHeartbeat}); err != nil {
+					logger.Debug("could not broadcast heartbeat", zap.Error(err))
+				}
+				lastSent = now
+			}
+			timer.Reset(interval - now.Sub(lastSent))
+		}
+	}
+}
+
 // RegisterHandler registers an end point
-func (ws *wsServer) RegisterHandler(logger *zap.Logger, endPoint string, handler func(logger *zap.Logger, conn *websocket.Conn)) {
+func (ws *wsServer) RegisterHandler(logger *zap.Logger, endPoint string, handler func(logger *zap.Logger, r *http.Request, conn *websocket.Conn)) {
 	ws.router.HandleFunc(endPoint, func(w http.ResponseWriter, r *http.Request) {
 		conn, err := upgrader.Upgrade(w, r, w.Header())
 		logger := logger.With(zap.String("remote addr", conn.RemoteAddr().String()))
@@ -109,12 +180,12 @@ func (ws *wsServer) RegisterHandler(logger *zap.Logger, endPoint string, handler
 				logger.Error("could not close connection", zap.Error(err))
 			}
 		}()
-		handler(logger, conn)
+		handler(logger, r, conn)
 	})
 }
 
 // handleQuery receives query message and respond async
-func (ws *wsServer) handleQuery(logger *zap.Logger, conn *websocket.Conn) {
+func (ws *wsServer) handleQuery(logger *zap.Logger, _ *http.Request, conn *websocket.Conn) {
 	if ws.handler == nil {
 		return
 	}
@@ -152,23 +223,140 @@ func (ws *wsServer) handleQuery(logger *zap.Logger, conn *websocket.Conn) {
 	}
 }
 
-// handleStream registers the connection for broadcasting of stream messages
-func (ws *wsServer) handleStream(logger *zap.Logger, wsc *websocket.Conn) {
+// handleStream registers the connection for broadcasting of stream messages,
+// encoded with the encoding negotiated by the client
+func (ws *wsServer) handleStream(logger *zap.Logger, r *http.Request, wsc *websocket.Conn) {
 	cid := ConnectionID(wsc)
 	logger = logger.With(fields.ConnectionID(cid))
 	defer logger.Debug("stream handler done")
 
+	encoding, err := negotiateEncoding(r)
+	if err != nil {
+		logger.Warn("rejecting stream connection", zap.Error(err))
+		closeMsg := websocket.FormatCloseMessage(websocket.CloseUnsupportedData, err.Error())
+		if err := wsc.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(sendTimeout)); err != nil {
+			logger.Debug("could not send close message", zap.Error(err))
+		}
+		return
+	}
+	logger = logger.With(zap.String("encoding", string(encoding)))
+
+	if !ws.addSubscriber() {
+		logger.Warn("rejecting stream connection, too many subscribers", zap.Int("max_subscribers", ws.maxSubscribers))
+		closeMsg := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "too many stream subscribers")
+		if err := wsc.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(sendTimeout)); err != nil {
+			logger.Debug("could not send close message", zap.Error(err))
+		}
+		return
+	}
+	defer ws.removeSubscriber()
+
 	ctx, cancel := context.WithCancel(ws.ctx)
-	c := newConn(ctx, wsc, cid, sendTimeout, ws.withPing)
+	c := newConn(ctx, wsc, cid, sendTimeout, encoding, ws.withPing)
 	defer cancel()
 
-	if !ws.broadcaster.Register(c) {
+	// the connection is registered before the replay is taken, so that no live message is missed in between,
+	// but live messages are held back until the replay is queued, so that replayed messages come first
+	rc := newReplayingConn(c)
+	if !ws.broadcaster.Register(rc) {
 		logger.Warn("known connection")
 		return
 	}
-	defer ws.broadcaster.Deregister(c)
+	defer ws.broadcaster.Deregister(rc)
+	ws.replayStream(logger, c)
+	rc.replayed()
 
-	go c.ReadLoop(logger)
+	go func() {
+		c.ReadLoop(logger)
+		// stop writing once the client is gone, so that it doesn't hold a subscriber slot
+		cancel()
+	}()
 
 	c.WriteLoop(logger)
 }
+
+// addSubscriber counts a new stream connection, returning false if the limit is reached
+func (ws *wsServer) addSubscriber() bool {
+	ws.subscribersLock.Lock()
+	defer ws.subscribersLock.Unlock()
+
+	if ws.maxSubscribers > 0 && ws.subscribers >= ws.maxSubscribers {
+		metricStreamSubscribersRejected.Inc()
+		return false
+	}
+	ws.subscribers++
+	if ws.subscribers > ws.peakSubscribers {
+		ws.peakSubscribers = ws.subscribers
+	}
+	metricStreamSubscribers.Set(float64(ws.subscribers))
+	metricStreamSubscribersPeak.Set(float64(ws.peakSubscribers))
+	return true
+}
+
+// removeSubscriber uncounts a closed stream connection
+func (ws *wsServer) removeSubscriber() {
+	ws.subscribersLock.Lock()
+	defer ws.subscribersLock.Unlock()
+
+	ws.subscribers--
+	metricStreamSubscribers.Set(float64(ws.subscribers))
+}
+
+// replayStream sends the recent stream messages to the given connection
+func (ws *wsServer) replayStream(logger *zap.Logger, c Conn) {
+	if ws.replay == nil {
+		return
+	}
+	msgs := ws.replay()
+	for i := range msgs {
+		data, err := EncodeMessage(&msgs[i], c.Encoding())
+		if err != nil {
+			logger.Warn("could not marshal replayed message", zap.Error(err))
+			continue
+		}
+		c.Send(data)
+	}
+	if len(msgs) > 0 {
+		logger.Debug("replayed stream messages", zap.Int("count", len(msgs)))
+	}
+}
+
+// replayingConn holds back the messages broadcasted to a connection until its replay is queued.
+// a message broadcasted while the replay is taken may be sent twice.
+type replayingConn struct {
+	Conn
+
+	mu       sync.Mutex
+	done     bool
+	heldBack [][]byte
+}
+
+func newReplayingConn(c Conn) *replayingConn {
+	return &replayingConn{Conn: c}
+}
+
+// Send sends the given message, or holds it back if the replay isn't queued yet
+func (c *replayingConn) Send(msg []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.done {
+		c.Conn.Send(msg)
+		return
+	}
+	if len(c.heldBack) < chanSize {
+		c.heldBack = append(c.heldBack, msg)
+	}
+}
+
+// replayed sends the held back messages, and any further message right away
+func (c *replayingConn) replayed() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, msg := range c.heldBack {
+		c.Conn.Send(msg)
+	}
+	c.heldBack = nil
+	c.done = true
+}
@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"go.uber.org/zap"
 
 	"github.com/stretchr/testify/require"
@@ -117,6 +118,112 @@ func TestHandleStream(t *testing.T) {
 	}
 }
 
+func TestHandleStreamReplay(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	ctx := context.Background()
+	mux := http.NewServeMux()
+	ws := NewWsServer(ctx, nil, mux, false).(*wsServer)
+	ws.UseStreamReplay(func() []Message {
+		return []Message{newTestMessage(), newTestMessage()}
+	})
+	addr := fmt.Sprintf(":%d", getRandomPort(8001, 14000))
+	go func() {
+		require.NoError(t, ws.Start(logger, addr))
+	}()
+
+	testCtx, cancelCtx := context.WithCancel(ctx)
+	defer cancelCtx()
+	client := NewWSClient(testCtx)
+	go func() {
+		// sleep so setup will be finished
+		time.Sleep(100 * time.Millisecond)
+		require.NoError(t, client.StartStream(logger, addr, "/stream"))
+	}()
+
+	go func() {
+		// sleep so setup will be finished
+		time.Sleep(200 * time.Millisecond)
+		ws.out.Send(newTestMessage())
+	}()
+
+	// 2 replayed messages and 1 live message
+	for {
+		if client.MessageCount() == 3 {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func TestReplayingConn(t *testing.T) {
+	c := newConn(context.Background(), nil, "test", 0, EncodingJSON, false).(*conn)
+	rc := newReplayingConn(c)
+
+	// live messages are held back until the replay is queued
+	rc.Send([]byte("live-1"))
+	require.Empty(t, c.send)
+	c.Send([]byte("replayed"))
+	rc.replayed()
+	rc.Send([]byte("live-2"))
+
+	require.Len(t, c.send, 3)
+	for _, expected := range []string{"replayed", "live-1", "live-2"} {
+		require.Equal(t, expected, string(<-c.send))
+	}
+}
+
+func TestHandleStreamMaxSubscribers(t *testing.T) {
+	// the server outlives the test, so it mustn't log to the test
+	logger := zap.NewNop()
+	ctx := context.Background()
+	mux := http.NewServeMux()
+	ws := NewWsServer(ctx, nil, mux, false).(*wsServer)
+	ws.UseMaxStreamSubscribers(1)
+	addr := fmt.Sprintf("localhost:%d", getRandomPort(8001, 14000))
+	go func() {
+		require.NoError(t, ws.Start(logger, addr))
+	}()
+	streamURL := fmt.Sprintf("ws://%s/stream", addr)
+
+	var first *websocket.Conn
+	require.Eventually(t, func() bool {
+		var err error
+		first, _, err = websocket.DefaultDialer.Dial(streamURL, nil)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return ws.subscriberCounts() == [2]int{1, 1}
+	}, time.Second, 10*time.Millisecond)
+
+	// Connections beyond the limit are closed with a try-again-later code.
+	second, _, err := websocket.DefaultDialer.Dial(streamURL, nil)
+	require.NoError(t, err)
+	_, _, err = second.ReadMessage()
+	require.True(t, websocket.IsCloseError(err, websocket.CloseTryAgainLater), "unexpected error: %v", err)
+	_ = second.Close()
+
+	// Once the first client leaves, its slot is released.
+	require.NoError(t, first.Close())
+	require.Eventually(t, func() bool {
+		return ws.subscriberCounts() == [2]int{0, 1}
+	}, time.Second, 10*time.Millisecond)
+
+	third, _, err := websocket.DefaultDialer.Dial(streamURL, nil)
+	require.NoError(t, err)
+	defer third.Close()
+	require.Eventually(t, func() bool {
+		return ws.subscriberCounts() == [2]int{1, 1}
+	}, time.Second, 10*time.Millisecond)
+}
+
+// subscriberCounts returns the current and peak number of stream connections
+func (ws *wsServer) subscriberCounts() [2]int {
+	ws.subscribersLock.Lock()
+	defer ws.subscribersLock.Unlock()
+
+	return [2]int{ws.subscribers, ws.peakSubscribers}
+}
+
 func newTestMessage() Message {
 	return Message{
 		Type:   TypeValidator,
@@ -147,3 +254,32 @@ func checkPort(port int) error {
 	_ = conn.Close()
 	return nil
 }
+
+func TestHandleStreamHeartbeat(t *testing.T) {
+	// the server outlives the test, so it mustn't log to the test
+	logger := zap.NewNop()
+	ctx := context.Background()
+	mux := http.NewServeMux()
+	ws := NewWsServer(ctx, nil, mux, false).(*wsServer)
+	ws.UseStreamHeartbeat(50 * time.Millisecond)
+	addr := fmt.Sprintf("localhost:%d", getRandomPort(8001, 14000))
+	go func() {
+		require.NoError(t, ws.Start(logger, addr))
+	}()
+	streamURL := fmt.Sprintf("ws://%s/stream", addr)
+
+	var conn *websocket.Conn
+	require.Eventually(t, func() bool {
+		var err error
+		conn, _, err = websocket.DefaultDialer.Dial(streamURL, nil)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	defer conn.Close()
+
+	// Quiet streams receive heartbeats, which are distinguishable from decided messages by their type.
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	var msg Message
+	require.NoError(t, conn.ReadJSON(&msg))
+	require.Equal(t, TypeHeartbeat, msg.Type)
+	require.Nil(t, msg.Data)
+}
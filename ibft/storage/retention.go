@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v2/qbft/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+var (
+	metricsStoredInstances = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:validator:ibft_stored_instances",
+		Help: "The number of stored historical instances, as of their last pruning",
+	}, []string{"role"})
+	metricsPrunedInstances = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:validator:ibft_pruned_instances",
+		Help: "The number of historical instances pruned past the retention window",
+	}, []string{"role"})
+)
+
+func init() {
+	logger := zap.L()
+	for _, c := range []prometheus.Collector{metricsStoredInstances, metricsPrunedInstances} {
+		if err := prometheus.Register(c); err != nil {
+			logger.Debug("could not register prometheus collector")
+		}
+	}
+}
+
+const (
+	// pruneBatchSize is the maximal number of instances deleted in a single transaction.
+	pruneBatchSize = 1000
+
+	// defaultPruneInterval is the interval between prunings if none is given.
+	defaultPruneInterval = 10 * time.Minute
+)
+
+// PruneInstances removes the historical instances below the given height of all identifiers,
+// returning the number of the removed instances. Highest instances are kept.
+func (i *ibftStorage) PruneInstances(below specqbft.Height) (int, error) {
+	var (
+		stale     [][]byte
+		pruned    int
+		remaining int
+	)
+	err := i.db.GetAll(i.prefix, func(_ int, obj basedb.Obj) error {
+		height, ok := historicalInstanceHeight(obj.Key)
+		if !ok {
+			return nil
+		}
+		if height < below {
+			stale = append(stale, obj.Key)
+		} else {
+			remaining++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list instances")
+	}
+
+	for len(stale) != 0 {
+		batch := stale
+		if len(batch) > pruneBatchSize {
+			batch = batch[:pruneBatchSize]
+		}
+		err := i.db.Update(func(txn basedb.Txn) error {
+			for _, key := range batch {
+				if err := txn.Delete(i.prefix, key); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to delete instances")
+		}
+		stale = stale[len(batch):]
+		pruned += len(batch)
+		metricsPrunedInstances.WithLabelValues(string(i.prefix)).Add(float64(len(batch)))
+	}
+
+	metricsStoredInstances.WithLabelValues(string(i.prefix)).Set(float64(remaining))
+	return pruned, nil
+}
+
+// historicalInstanceHeight returns the height of a historical instance by its key under the store's prefix,
+// or false if the key isn't of a historical instance.
+func historicalInstanceHeight(key []byte) (specqbft.Height, bool) {
+	identifierSize := len(spectypes.MessageID{})
+	if len(key) != identifierSize+len(instanceKey)+8 || !bytes.Equal(key[identifierSize:identifierSize+len(instanceKey)], []byte(instanceKey)) {
+		return 0, false
+	}
+	return specqbft.Height(binary.LittleEndian.Uint64(key[identifierSize+len(instanceKey):])), true
+}
+
+// RetainInstances prunes the historical instances of all roles which are more than the given number
+// of slots older than the current slot, on startup and then on every interval until the context is done.
+func (qs *QBFTStores) RetainInstances(
+	ctx context.Context,
+	logger *zap.Logger,
+	currentSlot func() phase0.Slot,
+	retention phase0.Slot,
+	interval time.Duration,
+) {
+	if interval <= 0 {
+		interval = defaultPruneInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		slot := currentSlot()
+		if slot > retention {
+			qs.pruneInstances(logger, specqbft.Height(slot-retention))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (qs *QBFTStores) pruneInstances(logger *zap.Logger, below specqbft.Height) {
+	_ = qs.Each(func(role spectypes.BeaconRole, store qbftstorage.QBFTStore) error {
+		start := time.Now()
+		pruned, err := store.PruneInstances(below)
+		if err != nil {
+			logger.Warn("failed to prune instances", fields.Role(role), zap.Error(err))
+			return nil
+		}
+		if pruned != 0 {
+			logger.Debug("pruned instances",
+				fields.Role(role),
+				fields.Height(below),
+				fields.Count(pruned),
+				fields.Took(time.Since(start)),
+			)
+		}
+		return nil
+	})
+}
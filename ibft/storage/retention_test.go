@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"testing"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v2/qbft/storage"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestPruneInstances(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	// The prefix of one role's store may be a prefix of another's.
+	syncCommittee := New(db, spectypes.BNRoleSyncCommittee.String())
+	contributions := New(db, spectypes.BNRoleSyncCommitteeContribution.String())
+
+	storedInstance := func(id spectypes.MessageID, height specqbft.Height) *qbftstorage.StoredInstance {
+		return &qbftstorage.StoredInstance{
+			State: &specqbft.State{
+				ID:     id[:],
+				Height: height,
+			},
+			DecidedMessage: &specqbft.SignedMessage{
+				Signers: []spectypes.OperatorID{1},
+				Message: specqbft.Message{
+					MsgType:    specqbft.CommitMsgType,
+					Height:     height,
+					Identifier: id[:],
+				},
+			},
+		}
+	}
+
+	msgIDs := []spectypes.MessageID{
+		spectypes.NewMsgID(types.GetDefaultDomain(), []byte("pk1"), spectypes.BNRoleSyncCommittee),
+		spectypes.NewMsgID(types.GetDefaultDomain(), []byte("pk2"), spectypes.BNRoleSyncCommittee),
+	}
+	for _, msgID := range msgIDs {
+		for height := specqbft.Height(1); height <= 10; height++ {
+			require.NoError(t, syncCommittee.SaveHighestAndHistoricalInstance(storedInstance(msgID, height)))
+			require.NoError(t, contributions.SaveInstance(storedInstance(msgID, height)))
+		}
+	}
+
+	pruned, err := syncCommittee.PruneInstances(6)
+	require.NoError(t, err)
+	require.Equal(t, 10, pruned)
+
+	for _, msgID := range msgIDs {
+		instances, err := syncCommittee.GetInstancesInRange(msgID[:], 1, 10)
+		require.NoError(t, err)
+		require.Len(t, instances, 5)
+		require.Equal(t, specqbft.Height(6), instances[0].State.Height)
+
+		highest, err := syncCommittee.GetHighestInstance(msgID[:])
+		require.NoError(t, err)
+		require.Equal(t, specqbft.Height(10), highest.State.Height)
+
+		instances, err = contributions.GetInstancesInRange(msgID[:], 1, 10)
+		require.NoError(t, err)
+		require.Len(t, instances, 10)
+	}
+
+	// Pruning is idempotent.
+	pruned, err = syncCommittee.PruneInstances(6)
+	require.NoError(t, err)
+	require.Zero(t, pruned)
+}
@@ -41,6 +41,7 @@ const (
 	FieldClusterIndex        = "cluster_index"
 	FieldConfig              = "config"
 	FieldConnectionID        = "connection_id"
+	FieldContract            = "contract"
 	FieldConsensusTime       = "consensus_time"
 	FieldSubmissionTime      = "submission_time"
 	FieldCount               = "count"
@@ -347,6 +348,10 @@ func Owner(addr common.Address) zap.Field {
 	return zap.String(FieldOwnerAddress, addr.Hex())
 }
 
+func Contract(addr common.Address) zap.Field {
+	return zap.String(FieldContract, addr.Hex())
+}
+
 func Type(v any) zapcore.Field {
 	return zap.String(FieldType, fmt.Sprintf("%T", v))
 }
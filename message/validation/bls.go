@@ -0,0 +1,173 @@
+package validation
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/herumi/bls-eth-go-binary/bls"
+
+	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+// partialSignatureBatchDelay is the longest a message waits for other messages of its slot
+// to fill its batch before the batch is verified.
+const partialSignatureBatchDelay = 5 * time.Millisecond
+
+// verifyPartialSignatures verifies the partial signatures of the message together with the ones
+// of other messages of the same slot which are validated concurrently, see partialSignatureBatcher.
+func (mv *messageValidator) verifyPartialSignatures(share *ssvtypes.SSVShare, m *spectypes.SignedPartialSignatureMessage) error {
+	var signerPubKey []byte
+	for _, operator := range share.Committee {
+		if operator.OperatorID == m.Signer {
+			signerPubKey = operator.PubKey
+			break
+		}
+	}
+
+	pubKey, err := ssvtypes.DeserializeBLSPublicKey(signerPubKey)
+	if err != nil {
+		e := ErrInvalidPartialSignature
+		e.innerErr = fmt.Errorf("deserialize signer public key: %w", err)
+		return e
+	}
+
+	set := &partialSignatureSet{
+		pubKey:   pubKey,
+		messages: m.Message.Messages,
+		sigs:     make([]bls.Sign, len(m.Message.Messages)),
+		result:   make(chan error, 1),
+	}
+	for i, message := range m.Message.Messages {
+		if err := set.sigs[i].Deserialize(message.PartialSignature); err != nil {
+			e := ErrInvalidPartialSignature
+			e.innerErr = fmt.Errorf("deserialize signature: %w", err)
+			return e
+		}
+	}
+
+	return mv.partialSignatures.verify(m.Message.Slot, set)
+}
+
+// partialSignatureSet is the partial signatures of a single message, awaiting verification.
+type partialSignatureSet struct {
+	pubKey   bls.PublicKey
+	messages []*spectypes.PartialSignatureMessage
+	sigs     []bls.Sign
+	result   chan error
+}
+
+// verify verifies the signatures one by one, returning an error describing the first invalid one.
+func (s *partialSignatureSet) verify() error {
+	for i, message := range s.messages {
+		// the root is copied, as cgo calls mustn't receive pointers into the message, which holds Go pointers
+		root := message.SigningRoot
+		if !s.sigs[i].VerifyByte(&s.pubKey, root[:]) {
+			e := ErrInvalidPartialSignature
+			e.got = fmt.Sprintf("signing root %x", message.SigningRoot)
+			return e
+		}
+	}
+	return nil
+}
+
+// partialSignatureBatchMetrics is implemented by the metrics reporter.
+type partialSignatureBatchMetrics interface {
+	PartialSignatureBatch(size int)
+	PartialSignatureBatchFallback()
+}
+
+// partialSignatureBatcher verifies the partial signatures of the messages of each slot in batches.
+// A batch is verified once it holds at least batchSize signatures, or once delay passed since it was started.
+// If a batch fails verification, each of its messages is verified signature by signature to find the invalid ones,
+// so that only the messages with invalid signatures fail.
+type partialSignatureBatcher struct {
+	metrics   partialSignatureBatchMetrics
+	batchSize int
+	delay     time.Duration
+
+	mu      sync.Mutex
+	pending map[phase0.Slot]*partialSignatureBatch
+}
+
+type partialSignatureBatch struct {
+	sets  []*partialSignatureSet
+	size  int // the number of signatures of the sets
+	timer *time.Timer
+}
+
+func newPartialSignatureBatcher(metrics partialSignatureBatchMetrics, batchSize int, delay time.Duration) *partialSignatureBatcher {
+	return &partialSignatureBatcher{
+		metrics:   metrics,
+		batchSize: batchSize,
+		delay:     delay,
+		pending:   make(map[phase0.Slot]*partialSignatureBatch),
+	}
+}
+
+// verify adds the set to the pending batch of the slot and waits for the batch to be verified.
+func (b *partialSignatureBatcher) verify(slot phase0.Slot, set *partialSignatureSet) error {
+	b.mu.Lock()
+	batch, ok := b.pending[slot]
+	if !ok {
+		batch = &partialSignatureBatch{}
+		b.pending[slot] = batch
+		batch.timer = time.AfterFunc(b.delay, func() {
+			b.flush(slot, batch)
+		})
+	}
+	batch.sets = append(batch.sets, set)
+	batch.size += len(set.sigs)
+	full := batch.size >= b.batchSize
+	b.mu.Unlock()
+
+	if full {
+		b.flush(slot, batch)
+	}
+	return <-set.result
+}
+
+// flush verifies the given batch of the slot, unless it was already verified.
+func (b *partialSignatureBatcher) flush(slot phase0.Slot, batch *partialSignatureBatch) {
+	b.mu.Lock()
+	if b.pending[slot] != batch {
+		b.mu.Unlock()
+		return
+	}
+	delete(b.pending, slot)
+	b.mu.Unlock()
+
+	batch.timer.Stop()
+	b.verifyBatch(batch)
+}
+
+func (b *partialSignatureBatcher) verifyBatch(batch *partialSignatureBatch) {
+	b.metrics.PartialSignatureBatch(batch.size)
+
+	sigs := make([]bls.Sign, 0, batch.size)
+	pubKeys := make([]bls.PublicKey, 0, batch.size)
+	roots := make([]byte, 0, batch.size*32)
+	for _, set := range batch.sets {
+		for i, message := range set.messages {
+			sigs = append(sigs, set.sigs[i])
+			pubKeys = append(pubKeys, set.pubKey)
+			roots = append(roots, message.SigningRoot[:]...)
+		}
+	}
+
+	if len(sigs) > 1 && bls.MultiVerify(sigs, pubKeys, roots) {
+		for _, set := range batch.sets {
+			set.result <- nil
+		}
+		return
+	}
+
+	if len(sigs) > 1 {
+		b.metrics.PartialSignatureBatchFallback()
+	}
+	for _, set := range batch.sets {
+		set.result <- set.verify()
+	}
+}
@@ -2,10 +2,14 @@ package validation
 
 import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/cornelk/hashmap"
 )
 
+// maxProposalRounds is the maximal number of rounds whose proposal roots are kept for a slot.
+const maxProposalRounds = 16
+
 // ConsensusID uniquely identifies a public key and role pair to keep track of state.
 type ConsensusID struct {
 	PubKey phase0.BLSPubKey
@@ -16,6 +20,16 @@ type ConsensusID struct {
 type ConsensusState struct {
 	// TODO: consider evicting old data to avoid excessive memory consumption
 	Signers *hashmap.Map[spectypes.OperatorID, *SignerState]
+
+	// proposalSlot is the latest slot whose proposal roots are kept in proposalRoots, by round.
+	proposalSlot  phase0.Slot
+	proposalRoots map[specqbft.Round][32]byte
+
+	// committee is the latest known committee of the validator. Once it changes, the size of the
+	// previous committee and the slot of the change are kept to account for it during reconfiguration.
+	committee             []spectypes.OperatorID
+	previousCommitteeSize int
+	committeeChangeSlot   phase0.Slot
 }
 
 // GetSignerState retrieves the state for the given signer.
@@ -32,3 +46,68 @@ func (cs *ConsensusState) CreateSignerState(signer spectypes.OperatorID) *Signer
 
 	return signerState
 }
+
+// RecordProposalRoot records the root of an accepted proposal. The roots of earlier slots are evicted
+// once a proposal of a later slot is recorded, and proposals of earlier slots aren't recorded.
+func (cs *ConsensusState) RecordProposalRoot(slot phase0.Slot, round specqbft.Round, root [32]byte) {
+	if cs.proposalRoots == nil || slot > cs.proposalSlot {
+		cs.proposalSlot = slot
+		cs.proposalRoots = make(map[specqbft.Round][32]byte)
+	}
+	if slot < cs.proposalSlot || len(cs.proposalRoots) >= maxProposalRounds {
+		return
+	}
+	if _, ok := cs.proposalRoots[round]; !ok {
+		cs.proposalRoots[round] = root
+	}
+}
+
+// ProposalRoot returns the root of the accepted proposal of the given slot and round, if it's known.
+func (cs *ConsensusState) ProposalRoot(slot phase0.Slot, round specqbft.Round) ([32]byte, bool) {
+	if slot != cs.proposalSlot {
+		return [32]byte{}, false
+	}
+	root, ok := cs.proposalRoots[round]
+	return root, ok
+}
+
+// RecordCommittee records the validator's committee as of the given slot, keeping track of its last change.
+// It allocates only when the committee changes, as it's called for every consensus message.
+func (cs *ConsensusState) RecordCommittee(slot phase0.Slot, committee []*spectypes.Operator) {
+	if sameCommittee(cs.committee, committee) {
+		return
+	}
+	if cs.committee != nil {
+		cs.previousCommitteeSize = len(cs.committee)
+		cs.committeeChangeSlot = slot
+	}
+	operatorIDs := make([]spectypes.OperatorID, len(committee))
+	for i, operator := range committee {
+		operatorIDs[i] = operator.OperatorID
+	}
+	cs.committee = operatorIDs
+}
+
+// sameCommittee returns whether the recorded operator IDs are those of the given committee.
+func sameCommittee(operatorIDs []spectypes.OperatorID, committee []*spectypes.Operator) bool {
+	if operatorIDs == nil || len(operatorIDs) != len(committee) {
+		return false
+	}
+	for i, operator := range committee {
+		if operatorIDs[i] != operator.OperatorID {
+			return false
+		}
+	}
+	return true
+}
+
+// DecidedLimit returns the maximum number of decided messages from a signer within a slot & round.
+// During the reconfiguration window following a change of the committee, decided messages of both
+// the previous and the current committees may be seen, so the limits of both are allowed.
+func (cs *ConsensusState) DecidedLimit(slot, reconfigurationWindow phase0.Slot) int {
+	limit := maxDecidedCount(len(cs.committee))
+	if cs.previousCommitteeSize != 0 && slot < cs.committeeChangeSlot+reconfigurationWindow {
+		limit += maxDecidedCount(cs.previousCommitteeSize)
+	}
+	return limit
+}
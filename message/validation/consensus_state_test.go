@@ -0,0 +1,76 @@
+package validation
+
+import (
+	"testing"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProposalRoots(t *testing.T) {
+	cs := &ConsensusState{}
+	_, ok := cs.ProposalRoot(0, 1)
+	require.False(t, ok)
+
+	cs.RecordProposalRoot(10, 1, [32]byte{1})
+	cs.RecordProposalRoot(10, 1, [32]byte{2}) // The first proposal of a round is kept.
+	cs.RecordProposalRoot(10, 2, [32]byte{3})
+	root, ok := cs.ProposalRoot(10, 1)
+	require.True(t, ok)
+	require.Equal(t, [32]byte{1}, root)
+
+	// Proposals of earlier slots aren't recorded.
+	cs.RecordProposalRoot(9, 1, [32]byte{4})
+	_, ok = cs.ProposalRoot(9, 1)
+	require.False(t, ok)
+
+	// The roots of earlier slots are evicted on slot rollover.
+	cs.RecordProposalRoot(11, 1, [32]byte{5})
+	_, ok = cs.ProposalRoot(10, 2)
+	require.False(t, ok)
+	root, _ = cs.ProposalRoot(11, 1)
+	require.Equal(t, [32]byte{5}, root)
+
+	// The number of rounds kept is bounded.
+	for round := 2; round < 2*maxProposalRounds; round++ {
+		cs.RecordProposalRoot(11, specqbft.Round(round), [32]byte{6})
+	}
+	require.Len(t, cs.proposalRoots, maxProposalRounds)
+}
+
+func TestDecidedLimitReconfiguration(t *testing.T) {
+	committee := func(operatorIDs ...spectypes.OperatorID) []*spectypes.Operator {
+		var operators []*spectypes.Operator
+		for _, id := range operatorIDs {
+			operators = append(operators, &spectypes.Operator{OperatorID: id})
+		}
+		return operators
+	}
+	const window = 10
+
+	cs := &ConsensusState{}
+	cs.RecordCommittee(100, committee(1, 2, 3, 4))
+	require.Equal(t, maxDecidedCount(4), cs.DecidedLimit(100, window))
+
+	// Without a change, the limit is of the current committee.
+	cs.RecordCommittee(105, committee(1, 2, 3, 4))
+	require.Equal(t, maxDecidedCount(4), cs.DecidedLimit(105, window))
+
+	// Once the committee grows, decided messages of both committees are allowed within the window.
+	cs.RecordCommittee(110, committee(1, 2, 3, 4, 5, 6, 7))
+	require.Equal(t, maxDecidedCount(7)+maxDecidedCount(4), cs.DecidedLimit(110, window))
+	require.Equal(t, maxDecidedCount(7)+maxDecidedCount(4), cs.DecidedLimit(119, window))
+	require.Equal(t, maxDecidedCount(7), cs.DecidedLimit(120, window))
+
+	// Replacing an operator is a reconfiguration as well, even though the size is unchanged.
+	cs.RecordCommittee(200, committee(1, 2, 3, 4, 5, 6, 8))
+	require.Equal(t, 2*maxDecidedCount(7), cs.DecidedLimit(200, window))
+	require.Equal(t, maxDecidedCount(7), cs.DecidedLimit(200, 0))
+
+	// Recording an unchanged committee doesn't allocate.
+	unchanged := committee(1, 2, 3, 4, 5, 6, 8)
+	require.Zero(t, testing.AllocsPerRun(100, func() {
+		cs.RecordCommittee(201, unchanged)
+	}))
+}
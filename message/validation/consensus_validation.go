@@ -4,12 +4,14 @@ package validation
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
 
 	"github.com/bloxapp/ssv/protocol/v2/qbft/instance"
@@ -18,16 +20,18 @@ import (
 )
 
 func (mv *messageValidator) validateConsensusMessage(
+	vctx *ValidationContext,
 	share *ssvtypes.SSVShare,
 	signedMsg *specqbft.SignedMessage,
 	messageID spectypes.MessageID,
-	receivedAt time.Time,
 	signatureVerifier func() error,
 ) (ConsensusDescriptor, phase0.Slot, error) {
 	var consensusDescriptor ConsensusDescriptor
 
 	if mv.operatorDataStore != nil && mv.operatorDataStore.OperatorIDReady() {
-		if mv.inCommittee(share) {
+		inCommittee := mv.inCommittee(share)
+		vctx.Annotate(zap.Bool("in_committee", inCommittee))
+		if inCommittee {
 			mv.metrics.InCommitteeMessage(spectypes.SSVConsensusMsgType, mv.isDecidedMessage(signedMsg))
 		} else {
 			mv.metrics.NonCommitteeMessage(spectypes.SSVConsensusMsgType, mv.isDecidedMessage(signedMsg))
@@ -67,14 +71,24 @@ func (mv *messageValidator) validateConsensusMessage(
 
 	role := messageID.GetRoleType()
 
+	receivedAt := vctx.ReceivedAt
 	if err := mv.validateSlotTime(msgSlot, role, receivedAt); err != nil {
 		return consensusDescriptor, msgSlot, err
 	}
 
+	if maxRound := mv.maxPlausibleRound(role); msgRound > maxRound {
+		mv.metrics.ImplausibleRound(role)
+		err := ErrImplausibleRound
+		err.got = fmt.Sprintf("%v (%v role)", msgRound, role)
+		err.want = fmt.Sprintf("%v (%v role)", maxRound, role)
+		return consensusDescriptor, msgSlot, err
+	}
+
 	if maxRound := mv.maxRound(role); msgRound > maxRound {
 		err := ErrRoundTooHigh
 		err.got = fmt.Sprintf("%v (%v role)", msgRound, role)
 		err.want = fmt.Sprintf("%v (%v role)", maxRound, role)
+		err.reject = mv.strictSpec
 		return consensusDescriptor, msgSlot, err
 	}
 
@@ -87,6 +101,7 @@ func (mv *messageValidator) validateConsensusMessage(
 		sinceSlotStart = receivedAt.Sub(slotStartTime)
 		estimatedRound = mv.currentEstimatedRound(sinceSlotStart)
 	}
+	vctx.Annotate(zap.Uint64("estimated_round", uint64(estimatedRound)))
 
 	// TODO: lowestAllowed is not supported yet because first round is non-deterministic now
 	lowestAllowed := /*estimatedRound - allowedRoundsInPast*/ specqbft.FirstRound
@@ -96,6 +111,7 @@ func (mv *messageValidator) validateConsensusMessage(
 		err := ErrEstimatedRoundTooFar
 		err.got = fmt.Sprintf("%v (%v role)", msgRound, role)
 		err.want = fmt.Sprintf("between %v and %v (%v role) / %v passed", lowestAllowed, highestAllowed, role, sinceSlotStart)
+		err.reject = mv.strictSpec
 		return consensusDescriptor, msgSlot, err
 	}
 
@@ -108,6 +124,12 @@ func (mv *messageValidator) validateConsensusMessage(
 		if hashedFullData != signedMsg.Message.Root {
 			return consensusDescriptor, msgSlot, ErrInvalidHash
 		}
+
+		if mv.slotSkewValidation {
+			if err := mv.validateSlotSkew(signedMsg); err != nil {
+				return consensusDescriptor, msgSlot, err
+			}
+		}
 	}
 
 	if err := mv.validateBeaconDuty(messageID.GetRoleType(), msgSlot, share); err != nil {
@@ -115,6 +137,11 @@ func (mv *messageValidator) validateConsensusMessage(
 	}
 
 	state := mv.consensusState(messageID)
+	if err := mv.validateCommitRoot(state, signedMsg); err != nil {
+		return consensusDescriptor, msgSlot, err
+	}
+	state.RecordCommittee(msgSlot, share.Committee)
+
 	for _, signer := range signedMsg.Signers {
 		if err := mv.validateSignerBehaviorConsensus(state, signer, share, messageID, signedMsg); err != nil {
 			return consensusDescriptor, msgSlot, fmt.Errorf("bad signer behavior: %w", err)
@@ -143,12 +170,59 @@ func (mv *messageValidator) validateConsensusMessage(
 			signerState.ProposalData = signedMsg.FullData
 		}
 
-		signerState.MessageCounts.RecordConsensusMessage(signedMsg)
+		if err := signerState.MessageCounts.RecordConsensusMessage(signedMsg, len(share.Committee)); err != nil {
+			return consensusDescriptor, msgSlot, err
+		}
+	}
+
+	if mv.commitRootValidation && signedMsg.Message.MsgType == specqbft.ProposalMsgType {
+		state.RecordProposalRoot(msgSlot, msgRound, signedMsg.Message.Root)
 	}
 
 	return consensusDescriptor, msgSlot, nil
 }
 
+// validateCommitRoot checks that a commit's root matches the root of the accepted proposal of its slot and round, if it's known.
+func (mv *messageValidator) validateCommitRoot(state *ConsensusState, signedMsg *specqbft.SignedMessage) error {
+	if !mv.commitRootValidation || signedMsg.Message.MsgType != specqbft.CommitMsgType {
+		return nil
+	}
+
+	proposalRoot, ok := state.ProposalRoot(phase0.Slot(signedMsg.Message.Height), signedMsg.Message.Round)
+	if !ok || proposalRoot == signedMsg.Message.Root {
+		return nil
+	}
+
+	e := ErrCommitRootMismatch
+	e.got = hex.EncodeToString(signedMsg.Message.Root[:])
+	e.want = hex.EncodeToString(proposalRoot[:])
+	return e
+}
+
+// validateSlotSkew checks that the duty slot of the message's full data is within maxSlotSkew of its height,
+// which is the slot the message is validated and logged with.
+// Full data which isn't consensus data is left to be rejected by the consensus.
+func (mv *messageValidator) validateSlotSkew(signedMsg *specqbft.SignedMessage) error {
+	var consensusData spectypes.ConsensusData
+	if err := consensusData.Decode(signedMsg.FullData); err != nil {
+		return nil
+	}
+
+	msgSlot := phase0.Slot(signedMsg.Message.Height)
+	dutySlot := consensusData.Duty.Slot
+	skew := dutySlot - msgSlot
+	if dutySlot < msgSlot {
+		skew = msgSlot - dutySlot
+	}
+	if skew > mv.maxSlotSkew {
+		e := ErrSlotSkew
+		e.got = dutySlot
+		e.want = fmt.Sprintf("%v (±%v)", msgSlot, mv.maxSlotSkew)
+		return e
+	}
+	return nil
+}
+
 func (mv *messageValidator) validateJustifications(
 	share *ssvtypes.SSVShare,
 	signedMsg *specqbft.SignedMessage,
@@ -179,6 +253,10 @@ func (mv *messageValidator) validateJustifications(
 		return e
 	}
 
+	if err := mv.validateJustificationSigners(share, pj, rcj); err != nil {
+		return err
+	}
+
 	if signedMsg.Message.MsgType == specqbft.ProposalMsgType {
 		cfg := newQBFTConfig(mv.netCfg.Domain)
 
@@ -200,6 +278,21 @@ func (mv *messageValidator) validateJustifications(
 	return nil
 }
 
+// validateJustificationSigners checks that the justifications are signed by committee members only,
+// since only the justifications of proposals are verified against the committee otherwise.
+func (mv *messageValidator) validateJustificationSigners(share *ssvtypes.SSVShare, justifications ...[]*specqbft.SignedMessage) error {
+	for _, messages := range justifications {
+		for _, msg := range messages {
+			for _, signer := range msg.Signers {
+				if err := mv.commonSignerValidation(signer, share); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func (mv *messageValidator) validateSignerBehaviorConsensus(
 	state *ConsensusState,
 	signer spectypes.OperatorID,
@@ -251,7 +344,8 @@ func (mv *messageValidator) validateSignerBehaviorConsensus(
 		}
 
 		limits := maxMessageCounts(len(share.Committee))
-		if err := signerState.MessageCounts.ValidateConsensusMessage(signedMsg, limits); err != nil {
+		limits.Decided = state.DecidedLimit(msgSlot, mv.reconfigurationWindow)
+		if err := signerState.MessageCounts.ValidateConsensusMessage(signedMsg, limits, len(share.Committee)); err != nil {
 			return err
 		}
 	}
@@ -310,7 +404,9 @@ func (mv *messageValidator) validateBeaconDuty(
 
 		period := mv.netCfg.Beacon.EstimatedSyncCommitteePeriodAtEpoch(mv.netCfg.Beacon.EstimatedEpochAtSlot(slot))
 		if mv.dutyStore != nil && mv.dutyStore.SyncCommittee.Duty(period, share.Metadata.BeaconMetadata.Index) == nil {
-			return ErrNoDutyIgnored
+			err := ErrNoDutyIgnored
+			err.reject = mv.strictSpec
+			return err
 		}
 
 		return nil
@@ -342,6 +438,21 @@ func (mv *messageValidator) maxRound(role spectypes.BeaconRole) specqbft.Round {
 	}
 }
 
+// maxPlausibleRound returns the highest round which a consensus message of the role can reach
+// by the round timeouts before it expires, unless overridden by WithMaxPlausibleRound.
+func (mv *messageValidator) maxPlausibleRound(role spectypes.BeaconRole) specqbft.Round {
+	if mv.plausibleRoundLimit > 0 {
+		return mv.plausibleRoundLimit
+	}
+
+	ttl, ok := messageTTL(role)
+	if !ok {
+		return specqbft.FirstRound
+	}
+	lifetime := time.Duration(ttl)*mv.netCfg.Beacon.SlotDurationSec() + lateMessageMargin + clockErrorTolerance
+	return mv.currentEstimatedRound(lifetime) + allowedRoundsInFuture
+}
+
 func (mv *messageValidator) currentEstimatedRound(sinceSlotStart time.Duration) specqbft.Round {
 	if currentQuickRound := specqbft.FirstRound + specqbft.Round(sinceSlotStart/roundtimer.QuickTimeout); currentQuickRound <= roundtimer.QuickTimeoutThreshold {
 		return currentQuickRound
@@ -3,6 +3,11 @@ package validation
 import (
 	"fmt"
 	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/ssv/queue"
 )
 
 type Error struct {
@@ -12,6 +17,9 @@ type Error struct {
 	innerErr error
 	reject   bool
 	silent   bool
+
+	// msg is the decoded message that failed validation, if it could be decoded.
+	msg *queue.DecodedSSVMessage
 }
 
 func (e Error) Error() string {
@@ -39,30 +47,106 @@ func (e Error) Silent() bool {
 	return e.silent
 }
 
+// Text returns the error text without any message-specific details.
+// Its cardinality is bounded, so it's suitable for metric labels.
 func (e Error) Text() string {
 	return e.text
 }
 
+// Message returns the decoded message that failed validation.
+// Returns nil if the message couldn't be decoded.
+func (e Error) Message() *queue.DecodedSSVMessage {
+	return e.msg
+}
+
+// Is reports whether the target is the same validation error, ignoring the attached message.
+func (e Error) Is(target error) bool {
+	t, ok := target.(Error)
+	if !ok {
+		return false
+	}
+
+	e.msg, t.msg = nil, nil
+	return e == t
+}
+
+// LoggerFields returns zap logging fields describing the message that failed validation.
+func (e Error) LoggerFields() []zap.Field {
+	return messageFields(e.msg)
+}
+
+// withMessage attaches the decoded message to the validation error contained in err.
+func withMessage(err error, msg *queue.DecodedSSVMessage) error {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case Error:
+		e.msg = msg
+		return e
+	}
+
+	var valErr Error
+	if !errors.As(err, &valErr) {
+		return err
+	}
+
+	valErr.msg = msg
+	return &wrappedError{outer: err, inner: valErr}
+}
+
+// wrappedError keeps the text of a wrapping error while exposing
+// the inner validation error with its attached message.
+type wrappedError struct {
+	outer error
+	inner Error
+}
+
+func (e *wrappedError) Error() string {
+	return e.outer.Error()
+}
+
+func (e *wrappedError) Unwrap() error {
+	return e.inner
+}
+
+// Validation errors are either ignored or rejected, as rejections are penalized by the gossipsub
+// scoring (invalid message deliveries) of the peer which delivered the message:
+//   - Ignored errors may occur with honest peers, such as duplicates, messages which are
+//     slightly early or late, or messages depending on state that peers may not agree on yet.
+//   - Rejected errors may only occur with malicious or malformed messages, such as invalid
+//     signatures, undecodable data, or violations of the protocol's rules.
+
+// Ignored errors.
+var (
+	ErrEmptyData                       = Error{text: "empty data"}
+	ErrWrongDomain                     = Error{text: "wrong domain", silent: true}
+	ErrNoShareMetadata                 = Error{text: "share has no metadata"}
+	ErrUnknownValidator                = Error{text: "unknown validator"}
+	ErrValidatorLiquidated             = Error{text: "validator is liquidated"}
+	ErrValidatorNotAttesting           = Error{text: "validator is not attesting"}
+	ErrSlotAlreadyAdvanced             = Error{text: "signer has already advanced to a later slot"}
+	ErrRoundAlreadyAdvanced            = Error{text: "signer has already advanced to a later round"}
+	ErrRoundTooHigh                    = Error{text: "round is too high for this role" /*, reject: true*/} // TODO: enable reject
+	ErrEarlyMessage                    = Error{text: "early message"}
+	ErrLateMessage                     = Error{text: "late message"}
+	ErrTooManySameTypeMessagesPerRound = Error{text: "too many messages of same type per round"}
+	ErrEstimatedRoundTooFar            = Error{text: "message round is too far from estimated"}
+	ErrNoDutyIgnored                   = Error{text: "no duty for this epoch (ignored)"}
+	ErrRoleValidationDisabled          = Error{text: "validation of role is disabled"}
+	ErrNonCommitteeValidator           = Error{text: "validator's committee doesn't include own operator"}
+)
+
+// Rejected errors.
 var (
-	ErrEmptyData                           = Error{text: "empty data"}
-	ErrWrongDomain                         = Error{text: "wrong domain", silent: true}
-	ErrNoShareMetadata                     = Error{text: "share has no metadata"}
-	ErrUnknownValidator                    = Error{text: "unknown validator"}
-	ErrValidatorLiquidated                 = Error{text: "validator is liquidated"}
-	ErrValidatorNotAttesting               = Error{text: "validator is not attesting"}
-	ErrSlotAlreadyAdvanced                 = Error{text: "signer has already advanced to a later slot"}
-	ErrRoundAlreadyAdvanced                = Error{text: "signer has already advanced to a later round"}
-	ErrRoundTooHigh                        = Error{text: "round is too high for this role" /*, reject: true*/} // TODO: enable reject
-	ErrEarlyMessage                        = Error{text: "early message"}
-	ErrLateMessage                         = Error{text: "late message"}
-	ErrTooManySameTypeMessagesPerRound     = Error{text: "too many messages of same type per round"}
 	ErrSignatureVerification               = Error{text: "signature verification", reject: true}
 	ErrOperatorNotFound                    = Error{text: "operator not found", reject: true}
+	ErrSignerMismatch                      = Error{text: "signed message's operator isn't the signer of its inner message", reject: true}
 	ErrPubSubMessageHasNoData              = Error{text: "pub-sub message has no data", reject: true}
 	ErrPubSubDataTooBig                    = Error{text: "pub-sub message data too big", reject: true}
 	ErrMalformedPubSubMessage              = Error{text: "pub-sub message is malformed", reject: true}
 	ErrEmptyPubSubMessage                  = Error{text: "pub-sub message is empty", reject: true}
 	ErrTopicNotFound                       = Error{text: "topic not found", reject: true}
+	ErrWrongTopicMessageType               = Error{text: "message type is not carried by topic", reject: true}
 	ErrSSVDataTooBig                       = Error{text: "ssv message data too big", reject: true}
 	ErrInvalidRole                         = Error{text: "invalid role", reject: true}
 	ErrUnexpectedConsensusMessage          = Error{text: "unexpected consensus message for this role", reject: true}
@@ -76,7 +160,7 @@ var (
 	ErrSignersNotSorted                    = Error{text: "signers are not sorted", reject: true}
 	ErrUnexpectedSigner                    = Error{text: "signer is not expected", reject: true}
 	ErrInvalidHash                         = Error{text: "root doesn't match full data hash", reject: true}
-	ErrEstimatedRoundTooFar                = Error{text: "message round is too far from estimated"}
+	ErrSlotSkew                            = Error{text: "full data duty slot is too far from message height", reject: true}
 	ErrMalformedMessage                    = Error{text: "message could not be decoded", reject: true}
 	ErrMalformedSignedMessage              = Error{text: "signed message could not be decoded", reject: true}
 	ErrUnknownSSVMessageType               = Error{text: "unknown SSV message type", reject: true}
@@ -86,6 +170,7 @@ var (
 	ErrNonDecidedWithMultipleSigners       = Error{text: "non-decided with multiple signers", reject: true}
 	ErrWrongSignersLength                  = Error{text: "decided signers size is not between quorum and committee size", reject: true}
 	ErrDuplicatedProposalWithDifferentData = Error{text: "duplicated proposal with different data", reject: true}
+	ErrCommitRootMismatch                  = Error{text: "commit root doesn't match proposal", reject: true}
 	ErrEventMessage                        = Error{text: "event messages are not broadcast", reject: true}
 	ErrDKGMessage                          = Error{text: "DKG messages are not supported", reject: true}
 	ErrMalformedPrepareJustifications      = Error{text: "malformed prepare justifications", reject: true}
@@ -93,10 +178,11 @@ var (
 	ErrMalformedRoundChangeJustifications  = Error{text: "malformed round change justifications", reject: true}
 	ErrUnexpectedRoundChangeJustifications = Error{text: "round change justifications unexpected for this message type", reject: true}
 	ErrInvalidJustifications               = Error{text: "invalid justifications", reject: true}
+	ErrImplausibleRound                    = Error{text: "round can't be reached before the message expires", reject: true}
 	ErrTooManyDutiesPerEpoch               = Error{text: "too many duties per epoch", reject: true}
 	ErrNoDuty                              = Error{text: "no duty for this epoch", reject: true}
-	ErrNoDutyIgnored                       = Error{text: "no duty for this epoch (ignored)"}
 	ErrDeserializePublicKey                = Error{text: "deserialize public key", reject: true}
 	ErrNoPartialMessages                   = Error{text: "no partial messages", reject: true}
 	ErrDuplicatedPartialSignatureMessage   = Error{text: "duplicated partial signature message", reject: true}
+	ErrInvalidPartialSignature             = Error{text: "invalid partial signature", reject: true}
 )
@@ -11,18 +11,19 @@ import (
 
 // MessageCounts tracks the number of various message types received for validation.
 type MessageCounts struct {
-	PreConsensus  int
-	Proposal      int
-	Prepare       int
-	Commit        int
-	Decided       int
-	RoundChange   int
-	PostConsensus int
+	PreConsensus          int
+	Proposal              int
+	Prepare               int
+	Commit                int
+	Decided               int
+	RoundChange           int
+	PostConsensus         int
+	ValidatorRegistration int
 }
 
 // String provides a formatted representation of the MessageCounts.
 func (c *MessageCounts) String() string {
-	return fmt.Sprintf("pre-consensus: %v, proposal: %v, prepare: %v, commit: %v, decided: %v, round change: %v, post-consensus: %v",
+	return fmt.Sprintf("pre-consensus: %v, proposal: %v, prepare: %v, commit: %v, decided: %v, round change: %v, post-consensus: %v, validator registration: %v",
 		c.PreConsensus,
 		c.Proposal,
 		c.Prepare,
@@ -30,12 +31,27 @@ func (c *MessageCounts) String() string {
 		c.Decided,
 		c.RoundChange,
 		c.PostConsensus,
+		c.ValidatorRegistration,
 	)
 }
 
+// Snapshot returns a copy of the counts, which isn't affected by later updates,
+// e.g. to archive the counts of a slot before they're reset.
+func (c *MessageCounts) Snapshot() MessageCounts {
+	return *c
+}
+
+// Reset clears the counts, e.g. at a slot or round rollover.
+func (c *MessageCounts) Reset() {
+	*c = MessageCounts{}
+}
+
 // ValidateConsensusMessage checks if the provided consensus message exceeds the set limits.
 // Returns an error if the message type exceeds its respective count limit.
-func (c *MessageCounts) ValidateConsensusMessage(msg *specqbft.SignedMessage, limits MessageCounts) error {
+// Commits are limited as decided messages only if they're signed by a quorum of the given committee size.
+// The limits of commits and decided messages are independent: a signer's commit is accepted after decided messages
+// including its signature were seen, since those may have been aggregated by others, and vice versa.
+func (c *MessageCounts) ValidateConsensusMessage(msg *specqbft.SignedMessage, limits MessageCounts, committeeSize int) error {
 	switch msg.Message.MsgType {
 	case specqbft.ProposalMsgType:
 		if c.Proposal >= limits.Proposal {
@@ -50,14 +66,16 @@ func (c *MessageCounts) ValidateConsensusMessage(msg *specqbft.SignedMessage, li
 			return err
 		}
 	case specqbft.CommitMsgType:
-		if len(msg.Signers) == 1 {
+		if len(msg.Signers) == 0 {
+			return ErrNoSigners
+		}
+		if len(msg.Signers) < decidedQuorum(committeeSize) {
 			if c.Commit >= limits.Commit {
 				err := ErrTooManySameTypeMessagesPerRound
 				err.got = fmt.Sprintf("commit, having %v", c.String())
 				return err
 			}
-		}
-		if len(msg.Signers) > 1 {
+		} else {
 			if c.Decided >= limits.Decided {
 				err := ErrTooManySameTypeMessagesPerRound
 				err.got = fmt.Sprintf("decided, having %v", c.String())
@@ -81,12 +99,18 @@ func (c *MessageCounts) ValidateConsensusMessage(msg *specqbft.SignedMessage, li
 // Returns an error if the message type exceeds its respective count limit.
 func (c *MessageCounts) ValidatePartialSignatureMessage(m *spectypes.SignedPartialSignatureMessage, limits MessageCounts) error {
 	switch m.Message.Type {
-	case spectypes.RandaoPartialSig, spectypes.SelectionProofPartialSig, spectypes.ContributionProofs, spectypes.ValidatorRegistrationPartialSig, spectypes.VoluntaryExitPartialSig:
+	case spectypes.RandaoPartialSig, spectypes.SelectionProofPartialSig, spectypes.ContributionProofs, spectypes.VoluntaryExitPartialSig:
 		if c.PreConsensus > limits.PreConsensus {
 			err := ErrTooManySameTypeMessagesPerRound
 			err.got = fmt.Sprintf("pre-consensus, having %v", c.String())
 			return err
 		}
+	case spectypes.ValidatorRegistrationPartialSig:
+		if c.ValidatorRegistration >= limits.ValidatorRegistration {
+			err := ErrTooManySameTypeMessagesPerRound
+			err.got = fmt.Sprintf("validator registration, having %v", c.String())
+			return err
+		}
 	case spectypes.PostConsensusPartialSig:
 		if c.PostConsensus > limits.PostConsensus {
 			err := ErrTooManySameTypeMessagesPerRound
@@ -101,7 +125,9 @@ func (c *MessageCounts) ValidatePartialSignatureMessage(m *spectypes.SignedParti
 }
 
 // RecordConsensusMessage updates the counts based on the provided consensus message type.
-func (c *MessageCounts) RecordConsensusMessage(msg *specqbft.SignedMessage) {
+// Commits are counted as decided messages only if they're signed by a quorum of the given committee size.
+// Commits without signers are malformed, so they're rejected rather than counted.
+func (c *MessageCounts) RecordConsensusMessage(msg *specqbft.SignedMessage, committeeSize int) error {
 	switch msg.Message.MsgType {
 	case specqbft.ProposalMsgType:
 		c.Proposal++
@@ -109,25 +135,28 @@ func (c *MessageCounts) RecordConsensusMessage(msg *specqbft.SignedMessage) {
 		c.Prepare++
 	case specqbft.CommitMsgType:
 		switch {
-		case len(msg.Signers) == 1:
+		case len(msg.Signers) == 0:
+			return ErrNoSigners
+		case len(msg.Signers) < decidedQuorum(committeeSize):
 			c.Commit++
-		case len(msg.Signers) > 1:
-			c.Decided++
 		default:
-			panic("expected signers") // 0 length should be checked before
+			c.Decided++
 		}
 	case specqbft.RoundChangeMsgType:
 		c.RoundChange++
 	default:
 		panic("unexpected signed message type") // should be checked before
 	}
+	return nil
 }
 
 // RecordPartialSignatureMessage updates the counts based on the provided partial signature message type.
 func (c *MessageCounts) RecordPartialSignatureMessage(msg *spectypes.SignedPartialSignatureMessage) {
 	switch msg.Message.Type {
-	case spectypes.RandaoPartialSig, spectypes.SelectionProofPartialSig, spectypes.ContributionProofs, spectypes.ValidatorRegistrationPartialSig, spectypes.VoluntaryExitPartialSig:
+	case spectypes.RandaoPartialSig, spectypes.SelectionProofPartialSig, spectypes.ContributionProofs, spectypes.VoluntaryExitPartialSig:
 		c.PreConsensus++
+	case spectypes.ValidatorRegistrationPartialSig:
+		c.ValidatorRegistration++
 	case spectypes.PostConsensusPartialSig:
 		c.PostConsensus++
 	default:
@@ -140,16 +169,23 @@ func maxMessageCounts(committeeSize int) MessageCounts {
 	maxDecided := maxDecidedCount(committeeSize)
 
 	return MessageCounts{
-		PreConsensus:  1,
-		Proposal:      1,
-		Prepare:       1,
-		Commit:        1,
-		Decided:       maxDecided,
-		RoundChange:   1,
-		PostConsensus: 1,
+		PreConsensus:          1,
+		Proposal:              1,
+		Prepare:               1,
+		Commit:                1,
+		Decided:               maxDecided,
+		RoundChange:           1,
+		PostConsensus:         1,
+		ValidatorRegistration: 1,
 	}
 }
 
+// decidedQuorum returns the number of signers (2f+1) from which a commit of the given committee size is decided.
+func decidedQuorum(committeeSize int) int {
+	f := (committeeSize - 1) / 3
+	return 2*f + 1
+}
+
 func maxDecidedCount(committeeSize int) int {
 	f := (committeeSize - 1) / 3
 	return committeeSize * (f + 1) // N * (f + 1)
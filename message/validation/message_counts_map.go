@@ -0,0 +1,120 @@
+package validation
+
+// message_counts_map.go contains a concurrent-safe container of message counts per validator-slot-round.
+
+import (
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+)
+
+// MessageCountsKey identifies the validator, slot and round which messages are counted for.
+type MessageCountsKey struct {
+	PubKey phase0.BLSPubKey
+	Slot   phase0.Slot
+	Round  specqbft.Round
+}
+
+// MessageCountsMap keeps the MessageCounts of each validator-slot-round, safe for concurrent use.
+// Counts of old slots should be dropped with EvictBefore to bound memory.
+type MessageCountsMap struct {
+	mu     sync.Mutex
+	counts map[MessageCountsKey]*MessageCounts
+}
+
+// NewMessageCountsMap creates an empty MessageCountsMap.
+func NewMessageCountsMap() *MessageCountsMap {
+	return &MessageCountsMap{
+		counts: map[MessageCountsKey]*MessageCounts{},
+	}
+}
+
+// Get returns a copy of the counts of the given key, or false if no message was recorded for it.
+func (m *MessageCountsMap) Get(key MessageCountsKey) (MessageCounts, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counts, ok := m.counts[key]
+	if !ok {
+		return MessageCounts{}, false
+	}
+	return *counts, true
+}
+
+// RecordConsensusMessage updates the counts of the given key based on the provided consensus message type.
+func (m *MessageCountsMap) RecordConsensusMessage(key MessageCountsKey, msg *specqbft.SignedMessage, committeeSize int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.countsOf(key).RecordConsensusMessage(msg, committeeSize)
+}
+
+// RecordPartialSignatureMessage updates the counts of the given key based on the provided partial signature message type.
+func (m *MessageCountsMap) RecordPartialSignatureMessage(key MessageCountsKey, msg *spectypes.SignedPartialSignatureMessage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.countsOf(key).RecordPartialSignatureMessage(msg)
+}
+
+// ValidateConsensusMessage checks the counts of the given key against the limits, as MessageCounts.ValidateConsensusMessage does.
+func (m *MessageCountsMap) ValidateConsensusMessage(key MessageCountsKey, msg *specqbft.SignedMessage, limits MessageCounts, committeeSize int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.peek(key).ValidateConsensusMessage(msg, limits, committeeSize)
+}
+
+// ValidatePartialSignatureMessage checks the counts of the given key against the limits,
+// as MessageCounts.ValidatePartialSignatureMessage does.
+func (m *MessageCountsMap) ValidatePartialSignatureMessage(key MessageCountsKey, msg *spectypes.SignedPartialSignatureMessage, limits MessageCounts) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.peek(key).ValidatePartialSignatureMessage(msg, limits)
+}
+
+// EvictBefore drops the counts of slots before the given slot, and returns how many keys were dropped.
+func (m *MessageCountsMap) EvictBefore(slot phase0.Slot) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	evicted := 0
+	for key := range m.counts {
+		if key.Slot < slot {
+			delete(m.counts, key)
+			evicted++
+		}
+	}
+	return evicted
+}
+
+// Len returns the number of keys with recorded messages.
+func (m *MessageCountsMap) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.counts)
+}
+
+// countsOf returns the counts of the given key, creating them if needed.
+// It must be called with the lock held.
+func (m *MessageCountsMap) countsOf(key MessageCountsKey) *MessageCounts {
+	counts, ok := m.counts[key]
+	if !ok {
+		counts = &MessageCounts{}
+		m.counts[key] = counts
+	}
+	return counts
+}
+
+// peek returns the counts of the given key, or empty counts without creating them if there are none.
+// It must be called with the lock held.
+func (m *MessageCountsMap) peek(key MessageCountsKey) *MessageCounts {
+	if counts, ok := m.counts[key]; ok {
+		return counts
+	}
+	return &MessageCounts{}
+}
@@ -0,0 +1,75 @@
+package validation
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageCountsMapConcurrentRecord(t *testing.T) {
+	m := NewMessageCountsMap()
+	key := MessageCountsKey{PubKey: phase0.BLSPubKey{1}, Slot: 100, Round: specqbft.FirstRound}
+	prepare := &specqbft.SignedMessage{
+		Signers: []spectypes.OperatorID{1},
+		Message: specqbft.Message{MsgType: specqbft.PrepareMsgType},
+	}
+	postConsensus := &spectypes.SignedPartialSignatureMessage{
+		Message: spectypes.PartialSignatureMessages{Type: spectypes.PostConsensusPartialSig},
+	}
+
+	const goroutines = 10
+	const messages = 100
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < messages; j++ {
+				assert.NoError(t, m.RecordConsensusMessage(key, prepare, 4))
+				m.RecordPartialSignatureMessage(key, postConsensus)
+			}
+		}()
+	}
+	wg.Wait()
+
+	counts, ok := m.Get(key)
+	require.True(t, ok)
+	require.Equal(t, MessageCounts{Prepare: goroutines * messages, PostConsensus: goroutines * messages}, counts)
+
+	// Counts are checked against the limits of their own key only.
+	limits := maxMessageCounts(4)
+	require.ErrorContains(t, m.ValidateConsensusMessage(key, prepare, limits, 4), ErrTooManySameTypeMessagesPerRound.Error())
+	nextRound := key
+	nextRound.Round++
+	require.NoError(t, m.ValidateConsensusMessage(nextRound, prepare, limits, 4))
+	require.Equal(t, 1, m.Len())
+}
+
+func TestMessageCountsMapEviction(t *testing.T) {
+	m := NewMessageCountsMap()
+	prepare := &specqbft.SignedMessage{
+		Signers: []spectypes.OperatorID{1},
+		Message: specqbft.Message{MsgType: specqbft.PrepareMsgType},
+	}
+	for slot := phase0.Slot(1); slot <= 10; slot++ {
+		for round := specqbft.FirstRound; round <= 2; round++ {
+			require.NoError(t, m.RecordConsensusMessage(MessageCountsKey{PubKey: phase0.BLSPubKey{1}, Slot: slot, Round: round}, prepare, 4))
+		}
+	}
+	require.Equal(t, 20, m.Len())
+
+	require.Equal(t, 14, m.EvictBefore(8))
+	require.Equal(t, 6, m.Len())
+	_, ok := m.Get(MessageCountsKey{PubKey: phase0.BLSPubKey{1}, Slot: 7, Round: specqbft.FirstRound})
+	require.False(t, ok)
+	_, ok = m.Get(MessageCountsKey{PubKey: phase0.BLSPubKey{1}, Slot: 8, Round: specqbft.FirstRound})
+	require.True(t, ok)
+
+	// Evicting again drops nothing.
+	require.Equal(t, 0, m.EvictBefore(8))
+}
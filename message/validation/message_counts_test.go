@@ -0,0 +1,148 @@
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageCountsDecidedQuorum(t *testing.T) {
+	tests := []struct {
+		committeeSize int
+		quorum        int
+	}{
+		{committeeSize: 4, quorum: 3},
+		{committeeSize: 7, quorum: 5},
+		{committeeSize: 10, quorum: 7},
+		{committeeSize: 13, quorum: 9},
+	}
+
+	commit := func(signers int) *specqbft.SignedMessage {
+		msg := &specqbft.SignedMessage{Message: specqbft.Message{MsgType: specqbft.CommitMsgType}}
+		for i := 1; i <= signers; i++ {
+			msg.Signers = append(msg.Signers, spectypes.OperatorID(i))
+		}
+		return msg
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("committee of %d", tt.committeeSize), func(t *testing.T) {
+			require.Equal(t, tt.quorum, decidedQuorum(tt.committeeSize))
+			limits := maxMessageCounts(tt.committeeSize)
+
+			// Commits signed by less than a quorum are counted as commits, even if aggregated.
+			for signers := 1; signers < tt.quorum; signers++ {
+				var counts MessageCounts
+				require.NoError(t, counts.RecordConsensusMessage(commit(signers), tt.committeeSize))
+				require.Equal(t, MessageCounts{Commit: 1}, counts, "%d signers", signers)
+				require.ErrorContains(t, counts.ValidateConsensusMessage(commit(signers), limits, tt.committeeSize),
+					ErrTooManySameTypeMessagesPerRound.Error())
+			}
+
+			// Commits signed by a quorum are decided.
+			for signers := tt.quorum; signers <= tt.committeeSize; signers++ {
+				var counts MessageCounts
+				require.NoError(t, counts.RecordConsensusMessage(commit(signers), tt.committeeSize))
+				require.Equal(t, MessageCounts{Decided: 1}, counts, "%d signers", signers)
+				require.NoError(t, counts.ValidateConsensusMessage(commit(signers), limits, tt.committeeSize))
+			}
+		})
+	}
+}
+
+func TestValidateCommitCounts(t *testing.T) {
+	const committeeSize = 4
+	limits := maxMessageCounts(committeeSize)
+
+	commit := func(signers int) *specqbft.SignedMessage {
+		msg := &specqbft.SignedMessage{Message: specqbft.Message{MsgType: specqbft.CommitMsgType}}
+		for i := 1; i <= signers; i++ {
+			msg.Signers = append(msg.Signers, spectypes.OperatorID(i))
+		}
+		return msg
+	}
+
+	tests := []struct {
+		name     string
+		counts   MessageCounts
+		signers  int
+		accepted bool
+	}{
+		{name: "first commit", counts: MessageCounts{}, signers: 1, accepted: true},
+		{name: "second commit", counts: MessageCounts{Commit: 1}, signers: 1, accepted: false},
+		{name: "commit after decided", counts: MessageCounts{Decided: 1}, signers: 1, accepted: true},
+		{name: "commit after max decided", counts: MessageCounts{Decided: limits.Decided}, signers: 1, accepted: true},
+		{name: "second commit after decided", counts: MessageCounts{Commit: 1, Decided: 1}, signers: 1, accepted: false},
+		{name: "commit after post-consensus", counts: MessageCounts{PostConsensus: 1}, signers: 1, accepted: true},
+		{name: "aggregated commit below quorum", counts: MessageCounts{Commit: 1}, signers: 2, accepted: false},
+		{name: "first decided", counts: MessageCounts{}, signers: 3, accepted: true},
+		{name: "decided after commit", counts: MessageCounts{Commit: 1}, signers: 3, accepted: true},
+		{name: "decided after decided", counts: MessageCounts{Decided: 1}, signers: 4, accepted: true},
+		{name: "decided below max", counts: MessageCounts{Commit: 1, Decided: limits.Decided - 1}, signers: 3, accepted: true},
+		{name: "decided at max", counts: MessageCounts{Decided: limits.Decided}, signers: 3, accepted: false},
+		{name: "decided after post-consensus", counts: MessageCounts{PostConsensus: 1}, signers: 4, accepted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.counts.ValidateConsensusMessage(commit(tt.signers), limits, committeeSize)
+			if tt.accepted {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, ErrTooManySameTypeMessagesPerRound.Error())
+			}
+		})
+	}
+}
+
+func TestMessageCountsNoSigners(t *testing.T) {
+	commit := &specqbft.SignedMessage{Message: specqbft.Message{MsgType: specqbft.CommitMsgType}}
+	limits := maxMessageCounts(4)
+
+	var counts MessageCounts
+	require.ErrorIs(t, counts.ValidateConsensusMessage(commit, limits, 4), ErrNoSigners)
+
+	// Counting doesn't panic, and leaves the counts unchanged.
+	require.ErrorIs(t, counts.RecordConsensusMessage(commit, 4), ErrNoSigners)
+	require.Equal(t, MessageCounts{}, counts)
+
+	m := NewMessageCountsMap()
+	key := MessageCountsKey{Slot: 1, Round: specqbft.FirstRound}
+	require.ErrorIs(t, m.RecordConsensusMessage(key, commit, 4), ErrNoSigners)
+	require.ErrorIs(t, m.ValidateConsensusMessage(key, commit, limits, 4), ErrNoSigners)
+}
+
+func TestMessageCountsSnapshot(t *testing.T) {
+	prepare := &specqbft.SignedMessage{
+		Signers: []spectypes.OperatorID{1},
+		Message: specqbft.Message{MsgType: specqbft.PrepareMsgType},
+	}
+	postConsensus := &spectypes.SignedPartialSignatureMessage{
+		Message: spectypes.PartialSignatureMessages{Type: spectypes.PostConsensusPartialSig},
+	}
+
+	var counts MessageCounts
+	require.NoError(t, counts.RecordConsensusMessage(prepare, 4))
+	counts.RecordPartialSignatureMessage(postConsensus)
+
+	snapshot := counts.Snapshot()
+	require.Equal(t, MessageCounts{Prepare: 1, PostConsensus: 1}, snapshot)
+
+	// The snapshot is independent of later updates and resets.
+	require.NoError(t, counts.RecordConsensusMessage(prepare, 4))
+	require.Equal(t, MessageCounts{Prepare: 2, PostConsensus: 1}, counts)
+	require.Equal(t, MessageCounts{Prepare: 1, PostConsensus: 1}, snapshot)
+
+	counts.Reset()
+	require.Equal(t, MessageCounts{}, counts)
+	require.Equal(t, MessageCounts{Prepare: 1, PostConsensus: 1}, snapshot)
+
+	// And vice versa.
+	snapshot.Reset()
+	require.NoError(t, counts.RecordConsensusMessage(prepare, 4))
+	require.Equal(t, MessageCounts{Prepare: 1}, counts)
+	require.Equal(t, MessageCounts{}, snapshot)
+}
@@ -6,21 +6,31 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	"go.uber.org/zap"
 
 	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
 )
 
 func (mv *messageValidator) validatePartialSignatureMessage(
+	vctx *ValidationContext,
 	share *ssvtypes.SSVShare,
 	signedMsg *spectypes.SignedPartialSignatureMessage,
 	msgID spectypes.MessageID,
 	signatureVerifier func() error,
 ) (phase0.Slot, error) {
 	if mv.operatorDataStore != nil && mv.operatorDataStore.OperatorIDReady() {
-		if mv.inCommittee(share) {
+		inCommittee := mv.inCommittee(share)
+		vctx.Annotate(zap.Bool("in_committee", inCommittee))
+		if inCommittee {
 			mv.metrics.InCommitteeMessage(spectypes.SSVPartialSignatureMsgType, false)
 		} else {
 			mv.metrics.NonCommitteeMessage(spectypes.SSVPartialSignatureMsgType, false)
+			if mv.committeeValidatorsOnly {
+				mv.metrics.NonCommitteePartialSignatureIgnored()
+				e := ErrNonCommitteeValidator
+				e.want = mv.operatorDataStore.GetOperatorID()
+				return signedMsg.Message.Slot, e
+			}
 		}
 	}
 
@@ -59,6 +69,13 @@ func (mv *messageValidator) validatePartialSignatureMessage(
 		}
 	}
 
+	if mv.partialSignatureBatchSize > 0 {
+		vctx.Annotate(zap.Bool("partial_signatures_batched", true))
+		if err := mv.verifyPartialSignatures(share, signedMsg); err != nil {
+			return msgSlot, err
+		}
+	}
+
 	if signerState == nil {
 		signerState = state.CreateSignerState(signedMsg.Signer)
 	}
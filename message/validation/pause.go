@@ -0,0 +1,35 @@
+package validation
+
+// ValidationPauser pauses message validation at runtime, e.g. during maintenance.
+type ValidationPauser interface {
+	Pause()
+	Resume()
+	Paused() bool
+}
+
+// Pause ignores all messages from other peers, so that they're neither processed nor rebroadcast,
+// without disconnecting from peers. Paused messages aren't decoded, verified or counted, saving the CPU.
+// Since ignored messages aren't penalized and mesh delivery scoring is disabled, neither the node
+// nor its peers are penalized for the messages which aren't forwarded meanwhile.
+// The node's own messages are still validated, so that it may keep publishing them.
+func (mv *messageValidator) Pause() {
+	if !mv.paused.CompareAndSwap(false, true) {
+		return
+	}
+	mv.metrics.MessageValidationPaused(true)
+	mv.logger.Warn("paused message validation, ignoring messages of other peers")
+}
+
+// Resume resumes the validation of messages paused by Pause.
+func (mv *messageValidator) Resume() {
+	if !mv.paused.CompareAndSwap(true, false) {
+		return
+	}
+	mv.metrics.MessageValidationPaused(false)
+	mv.logger.Warn("resumed message validation")
+}
+
+// Paused returns whether message validation is paused.
+func (mv *messageValidator) Paused() bool {
+	return mv.paused.Load()
+}
@@ -0,0 +1,84 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+
+	"github.com/bloxapp/ssv/logging/fields"
+)
+
+// roleSwitches holds the roles whose messages aren't validated, so that they're ignored and not rebroadcast.
+// All roles are validated by default.
+type roleSwitches struct {
+	mu       sync.RWMutex
+	disabled map[spectypes.BeaconRole]struct{}
+}
+
+func (s *roleSwitches) enabled(role spectypes.BeaconRole) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, disabled := s.disabled[role]
+	return !disabled
+}
+
+// set enables or disables the given role, returning whether it changed.
+func (s *roleSwitches) set(role spectypes.BeaconRole, enabled bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, disabled := s.disabled[role]
+	if enabled != disabled {
+		return false
+	}
+	if enabled {
+		delete(s.disabled, role)
+	} else {
+		if s.disabled == nil {
+			s.disabled = make(map[spectypes.BeaconRole]struct{})
+		}
+		s.disabled[role] = struct{}{}
+	}
+	return true
+}
+
+func (s *roleSwitches) list() []spectypes.BeaconRole {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	roles := make([]spectypes.BeaconRole, 0, len(s.disabled))
+	for role := range s.disabled {
+		roles = append(roles, role)
+	}
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i] < roles[j]
+	})
+	return roles
+}
+
+// SetRoleValidation enables or disables the validation of the given role's messages at runtime.
+// Messages of a disabled role are ignored, so that they're neither processed nor rebroadcast.
+func (mv *messageValidator) SetRoleValidation(role spectypes.BeaconRole, enabled bool) error {
+	if !mv.validRole(role) {
+		return fmt.Errorf("invalid role %v", role)
+	}
+	if !mv.roleSwitches.set(role, enabled) {
+		return nil
+	}
+
+	mv.metrics.MessageValidationRoleEnabled(role, enabled)
+	if enabled {
+		mv.logger.Warn("enabled message validation of role", fields.Role(role))
+	} else {
+		mv.logger.Warn("disabled message validation of role, ignoring its messages", fields.Role(role))
+	}
+	return nil
+}
+
+// DisabledRoles returns the roles whose messages are ignored, sorted.
+func (mv *messageValidator) DisabledRoles() []spectypes.BeaconRole {
+	return mv.roleSwitches.list()
+}
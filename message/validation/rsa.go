@@ -3,11 +3,16 @@ package validation
 import (
 	"fmt"
 
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 
 	"github.com/bloxapp/ssv/operator/keys"
+	"github.com/bloxapp/ssv/protocol/v2/ssv/queue"
 )
 
+// verifySignature verifies the signature of the given operator over the message data.
+// Verifications of known operators are counted by operator and result, since an operator
+// whose messages all fail verification likely has a misconfigured key.
 func (mv *messageValidator) verifySignature(messageData []byte, operatorID spectypes.OperatorID, signature []byte) error {
 	operatorPubKey, ok := mv.operatorIDToPubkeyCache.Get(operatorID)
 	if !ok {
@@ -26,6 +31,7 @@ func (mv *messageValidator) verifySignature(messageData []byte, operatorID spect
 
 		operatorPubKey, err = keys.PublicKeyFromString(string(operator.PublicKey))
 		if err != nil {
+			mv.metrics.MessageValidationRSAOperatorCheck(operatorID, false)
 			e := ErrSignatureVerification
 			e.innerErr = fmt.Errorf("decode public key: %w", err)
 			return e
@@ -35,10 +41,44 @@ func (mv *messageValidator) verifySignature(messageData []byte, operatorID spect
 	}
 
 	if err := operatorPubKey.Verify(messageData, signature); err != nil {
+		mv.metrics.MessageValidationRSAOperatorCheck(operatorID, false)
 		e := ErrSignatureVerification
 		e.innerErr = fmt.Errorf("verify opid: %v signature: %w", operatorID, err)
 		return e
 	}
 
+	mv.metrics.MessageValidationRSAOperatorCheck(operatorID, true)
+	return nil
+}
+
+// validateSignerConsistency checks that the operator which signed the message is the signer of its inner message,
+// since a mismatch means that the inner message was signed by one operator and relayed under the signature of another.
+// Decided messages aggregate the signatures of several operators and may be broadcast by any operator
+// of the committee, so their signers aren't required to include the operator which signed the message.
+func validateSignerConsistency(operatorID spectypes.OperatorID, msg *queue.DecodedSSVMessage) error {
+	if msg == nil {
+		return nil
+	}
+
+	var signer spectypes.OperatorID
+	switch body := msg.Body.(type) {
+	case *specqbft.SignedMessage:
+		if len(body.Signers) != 1 {
+			return nil
+		}
+		signer = body.Signers[0]
+	case *spectypes.SignedPartialSignatureMessage:
+		// The signers of the partial signatures are checked against this signer by validatePartialMessages.
+		signer = body.Signer
+	default:
+		return nil
+	}
+
+	if signer != operatorID {
+		e := ErrSignerMismatch
+		e.got = signer
+		e.want = operatorID
+		return e
+	}
 	return nil
 }
@@ -0,0 +1,49 @@
+package validation
+
+import (
+	"crypto/sha256"
+	"errors"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+)
+
+const (
+	// defaultSignatureCacheSize is the default number of signature verification results to cache.
+	defaultSignatureCacheSize = 10_000
+	// defaultSignatureCacheTTL is the default age at which cached signature verification results are evicted.
+	defaultSignatureCacheTTL = time.Minute
+)
+
+// signatureCache caches the results of signature verifications by the content hash of the signed message,
+// so that identical messages arriving from several peers are verified only once.
+// Only valid signatures and deterministic verification failures are cached: failures to look up
+// the signer, which may be resolved by syncing registry events, are verified again.
+type signatureCache struct {
+	cache *ttlcache.Cache[[32]byte, error]
+}
+
+func newSignatureCache(size uint64, ttl time.Duration) *signatureCache {
+	return &signatureCache{
+		cache: ttlcache.New(
+			ttlcache.WithCapacity[[32]byte, error](size),
+			ttlcache.WithTTL[[32]byte, error](ttl),
+			ttlcache.WithDisableTouchOnHit[[32]byte, error](),
+		),
+	}
+}
+
+// verify returns the cached verification result of the given signed message data, verifying it with verify on a miss.
+func (c *signatureCache) verify(signedData []byte, verify func() error) error {
+	key := sha256.Sum256(signedData)
+	if item := c.cache.Get(key); item != nil {
+		return item.Value()
+	}
+
+	err := verify()
+	var valErr Error
+	if err == nil || (errors.As(err, &valErr) && valErr.text == ErrSignatureVerification.text) {
+		c.cache.Set(key, err, ttlcache.DefaultTTL)
+	}
+	return err
+}
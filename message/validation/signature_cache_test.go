@@ -0,0 +1,57 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignatureCache(t *testing.T) {
+	cache := newSignatureCache(2, time.Minute)
+	verifications := 0
+	verifier := func(err error) func() error {
+		return func() error {
+			verifications++
+			return err
+		}
+	}
+
+	// Valid signatures are verified once.
+	require.NoError(t, cache.verify([]byte("valid"), verifier(nil)))
+	require.NoError(t, cache.verify([]byte("valid"), verifier(nil)))
+	require.Equal(t, 1, verifications)
+
+	// Invalid signatures are verified once too.
+	require.ErrorIs(t, cache.verify([]byte("invalid"), verifier(ErrSignatureVerification)), ErrSignatureVerification)
+	require.ErrorIs(t, cache.verify([]byte("invalid"), verifier(nil)), ErrSignatureVerification)
+	require.Equal(t, 2, verifications)
+
+	// Failures other than verification failures aren't cached.
+	lookupErr := errors.New("operator not found")
+	require.ErrorIs(t, cache.verify([]byte("unknown"), verifier(lookupErr)), lookupErr)
+	require.NoError(t, cache.verify([]byte("unknown"), verifier(nil)))
+	require.Equal(t, 4, verifications)
+
+	// The cache is bounded.
+	require.Equal(t, 2, cache.cache.Len())
+}
+
+func TestSignatureCacheTTL(t *testing.T) {
+	cache := newSignatureCache(10, 10*time.Millisecond)
+	verifications := 0
+	verify := func() error {
+		verifications++
+		return nil
+	}
+
+	require.NoError(t, cache.verify([]byte("valid"), verify))
+	require.NoError(t, cache.verify([]byte("valid"), verify))
+	require.Equal(t, 1, verifications)
+
+	// Results are evicted by age, regardless of hits.
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, cache.verify([]byte("valid"), verify))
+	require.Equal(t, 2, verifications)
+}
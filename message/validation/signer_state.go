@@ -26,7 +26,7 @@ func (s *SignerState) ResetSlot(slot phase0.Slot, round specqbft.Round, newEpoch
 	s.Start = time.Now()
 	s.Slot = slot
 	s.Round = round
-	s.MessageCounts = MessageCounts{}
+	s.MessageCounts.Reset()
 	s.ProposalData = nil
 	if newEpoch {
 		s.EpochDuties = 1
@@ -40,6 +40,6 @@ func (s *SignerState) ResetSlot(slot phase0.Slot, round specqbft.Round, newEpoch
 func (s *SignerState) ResetRound(round specqbft.Round) {
 	s.Start = time.Now()
 	s.Round = round
-	s.MessageCounts = MessageCounts{}
+	s.MessageCounts.Reset()
 	s.ProposalData = nil
 }
@@ -0,0 +1,86 @@
+package validation
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/ssv/queue"
+)
+
+// Reasons for which AsyncMessageSink drops messages.
+const (
+	sinkDropBackpressure = "backpressure"
+	sinkDropPublishError = "publish_error"
+)
+
+// MessageSink receives the messages accepted by message validation, e.g. to export them to a message bus.
+// Accept is called on the pubsub validation path, so implementations must not block.
+type MessageSink interface {
+	Accept(msg *queue.DecodedSSVMessage, descriptor Descriptor)
+}
+
+// nopMessageSink discards accepted messages.
+type nopMessageSink struct{}
+
+func (nopMessageSink) Accept(*queue.DecodedSSVMessage, Descriptor) {}
+
+// sinkMetrics is implemented by the metrics reporter.
+type sinkMetrics interface {
+	MessageSinkDropped(reason string)
+}
+
+// SinkMessage is an accepted message, as published by AsyncMessageSink.
+type SinkMessage struct {
+	Message    *queue.DecodedSSVMessage
+	Descriptor Descriptor
+	AcceptedAt time.Time
+}
+
+// SinkPublisher publishes an accepted message, e.g. to a Kafka topic or a NATS subject.
+type SinkPublisher func(ctx context.Context, msg SinkMessage) error
+
+// AsyncMessageSink buffers accepted messages and publishes them in the background, so that slow publishing
+// doesn't hold up validation. Messages accepted while the buffer is full are dropped and counted.
+type AsyncMessageSink struct {
+	logger  *zap.Logger
+	metrics sinkMetrics
+	publish SinkPublisher
+	buffer  chan SinkMessage
+}
+
+// NewAsyncMessageSink returns an AsyncMessageSink which buffers up to bufferSize messages for the publisher.
+// Messages are published once Run is started.
+func NewAsyncMessageSink(logger *zap.Logger, metrics sinkMetrics, bufferSize int, publish SinkPublisher) *AsyncMessageSink {
+	return &AsyncMessageSink{
+		logger:  logger.Named("message_sink"),
+		metrics: metrics,
+		publish: publish,
+		buffer:  make(chan SinkMessage, bufferSize),
+	}
+}
+
+// Accept buffers the message for publishing, dropping it if the buffer is full.
+func (s *AsyncMessageSink) Accept(msg *queue.DecodedSSVMessage, descriptor Descriptor) {
+	select {
+	case s.buffer <- SinkMessage{Message: msg, Descriptor: descriptor, AcceptedAt: time.Now()}:
+	default:
+		s.metrics.MessageSinkDropped(sinkDropBackpressure)
+	}
+}
+
+// Run publishes the buffered messages until the context is done.
+func (s *AsyncMessageSink) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-s.buffer:
+			if err := s.publish(ctx, msg); err != nil {
+				s.metrics.MessageSinkDropped(sinkDropPublishError)
+				s.logger.Debug("failed to publish accepted message", zap.Error(err))
+			}
+		}
+	}
+}
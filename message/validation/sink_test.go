@@ -0,0 +1,70 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/ssv/queue"
+)
+
+type sinkDropsRecorder struct {
+	mu    sync.Mutex
+	drops map[string]int
+}
+
+func (r *sinkDropsRecorder) MessageSinkDropped(reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.drops[reason]++
+}
+
+func (r *sinkDropsRecorder) count(reason string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.drops[reason]
+}
+
+func TestAsyncMessageSink(t *testing.T) {
+	metrics := &sinkDropsRecorder{drops: map[string]int{}}
+	published := make(chan SinkMessage)
+	sink := NewAsyncMessageSink(zap.NewNop(), metrics, 2, func(ctx context.Context, msg SinkMessage) error {
+		if msg.Descriptor.Role == spectypes.BNRoleProposer {
+			return errors.New("test error")
+		}
+		published <- msg
+		return nil
+	})
+	msg := &queue.DecodedSSVMessage{}
+
+	// Accepting never blocks: messages beyond the buffer are dropped until they're published.
+	sink.Accept(msg, Descriptor{Role: spectypes.BNRoleAttester})
+	sink.Accept(msg, Descriptor{Role: spectypes.BNRoleProposer})
+	sink.Accept(msg, Descriptor{Role: spectypes.BNRoleAggregator})
+	require.Equal(t, 1, metrics.count(sinkDropBackpressure))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go sink.Run(ctx)
+
+	select {
+	case got := <-published:
+		require.Same(t, msg, got.Message)
+		require.Equal(t, spectypes.BNRoleAttester, got.Descriptor.Role)
+	case <-time.After(time.Second):
+		require.Fail(t, "message wasn't published")
+	}
+
+	// Messages which fail to publish are dropped as well.
+	require.Eventually(t, func() bool {
+		return metrics.count(sinkDropPublishError) == 1
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,50 @@
+package validation
+
+import (
+	"encoding/hex"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+
+	"github.com/bloxapp/ssv/network/commons"
+	ssvmessage "github.com/bloxapp/ssv/protocol/v2/message"
+)
+
+// subnetTopicMessageTypes are the message types carried by subnet topics.
+var subnetTopicMessageTypes = map[spectypes.MsgType]struct{}{
+	spectypes.SSVConsensusMsgType:        {},
+	spectypes.SSVPartialSignatureMsgType: {},
+}
+
+// knownMessageTypes are the message types recognized by topic validation.
+// Other message types are left to the SSV stage, which rejects them as unknown.
+var knownMessageTypes = map[spectypes.MsgType]struct{}{
+	spectypes.SSVConsensusMsgType:        {},
+	spectypes.SSVPartialSignatureMsgType: {},
+	spectypes.DKGMsgType:                 {},
+	ssvmessage.SSVSyncMsgType:            {},
+	ssvmessage.SSVEventMsgType:           {},
+}
+
+// validateTopic validates that the message belongs on the topic it arrived on: that the topic is a subnet topic,
+// that subnet topics carry the message's type, and that the message's validator is mapped to the subnet.
+func (mv *messageValidator) validateTopic(topic string, msg *spectypes.SSVMessage) error {
+	subnet, ok := commons.TopicSubnet(topic)
+	if !ok {
+		e := ErrTopicNotFound
+		e.got = topic
+		return e
+	}
+
+	if _, known := knownMessageTypes[msg.MsgType]; known {
+		if _, allowed := subnetTopicMessageTypes[msg.MsgType]; !allowed {
+			e := ErrWrongTopicMessageType
+			e.got = ssvmessage.MsgTypeToString(msg.MsgType)
+			return e
+		}
+	}
+
+	if commons.ValidatorSubnet(hex.EncodeToString(msg.GetID().GetPubKey())) != subnet {
+		return ErrTopicNotFound
+	}
+	return nil
+}
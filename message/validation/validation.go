@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -17,6 +18,7 @@ import (
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/cornelk/hashmap"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	ps_pb "github.com/libp2p/go-libp2p-pubsub/pb"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -51,6 +53,16 @@ const (
 	lateSlotAllowance          = 2
 	signatureSize              = 96
 	maxDutiesPerEpoch          = 2
+
+	// defaultReconfigurationWindow is the default number of slots (two mainnet epochs) following a change of
+	// a validator's committee during which the decided messages of its previous committee are allowed as well.
+	defaultReconfigurationWindow = 64
+
+	// maxWireMessageSize is the default maximum size of pubsub message data: the max possible
+	// MsgType + MsgID + Data plus 10% for encoding overhead, plus the operator's RSA signature and ID.
+	maxWireMessageSize = maxEncodedMsgSize + 256 + 8
+	maxEncodedMsgSize  = maxSSVMessageSize + maxSSVMessageSize/10
+	maxSSVMessageSize  = 4 + 56 + 8388668
 )
 
 // PubsubMessageValidator defines methods for validating pubsub messages.
@@ -68,6 +80,21 @@ type SSVMessageValidator interface {
 type MessageValidator interface {
 	PubsubMessageValidator
 	SSVMessageValidator
+	RoleValidationSwitch
+	ValidationPauser
+}
+
+// RawMessageValidator validates captured pubsub messages offline, without a libp2p host,
+// e.g. to reproduce the rejections of messages reported by operators.
+type RawMessageValidator interface {
+	ValidateRaw(topic string, data []byte, from string) (pubsub.ValidationResult, *ValidationContext, error)
+	ValidateRawAt(receivedAt time.Time, topic string, data []byte, from string) (pubsub.ValidationResult, *ValidationContext, error)
+}
+
+// RoleValidationSwitch toggles the validation of each role's messages at runtime.
+type RoleValidationSwitch interface {
+	SetRoleValidation(role spectypes.BeaconRole, enabled bool) error
+	DisabledRoles() []spectypes.BeaconRole
 }
 
 type messageValidator struct {
@@ -85,8 +112,51 @@ type messageValidator struct {
 	validationLocks map[spectypes.MessageID]*sync.Mutex
 	validationMutex sync.Mutex
 
-	selfPID    peer.ID
-	selfAccept bool
+	selfPID        peer.ID
+	selfAccept     bool
+	observer       bool
+	maxMessageSize int
+
+	// observed receives the messages which observer mode would accept, see ObservedMessageDeliverer.
+	observed func(ctx context.Context, msg *queue.DecodedSSVMessage)
+
+	// partialSignatureBatchSize is the number of partial signatures of a slot verified in a single batch.
+	// Zero disables the verification of partial signatures.
+	partialSignatureBatchSize int
+	partialSignatures         *partialSignatureBatcher
+
+	// strictSpec rejects messages which are otherwise let through or ignored leniently.
+	strictSpec bool
+
+	// commitRootValidation rejects commits whose root doesn't match the proposal of their slot and round.
+	commitRootValidation bool
+
+	// signatureCache caches the results of signature verifications, if set.
+	signatureCache *signatureCache
+
+	// roleSwitches holds the roles whose messages are ignored rather than validated.
+	roleSwitches roleSwitches
+
+	// paused ignores the messages of other peers without validating them, see Pause.
+	paused atomic.Bool
+
+	// slotSkewValidation rejects consensus messages whose full data is of a duty slot too far from their height.
+	slotSkewValidation bool
+	// maxSlotSkew is the maximum distance between the height of a consensus message and the duty slot of its full data.
+	maxSlotSkew phase0.Slot
+
+	// sink receives the accepted messages.
+	sink MessageSink
+
+	// reconfigurationWindow is the number of slots following a change of a validator's committee
+	// during which decided messages of its previous committee are allowed as well.
+	reconfigurationWindow phase0.Slot
+
+	// plausibleRoundLimit overrides the highest round a consensus message can reach before it expires, if set.
+	plausibleRoundLimit specqbft.Round
+
+	// committeeValidatorsOnly ignores partial signature messages of validators whose committee doesn't include own operator.
+	committeeValidatorsOnly bool
 }
 
 // NewMessageValidator returns a new MessageValidator with the given network configuration and options.
@@ -97,12 +167,20 @@ func NewMessageValidator(netCfg networkconfig.NetworkConfig, opts ...Option) Mes
 		netCfg:                  netCfg,
 		operatorIDToPubkeyCache: hashmap.New[spectypes.OperatorID, keys.OperatorPublicKey](),
 		validationLocks:         make(map[spectypes.MessageID]*sync.Mutex),
+		maxMessageSize:          maxWireMessageSize,
+		signatureCache:          newSignatureCache(defaultSignatureCacheSize, defaultSignatureCacheTTL),
+		reconfigurationWindow:   defaultReconfigurationWindow,
+		sink:                    nopMessageSink{},
 	}
 
 	for _, opt := range opts {
 		opt(mv)
 	}
 
+	if mv.partialSignatureBatchSize > 0 {
+		mv.partialSignatures = newPartialSignatureBatcher(mv.metrics, mv.partialSignatureBatchSize, partialSignatureBatchDelay)
+	}
+
 	return mv
 }
 
@@ -144,6 +222,14 @@ func WithNodeStorage(nodeStorage operatorstorage.Storage) Option {
 	}
 }
 
+// WithSelfPeerID sets the node's own peer ID. The node's own messages are attributed to the local origin,
+// and are still validated while validation is paused, so that the node may keep publishing them.
+func WithSelfPeerID(selfPID peer.ID) Option {
+	return func(mv *messageValidator) {
+		mv.selfPID = selfPID
+	}
+}
+
 // WithSelfAccept blindly accepts messages sent from self. Useful for testing.
 func WithSelfAccept(selfPID peer.ID, selfAccept bool) Option {
 	return func(mv *messageValidator) {
@@ -152,6 +238,108 @@ func WithSelfAccept(selfPID peer.ID, selfAccept bool) Option {
 	}
 }
 
+// WithMaxMessageSize sets the maximum size of pubsub message data, checked before decoding.
+func WithMaxMessageSize(size int) Option {
+	return func(mv *messageValidator) {
+		mv.maxMessageSize = size
+	}
+}
+
+// WithObserverMode runs all checks, logging and reporting metrics for every message,
+// but always ignores messages so that the node doesn't affect their propagation.
+// Since ignored messages aren't delivered locally either, the messages which would be accepted
+// are delivered through ObservedMessageDeliverer instead.
+func WithObserverMode() Option {
+	return func(mv *messageValidator) {
+		mv.observer = true
+	}
+}
+
+// WithPartialSignatureVerification verifies the BLS partial signatures of partial signature messages,
+// aggregating the signatures of concurrently validated messages of the same slot into a single verification
+// once they reach batchSize signatures, or after a short delay.
+// A batch that fails verification is verified message by message, signature by signature, to find the invalid ones.
+func WithPartialSignatureVerification(batchSize int) Option {
+	return func(mv *messageValidator) {
+		mv.partialSignatureBatchSize = batchSize
+	}
+}
+
+// WithStrictSpec rejects messages which are otherwise ignored leniently: messages with a round beyond
+// the role's limit or too far from the estimated round, and sync committee messages without a duty.
+// It's meant for conformance testing, so that gaps in validation surface as rejections.
+func WithStrictSpec() Option {
+	return func(mv *messageValidator) {
+		mv.strictSpec = true
+	}
+}
+
+// WithCommitRootValidation rejects commit messages whose root doesn't match the root of the accepted proposal
+// of the same validator, role, slot and round, since such commits are either buggy or malicious.
+// Commits whose proposal wasn't seen are validated as usual.
+func WithCommitRootValidation() Option {
+	return func(mv *messageValidator) {
+		mv.commitRootValidation = true
+	}
+}
+
+// WithCommitteeValidatorsOnly ignores partial signature messages of validators whose committee doesn't include
+// own operator before verifying their signatures, saving the verification of spam targeting other validators.
+// Ignored messages aren't relayed, so it should only be enabled by nodes which don't need to relay the partial
+// signatures of other committees. Messages are validated as usual while own operator ID isn't known yet, and
+// messages of validators which aren't synced yet are ignored as unknown validators.
+func WithCommitteeValidatorsOnly() Option {
+	return func(mv *messageValidator) {
+		mv.committeeValidatorsOnly = true
+	}
+}
+
+// WithSignatureCache caches the results of up to the given number of signature verifications for the given duration,
+// so that identical messages aren't verified again. Zero size disables the cache.
+func WithSignatureCache(size uint64, ttl time.Duration) Option {
+	return func(mv *messageValidator) {
+		if size == 0 {
+			mv.signatureCache = nil
+			return
+		}
+		mv.signatureCache = newSignatureCache(size, ttl)
+	}
+}
+
+// WithMaxSlotSkew enables the rejection of consensus messages whose full data is of a duty slot
+// more than the given number of slots away from their height. Zero requires them to be equal.
+// Without it, the full data's duty slot isn't checked, which spares decoding it.
+func WithMaxSlotSkew(slots phase0.Slot) Option {
+	return func(mv *messageValidator) {
+		mv.slotSkewValidation = true
+		mv.maxSlotSkew = slots
+	}
+}
+
+// WithReconfigurationWindow sets the number of slots following a change of a validator's committee during
+// which the decided messages of its previous committee are allowed in addition to the current one's,
+// as operators of both committees may transiently run the validator. Defaults to defaultReconfigurationWindow.
+func WithReconfigurationWindow(slots phase0.Slot) Option {
+	return func(mv *messageValidator) {
+		mv.reconfigurationWindow = slots
+	}
+}
+
+// WithMaxPlausibleRound overrides the highest round of consensus messages beyond which they're rejected,
+// which otherwise is the round the round timeouts reach by the time the message expires.
+func WithMaxPlausibleRound(round specqbft.Round) Option {
+	return func(mv *messageValidator) {
+		mv.plausibleRoundLimit = round
+	}
+}
+
+// WithMessageSink passes the messages accepted from pubsub to the given sink, which must not block.
+func WithMessageSink(sink MessageSink) Option {
+	return func(mv *messageValidator) {
+		mv.sink = sink
+	}
+}
+
 // ConsensusDescriptor provides details about the consensus for a message. It's used for logging and metrics.
 type ConsensusDescriptor struct {
 	Round           specqbft.Round
@@ -230,7 +418,37 @@ func (mv *messageValidator) ValidatorForTopic(_ string) func(ctx context.Context
 
 // ValidatePubsubMessage validates the given pubsub message.
 // Depending on the outcome, it will return one of the pubsub validation results (Accept, Ignore, or Reject).
-func (mv *messageValidator) ValidatePubsubMessage(_ context.Context, peerID peer.ID, pmsg *pubsub.Message) pubsub.ValidationResult {
+// In observer mode, the outcome is only logged and reported, and the message is always ignored.
+// While validation is paused, messages of other peers are ignored without validating them.
+func (mv *messageValidator) ValidatePubsubMessage(ctx context.Context, peerID peer.ID, pmsg *pubsub.Message) pubsub.ValidationResult {
+	if mv.paused.Load() && peerID != mv.selfPID {
+		return pubsub.ValidationIgnore
+	}
+	result := mv.validatePubsubMessage(ctx, peerID, pmsg)
+	if mv.observer {
+		if decMsg, ok := pmsg.ValidatorData.(*queue.DecodedSSVMessage); ok && result == pubsub.ValidationAccept && mv.observed != nil {
+			mv.observed(ctx, decMsg)
+		}
+		return pubsub.ValidationIgnore
+	}
+	return result
+}
+
+// ObservedMessageDeliverer delivers the messages which the validator would accept in observer mode
+// to the node itself, as they're ignored by pubsub and therefore neither forwarded nor delivered.
+type ObservedMessageDeliverer interface {
+	// DeliverObserved sets the handler of observed messages, which must not block. It must be set before validating messages.
+	DeliverObserved(handler func(ctx context.Context, msg *queue.DecodedSSVMessage))
+}
+
+var _ ObservedMessageDeliverer = (*messageValidator)(nil)
+
+// DeliverObserved sets the handler of the messages which would be accepted in observer mode.
+func (mv *messageValidator) DeliverObserved(handler func(ctx context.Context, msg *queue.DecodedSSVMessage)) {
+	mv.observed = handler
+}
+
+func (mv *messageValidator) validatePubsubMessage(_ context.Context, peerID peer.ID, pmsg *pubsub.Message) pubsub.ValidationResult {
 	if mv.selfAccept && peerID == mv.selfPID {
 		msg, _ := commons.DecodeNetworkMsg(pmsg.Data)
 		decMsg, _ := queue.DecodeSSVMessage(msg)
@@ -238,63 +456,117 @@ func (mv *messageValidator) ValidatePubsubMessage(_ context.Context, peerID peer
 		return pubsub.ValidationAccept
 	}
 
-	start := time.Now()
 	var validationDurationLabels []string // TODO: implement
 
-	defer func() {
-		sinceStart := time.Since(start)
-		mv.metrics.MessageValidationDuration(sinceStart, validationDurationLabels...)
-	}()
-
-	decodedMessage, descriptor, err := mv.validateP2PMessage(pmsg, time.Now())
-	round := specqbft.Round(0)
-	if descriptor.Consensus != nil {
-		round = descriptor.Consensus.Round
+	vctx := newValidationContext(time.Now())
+	vctx.Origin = OriginGossip
+	if peerID == mv.selfPID {
+		vctx.Origin = OriginLocal
 	}
+	vctx.Annotate(fields.PeerID(peerID))
+	vctx.finalize(mv.validateP2PMessageWithContext(vctx, pmsg))
 
-	f := append(descriptor.Fields(), fields.PeerID(peerID))
-
-	if err != nil {
-		var valErr Error
-		if errors.As(err, &valErr) {
-			if valErr.Reject() {
-				if !valErr.Silent() {
-					f = append(f, zap.Error(err))
-					mv.logger.Debug("rejecting invalid message", f...)
-				}
-
-				mv.metrics.MessageRejected(valErr.Text(), descriptor.Role, round)
-				return pubsub.ValidationReject
-			}
+	mv.metrics.MessageValidationDuration(vctx.Duration, validationDurationLabels...)
 
-			if !valErr.Silent() {
-				f = append(f, zap.Error(err))
-				mv.logger.Debug("ignoring invalid message", f...)
-			}
-			mv.metrics.MessageIgnored(valErr.Text(), descriptor.Role, round)
-			return pubsub.ValidationIgnore
+	switch vctx.Result {
+	case pubsub.ValidationAccept:
+		pmsg.ValidatorData = vctx.Message
+		mv.metrics.MessageAccepted(vctx.Descriptor.Role, vctx.Round())
+		if vctx.Message != nil {
+			mv.metrics.MessageAcceptedType(vctx.Descriptor.Role, vctx.Message.MsgType)
+			mv.sink.Accept(vctx.Message, vctx.Descriptor)
 		}
-
-		mv.metrics.MessageIgnored(err.Error(), descriptor.Role, round)
-		f = append(f, zap.Error(err))
-		mv.logger.Debug("ignoring invalid message", f...)
-		return pubsub.ValidationIgnore
+	case pubsub.ValidationReject:
+		if !vctx.Silent() {
+			mv.logger.Debug("rejecting invalid message", vctx.LoggerFields()...)
+		}
+		mv.metrics.MessageRejected(vctx.Reason, vctx.Descriptor.Role, vctx.Round())
+	default:
+		if !vctx.Silent() {
+			mv.logger.Debug("ignoring invalid message", vctx.LoggerFields()...)
+		}
+		mv.metrics.MessageIgnored(vctx.Reason, vctx.Descriptor.Role, vctx.Round())
 	}
 
-	pmsg.ValidatorData = decodedMessage
+	return vctx.Result
+}
 
-	mv.metrics.MessageAccepted(descriptor.Role, round)
+// validationResult returns the pubsub verdict for a message which failed validation.
+// Only validation errors which are explicitly rejected are rejected,
+// since rejections are penalized by the gossipsub scoring of the peer which delivered the message.
+// Any other error is ignored.
+func validationResult(err error) pubsub.ValidationResult {
+	var valErr Error
+	if errors.As(err, &valErr) && valErr.Reject() {
+		return pubsub.ValidationReject
+	}
+	return pubsub.ValidationIgnore
+}
 
-	return pubsub.ValidationAccept
+// errorReason returns a bounded-cardinality description of the error for metric labels:
+// the text of validation errors, and "other" for any other error, whose text may be unbounded.
+func errorReason(err error) string {
+	var valErr Error
+	if errors.As(err, &valErr) {
+		return valErr.Text()
+	}
+	return "other"
 }
 
-// ValidateSSVMessage validates the given SSV message.
+// ValidateSSVMessage validates the given SSV message, produced by this node rather than received over gossip.
 // If successful, it returns the decoded message and its descriptor. Otherwise, it returns an error.
 func (mv *messageValidator) ValidateSSVMessage(ssvMessage *spectypes.SSVMessage) (*queue.DecodedSSVMessage, Descriptor, error) {
 	return mv.validateSSVMessage(ssvMessage, time.Now(), nil)
 }
 
+var _ RawMessageValidator = (*messageValidator)(nil)
+
+// ValidateRaw validates the raw data of a pubsub message received on the given topic from the given peer,
+// whose ID is given in its text (base58) form, as it would be validated when received now over gossip, regardless of observer mode.
+// It returns the verdict, the validation context describing the message and the outcome (see ValidationContext.LoggerFields),
+// and the error which failed validation, if any. As with gossip messages, accepted messages update the validator's state.
+// If the peer ID is invalid, the message isn't validated and no validation context is returned.
+func (mv *messageValidator) ValidateRaw(topic string, data []byte, from string) (pubsub.ValidationResult, *ValidationContext, error) {
+	return mv.ValidateRawAt(time.Now(), topic, data, from)
+}
+
+// ValidateRawAt is like ValidateRaw, but validates the message's timing as if it was received at the given time,
+// e.g. the capture time of the message.
+func (mv *messageValidator) ValidateRawAt(receivedAt time.Time, topic string, data []byte, from string) (pubsub.ValidationResult, *ValidationContext, error) {
+	peerID, err := peer.Decode(from)
+	if err != nil {
+		return pubsub.ValidationReject, nil, fmt.Errorf("invalid peer ID: %w", err)
+	}
+	pmsg := &pubsub.Message{
+		Message: &ps_pb.Message{
+			Data:  data,
+			Topic: &topic,
+			From:  []byte(peerID),
+		},
+		ReceivedFrom: peerID,
+	}
+
+	vctx := newValidationContext(receivedAt)
+	vctx.Origin = OriginReplay
+	vctx.Annotate(fields.PeerID(pmsg.ReceivedFrom))
+	vctx.finalize(mv.validateP2PMessageWithContext(vctx, pmsg))
+	return vctx.Result, vctx, vctx.Err
+}
+
+// validateP2PMessage validates the pubsub envelope of the message, and then the SSV message within it,
+// as received at the given time. If successful, it returns the decoded message and its descriptor.
 func (mv *messageValidator) validateP2PMessage(pMsg *pubsub.Message, receivedAt time.Time) (*queue.DecodedSSVMessage, Descriptor, error) {
+	vctx := newValidationContext(receivedAt)
+	if err := mv.validateP2PMessageWithContext(vctx, pMsg); err != nil {
+		return nil, vctx.Descriptor, err
+	}
+	return vctx.Message, vctx.Descriptor, nil
+}
+
+// validateP2PMessageWithContext is like validateP2PMessage, recording what validation learns about the message in the validation context.
+func (mv *messageValidator) validateP2PMessageWithContext(vctx *ValidationContext, pMsg *pubsub.Message) error {
+	vctx.enter(stageP2P)
+
 	topic := pMsg.GetTopic()
 
 	mv.metrics.ActiveMsgValidation(topic)
@@ -304,91 +576,106 @@ func (mv *messageValidator) validateP2PMessage(pMsg *pubsub.Message, receivedAt
 	defer mv.metrics.ActiveMsgValidationDone(topic)
 
 	messageData := pMsg.GetData()
+	if len(messageData) > mv.maxMessageSize {
+		mv.metrics.OversizedMessage()
+		e := ErrPubSubDataTooBig
+		e.got = len(messageData)
+		return e
+	}
 
 	var signatureVerifier func() error
 
-	currentEpoch := mv.netCfg.Beacon.EstimatedEpochAtSlot(mv.netCfg.Beacon.EstimatedSlotAtTime(receivedAt.Unix()))
+	currentEpoch := mv.netCfg.Beacon.EstimatedEpochAtSlot(mv.netCfg.Beacon.EstimatedSlotAtTime(vctx.ReceivedAt.Unix()))
 	if currentEpoch > mv.netCfg.PermissionlessActivationEpoch {
 		decMessageData, operatorID, signature, err := commons.DecodeSignedSSVMessage(messageData)
 		messageData = decMessageData
 		if err != nil {
 			e := ErrMalformedSignedMessage
 			e.innerErr = err
-			return nil, Descriptor{}, e
+			return e
 		}
 
+		vctx.Annotate(zap.Uint64("rsa_signer", operatorID))
+
+		signedData := pMsg.GetData()
 		signatureVerifier = func() error {
-			mv.metrics.MessageValidationRSAVerifications()
-			return mv.verifySignature(messageData, operatorID, signature)
+			// The signer is checked first, since it's cheap compared to verifying the signature.
+			if err := validateSignerConsistency(operatorID, vctx.Message); err != nil {
+				return err
+			}
+			verify := func() error {
+				mv.metrics.MessageValidationRSAVerifications()
+				return mv.verifySignature(messageData, operatorID, signature)
+			}
+			var err error
+			if mv.signatureCache == nil {
+				err = verify()
+			} else {
+				// Duplicates short-circuit only the verification, so they're still counted by the signer's state.
+				err = mv.signatureCache.verify(signedData, verify)
+			}
+			return err
 		}
 	}
 
 	if len(messageData) == 0 {
-		return nil, Descriptor{}, ErrPubSubMessageHasNoData
+		return ErrPubSubMessageHasNoData
 	}
 
 	mv.metrics.MessageSize(len(messageData))
 
-	// Max possible MsgType + MsgID + Data plus 10% for encoding overhead
-	const maxMsgSize = 4 + 56 + 8388668
-	const maxEncodedMsgSize = maxMsgSize + maxMsgSize/10
-	if len(messageData) > maxEncodedMsgSize {
-		e := ErrPubSubDataTooBig
-		e.got = len(messageData)
-		return nil, Descriptor{}, e
-	}
-
 	msg, err := commons.DecodeNetworkMsg(messageData)
 	if err != nil {
 		e := ErrMalformedPubSubMessage
 		e.innerErr = err
-		return nil, Descriptor{}, e
+		return e
 	}
 
 	if msg == nil {
-		return nil, Descriptor{}, ErrEmptyPubSubMessage
+		return ErrEmptyPubSubMessage
 	}
 
 	// Check if the message was sent on the right topic.
-	currentTopic := pMsg.GetTopic()
-	currentTopicBaseName := commons.GetTopicBaseName(currentTopic)
-	topics := commons.ValidatorTopicID(msg.GetID().GetPubKey())
-
-	topicFound := false
-	for _, tp := range topics {
-		if tp == currentTopicBaseName {
-			topicFound = true
-			break
-		}
-	}
-	if !topicFound {
-		return nil, Descriptor{}, ErrTopicNotFound
+	if err := mv.validateTopic(pMsg.GetTopic(), msg); err != nil {
+		return err
 	}
 
 	mv.metrics.SSVMessageType(msg.MsgType)
 
-	return mv.validateSSVMessage(msg, receivedAt, signatureVerifier)
+	return mv.validateSSVMessageWithContext(vctx, msg, signatureVerifier)
 }
 
+// validateSSVMessage validates the SSV message as received at the given time.
+// If successful, it returns the decoded message and its descriptor.
 func (mv *messageValidator) validateSSVMessage(ssvMessage *spectypes.SSVMessage, receivedAt time.Time, signatureVerifier func() error) (*queue.DecodedSSVMessage, Descriptor, error) {
-	var descriptor Descriptor
+	vctx := newValidationContext(receivedAt)
+	if err := mv.validateSSVMessageWithContext(vctx, ssvMessage, signatureVerifier); err != nil {
+		return nil, vctx.Descriptor, err
+	}
+	return vctx.Message, vctx.Descriptor, nil
+}
+
+// validateSSVMessageWithContext is like validateSSVMessage, recording its descriptor and decoded message in the validation context.
+func (mv *messageValidator) validateSSVMessageWithContext(vctx *ValidationContext, ssvMessage *spectypes.SSVMessage, signatureVerifier func() error) error {
+	vctx.enter(stageSSV)
+	descriptor := &vctx.Descriptor
 
 	if len(ssvMessage.Data) == 0 {
-		return nil, descriptor, ErrEmptyData
+		return ErrEmptyData
 	}
 
 	if len(ssvMessage.Data) > maxMessageSize {
 		err := ErrSSVDataTooBig
 		err.got = len(ssvMessage.Data)
 		err.want = maxMessageSize
-		return nil, descriptor, err
+		return err
 	}
 
 	if !bytes.Equal(ssvMessage.MsgID.GetDomain(), mv.netCfg.Domain[:]) {
 		err := ErrWrongDomain
 		err.got = hex.EncodeToString(ssvMessage.MsgID.GetDomain())
 		err.want = hex.EncodeToString(mv.netCfg.Domain[:])
-		return nil, descriptor, err
+		return err
 	}
 
 	validatorPK := ssvMessage.GetID().GetPubKey()
@@ -397,14 +684,20 @@ func (mv *messageValidator) validateSSVMessage(ssvMessage *spectypes.SSVMessage,
 	descriptor.ValidatorPK = validatorPK
 
 	if !mv.validRole(role) {
-		return nil, descriptor, ErrInvalidRole
+		return ErrInvalidRole
+	}
+
+	if !mv.roleSwitches.enabled(role) {
+		e := ErrRoleValidationDisabled
+		e.got = role
+		return e
 	}
 
 	publicKey, err := ssvtypes.DeserializeBLSPublicKey(validatorPK)
 	if err != nil {
 		e := ErrDeserializePublicKey
 		e.innerErr = err
-		return nil, descriptor, e
+		return e
 	}
 
 	var share *ssvtypes.SSVShare
@@ -413,21 +706,21 @@ func (mv *messageValidator) validateSSVMessage(ssvMessage *spectypes.SSVMessage,
 		if share == nil {
 			e := ErrUnknownValidator
 			e.got = publicKey.SerializeToHexStr()
-			return nil, descriptor, e
+			return e
 		}
 
 		if share.Liquidated {
-			return nil, descriptor, ErrValidatorLiquidated
+			return ErrValidatorLiquidated
 		}
 
 		if share.BeaconMetadata == nil {
-			return nil, descriptor, ErrNoShareMetadata
+			return ErrNoShareMetadata
 		}
 
 		if !share.IsAttesting(mv.netCfg.Beacon.EstimatedCurrentEpoch()) {
 			err := ErrValidatorNotAttesting
 			err.got = share.BeaconMetadata.Status.String()
-			return nil, descriptor, err
+			return err
 		}
 	}
 
@@ -436,14 +729,16 @@ func (mv *messageValidator) validateSSVMessage(ssvMessage *spectypes.SSVMessage,
 		if errors.Is(err, queue.ErrUnknownMessageType) {
 			e := ErrUnknownSSVMessageType
 			e.got = ssvMessage.GetType()
-			return nil, descriptor, e
+			return e
 		}
 
 		e := ErrMalformedMessage
 		e.innerErr = err
-		return nil, descriptor, e
+		return e
 	}
 
+	vctx.Message = msg
+
 	// Lock this SSV message ID to prevent concurrent access to the same state.
 	mv.validationMutex.Lock()
 	mutex, ok := mv.validationLocks[msg.GetID()]
@@ -464,15 +759,16 @@ func (mv *messageValidator) validateSSVMessage(ssvMessage *spectypes.SSVMessage,
 				e := ErrSSVDataTooBig
 				e.got = len(ssvMessage.Data)
 				e.want = maxConsensusMsgSize
-				return nil, descriptor, e
+				return withMessage(e, msg)
 			}
 
+			vctx.enter(stageConsensus)
 			signedMessage := msg.Body.(*specqbft.SignedMessage)
-			consensusDescriptor, slot, err := mv.validateConsensusMessage(share, signedMessage, msg.GetID(), receivedAt, signatureVerifier)
+			consensusDescriptor, slot, err := mv.validateConsensusMessage(vctx, share, signedMessage, msg.GetID(), signatureVerifier)
 			descriptor.Consensus = &consensusDescriptor
 			descriptor.Slot = slot
 			if err != nil {
-				return nil, descriptor, err
+				return withMessage(err, msg)
 			}
 
 		case spectypes.SSVPartialSignatureMsgType:
@@ -480,25 +776,26 @@ func (mv *messageValidator) validateSSVMessage(ssvMessage *spectypes.SSVMessage,
 				e := ErrSSVDataTooBig
 				e.got = len(ssvMessage.Data)
 				e.want = maxPartialSignatureMsgSize
-				return nil, descriptor, e
+				return withMessage(e, msg)
 			}
 
+			vctx.enter(stagePartialSignature)
 			partialSignatureMessage := msg.Body.(*spectypes.SignedPartialSignatureMessage)
-			slot, err := mv.validatePartialSignatureMessage(share, partialSignatureMessage, msg.GetID(), signatureVerifier)
+			slot, err := mv.validatePartialSignatureMessage(vctx, share, partialSignatureMessage, msg.GetID(), signatureVerifier)
 			descriptor.Slot = slot
 			if err != nil {
-				return nil, descriptor, err
+				return withMessage(err, msg)
 			}
 
 		case ssvmessage.SSVEventMsgType:
-			return nil, descriptor, ErrEventMessage
+			return withMessage(ErrEventMessage, msg)
 
 		case spectypes.DKGMsgType:
-			return nil, descriptor, ErrDKGMessage
+			return withMessage(ErrDKGMessage, msg)
 		}
 	}
 
-	return msg, descriptor, nil
+	return nil
 }
 
 func (mv *messageValidator) containsSignerFunc(signer spectypes.OperatorID) func(operator *spectypes.Operator) bool {
@@ -551,14 +848,22 @@ func (mv *messageValidator) earlyMessage(slot phase0.Slot, receivedAt time.Time)
 		Add(-clockErrorTolerance).Before(mv.netCfg.Beacon.GetSlotStartTime(slot))
 }
 
-func (mv *messageValidator) lateMessage(slot phase0.Slot, role spectypes.BeaconRole, receivedAt time.Time) time.Duration {
-	var ttl phase0.Slot
+// messageTTL returns the number of slots following its slot in which a message of the role is valid,
+// or false if messages of the role don't expire.
+func messageTTL(role spectypes.BeaconRole) (phase0.Slot, bool) {
 	switch role {
 	case spectypes.BNRoleProposer, spectypes.BNRoleSyncCommittee, spectypes.BNRoleSyncCommitteeContribution:
-		ttl = 1 + lateSlotAllowance
+		return 1 + lateSlotAllowance, true
 	case spectypes.BNRoleAttester, spectypes.BNRoleAggregator:
-		ttl = 32 + lateSlotAllowance
-	case spectypes.BNRoleValidatorRegistration, spectypes.BNRoleVoluntaryExit:
+		return 32 + lateSlotAllowance, true
+	default:
+		return 0, false
+	}
+}
+
+func (mv *messageValidator) lateMessage(slot phase0.Slot, role spectypes.BeaconRole, receivedAt time.Time) time.Duration {
+	ttl, ok := messageTTL(role)
+	if !ok {
 		return 0
 	}
 
@@ -0,0 +1,152 @@
+package validation
+
+import (
+	"time"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/protocol/v2/ssv/queue"
+)
+
+// Validation stages, in the order a message passes through them.
+const (
+	stageP2P              = "p2p"
+	stageSSV              = "ssv"
+	stageConsensus        = "consensus"
+	stagePartialSignature = "partial_signature"
+)
+
+// MessageOrigin describes where a validated message came from.
+type MessageOrigin string
+
+// Message origins.
+const (
+	// OriginGossip is a message received from another peer over gossip.
+	OriginGossip MessageOrigin = "gossip"
+	// OriginLocal is a message produced by this node.
+	OriginLocal MessageOrigin = "local"
+	// OriginReplay is a previously received message which is validated again.
+	OriginReplay MessageOrigin = "replay"
+)
+
+// ValidationContext is threaded through the validation stages of a message, accumulating
+// what each stage learns about it, so that the outcome can be logged and reported
+// without decoding the message again.
+type ValidationContext struct {
+	// Descriptor describes the message, as far as validation got.
+	Descriptor Descriptor
+	// Message is the decoded message, or nil if validation failed before decoding it.
+	Message *queue.DecodedSSVMessage
+	// Origin is where the message came from, as set by the caller.
+	Origin MessageOrigin
+	// ReceivedAt is the time the message was received, against which its timing is validated.
+	ReceivedAt time.Time
+	// Duration is the time validation took.
+	Duration time.Duration
+	// Stage is the last validation stage the message entered, which is the one that failed it if it's not accepted.
+	Stage string
+	// Result is the verdict of the validation.
+	Result pubsub.ValidationResult
+	// Reason describes why the message wasn't accepted, with a bounded cardinality.
+	Reason string
+	// Err is the error which failed validation, if any.
+	Err error
+
+	start       time.Time
+	annotations []zap.Field
+}
+
+func newValidationContext(receivedAt time.Time) *ValidationContext {
+	return &ValidationContext{
+		ReceivedAt: receivedAt,
+		start:      time.Now(),
+	}
+}
+
+// enter records that the message entered the given validation stage.
+func (vc *ValidationContext) enter(stage string) {
+	vc.Stage = stage
+}
+
+// Annotate records findings of a validation stage, to be included in the logger fields.
+func (vc *ValidationContext) Annotate(fields ...zap.Field) {
+	vc.annotations = append(vc.annotations, fields...)
+}
+
+// finalize records the outcome of the validation.
+func (vc *ValidationContext) finalize(err error) {
+	vc.Duration = time.Since(vc.start)
+	vc.Err = err
+	if err == nil {
+		vc.Result = pubsub.ValidationAccept
+		vc.Reason = ""
+		return
+	}
+	vc.Result = validationResult(err)
+	vc.Reason = errorReason(err)
+}
+
+// Round returns the consensus round of the message, or zero for non-consensus messages.
+func (vc *ValidationContext) Round() specqbft.Round {
+	if vc.Descriptor.Consensus == nil {
+		return 0
+	}
+	return vc.Descriptor.Consensus.Round
+}
+
+// Silent returns whether the outcome shouldn't be logged.
+func (vc *ValidationContext) Silent() bool {
+	var valErr Error
+	return errors.As(vc.Err, &valErr) && valErr.Silent()
+}
+
+// LoggerFields returns zap logging fields describing the message and the outcome of its validation.
+func (vc *ValidationContext) LoggerFields() []zap.Field {
+	result := vc.Descriptor.Fields()
+	result = append(result, messageFields(vc.Message)...)
+	result = append(result, vc.annotations...)
+	if vc.Origin != "" {
+		result = append(result, zap.String("origin", string(vc.Origin)))
+	}
+	result = append(result,
+		zap.String("validation_stage", vc.Stage),
+		fields.Took(vc.Duration),
+	)
+	if vc.Err != nil && !vc.Silent() {
+		result = append(result, zap.Error(vc.Err))
+	}
+	return result
+}
+
+// messageFields returns zap logging fields describing the decoded message.
+func messageFields(msg *queue.DecodedSSVMessage) []zap.Field {
+	if msg == nil || msg.SSVMessage == nil {
+		return nil
+	}
+
+	result := []zap.Field{
+		fields.MessageID(msg.MsgID),
+		fields.MessageType(msg.MsgType),
+	}
+
+	switch body := msg.Body.(type) {
+	case *specqbft.SignedMessage:
+		result = append(result,
+			fields.Height(body.Message.Height),
+			zap.Int("full_data_size", len(body.FullData)),
+		)
+	case *spectypes.SignedPartialSignatureMessage:
+		result = append(result,
+			zap.Uint64("partial_signature_signer", body.Signer),
+			zap.Int("partial_signature_type", int(body.Message.Type)),
+			zap.Int("partial_signature_messages", len(body.Message.Messages)),
+		)
+	}
+
+	return result
+}
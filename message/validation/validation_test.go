@@ -2,8 +2,12 @@ package validation
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
+	"fmt"
 	"math"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -16,17 +20,23 @@ import (
 	"github.com/herumi/bls-eth-go-binary/bls"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	pspb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/require"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 
+	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/monitoring/metricsreporter"
 	"github.com/bloxapp/ssv/network/commons"
 	"github.com/bloxapp/ssv/networkconfig"
+	operatordatastore "github.com/bloxapp/ssv/operator/datastore"
 	"github.com/bloxapp/ssv/operator/duties/dutystore"
 	"github.com/bloxapp/ssv/operator/keys"
 	"github.com/bloxapp/ssv/operator/storage"
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 	ssvmessage "github.com/bloxapp/ssv/protocol/v2/message"
+	"github.com/bloxapp/ssv/protocol/v2/ssv/queue"
 	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
 	registrystorage "github.com/bloxapp/ssv/registry/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
@@ -181,6 +191,36 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		require.ErrorIs(t, err, ErrPubSubMessageHasNoData)
 	})
 
+	// Send a pubsub message exceeding the configured max size should be rejected before decoding
+	t.Run("oversize message", func(t *testing.T) {
+		const maxSize = 1000
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns), WithMaxMessageSize(maxSize)).(*messageValidator)
+
+		slot := netCfg.Beacon.FirstSlotAtEpoch(1)
+
+		topic := commons.GetTopicFullName(commons.ValidatorTopicID(share.ValidatorPubKey)[0])
+		pmsg := &pubsub.Message{
+			Message: &pspb.Message{
+				Data:  bytes.Repeat([]byte{1}, maxSize+1),
+				Topic: &topic,
+				From:  []byte("16Uiu2HAkyWQyCb6reWXGQeBUt9EXArk6h3aq3PsFMwLNq3pPGH1r"),
+			},
+		}
+
+		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
+		_, _, err := validator.validateP2PMessage(pmsg, receivedAt)
+
+		e := ErrPubSubDataTooBig
+		e.got = maxSize + 1
+		require.ErrorIs(t, err, e)
+		require.Equal(t, pubsub.ValidationReject, validator.ValidatePubsubMessage(context.Background(), "peer", pmsg))
+
+		// Messages within the limit are decoded.
+		pmsg.Data = pmsg.Data[:maxSize]
+		_, _, err = validator.validateP2PMessage(pmsg, receivedAt)
+		require.ErrorContains(t, err, ErrMalformedPubSubMessage.Error())
+	})
+
 	// Send a pubsub message where there is too much data should cause an error
 	t.Run("pubsub data too big", func(t *testing.T) {
 		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
@@ -204,6 +244,100 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		require.ErrorIs(t, err, e)
 	})
 
+	// Send a valid message on a topic which shouldn't carry it should cause an error
+	t.Run("wrong topic", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+
+		slot := netCfg.Beacon.FirstSlotAtEpoch(1)
+		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
+
+		encoded, err := spectestingutils.TestingProposalMessage(ks.Shares[1], 1).Encode()
+		require.NoError(t, err)
+
+		message := &spectypes.SSVMessage{
+			MsgType: spectypes.SSVConsensusMsgType,
+			MsgID:   spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, roleAttester),
+			Data:    encoded,
+		}
+		encodedMsg, err := commons.EncodeNetworkMsg(message)
+		require.NoError(t, err)
+
+		subnet := commons.ValidatorSubnet(hex.EncodeToString(share.ValidatorPubKey))
+		pMsg := func(topic string, data []byte) *pubsub.Message {
+			return &pubsub.Message{
+				Message: &pspb.Message{
+					Topic: &topic,
+					Data:  data,
+				},
+			}
+		}
+
+		// Another validator's subnet.
+		otherSubnet := commons.GetTopicFullName(commons.SubnetTopicID((subnet + 1) % commons.Subnets()))
+		_, _, err = validator.validateP2PMessage(pMsg(otherSubnet, encodedMsg), receivedAt)
+		require.ErrorIs(t, err, ErrTopicNotFound)
+
+		// Not a subnet topic.
+		decidedTopic := commons.GetTopicFullName("decided")
+		_, _, err = validator.validateP2PMessage(pMsg(decidedTopic, encodedMsg), receivedAt)
+		require.ErrorContains(t, err, ErrTopicNotFound.Error())
+
+		// A message type which subnets don't carry, on the validator's subnet.
+		message.MsgType = ssvmessage.SSVEventMsgType
+		message.Data, err = (&ssvtypes.EventMsg{}).Encode()
+		require.NoError(t, err)
+		encodedMsg, err = commons.EncodeNetworkMsg(message)
+		require.NoError(t, err)
+
+		validatorSubnet := commons.GetTopicFullName(commons.SubnetTopicID(subnet))
+		_, _, err = validator.validateP2PMessage(pMsg(validatorSubnet, encodedMsg), receivedAt)
+		require.ErrorContains(t, err, ErrWrongTopicMessageType.Error())
+		require.Equal(t, pubsub.ValidationReject, validator.ValidatePubsubMessage(context.Background(), "peer", pMsg(validatorSubnet, encodedMsg)))
+	})
+
+	// Captured messages can be validated offline as if they were received at their capture time
+	t.Run("raw message", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns), WithObserverMode()).(*messageValidator)
+
+		slot := netCfg.Beacon.FirstSlotAtEpoch(1)
+		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
+
+		encoded, err := spectestingutils.TestingProposalMessageWithHeight(ks.Shares[1], 1, specqbft.Height(slot)).Encode()
+		require.NoError(t, err)
+		encodedMsg, err := commons.EncodeNetworkMsg(&spectypes.SSVMessage{
+			MsgType: spectypes.SSVConsensusMsgType,
+			MsgID:   spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, roleAttester),
+			Data:    encoded,
+		})
+		require.NoError(t, err)
+
+		subnet := commons.ValidatorSubnet(hex.EncodeToString(share.ValidatorPubKey))
+		validatorSubnet := commons.GetTopicFullName(commons.SubnetTopicID(subnet))
+		from := "16Uiu2HAkyWQyCb6reWXGQeBUt9EXArk6h3aq3PsFMwLNq3pPGH1r"
+		peerID, err := peer.Decode(from)
+		require.NoError(t, err)
+
+		// The verdict isn't affected by observer mode.
+		result, vctx, err := validator.ValidateRawAt(receivedAt, validatorSubnet, encodedMsg, from)
+		require.NoError(t, err)
+		require.Equal(t, pubsub.ValidationAccept, result)
+		require.Equal(t, OriginReplay, vctx.Origin)
+		require.NotNil(t, vctx.Message)
+
+		// Rejections are reproduced along with the fields they were logged with.
+		otherSubnet := commons.GetTopicFullName(commons.SubnetTopicID((subnet + 1) % commons.Subnets()))
+		result, vctx, err = validator.ValidateRawAt(receivedAt, otherSubnet, encodedMsg, from)
+		require.ErrorIs(t, err, ErrTopicNotFound)
+		require.Equal(t, pubsub.ValidationReject, result)
+		require.Contains(t, vctx.LoggerFields(), zap.String("validation_stage", stageP2P))
+		require.Contains(t, vctx.LoggerFields(), fields.PeerID(peerID))
+
+		// Messages from invalid peer IDs aren't validated.
+		_, vctx, err = validator.ValidateRawAt(receivedAt, validatorSubnet, encodedMsg, "invalid")
+		require.Error(t, err)
+		require.Nil(t, vctx)
+	})
+
 	// Send a malformed pubsub message (empty message) should return an error
 	t.Run("empty pubsub message", func(t *testing.T) {
 		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
@@ -225,6 +359,41 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		require.ErrorContains(t, err, ErrMalformedPubSubMessage.Error())
 	})
 
+	// Observer mode should ignore messages regardless of the validation outcome
+	t.Run("observer mode", func(t *testing.T) {
+		topic := commons.GetTopicFullName(commons.ValidatorTopicID(share.ValidatorPubKey)[0])
+		pmsg := &pubsub.Message{
+			Message: &pspb.Message{
+				Data:  bytes.Repeat([]byte{1}, 10_000_000),
+				Topic: &topic,
+				From:  []byte("16Uiu2HAkyWQyCb6reWXGQeBUt9EXArk6h3aq3PsFMwLNq3pPGH1r"),
+			},
+		}
+
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns))
+		require.Equal(t, pubsub.ValidationReject, validator.ValidatePubsubMessage(context.Background(), "peer", pmsg))
+
+		var observed []*queue.DecodedSSVMessage
+		observer := NewMessageValidator(netCfg, WithNodeStorage(ns), WithObserverMode(), WithSelfAccept("self", true))
+		observer.(ObservedMessageDeliverer).DeliverObserved(func(_ context.Context, msg *queue.DecodedSSVMessage) {
+			observed = append(observed, msg)
+		})
+		require.Equal(t, pubsub.ValidationIgnore, observer.ValidatePubsubMessage(context.Background(), "peer", pmsg))
+		require.Empty(t, observed)
+
+		// Messages which would be accepted are delivered locally, although they're ignored.
+		msg, err := commons.EncodeNetworkMsg(&spectypes.SSVMessage{
+			MsgType: spectypes.SSVConsensusMsgType,
+			MsgID:   spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, roleAttester),
+			Data:    []byte{1},
+		})
+		require.NoError(t, err)
+		pmsg.Data = msg
+		require.Equal(t, pubsub.ValidationIgnore, observer.ValidatePubsubMessage(context.Background(), "self", pmsg))
+		require.Len(t, observed, 1)
+		require.Equal(t, pmsg.ValidatorData, observed[0])
+	})
+
 	// Send a message with incorrect data (unable to decode incorrect message type)
 	t.Run("bad data format", func(t *testing.T) {
 		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
@@ -402,6 +571,86 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		require.ErrorIs(t, err, ErrInvalidRole)
 	})
 
+	// Messages of a role whose validation is disabled are ignored until it's enabled again
+	t.Run("disabled role", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+
+		slot := netCfg.Beacon.FirstSlotAtEpoch(1)
+		height := specqbft.Height(slot)
+
+		validSignedMessage := spectestingutils.TestingProposalMessageWithHeight(ks.Shares[1], 1, height)
+		encodedValidSignedMessage, err := validSignedMessage.Encode()
+		require.NoError(t, err)
+
+		message := &spectypes.SSVMessage{
+			MsgType: spectypes.SSVConsensusMsgType,
+			MsgID:   spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, roleAttester),
+			Data:    encodedValidSignedMessage,
+		}
+
+		require.ErrorContains(t, validator.SetRoleValidation(math.MaxUint64, false), "invalid role")
+		require.NoError(t, validator.SetRoleValidation(roleAttester, false))
+		require.Equal(t, []spectypes.BeaconRole{roleAttester}, validator.DisabledRoles())
+
+		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
+		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		expectedErr := ErrRoleValidationDisabled
+		expectedErr.got = roleAttester
+		require.ErrorIs(t, err, expectedErr)
+		require.False(t, expectedErr.Reject())
+
+		require.NoError(t, validator.SetRoleValidation(roleAttester, true))
+		require.Empty(t, validator.DisabledRoles())
+		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		require.NoError(t, err)
+	})
+
+	// Messages of other peers are ignored without validating them while validation is paused
+	t.Run("paused", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+		topic := commons.GetTopicFullName(commons.ValidatorTopicID(share.ValidatorPubKey)[0])
+		pmsg := &pubsub.Message{
+			Message: &pspb.Message{
+				Topic: &topic,
+				Data:  []byte{1, 2, 3},
+			},
+		}
+		unpaused := validator.ValidatePubsubMessage(context.Background(), "peer", pmsg)
+		require.NotEqual(t, pubsub.ValidationIgnore, unpaused)
+
+		validator.Pause()
+		validator.Pause()
+		require.True(t, validator.Paused())
+		require.Equal(t, pubsub.ValidationIgnore, validator.ValidatePubsubMessage(context.Background(), "peer", pmsg))
+
+		validator.Resume()
+		require.False(t, validator.Paused())
+		require.Equal(t, unpaused, validator.ValidatePubsubMessage(context.Background(), "peer", pmsg))
+	})
+
+	// The node's own messages are validated while validation is paused, given the peer ID of its network key as in production
+	t.Run("paused with self peer ID", func(t *testing.T) {
+		netKey, err := commons.GenNetworkKey()
+		require.NoError(t, err)
+		selfPID, err := commons.PeerIDFromNetworkKey(netKey)
+		require.NoError(t, err)
+
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns), WithSelfPeerID(selfPID)).(*messageValidator)
+		topic := commons.GetTopicFullName(commons.ValidatorTopicID(share.ValidatorPubKey)[0])
+		pmsg := &pubsub.Message{
+			Message: &pspb.Message{
+				Topic: &topic,
+				Data:  []byte{1, 2, 3},
+			},
+		}
+		unpaused := validator.ValidatePubsubMessage(context.Background(), selfPID, pmsg)
+		require.NotEqual(t, pubsub.ValidationIgnore, unpaused)
+
+		validator.Pause()
+		require.Equal(t, unpaused, validator.ValidatePubsubMessage(context.Background(), selfPID, pmsg))
+		require.Equal(t, pubsub.ValidationIgnore, validator.ValidatePubsubMessage(context.Background(), "peer", pmsg))
+	})
+
 	// Perform validator registration or voluntary exit with a consensus type message will give an error
 	t.Run("unexpected consensus message", func(t *testing.T) {
 		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
@@ -820,6 +1069,130 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		require.ErrorIs(t, err, ErrDuplicatedPartialSignatureMessage)
 	})
 
+	// Partial signature messages of validators outside own operator's committees are ignored before verification
+	t.Run("partial non-committee validator", func(t *testing.T) {
+		slot := netCfg.Beacon.FirstSlotAtEpoch(1)
+
+		msg := spectestingutils.PostConsensusAttestationMsg(ks.Shares[1], 1, specqbft.Height(slot))
+		encoded, err := msg.Encode()
+		require.NoError(t, err)
+
+		message := &spectypes.SSVMessage{
+			MsgType: spectypes.SSVPartialSignatureMsgType,
+			MsgID:   spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, roleAttester),
+			Data:    encoded,
+		}
+		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(time.Second)
+		failingVerifier := func() error {
+			return ErrSignatureVerification
+		}
+
+		nonCommitteeOperator := operatordatastore.New(&registrystorage.OperatorData{ID: 5})
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns), WithOwnOperatorID(nonCommitteeOperator), WithCommitteeValidatorsOnly()).(*messageValidator)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, failingVerifier)
+		expectedErr := ErrNonCommitteeValidator
+		expectedErr.want = spectypes.OperatorID(5)
+		require.ErrorIs(t, err, expectedErr)
+
+		// Messages of own committees are verified.
+		committeeOperator := operatordatastore.New(&registrystorage.OperatorData{ID: 1})
+		validator = NewMessageValidator(netCfg, WithNodeStorage(ns), WithOwnOperatorID(committeeOperator), WithCommitteeValidatorsOnly()).(*messageValidator)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, failingVerifier)
+		require.ErrorIs(t, err, ErrSignatureVerification)
+
+		// Until own operator ID is known, committees can't be told apart, so messages are verified.
+		validator = NewMessageValidator(netCfg, WithNodeStorage(ns), WithOwnOperatorID(operatordatastore.New(nil)), WithCommitteeValidatorsOnly()).(*messageValidator)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, failingVerifier)
+		require.ErrorIs(t, err, ErrSignatureVerification)
+
+		// Without the option, messages of other committees are verified and relayed.
+		validator = NewMessageValidator(netCfg, WithNodeStorage(ns), WithOwnOperatorID(nonCommitteeOperator)).(*messageValidator)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		require.NoError(t, err)
+	})
+
+	// Partial signatures are verified in batches, pinpointing the invalid signature on batch failure
+	t.Run("partial signature verification", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns), WithPartialSignatureVerification(2)).(*messageValidator)
+
+		msg := spectestingutils.PostConsensusSyncCommitteeContributionMsg(ks.Shares[1], 1, ks)
+		require.Len(t, msg.Message.Messages, 3)
+		require.NoError(t, validator.verifyPartialSignatures(share, msg))
+
+		// Signatures of another signer
+		msg.Signer = 2
+		require.ErrorContains(t, validator.verifyPartialSignatures(share, msg), ErrInvalidPartialSignature.Error())
+		msg.Signer = 1
+
+		// Swapped signatures fail individual verification even though their aggregate is valid
+		msg.Message.Messages[0].PartialSignature, msg.Message.Messages[1].PartialSignature =
+			msg.Message.Messages[1].PartialSignature, msg.Message.Messages[0].PartialSignature
+		expectedErr := ErrInvalidPartialSignature
+		expectedErr.got = fmt.Sprintf("signing root %x", msg.Message.Messages[0].SigningRoot)
+		require.ErrorIs(t, validator.verifyPartialSignatures(share, msg), expectedErr)
+	})
+
+	// Partial signatures of concurrently validated messages of the same slot are verified together,
+	// and only the messages with invalid signatures fail
+	t.Run("partial signature verification across messages", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns), WithPartialSignatureVerification(6)).(*messageValidator)
+		// Batches are verified only once they're full.
+		validator.partialSignatures = newPartialSignatureBatcher(validator.metrics, 6, time.Hour)
+
+		verifyConcurrently := func(msgs ...*spectypes.SignedPartialSignatureMessage) []error {
+			errs := make([]error, len(msgs))
+			var wg sync.WaitGroup
+			for i, msg := range msgs {
+				wg.Add(1)
+				go func(i int, msg *spectypes.SignedPartialSignatureMessage) {
+					defer wg.Done()
+					errs[i] = validator.verifyPartialSignatures(share, msg)
+				}(i, msg)
+			}
+			wg.Wait()
+			return errs
+		}
+
+		msg1 := spectestingutils.PostConsensusSyncCommitteeContributionMsg(ks.Shares[1], 1, ks)
+		msg2 := spectestingutils.PostConsensusSyncCommitteeContributionMsg(ks.Shares[2], 2, ks)
+		require.Equal(t, []error{nil, nil}, verifyConcurrently(msg1, msg2))
+
+		msg2.Message.Messages[0].PartialSignature, msg2.Message.Messages[1].PartialSignature =
+			msg2.Message.Messages[1].PartialSignature, msg2.Message.Messages[0].PartialSignature
+		errs := verifyConcurrently(msg1, msg2)
+		require.NoError(t, errs[0])
+		require.ErrorContains(t, errs[1], ErrInvalidPartialSignature.Error())
+	})
+
+	// Receive error when a validator registration partial signature is repeated within a slot
+	t.Run("repeated validator registration partial signatures", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+
+		msg := spectestingutils.PreConsensusValidatorRegistrationMsg(ks.Shares[1], 1)
+		encoded, err := msg.Encode()
+		require.NoError(t, err)
+
+		msgID := spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, spectypes.BNRoleValidatorRegistration)
+		message := &spectypes.SSVMessage{
+			MsgType: spectypes.SSVPartialSignatureMsgType,
+			MsgID:   msgID,
+			Data:    encoded,
+		}
+
+		receivedAt := netCfg.Beacon.GetSlotStartTime(msg.Message.Slot)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		require.NoError(t, err)
+
+		signerState := validator.consensusState(msgID).GetSignerState(1)
+		require.NotNil(t, signerState)
+		require.EqualValues(t, MessageCounts{ValidatorRegistration: 1}, signerState.MessageCounts)
+
+		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		expectedErr := ErrTooManySameTypeMessagesPerRound
+		expectedErr.got = "validator registration, having pre-consensus: 0, proposal: 0, prepare: 0, commit: 0, decided: 0, round change: 0, post-consensus: 0, validator registration: 1"
+		require.ErrorIs(t, err, expectedErr)
+	})
+
 	// Receive error when "partialSignatureMessages" does not contain any "partialSignatureMessage"
 	t.Run("no partial signature messages", func(t *testing.T) {
 		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
@@ -1451,6 +1824,38 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		require.ErrorIs(t, err, expectedErr)
 	})
 
+	// Send round change message with a justification signed by an operator outside of the committee should receive an error
+	t.Run("round change justification signer not in committee", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+
+		slot := netCfg.Beacon.FirstSlotAtEpoch(1)
+
+		msg := spectestingutils.TestingProposalMessageWithParams(
+			ks.Shares[1], spectypes.OperatorID(1), specqbft.FirstRound, specqbft.Height(slot), spectestingutils.TestingQBFTRootData,
+			spectestingutils.MarshalJustifications([]*specqbft.SignedMessage{
+				spectestingutils.TestingPrepareMessage(ks.Shares[1], spectypes.OperatorID(5)),
+			}),
+			nil,
+		)
+		msg.Message.MsgType = specqbft.RoundChangeMsgType
+
+		encodedValidSignedMessage, err := msg.Encode()
+		require.NoError(t, err)
+
+		message := &spectypes.SSVMessage{
+			MsgType: spectypes.SSVConsensusMsgType,
+			MsgID:   spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, roleAttester),
+			Data:    encodedValidSignedMessage,
+		}
+
+		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
+		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		require.ErrorIs(t, err, ErrSignerNotInCommittee)
+
+		// The message isn't counted toward the signer's limits.
+		require.Nil(t, validator.consensusState(message.GetID()).GetSignerState(1))
+	})
+
 	// Send round change justification message with a malformed message (1 byte) should receive an error
 	t.Run("malformed round change justification", func(t *testing.T) {
 		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
@@ -1502,6 +1907,57 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		require.ErrorIs(t, err, expectedErr)
 	})
 
+	// Send message whose full data is of a duty slot other than its height should receive an error
+	t.Run("slot skew", func(t *testing.T) {
+		slot := netCfg.Beacon.FirstSlotAtEpoch(1)
+
+		proposal := func(dutySlot phase0.Slot) *spectypes.SSVMessage {
+			consensusData := *spectestingutils.TestAttesterConsensusData
+			consensusData.Duty.Slot = dutySlot
+			fullData, err := consensusData.Encode()
+			require.NoError(t, err)
+
+			signedMsg := spectestingutils.TestingProposalMessageWithHeight(ks.Shares[1], 1, specqbft.Height(slot))
+			signedMsg.FullData = fullData
+			signedMsg.Message.Root, err = specqbft.HashDataRoot(fullData)
+			require.NoError(t, err)
+
+			encoded, err := signedMsg.Encode()
+			require.NoError(t, err)
+			return &spectypes.SSVMessage{
+				MsgType: spectypes.SSVConsensusMsgType,
+				MsgID:   spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, roleAttester),
+				Data:    encoded,
+			}
+		}
+
+		// The duty slot isn't checked unless enabled.
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
+
+		_, _, err := validator.validateSSVMessage(proposal(slot+1), receivedAt, nil)
+		require.NoError(t, err)
+
+		validator = NewMessageValidator(netCfg, WithNodeStorage(ns), WithMaxSlotSkew(0)).(*messageValidator)
+		_, _, err = validator.validateSSVMessage(proposal(slot+1), receivedAt, nil)
+		expectedErr := ErrSlotSkew
+		expectedErr.got = slot + 1
+		expectedErr.want = fmt.Sprintf("%v (±%v)", slot, 0)
+		require.ErrorIs(t, err, expectedErr)
+
+		_, _, err = validator.validateSSVMessage(proposal(slot), receivedAt, nil)
+		require.NoError(t, err)
+
+		// Skew within the tolerance is accepted.
+		validator = NewMessageValidator(netCfg, WithNodeStorage(ns), WithMaxSlotSkew(1)).(*messageValidator)
+		_, _, err = validator.validateSSVMessage(proposal(slot-1), receivedAt, nil)
+		require.NoError(t, err)
+
+		validator = NewMessageValidator(netCfg, WithNodeStorage(ns), WithMaxSlotSkew(1)).(*messageValidator)
+		_, _, err = validator.validateSSVMessage(proposal(slot+2), receivedAt, nil)
+		require.ErrorContains(t, err, ErrSlotSkew.Error())
+	})
+
 	// Receive proposal from same operator twice with different messages (same round) should receive an error
 	t.Run("double proposal with different data", func(t *testing.T) {
 		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
@@ -1541,6 +1997,48 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		require.ErrorIs(t, err, expectedErr)
 	})
 
+	// Receive a commit whose root doesn't match the proposal of its round should receive an error
+	t.Run("commit root mismatch", func(t *testing.T) {
+		slot := netCfg.Beacon.FirstSlotAtEpoch(1)
+		msgID := spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, roleAttester)
+
+		encode := func(signedMsg *specqbft.SignedMessage) *spectypes.SSVMessage {
+			encoded, err := signedMsg.Encode()
+			require.NoError(t, err)
+			return &spectypes.SSVMessage{
+				MsgType: spectypes.SSVConsensusMsgType,
+				MsgID:   msgID,
+				Data:    encoded,
+			}
+		}
+
+		for _, commitRootValidation := range []bool{false, true} {
+			opts := []Option{WithNodeStorage(ns)}
+			if commitRootValidation {
+				opts = append(opts, WithCommitRootValidation())
+			}
+			validator := NewMessageValidator(netCfg, opts...).(*messageValidator)
+			receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
+
+			// Commits are validated as usual until the proposal is seen.
+			_, _, err := validator.validateSSVMessage(encode(spectestingutils.TestingCommitMessageWrongRoot(ks.Shares[4], 4)), receivedAt, nil)
+			require.NoError(t, err)
+
+			_, _, err = validator.validateSSVMessage(encode(spectestingutils.TestingProposalMessageWithRound(ks.Shares[1], 1, 1)), receivedAt, nil)
+			require.NoError(t, err)
+
+			_, _, err = validator.validateSSVMessage(encode(spectestingutils.TestingCommitMessage(ks.Shares[2], 2)), receivedAt, nil)
+			require.NoError(t, err)
+
+			_, _, err = validator.validateSSVMessage(encode(spectestingutils.TestingCommitMessageWrongRoot(ks.Shares[3], 3)), receivedAt, nil)
+			if commitRootValidation {
+				require.ErrorContains(t, err, ErrCommitRootMismatch.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		}
+	})
+
 	// Receive prepare from same operator twice with different messages (same round) should receive an error
 	t.Run("double prepare", func(t *testing.T) {
 		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
@@ -1575,7 +2073,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		_, _, err = validator.validateSSVMessage(message2, receivedAt, nil)
 		expectedErr := ErrTooManySameTypeMessagesPerRound
-		expectedErr.got = "prepare, having pre-consensus: 0, proposal: 0, prepare: 1, commit: 0, decided: 0, round change: 0, post-consensus: 0"
+		expectedErr.got = "prepare, having pre-consensus: 0, proposal: 0, prepare: 1, commit: 0, decided: 0, round change: 0, post-consensus: 0, validator registration: 0"
 		require.ErrorIs(t, err, expectedErr)
 	})
 
@@ -1611,7 +2109,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		_, _, err = validator.validateSSVMessage(message2, receivedAt, nil)
 		expectedErr := ErrTooManySameTypeMessagesPerRound
-		expectedErr.got = "commit, having pre-consensus: 0, proposal: 0, prepare: 0, commit: 1, decided: 0, round change: 0, post-consensus: 0"
+		expectedErr.got = "commit, having pre-consensus: 0, proposal: 0, prepare: 0, commit: 1, decided: 0, round change: 0, post-consensus: 0, validator registration: 0"
 		require.ErrorIs(t, err, expectedErr)
 	})
 
@@ -1647,7 +2145,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		_, _, err = validator.validateSSVMessage(message2, receivedAt, nil)
 		expectedErr := ErrTooManySameTypeMessagesPerRound
-		expectedErr.got = "round change, having pre-consensus: 0, proposal: 0, prepare: 0, commit: 0, decided: 0, round change: 1, post-consensus: 0"
+		expectedErr.got = "round change, having pre-consensus: 0, proposal: 0, prepare: 0, commit: 0, decided: 0, round change: 1, post-consensus: 0, validator registration: 0"
 		require.ErrorIs(t, err, expectedErr)
 	})
 
@@ -1679,7 +2177,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 
 		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
 		expectedErr := ErrTooManySameTypeMessagesPerRound
-		expectedErr.got = "decided, having pre-consensus: 0, proposal: 0, prepare: 0, commit: 0, decided: 8, round change: 0, post-consensus: 0"
+		expectedErr.got = "decided, having pre-consensus: 0, proposal: 0, prepare: 0, commit: 0, decided: 8, round change: 0, post-consensus: 0, validator registration: 0"
 		require.ErrorIs(t, err, expectedErr)
 	})
 
@@ -1717,6 +2215,104 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 	})
 
+	// In strict spec mode, a round beyond the role's limit is rejected rather than ignored
+	t.Run("strict spec round too high", func(t *testing.T) {
+		msgID := spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, spectypes.BNRoleProposer)
+
+		signedMessage := spectestingutils.TestingPrepareMessageWithRound(ks.Shares[1], 1, 7)
+		encodedMessage, err := signedMessage.Encode()
+		require.NoError(t, err)
+
+		ssvMessage := &spectypes.SSVMessage{
+			MsgType: spectypes.SSVConsensusMsgType,
+			MsgID:   msgID,
+			Data:    encodedMessage,
+		}
+
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+		receivedAt := netCfg.Beacon.GetSlotStartTime(0).Add(validator.waitAfterSlotStart(spectypes.BNRoleProposer))
+		_, _, err = validator.validateSSVMessage(ssvMessage, receivedAt, nil)
+		require.ErrorContains(t, err, ErrRoundTooHigh.Error())
+		require.Equal(t, pubsub.ValidationIgnore, validationResult(err))
+
+		validator = NewMessageValidator(netCfg, WithNodeStorage(ns), WithStrictSpec()).(*messageValidator)
+		_, _, err = validator.validateSSVMessage(ssvMessage, receivedAt, nil)
+		require.ErrorContains(t, err, ErrRoundTooHigh.Error())
+		require.Equal(t, pubsub.ValidationReject, validationResult(err))
+	})
+
+	// In strict spec mode, a round too far from the estimated round is rejected rather than ignored
+	t.Run("strict spec round too far from estimated", func(t *testing.T) {
+		msgID := spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, roleAttester)
+
+		signedMessage := spectestingutils.TestingPrepareMessageWithRound(ks.Shares[1], 1, 6)
+		encodedMessage, err := signedMessage.Encode()
+		require.NoError(t, err)
+
+		ssvMessage := &spectypes.SSVMessage{
+			MsgType: spectypes.SSVConsensusMsgType,
+			MsgID:   msgID,
+			Data:    encodedMessage,
+		}
+
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+		receivedAt := netCfg.Beacon.GetSlotStartTime(0).Add(validator.waitAfterSlotStart(roleAttester))
+		_, _, err = validator.validateSSVMessage(ssvMessage, receivedAt, nil)
+		require.ErrorContains(t, err, ErrEstimatedRoundTooFar.Error())
+		require.Equal(t, pubsub.ValidationIgnore, validationResult(err))
+
+		validator = NewMessageValidator(netCfg, WithNodeStorage(ns), WithStrictSpec()).(*messageValidator)
+		_, _, err = validator.validateSSVMessage(ssvMessage, receivedAt, nil)
+		require.ErrorContains(t, err, ErrEstimatedRoundTooFar.Error())
+		require.Equal(t, pubsub.ValidationReject, validationResult(err))
+	})
+
+	// Rounds which the round timeouts can't reach before the message expires are rejected
+	t.Run("implausible round", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+
+		// With 12 second slots, attester messages expire after 34 slots and 3 seconds, in which 8 quick and
+		// 3 slow rounds pass, and proposer messages expire after 3 slots and 3 seconds, in which 8 quick rounds pass.
+		require.EqualValues(t, 12*time.Second, netCfg.Beacon.SlotDurationSec())
+		require.Equal(t, specqbft.Round(13), validator.maxPlausibleRound(spectypes.BNRoleAttester))
+		require.Equal(t, specqbft.Round(10), validator.maxPlausibleRound(spectypes.BNRoleProposer))
+
+		validate := func(validator *messageValidator, role spectypes.BeaconRole, round specqbft.Round) error {
+			signedMessage := spectestingutils.TestingPrepareMessageWithRound(ks.Shares[1], 1, round)
+			encodedMessage, err := signedMessage.Encode()
+			require.NoError(t, err)
+
+			ssvMessage := &spectypes.SSVMessage{
+				MsgType: spectypes.SSVConsensusMsgType,
+				MsgID:   spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, role),
+				Data:    encodedMessage,
+			}
+			receivedAt := netCfg.Beacon.GetSlotStartTime(0).Add(validator.waitAfterSlotStart(role))
+			_, _, err = validator.validateSSVMessage(ssvMessage, receivedAt, nil)
+			return err
+		}
+		plausible := func(err error) bool {
+			return err == nil || !strings.Contains(err.Error(), ErrImplausibleRound.Error())
+		}
+
+		for _, role := range []spectypes.BeaconRole{spectypes.BNRoleAttester, spectypes.BNRoleProposer, spectypes.BNRoleSyncCommittee} {
+			maxRound := validator.maxPlausibleRound(role)
+			require.True(t, plausible(validate(validator, role, maxRound)), role.String())
+
+			err := validate(validator, role, maxRound+1)
+			require.ErrorContains(t, err, ErrImplausibleRound.Error(), role.String())
+			require.Equal(t, pubsub.ValidationReject, validationResult(err))
+		}
+
+		err := validate(validator, spectypes.BNRoleAttester, 10000)
+		require.ErrorContains(t, err, ErrImplausibleRound.Error())
+
+		// The limit can be overridden.
+		validator = NewMessageValidator(netCfg, WithNodeStorage(ns), WithMaxPlausibleRound(5)).(*messageValidator)
+		require.True(t, plausible(validate(validator, spectypes.BNRoleAttester, 5)))
+		require.ErrorContains(t, validate(validator, spectypes.BNRoleAttester, 6), ErrImplausibleRound.Error())
+	})
+
 	// Receive message from a round that is incorrect for current epoch should receive an error
 	t.Run("round already advanced", func(t *testing.T) {
 		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
@@ -1838,6 +2434,114 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		require.ErrorIs(t, err, ErrEventMessage)
 	})
 
+	// Validation errors should carry the decoded message that failed validation
+	t.Run("error carries message", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+
+		slot := netCfg.Beacon.FirstSlotAtEpoch(1)
+
+		signedMsg := spectestingutils.TestingPrepareMessage(ks.Shares[1], 1)
+		encodedMsg, err := signedMsg.Encode()
+		require.NoError(t, err)
+
+		message := &spectypes.SSVMessage{
+			MsgType: spectypes.SSVConsensusMsgType,
+			MsgID:   spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, roleAttester),
+			Data:    encodedMsg,
+		}
+
+		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
+		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		require.NoError(t, err)
+
+		// Wrapped by the signer behavior check.
+		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		require.ErrorContains(t, err, "bad signer behavior: "+ErrTooManySameTypeMessagesPerRound.Error())
+
+		var valErr Error
+		require.ErrorAs(t, err, &valErr)
+		require.NotNil(t, valErr.Message())
+		require.Equal(t, message.MsgID, valErr.Message().MsgID)
+		require.Equal(t, ErrTooManySameTypeMessagesPerRound.Text(), valErr.Text())
+		require.NotEmpty(t, valErr.LoggerFields())
+
+		// Returned directly.
+		message.MsgType = ssvmessage.SSVEventMsgType
+		message.Data, err = (&ssvtypes.EventMsg{}).Encode()
+		require.NoError(t, err)
+
+		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		require.ErrorIs(t, err, ErrEventMessage)
+		require.ErrorAs(t, err, &valErr)
+		require.NotNil(t, valErr.Message())
+		require.IsType(t, &ssvtypes.EventMsg{}, valErr.Message().Body)
+
+		// Errors before decoding have no message.
+		message.Data = nil
+		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		require.ErrorAs(t, err, &valErr)
+		require.Nil(t, valErr.Message())
+		require.Empty(t, valErr.LoggerFields())
+	})
+
+	// The validation context should record what each stage found about the message
+	t.Run("validation context", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+
+		slot := netCfg.Beacon.FirstSlotAtEpoch(1)
+
+		signedMsg := spectestingutils.TestingPrepareMessage(ks.Shares[1], 1)
+		encodedMsg, err := signedMsg.Encode()
+		require.NoError(t, err)
+
+		message := &spectypes.SSVMessage{
+			MsgType: spectypes.SSVConsensusMsgType,
+			MsgID:   spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, roleAttester),
+			Data:    encodedMsg,
+		}
+
+		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
+		vctx := newValidationContext(receivedAt)
+		vctx.finalize(validator.validateSSVMessageWithContext(vctx, message, nil))
+		require.Equal(t, pubsub.ValidationAccept, vctx.Result)
+		require.Empty(t, vctx.Reason)
+		require.Equal(t, stageConsensus, vctx.Stage)
+		require.Equal(t, phase0.Slot(signedMsg.Message.Height), vctx.Descriptor.Slot)
+		require.NotNil(t, vctx.Descriptor.Consensus)
+		require.NotNil(t, vctx.Message)
+		require.Equal(t, message.MsgID, vctx.Message.MsgID)
+		estimatedRound := validator.currentEstimatedRound(receivedAt.Sub(netCfg.Beacon.GetSlotStartTime(vctx.Descriptor.Slot)))
+		require.Contains(t, vctx.LoggerFields(), zap.Uint64("estimated_round", uint64(estimatedRound)))
+
+		// A duplicate is ignored by the consensus stage.
+		vctx = newValidationContext(receivedAt)
+		vctx.finalize(validator.validateSSVMessageWithContext(vctx, message, nil))
+		require.Equal(t, pubsub.ValidationIgnore, vctx.Result)
+		require.Equal(t, ErrTooManySameTypeMessagesPerRound.Text(), vctx.Reason)
+		require.Equal(t, stageConsensus, vctx.Stage)
+		require.NotNil(t, vctx.Message)
+		require.NotEmpty(t, vctx.LoggerFields())
+		require.NotContains(t, vctx.LoggerFields(), zap.String("origin", ""))
+
+		// The origin is logged once set by the caller.
+		vctx.Origin = OriginReplay
+		require.Contains(t, vctx.LoggerFields(), zap.String("origin", string(OriginReplay)))
+
+		// A message failing before decoding fails in the SSV stage.
+		message.Data = nil
+		vctx = newValidationContext(receivedAt)
+		vctx.finalize(validator.validateSSVMessageWithContext(vctx, message, nil))
+		require.Equal(t, pubsub.ValidationIgnore, vctx.Result)
+		require.Equal(t, ErrEmptyData.Text(), vctx.Reason)
+		require.Equal(t, stageSSV, vctx.Stage)
+		require.Nil(t, vctx.Message)
+
+		// Errors other than validation errors are described by a fixed reason, as their text is unbounded.
+		vctx = newValidationContext(receivedAt)
+		vctx.finalize(fmt.Errorf("hash data root: invalid data"))
+		require.Equal(t, "other", vctx.Reason)
+	})
+
 	// Get error when receiving an SSV message with an invalid signature.
 	t.Run("signature verification", func(t *testing.T) {
 		var afterFork = netCfg.PermissionlessActivationEpoch + 1000
@@ -1962,7 +2666,8 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		})
 
 		t.Run("signed message after fork", func(t *testing.T) {
-			validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+			metrics := &rsaChecksRecorder{MetricsReporter: metricsreporter.NewNop()}
+			validator := NewMessageValidator(netCfg, WithNodeStorage(ns), WithMetrics(metrics)).(*messageValidator)
 
 			slot := netCfg.Beacon.FirstSlotAtEpoch(afterFork)
 
@@ -2014,16 +2719,19 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
 			_, _, err = validator.validateP2PMessage(pMsg, receivedAt)
 			require.NoError(t, err)
+			require.Equal(t, map[spectypes.OperatorID][2]int{operatorID: {1, 0}}, metrics.checks)
 
 			require.NoError(t, ns.DeleteOperatorData(nil, operatorID))
 		})
 
 		t.Run("unexpected operator ID", func(t *testing.T) {
-			validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+			metrics := &rsaChecksRecorder{MetricsReporter: metricsreporter.NewNop()}
+			validator := NewMessageValidator(netCfg, WithNodeStorage(ns), WithMetrics(metrics)).(*messageValidator)
 
 			slot := netCfg.Beacon.FirstSlotAtEpoch(afterFork)
 
-			validSignedMessage := spectestingutils.TestingProposalMessageWithHeight(ks.Shares[1], 1, specqbft.Height(slot))
+			// The inner message is of the operator the message is signed under, whose key isn't known.
+			validSignedMessage := spectestingutils.TestingPrepareMessageWithHeight(ks.Shares[2], 2, specqbft.Height(slot))
 
 			encoded, err := validSignedMessage.Encode()
 			require.NoError(t, err)
@@ -2072,12 +2780,15 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
 			_, _, err = validator.validateP2PMessage(pMsg, receivedAt)
 			require.ErrorContains(t, err, ErrOperatorNotFound.Error())
+			// Unknown operators aren't counted, to bound the metric to known operators.
+			require.Empty(t, metrics.checks)
 
 			require.NoError(t, ns.DeleteOperatorData(nil, operatorID))
 		})
 
 		t.Run("malformed signature", func(t *testing.T) {
-			validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+			metrics := &rsaChecksRecorder{MetricsReporter: metricsreporter.NewNop()}
+			validator := NewMessageValidator(netCfg, WithNodeStorage(ns), WithMetrics(metrics)).(*messageValidator)
 
 			slot := netCfg.Beacon.FirstSlotAtEpoch(afterFork)
 
@@ -2126,8 +2837,165 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
 			_, _, err = validator.validateP2PMessage(pMsg, receivedAt)
 			require.ErrorContains(t, err, ErrSignatureVerification.Error())
+			require.Equal(t, map[spectypes.OperatorID][2]int{operatorID: {0, 1}}, metrics.checks)
+
+			require.NoError(t, ns.DeleteOperatorData(nil, operatorID))
+		})
+
+		t.Run("signer mismatch", func(t *testing.T) {
+			validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+
+			slot := netCfg.Beacon.FirstSlotAtEpoch(afterFork)
+
+			// The inner message is signed by operator 1, but relayed under the valid signature of operator 2.
+			validSignedMessage := spectestingutils.TestingProposalMessageWithHeight(ks.Shares[1], 1, specqbft.Height(slot))
+
+			encoded, err := validSignedMessage.Encode()
+			require.NoError(t, err)
+
+			message := &spectypes.SSVMessage{
+				MsgType: spectypes.SSVConsensusMsgType,
+				MsgID:   spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, roleAttester),
+				Data:    encoded,
+			}
+
+			encodedMsg, err := commons.EncodeNetworkMsg(message)
+			require.NoError(t, err)
+
+			privKey, err := keys.GeneratePrivateKey()
+			require.NoError(t, err)
+
+			pubKey, err := privKey.Public().Base64()
+			require.NoError(t, err)
+
+			const operatorID = spectypes.OperatorID(2)
+
+			od := &registrystorage.OperatorData{
+				ID:           operatorID,
+				PublicKey:    pubKey,
+				OwnerAddress: common.Address{},
+			}
+
+			found, err := ns.SaveOperatorData(nil, od)
+			require.NoError(t, err)
+			require.False(t, found)
+
+			signature, err := privKey.Sign(encodedMsg)
+			require.NoError(t, err)
+
+			topicID := commons.ValidatorTopicID(message.GetID().GetPubKey())
+			pMsg := &pubsub.Message{
+				Message: &pspb.Message{
+					Topic: &topicID[0],
+					Data:  commons.EncodeSignedSSVMessage(encodedMsg, operatorID, signature),
+				},
+			}
+
+			receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
+			_, _, err = validator.validateP2PMessage(pMsg, receivedAt)
+			expectedErr := ErrSignerMismatch
+			expectedErr.got = spectypes.OperatorID(1)
+			expectedErr.want = operatorID
+			require.ErrorIs(t, err, expectedErr)
+
+			// The signer is checked before the signature is verified.
+			pMsg.Message.Data = commons.EncodeSignedSSVMessage(encodedMsg, operatorID, make([]byte, len(signature)))
+			_, _, err = validator.validateP2PMessage(pMsg, receivedAt)
+			require.ErrorIs(t, err, expectedErr)
 
 			require.NoError(t, ns.DeleteOperatorData(nil, operatorID))
 		})
 	})
 }
+
+func TestValidateSignerConsistency(t *testing.T) {
+	consensusMessage := func(signers ...spectypes.OperatorID) *queue.DecodedSSVMessage {
+		return &queue.DecodedSSVMessage{Body: &specqbft.SignedMessage{Signers: signers}}
+	}
+	partialSignatureMessage := func(signer spectypes.OperatorID) *queue.DecodedSSVMessage {
+		return &queue.DecodedSSVMessage{Body: &spectypes.SignedPartialSignatureMessage{Signer: signer}}
+	}
+
+	tests := []struct {
+		name    string
+		msg     *queue.DecodedSSVMessage
+		wantErr bool
+	}{
+		{"consensus message of the signer", consensusMessage(1), false},
+		{"consensus message of another operator", consensusMessage(2), true},
+		{"decided message including the signer", consensusMessage(1, 2, 3), false},
+		{"decided message excluding the signer", consensusMessage(2, 3, 4), false},
+		{"partial signature message of the signer", partialSignatureMessage(1), false},
+		{"partial signature message of another operator", partialSignatureMessage(2), true},
+		{"undecoded message", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSignerConsistency(1, tt.msg)
+			if !tt.wantErr {
+				require.NoError(t, err)
+				return
+			}
+			var valErr Error
+			require.ErrorAs(t, err, &valErr)
+			require.Equal(t, ErrSignerMismatch.Text(), valErr.Text())
+			require.True(t, valErr.Reject())
+		})
+	}
+}
+
+func TestValidationResult(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want pubsub.ValidationResult
+	}{
+		// Benign issues which may occur with honest peers are ignored.
+		{"duplicate", ErrTooManySameTypeMessagesPerRound, pubsub.ValidationIgnore},
+		{"early", ErrEarlyMessage, pubsub.ValidationIgnore},
+		{"late", ErrLateMessage, pubsub.ValidationIgnore},
+		{"slot already advanced", ErrSlotAlreadyAdvanced, pubsub.ValidationIgnore},
+		{"round already advanced", ErrRoundAlreadyAdvanced, pubsub.ValidationIgnore},
+		{"unknown validator", ErrUnknownValidator, pubsub.ValidationIgnore},
+		{"liquidated validator", ErrValidatorLiquidated, pubsub.ValidationIgnore},
+		{"no duty (ignored)", ErrNoDutyIgnored, pubsub.ValidationIgnore},
+		{"non-committee validator", ErrNonCommitteeValidator, pubsub.ValidationIgnore},
+		{"empty data", ErrEmptyData, pubsub.ValidationIgnore},
+		{"non-validation error", fmt.Errorf("unexpected"), pubsub.ValidationIgnore},
+
+		// Malicious or malformed messages are rejected.
+		{"malformed message", ErrMalformedMessage, pubsub.ValidationReject},
+		{"too big", ErrPubSubDataTooBig, pubsub.ValidationReject},
+		{"invalid signature", ErrSignatureVerification, pubsub.ValidationReject},
+		{"invalid partial signature", ErrInvalidPartialSignature, pubsub.ValidationReject},
+		{"implausible round", ErrImplausibleRound, pubsub.ValidationReject},
+		{"signer not in committee", ErrSignerNotInCommittee, pubsub.ValidationReject},
+		{"signer not leader", ErrSignerNotLeader, pubsub.ValidationReject},
+		{"equivocation", ErrDuplicatedProposalWithDifferentData, pubsub.ValidationReject},
+		{"wrapped", fmt.Errorf("wrapped: %w", ErrMalformedMessage), pubsub.ValidationReject},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, validationResult(tt.err))
+		})
+	}
+}
+
+// rsaChecksRecorder records the RSA signature verifications by operator, as counts of valid and invalid signatures.
+type rsaChecksRecorder struct {
+	metricsreporter.MetricsReporter
+	checks map[spectypes.OperatorID][2]int
+}
+
+func (r *rsaChecksRecorder) MessageValidationRSAOperatorCheck(operatorID spectypes.OperatorID, valid bool) {
+	if r.checks == nil {
+		r.checks = make(map[spectypes.OperatorID][2]int)
+	}
+	counts := r.checks[operatorID]
+	if valid {
+		counts[0]++
+	} else {
+		counts[1]++
+	}
+	r.checks[operatorID] = counts
+}
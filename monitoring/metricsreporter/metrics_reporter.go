@@ -56,6 +56,10 @@ var (
 		Name: "ssv_execution_client_last_fetched_block",
 		Help: "Last fetched block by execution client",
 	})
+	executionClientLogsGaps = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv_execution_client_logs_gaps",
+		Help: "Count of fetched log batches which were out of order or had gaps",
+	})
 	validatorStatus = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "ssv:validator:v2:status",
 		Help: "Validator status",
@@ -76,6 +80,10 @@ var (
 		Name: "ssv_message_validation",
 		Help: "Message validation result",
 	}, []string{"status", "reason", "role", "round"})
+	messageValidationAccepted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_message_validation_accepted",
+		Help: "Count of accepted messages by role and SSV message type, for throughput via rate()",
+	}, []string{"role", "type"})
 	messageValidationSSVType = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "ssv_message_validation_ssv_type",
 		Help: "SSV message type",
@@ -99,6 +107,19 @@ var (
 		Help:    "Message size",
 		Buckets: []float64{100, 500, 1_000, 5_000, 10_000, 50_000, 100_000, 500_000, 1_000_000, 5_000_000},
 	}, []string{})
+	oversizedMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_message_oversized",
+		Help: "The amount of messages rejected for exceeding the maximum size before decoding",
+	}, []string{})
+	partialSignatureBatchSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ssv_message_validation_partial_signature_batch_size",
+		Help:    "Number of partial signatures verified in a single batch",
+		Buckets: []float64{1, 2, 4, 8, 16, 32, 64, 128, 256, 512},
+	}, []string{})
+	partialSignatureBatchFallbacks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_message_validation_partial_signature_batch_fallbacks",
+		Help: "The amount of failed partial signature batches verified one by one to find the invalid signature",
+	}, []string{})
 	activeMsgValidation = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "ssv:p2p:pubsub:msg:val:active",
 		Help: "Count active message validation",
@@ -148,6 +169,30 @@ var (
 		Name: "ssv_message_validation_rsa_checks",
 		Help: "The amount message validations",
 	}, []string{})
+	messageValidationRoleEnabled = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv_message_validation_role_enabled",
+		Help: "Whether the messages of the role are validated (1) or ignored (0), as toggled at runtime",
+	}, []string{"role"})
+	messageValidationPaused = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv_message_validation_paused",
+		Help: "Whether message validation is paused (1), ignoring the messages of other peers, or not (0)",
+	})
+	messageValidationSinkDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_message_validation_sink_dropped",
+		Help: "The amount of accepted messages which the message sink dropped, by reason",
+	}, []string{"reason"})
+	messageValidationNonCommitteeIgnored = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv_message_validation_non_committee_partial_signatures_ignored",
+		Help: "The amount of partial signature messages ignored before signature verification as their validator's committee doesn't include own operator",
+	})
+	messageValidationImplausibleRounds = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_message_validation_implausible_rounds",
+		Help: "The amount of consensus messages rejected for rounds which can't be reached before they expire, by role",
+	}, []string{"role"})
+	messageValidationRSAOperatorChecks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_message_validation_rsa_operator_checks",
+		Help: "The amount of RSA signature verifications of known operators by result",
+	}, []string{"operator_id", "result"})
 	pubsubPeerScore = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "ssv:p2p:pubsub:score:inspect",
 		Help: "Pubsub peer scores",
@@ -156,6 +201,18 @@ var (
 		Name: "ssv:p2p:pubsub:score:invalid_message_deliveries",
 		Help: "Pubsub peer P4 scores (sum of square of counters for invalid message deliveries)",
 	}, []string{"pid"})
+	pubsubPeerDuplicateMessages = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:p2p:pubsub:score:duplicate_message_deliveries",
+		Help: "Pubsub peer duplicate message deliveries since the previous score inspection",
+	}, []string{"pid"})
+	pubsubPeerValidationRejects = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:p2p:pubsub:score:validation_rejects",
+		Help: "Pubsub peer tally of messages rejected by message validation, decaying over time, by topic",
+	}, []string{"pid", "topic"})
+	pubsubPeerTotalValidationRejects = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:p2p:pubsub:score:validation_rejects:total",
+		Help: "Pubsub peer tally of messages rejected by message validation on all topics, decaying over time",
+	}, []string{"pid"})
 )
 
 type MetricsReporter interface {
@@ -165,6 +222,7 @@ type MetricsReporter interface {
 	ExecutionClientSyncing()
 	ExecutionClientFailure()
 	ExecutionClientLastFetchedBlock(block uint64)
+	ExecutionClientLogsGap()
 	OperatorPublicKey(operatorID spectypes.OperatorID, publicKey []byte)
 	ValidatorInactive(publicKey []byte)
 	ValidatorNoIndex(publicKey []byte)
@@ -182,9 +240,16 @@ type MetricsReporter interface {
 	MessagesReceivedFromPeer(peerId peer.ID)
 	MessagesReceivedTotal()
 	MessageValidationRSAVerifications()
+	MessageValidationRSAOperatorCheck(operatorID spectypes.OperatorID, valid bool)
+	MessageValidationRoleEnabled(role spectypes.BeaconRole, enabled bool)
+	MessageValidationPaused(paused bool)
+	MessageSinkDropped(reason string)
+	NonCommitteePartialSignatureIgnored()
+	ImplausibleRound(role spectypes.BeaconRole)
 	LastBlockProcessed(block uint64)
 	LogsProcessingError(err error)
 	MessageAccepted(role spectypes.BeaconRole, round specqbft.Round)
+	MessageAcceptedType(role spectypes.BeaconRole, msgType spectypes.MsgType)
 	MessageIgnored(reason string, role spectypes.BeaconRole, round specqbft.Round)
 	MessageRejected(reason string, role spectypes.BeaconRole, round specqbft.Round)
 	SSVMessageType(msgType spectypes.MsgType)
@@ -192,6 +257,9 @@ type MetricsReporter interface {
 	MessageValidationDuration(duration time.Duration, labels ...string)
 	SignatureValidationDuration(duration time.Duration, labels ...string)
 	MessageSize(size int)
+	OversizedMessage()
+	PartialSignatureBatch(size int)
+	PartialSignatureBatchFallback()
 	ActiveMsgValidation(topic string)
 	ActiveMsgValidationDone(topic string)
 	IncomingQueueMessage(messageID spectypes.MessageID)
@@ -204,6 +272,9 @@ type MetricsReporter interface {
 	NonCommitteeMessage(msgType spectypes.MsgType, decided bool)
 	PeerScore(peerId peer.ID, score float64)
 	PeerP4Score(peerId peer.ID, score float64)
+	PeerDuplicateMessages(peerId peer.ID, count float64)
+	PeerValidationRejects(peerId peer.ID, topic string, count float64)
+	PeerTotalValidationRejects(peerId peer.ID, count float64)
 	ResetPeerScores()
 	PeerDisconnected(peerId peer.ID)
 }
@@ -226,16 +297,21 @@ func New(opts ...Option) MetricsReporter {
 		ssvNodeStatus,
 		executionClientStatus,
 		executionClientLastFetchedBlock,
+		executionClientLogsGaps,
 		validatorStatus,
 		eventProcessed,
 		eventProcessingFailed,
 		operatorIndex,
 		messageValidationResult,
+		messageValidationAccepted,
 		messageValidationSSVType,
 		messageValidationConsensusType,
 		messageValidationDuration,
 		signatureValidationDuration,
 		messageSize,
+		oversizedMessages,
+		partialSignatureBatchSize,
+		partialSignatureBatchFallbacks,
 		activeMsgValidation,
 		incomingQueueMessages,
 		outgoingQueueMessages,
@@ -248,8 +324,17 @@ func New(opts ...Option) MetricsReporter {
 		messagesReceivedFromPeer,
 		messagesReceivedTotal,
 		messageValidationRSAVerifications,
+		messageValidationRSAOperatorChecks,
+		messageValidationRoleEnabled,
+		messageValidationPaused,
+		messageValidationSinkDropped,
+		messageValidationNonCommitteeIgnored,
+		messageValidationImplausibleRounds,
 		pubsubPeerScore,
 		pubsubPeerP4Score,
+		pubsubPeerDuplicateMessages,
+		pubsubPeerValidationRejects,
+		pubsubPeerTotalValidationRejects,
 	}
 
 	for i, c := range allMetrics {
@@ -289,6 +374,10 @@ func (m *metricsReporter) ExecutionClientLastFetchedBlock(block uint64) {
 	executionClientLastFetchedBlock.Set(float64(block))
 }
 
+func (m *metricsReporter) ExecutionClientLogsGap() {
+	executionClientLogsGaps.Inc()
+}
+
 func (m *metricsReporter) OperatorPublicKey(operatorID spectypes.OperatorID, publicKey []byte) {
 	pkHash := fmt.Sprintf("%x", sha256.Sum256(publicKey))
 	operatorIndex.WithLabelValues(pkHash, strconv.FormatUint(operatorID, 10)).Set(float64(operatorID))
@@ -348,6 +437,42 @@ func (m *metricsReporter) MessageValidationRSAVerifications() {
 	messageValidationRSAVerifications.WithLabelValues().Inc()
 }
 
+func (m *metricsReporter) MessageValidationRSAOperatorCheck(operatorID spectypes.OperatorID, valid bool) {
+	result := "valid"
+	if !valid {
+		result = "invalid"
+	}
+	messageValidationRSAOperatorChecks.WithLabelValues(strconv.FormatUint(operatorID, 10), result).Inc()
+}
+
+func (m *metricsReporter) MessageValidationRoleEnabled(role spectypes.BeaconRole, enabled bool) {
+	value := float64(0)
+	if enabled {
+		value = 1
+	}
+	messageValidationRoleEnabled.WithLabelValues(role.String()).Set(value)
+}
+
+func (m *metricsReporter) MessageValidationPaused(paused bool) {
+	value := float64(0)
+	if paused {
+		value = 1
+	}
+	messageValidationPaused.Set(value)
+}
+
+func (m *metricsReporter) MessageSinkDropped(reason string) {
+	messageValidationSinkDropped.WithLabelValues(reason).Inc()
+}
+
+func (m *metricsReporter) NonCommitteePartialSignatureIgnored() {
+	messageValidationNonCommitteeIgnored.Inc()
+}
+
+func (m *metricsReporter) ImplausibleRound(role spectypes.BeaconRole) {
+	messageValidationImplausibleRounds.WithLabelValues(role.String()).Inc()
+}
+
 // TODO implement
 func (m *metricsReporter) LastBlockProcessed(uint64) {}
 func (m *metricsReporter) LogsProcessingError(error) {}
@@ -364,6 +489,10 @@ func (m *metricsReporter) MessageAccepted(
 	).Inc()
 }
 
+func (m *metricsReporter) MessageAcceptedType(role spectypes.BeaconRole, msgType spectypes.MsgType) {
+	messageValidationAccepted.WithLabelValues(role.String(), ssvmessage.MsgTypeToString(msgType)).Inc()
+}
+
 func (m *metricsReporter) MessageIgnored(
 	reason string,
 	role spectypes.BeaconRole,
@@ -410,6 +539,18 @@ func (m *metricsReporter) MessageSize(size int) {
 	messageSize.WithLabelValues().Observe(float64(size))
 }
 
+func (m *metricsReporter) OversizedMessage() {
+	oversizedMessages.WithLabelValues().Inc()
+}
+
+func (m *metricsReporter) PartialSignatureBatch(size int) {
+	partialSignatureBatchSize.WithLabelValues().Observe(float64(size))
+}
+
+func (m *metricsReporter) PartialSignatureBatchFallback() {
+	partialSignatureBatchFallbacks.WithLabelValues().Inc()
+}
+
 func (m *metricsReporter) ActiveMsgValidation(topic string) {
 	activeMsgValidation.WithLabelValues(topic).Inc()
 }
@@ -466,9 +607,24 @@ func (m *metricsReporter) PeerP4Score(peerId peer.ID, score float64) {
 	pubsubPeerP4Score.WithLabelValues(peerId.String()).Set(score)
 }
 
+func (m *metricsReporter) PeerDuplicateMessages(peerId peer.ID, count float64) {
+	pubsubPeerDuplicateMessages.WithLabelValues(peerId.String()).Set(count)
+}
+
+func (m *metricsReporter) PeerValidationRejects(peerId peer.ID, topic string, count float64) {
+	pubsubPeerValidationRejects.WithLabelValues(peerId.String(), topic).Set(count)
+}
+
+func (m *metricsReporter) PeerTotalValidationRejects(peerId peer.ID, count float64) {
+	pubsubPeerTotalValidationRejects.WithLabelValues(peerId.String()).Set(count)
+}
+
 func (m *metricsReporter) ResetPeerScores() {
 	pubsubPeerScore.Reset()
 	pubsubPeerP4Score.Reset()
+	pubsubPeerDuplicateMessages.Reset()
+	pubsubPeerValidationRejects.Reset()
+	pubsubPeerTotalValidationRejects.Reset()
 }
 
 // PeerDisconnected deletes all data about peers which connections have been closed by the current node
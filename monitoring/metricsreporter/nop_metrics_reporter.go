@@ -20,6 +20,7 @@ func (n *nopMetrics) ExecutionClientReady()
 func (n *nopMetrics) ExecutionClientSyncing()                                                       {}
 func (n *nopMetrics) ExecutionClientFailure()                                                       {}
 func (n *nopMetrics) ExecutionClientLastFetchedBlock(block uint64)                                  {}
+func (n *nopMetrics) ExecutionClientLogsGap()                                                       {}
 func (n *nopMetrics) OperatorPublicKey(operatorID spectypes.OperatorID, publicKey []byte)           {}
 func (n *nopMetrics) ValidatorInactive(publicKey []byte)                                            {}
 func (n *nopMetrics) ValidatorNoIndex(publicKey []byte)                                             {}
@@ -37,9 +38,16 @@ func (n *nopMetrics) EventProcessingFailed(eventName string)
 func (n *nopMetrics) MessagesReceivedFromPeer(peerId peer.ID)                                       {}
 func (n *nopMetrics) MessagesReceivedTotal()                                                        {}
 func (n *nopMetrics) MessageValidationRSAVerifications()                                            {}
+func (n *nopMetrics) MessageValidationRSAOperatorCheck(operatorID spectypes.OperatorID, valid bool) {}
+func (n *nopMetrics) MessageValidationRoleEnabled(role spectypes.BeaconRole, enabled bool)          {}
+func (n *nopMetrics) MessageValidationPaused(paused bool)                                           {}
+func (n *nopMetrics) MessageSinkDropped(reason string)                                              {}
+func (n *nopMetrics) NonCommitteePartialSignatureIgnored()                                          {}
+func (n *nopMetrics) ImplausibleRound(role spectypes.BeaconRole)                                    {}
 func (n *nopMetrics) LastBlockProcessed(block uint64)                                               {}
 func (n *nopMetrics) LogsProcessingError(err error)                                                 {}
 func (n *nopMetrics) MessageAccepted(role spectypes.BeaconRole, round specqbft.Round)               {}
+func (n *nopMetrics) MessageAcceptedType(role spectypes.BeaconRole, msgType spectypes.MsgType)      {}
 func (n *nopMetrics) MessageIgnored(reason string, role spectypes.BeaconRole, round specqbft.Round) {}
 func (n *nopMetrics) MessageRejected(reason string, role spectypes.BeaconRole, round specqbft.Round) {
 }
@@ -48,6 +56,9 @@ func (n *nopMetrics) ConsensusMsgType(msgType specqbft.MessageType, signers int)
 func (n *nopMetrics) MessageValidationDuration(duration time.Duration, labels ...string)   {}
 func (n *nopMetrics) SignatureValidationDuration(duration time.Duration, labels ...string) {}
 func (n *nopMetrics) MessageSize(size int)                                                 {}
+func (n *nopMetrics) OversizedMessage()                                                    {}
+func (n *nopMetrics) PartialSignatureBatch(size int)                                       {}
+func (n *nopMetrics) PartialSignatureBatchFallback()                                       {}
 func (n *nopMetrics) ActiveMsgValidation(topic string)                                     {}
 func (n *nopMetrics) ActiveMsgValidationDone(topic string)                                 {}
 func (n *nopMetrics) IncomingQueueMessage(messageID spectypes.MessageID)                   {}
@@ -60,5 +71,8 @@ func (n *nopMetrics) InCommitteeMessage(msgType spectypes.MsgType, decided bool)
 func (n *nopMetrics) NonCommitteeMessage(msgType spectypes.MsgType, decided bool)          {}
 func (n *nopMetrics) PeerScore(peerId peer.ID, score float64)                              {}
 func (n *nopMetrics) PeerP4Score(peerId peer.ID, score float64)                            {}
+func (n *nopMetrics) PeerDuplicateMessages(peerId peer.ID, count float64)                  {}
+func (n *nopMetrics) PeerValidationRejects(peerId peer.ID, topic string, count float64)    {}
+func (n *nopMetrics) PeerTotalValidationRejects(peerId peer.ID, count float64)             {}
 func (n *nopMetrics) ResetPeerScores()                                                     {}
 func (n *nopMetrics) PeerDisconnected(peerId peer.ID)                                      {}
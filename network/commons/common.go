@@ -14,6 +14,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/protocol"
 
 	p2pprotocol "github.com/bloxapp/ssv/protocol/v2/p2p"
+	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
 )
 
 const (
@@ -68,6 +69,17 @@ func SubnetTopicID(subnet int) string {
 	return fmt.Sprintf("%d", subnet)
 }
 
+// TopicSubnet returns the subnet of the given topic, by either its full or base name,
+// or false if it isn't a subnet topic.
+func TopicSubnet(topicName string) (int, bool) {
+	baseName := GetTopicBaseName(topicName)
+	subnet, err := strconv.Atoi(baseName)
+	if err != nil || subnet < 0 || subnet >= Subnets() || SubnetTopicID(subnet) != baseName {
+		return 0, false
+	}
+	return subnet, true
+}
+
 // ValidatorTopicID returns the topic to use for the given validator
 func ValidatorTopicID(pkByts []byte) []string {
 	pkHex := hex.EncodeToString(pkByts)
@@ -94,6 +106,26 @@ func ValidatorSubnet(validatorPKHex string) int {
 	return int(val % subnetsCount)
 }
 
+// RequiredSubnets returns the sorted set of subnets that the given shares' validators are mapped to
+func RequiredSubnets(shares []*ssvtypes.SSVShare) []int {
+	required := make([]bool, Subnets())
+	for _, share := range shares {
+		subnet := ValidatorSubnet(hex.EncodeToString(share.ValidatorPubKey))
+		if subnet < 0 {
+			continue
+		}
+		required[subnet] = true
+	}
+
+	subnets := make([]int, 0)
+	for subnet, ok := range required {
+		if ok {
+			subnets = append(subnets, subnet)
+		}
+	}
+	return subnets
+}
+
 // MsgIDFunc is the function that maps a message to a msg_id
 type MsgIDFunc func(msg []byte) string
 
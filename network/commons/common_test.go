@@ -0,0 +1,40 @@
+package commons
+
+import (
+	"testing"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+func TestRequiredSubnets(t *testing.T) {
+	newShare := func(pk ...byte) *ssvtypes.SSVShare {
+		return &ssvtypes.SSVShare{Share: spectypes.Share{ValidatorPubKey: pk}}
+	}
+
+	require.Empty(t, RequiredSubnets(nil))
+
+	shares := []*ssvtypes.SSVShare{
+		newShare(0, 0, 0, 0, 130), // subnet 2
+		newShare(0, 0, 0, 0, 5),   // subnet 5
+		newShare(0, 0, 0, 1, 5),   // subnet 5 (0x0105 % 128)
+		newShare(1),               // invalid public key
+	}
+	require.Equal(t, []int{2, 5}, RequiredSubnets(shares))
+}
+
+func TestTopicSubnet(t *testing.T) {
+	for _, topic := range []string{"5", GetTopicFullName("5"), GetTopicFullName("127")} {
+		_, ok := TopicSubnet(topic)
+		require.True(t, ok, topic)
+	}
+	subnet, _ := TopicSubnet(GetTopicFullName("42"))
+	require.Equal(t, 42, subnet)
+
+	for _, topic := range []string{"", "128", "-1", "05", "+5", GetTopicFullName("decided"), GetTopicFullName(UnknownSubnet)} {
+		_, ok := TopicSubnet(topic)
+		require.False(t, ok, topic)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	gcrypto "github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
 )
 
@@ -42,6 +43,15 @@ func ECDSAPrivToInterface(privkey *ecdsa.PrivateKey) (crypto.PrivKey, error) {
 	return crypto.UnmarshalSecp256k1PrivateKey(privBytes)
 }
 
+// PeerIDFromNetworkKey returns the peer ID of the host whose network identity is the given key.
+func PeerIDFromNetworkKey(privkey *ecdsa.PrivateKey) (peer.ID, error) {
+	sk, err := ECDSAPrivToInterface(privkey)
+	if err != nil {
+		return "", err
+	}
+	return peer.IDFromPrivateKey(sk)
+}
+
 // ECDSAPubFromInterface converts crypto.PubKey to ecdsa.PublicKey
 func ECDSAPubFromInterface(pubKey crypto.PubKey) *ecdsa.PublicKey {
 	pk := btcec.PublicKey(*(pubKey.(*crypto.Secp256k1PublicKey)))
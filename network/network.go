@@ -37,6 +37,8 @@ type P2PNetwork interface {
 	SubscribeAll(logger *zap.Logger) error
 	// SubscribeRandoms subscribes to random subnets
 	SubscribeRandoms(logger *zap.Logger, numSubnets int) error
+	// SubscribeSubnets subscribes to the given subnets
+	SubscribeSubnets(logger *zap.Logger, subnets []int) error
 }
 
 // GetValidatorStats returns stats of validators, including the following:
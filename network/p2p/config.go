@@ -48,13 +48,23 @@ type Config struct {
 	MaxBatchResponse uint64        `yaml:"MaxBatchResponse" env:"P2P_MAX_BATCH_RESPONSE" env-default:"25" env-description:"Maximum number of returned objects in a batch"`
 	MaxPeers         int           `yaml:"MaxPeers" env:"P2P_MAX_PEERS" env-default:"60" env-description:"Connected peers limit for connections"`
 	TopicMaxPeers    int           `yaml:"TopicMaxPeers" env:"P2P_TOPIC_MAX_PEERS" env-default:"10" env-description:"Connected peers limit per pubsub topic"`
+	// MinConsensusPeers is the number of peers a subnet must reach before its consensus messages are processed,
+	// so that a freshly started node doesn't act on them before it has a representative view of the subnet.
+	MinConsensusPeers int `yaml:"MinConsensusPeers" env:"P2P_MIN_CONSENSUS_PEERS" env-description:"Minimum peers on a subnet before its consensus messages are processed (0 disables)"`
 
 	// Subnets is a static bit list of subnets that this node will register upon start.
 	Subnets string `yaml:"Subnets" env:"SUBNETS" env-description:"Hex string that represents the subnets that this node will join upon start"`
 	// PubSubScoring is a flag to turn on/off pubsub scoring
 	PubSubScoring bool `yaml:"PubSubScoring" env:"PUBSUB_SCORING" env-default:"true" env-description:"Flag to turn on/off pubsub scoring"`
+	// DisableIPColocationScoring turns off the penalty of peers sharing an IP with many others,
+	// for private networks whose peers are all trusted. On public networks it exposes the node to sybil attacks.
+	DisableIPColocationScoring bool `yaml:"DisableIPColocationScoring" env:"PUBSUB_DISABLE_IP_COLOCATION_SCORING" env-description:"Disable the pubsub penalty of peers sharing an IP. Only for private networks of trusted peers, as it lets a single host run enough peers to dominate the mesh"`
+	// ValidationRejectScoring penalizes peers by the messages of theirs which message validation rejects.
+	ValidationRejectScoring bool `yaml:"ValidationRejectScoring" env:"PUBSUB_VALIDATION_REJECT_SCORING" env-description:"Penalize the pubsub score of peers whose messages are rejected by message validation, in addition to pubsub's own penalty of invalid messages"`
 	// PubSubTrace is a flag to turn on/off pubsub tracing in logs
 	PubSubTrace bool `yaml:"PubSubTrace" env:"PUBSUB_TRACE" env-description:"Flag to turn on/off pubsub tracing in logs"`
+	// PubSubTraceTypes limits pubsub tracing in logs to the given event types
+	PubSubTraceTypes []string `yaml:"PubSubTraceTypes" env:"PUBSUB_TRACE_TYPES" env-separator:"," env-description:"Comma-separated pubsub event types to trace in logs, e.g. REJECT_MESSAGE,DELIVER_MESSAGE (all types if empty)"`
 	// DiscoveryTrace is a flag to turn on/off discovery tracing in logs
 	DiscoveryTrace bool `yaml:"DiscoveryTrace" env:"DISCOVERY_TRACE" env-description:"Flag to turn on/off discovery tracing in logs"`
 	// NetworkPrivateKey is used for network identity, MUST be injected
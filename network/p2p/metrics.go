@@ -40,6 +40,10 @@ var (
 		Name: "ssv:network:router:in",
 		Help: "Counts incoming messages",
 	}, []string{"mt"})
+	metricsSubscribedSubnets = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv:network:subnets:subscribed",
+		Help: "Count of subnets that this node is subscribed to",
+	})
 )
 
 func init() {
@@ -56,6 +60,9 @@ func init() {
 	if err := prometheus.Register(metricsRouterIncoming); err != nil {
 		logger.Debug("could not register prometheus collector")
 	}
+	if err := prometheus.Register(metricsSubscribedSubnets); err != nil {
+		logger.Debug("could not register prometheus collector")
+	}
 }
 
 var unknown = "unknown"
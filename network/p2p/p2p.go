@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/cornelk/hashmap"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/connmgr"
 	connmgrcore "github.com/libp2p/go-libp2p/core/connmgr"
 	"github.com/libp2p/go-libp2p/core/host"
@@ -163,6 +164,21 @@ func (n *p2pNetwork) PeersByTopic() ([]peer.ID, map[string][]peer.ID) {
 	return allpeers, peerz
 }
 
+// TopicScoreParams returns the last-computed score params of each joined topic
+func (n *p2pNetwork) TopicScoreParams() map[string]*pubsub.TopicScoreParams {
+	return n.topicsCtrl.ScoreParams()
+}
+
+// PubsubTraceLog returns whether pubsub events are logged, and the event types which are logged (all if empty).
+func (n *p2pNetwork) PubsubTraceLog() (bool, []string) {
+	return n.topicsCtrl.TraceLog()
+}
+
+// SetPubsubTraceLog turns logging of pubsub events on or off, limited to the given event types or all if empty.
+func (n *p2pNetwork) SetPubsubTraceLog(enabled bool, types []string) error {
+	return n.topicsCtrl.SetTraceLog(enabled, types)
+}
+
 // Close implements io.Closer
 func (n *p2pNetwork) Close() error {
 	atomic.SwapInt32(&n.state, stateClosing)
@@ -310,7 +326,7 @@ func (n *p2pNetwork) UpdateSubnets(logger *zap.Logger) {
 			newSubnets[subnet] = byte(1)
 			return true
 		})
-		n.subnets = newSubnets
+		n.setSubnets(newSubnets)
 
 		// Compute the not yet registered subnets.
 		unregisteredSubnets := make([]int, 0)
@@ -90,7 +90,8 @@ func (n *p2pNetwork) SubscribeAll(logger *zap.Logger) error {
 	if !n.isReady() {
 		return p2pprotocol.ErrNetworkIsNotReady
 	}
-	n.subnets, _ = records.Subnets{}.FromString(records.AllSubnets)
+	allSubnets, _ := records.Subnets{}.FromString(records.AllSubnets)
+	n.setSubnets(allSubnets)
 	for subnet := 0; subnet < commons.Subnets(); subnet++ {
 		err := n.topicsCtrl.Subscribe(logger, commons.SubnetTopicID(subnet))
 		if err != nil {
@@ -126,11 +127,39 @@ func (n *p2pNetwork) SubscribeRandoms(logger *zap.Logger, numSubnets int) error
 	for _, subnet := range randomSubnets {
 		subnets[subnet] = byte(1)
 	}
-	n.subnets = subnets
+	n.setSubnets(subnets)
+
+	return nil
+}
+
+// SubscribeSubnets subscribes to the given subnets. This method isn't thread-safe.
+func (n *p2pNetwork) SubscribeSubnets(logger *zap.Logger, subnets []int) error {
+	if !n.isReady() {
+		return p2pprotocol.ErrNetworkIsNotReady
+	}
+
+	updated := make([]byte, commons.Subnets())
+	copy(updated, n.subnets)
+	for _, subnet := range subnets {
+		if subnet < 0 || subnet >= commons.Subnets() {
+			return fmt.Errorf("invalid subnet %d", subnet)
+		}
+		if err := n.topicsCtrl.Subscribe(logger, commons.SubnetTopicID(subnet)); err != nil {
+			return fmt.Errorf("could not subscribe to subnet %d: %w", subnet, err)
+		}
+		updated[subnet] = byte(1)
+	}
+	n.setSubnets(updated)
 
 	return nil
 }
 
+// setSubnets replaces the node's subnets and reports the subscribed subnets count.
+func (n *p2pNetwork) setSubnets(subnets []byte) {
+	n.subnets = subnets
+	metricsSubscribedSubnets.Set(float64(records.Subnets(subnets).Active()))
+}
+
 // Subscribe subscribes to validator subnet
 func (n *p2pNetwork) Subscribe(pk spectypes.ValidatorPK) error {
 	if !n.isReady() {
@@ -209,14 +238,30 @@ func (n *p2pNetwork) handlePubsubMessages(logger *zap.Logger) func(ctx context.C
 		// 		zap.String("role", ssvMsg.MsgID.GetRoleType().String()),
 		// 	).Debug("handlePubsubMessages")
 
-		metricsRouterIncoming.WithLabelValues(message.MsgTypeToString(decodedMsg.MsgType)).Inc()
-
-		n.msgRouter.Route(ctx, decodedMsg)
+		n.routeMessage(ctx, decodedMsg)
 
 		return nil
 	}
 }
 
+// handleObservedMessages routes the messages which the message validator accepts in observer mode,
+// which pubsub doesn't deliver as they're ignored so that they aren't forwarded.
+func (n *p2pNetwork) handleObservedMessages(logger *zap.Logger) func(ctx context.Context, msg *queue.DecodedSSVMessage) {
+	return func(ctx context.Context, msg *queue.DecodedSSVMessage) {
+		if n.msgRouter == nil {
+			logger.Debug("msg router is not configured")
+			return
+		}
+		n.routeMessage(ctx, msg)
+	}
+}
+
+func (n *p2pNetwork) routeMessage(ctx context.Context, msg *queue.DecodedSSVMessage) {
+	metricsRouterIncoming.WithLabelValues(message.MsgTypeToString(msg.MsgType)).Inc()
+
+	n.msgRouter.Route(ctx, msg)
+}
+
 // subscribeToSubnets subscribes to all the node's subnets
 func (n *p2pNetwork) subscribeToSubnets(logger *zap.Logger) error {
 	if len(n.subnets) == 0 {
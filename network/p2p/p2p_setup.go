@@ -21,6 +21,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/message/validation"
 	p2pcommons "github.com/bloxapp/ssv/network/commons"
 	"github.com/bloxapp/ssv/network/discovery"
 	"github.com/bloxapp/ssv/network/peers"
@@ -94,9 +95,9 @@ func (n *p2pNetwork) initCfg() error {
 		if err != nil {
 			return fmt.Errorf("parse subnet: %w", err)
 		}
-		n.subnets = subnets
+		n.setSubnets(subnets)
 	} else {
-		n.subnets = make(records.Subnets, p2pcommons.Subnets())
+		n.setSubnets(make(records.Subnets, p2pcommons.Subnets()))
 	}
 	if n.cfg.MaxPeers <= 0 {
 		n.cfg.MaxPeers = minPeersBuffer
@@ -279,18 +280,24 @@ func (n *p2pNetwork) setupDiscovery(logger *zap.Logger) error {
 }
 
 func (n *p2pNetwork) setupPubsub(logger *zap.Logger) error {
+	if deliverer, ok := n.msgValidator.(validation.ObservedMessageDeliverer); ok {
+		deliverer.DeliverObserved(n.handleObservedMessages(logger))
+	}
+
 	cfg := &topics.PubSubConfig{
-		Host:         n.host,
-		TraceLog:     n.cfg.PubSubTrace,
-		MsgValidator: n.msgValidator,
-		MsgHandler:   n.handlePubsubMessages(logger),
-		ScoreIndex:   n.idx,
+		Host:          n.host,
+		TraceLog:      n.cfg.PubSubTrace,
+		TraceLogTypes: n.cfg.PubSubTraceTypes,
+		MsgValidator:  n.msgValidator,
+		MsgHandler:    n.handlePubsubMessages(logger),
+		ScoreIndex:    n.idx,
 		//Discovery: n.disc,
 		OutboundQueueSize:   n.cfg.PubsubOutQueueSize,
 		ValidationQueueSize: n.cfg.PubsubValidationQueueSize,
 		ValidateThrottle:    n.cfg.PubsubValidateThrottle,
 		MsgIDCacheTTL:       n.cfg.PubsubMsgCacheTTL,
 		GetValidatorStats:   n.cfg.GetValidatorStats,
+		MinConsensusPeers:   n.cfg.MinConsensusPeers,
 	}
 
 	if n.cfg.PeerScoreInspector != nil && n.cfg.PeerScoreInspectorInterval > 0 {
@@ -301,6 +308,11 @@ func (n *p2pNetwork) setupPubsub(logger *zap.Logger) error {
 	if !n.cfg.PubSubScoring {
 		cfg.ScoreIndex = nil
 	}
+	cfg.Scoring = topics.NetworkScoringConfig(n.cfg.Network)
+	if n.cfg.DisableIPColocationScoring {
+		cfg.Scoring.DisableIPColocation = true
+	}
+	cfg.Scoring.ValidationRejects = n.cfg.ValidationRejectScoring
 
 	midHandler := topics.NewMsgIDHandler(n.ctx, time.Minute*2, n.cfg.Network)
 	n.msgResolver = midHandler
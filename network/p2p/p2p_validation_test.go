@@ -277,6 +277,26 @@ func (v *MockMessageValidator) ValidateSSVMessage(ssvMessage *spectypes.SSVMessa
 	panic("not implemented") // TODO: Implement
 }
 
+func (v *MockMessageValidator) SetRoleValidation(role spectypes.BeaconRole, enabled bool) error {
+	panic("not implemented") // TODO: Implement
+}
+
+func (v *MockMessageValidator) DisabledRoles() []spectypes.BeaconRole {
+	panic("not implemented") // TODO: Implement
+}
+
+func (v *MockMessageValidator) Pause() {
+	panic("not implemented") // TODO: Implement
+}
+
+func (v *MockMessageValidator) Resume() {
+	panic("not implemented") // TODO: Implement
+}
+
+func (v *MockMessageValidator) Paused() bool {
+	panic("not implemented") // TODO: Implement
+}
+
 type NodeIndex int
 
 type VirtualNode struct {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"strconv"
+	"sync"
 	"time"
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -28,10 +29,20 @@ type Controller interface {
 	Unsubscribe(logger *zap.Logger, topicName string, hard bool) error
 	// Peers returns the peers subscribed to the given topic
 	Peers(topicName string) ([]peer.ID, error)
+	// TopicPeers returns the number of connected peers subscribed to the given topic
+	TopicPeers(topicName string) int
+	// MeshPeers returns the number of peers in the mesh of the given topic
+	MeshPeers(topicName string) int
 	// Topics lists all the available topics
 	Topics() []string
 	// Broadcast publishes the message on the given topic
 	Broadcast(topicName string, data []byte, timeout time.Duration) error
+	// ScoreParams returns the last-computed score params of each joined topic
+	ScoreParams() map[string]*pubsub.TopicScoreParams
+	// TraceLog returns whether pubsub events are logged, and the event types which are logged (all if empty)
+	TraceLog() (bool, []string)
+	// SetTraceLog turns logging of pubsub events on or off, limited to the given event types or all if empty
+	SetTraceLog(enabled bool, types []string) error
 
 	io.Closer
 }
@@ -55,6 +66,12 @@ type topicsCtrl struct {
 	subFilter          SubFilter
 
 	container *topicsContainer
+
+	scoreParamsMu sync.Mutex
+	scoreParams   map[string]*pubsub.TopicScoreParams // last-computed score params by topic name
+
+	tracer     *psTracer
+	topicPeers TopicPeersProvider
 }
 
 // NewTopicsController creates an instance of Controller
@@ -66,6 +83,7 @@ func NewTopicsController(
 	subFilter SubFilter,
 	pubSub *pubsub.PubSub,
 	scoreParams func(string) *pubsub.TopicScoreParams,
+	tracer *psTracer,
 ) Controller {
 	ctrl := &topicsCtrl{
 		ctx:                ctx,
@@ -76,6 +94,10 @@ func NewTopicsController(
 		msgHandler:         msgHandler,
 
 		subFilter: subFilter,
+
+		scoreParams: make(map[string]*pubsub.TopicScoreParams),
+		tracer:      tracer,
+		topicPeers:  newTopicPeers(pubSub, tracer),
 	}
 
 	ctrl.container = newTopicsContainer(pubSub, ctrl.onNewTopic(logger))
@@ -98,6 +120,10 @@ func (ctrl *topicsCtrl) onNewTopic(logger *zap.Logger) onTopicJoined {
 				if err := topic.SetScoreParams(p); err != nil {
 					// logger.Warn("could not set topic score params", zap.String("topic", name), zap.Error(err))
 					logger.Warn("could not set topic score params", zap.String("topic", name), zap.Error(err))
+				} else {
+					ctrl.scoreParamsMu.Lock()
+					ctrl.scoreParams[name] = p
+					ctrl.scoreParamsMu.Unlock()
 				}
 			}
 		}
@@ -127,6 +153,16 @@ func (ctrl *topicsCtrl) Peers(name string) ([]peer.ID, error) {
 	return topic.ListPeers(), nil
 }
 
+// TopicPeers returns the number of connected peers subscribed to the given topic
+func (ctrl *topicsCtrl) TopicPeers(name string) int {
+	return ctrl.topicPeers.TopicPeers(commons.GetTopicFullName(name))
+}
+
+// MeshPeers returns the number of peers in the mesh of the given topic
+func (ctrl *topicsCtrl) MeshPeers(name string) int {
+	return ctrl.topicPeers.MeshPeers(commons.GetTopicFullName(name))
+}
+
 // Topics lists all the available topics
 func (ctrl *topicsCtrl) Topics() []string {
 	topics := ctrl.ps.GetTopics()
@@ -136,6 +172,31 @@ func (ctrl *topicsCtrl) Topics() []string {
 	return topics
 }
 
+// ScoreParams returns the score params that were last computed and set for each joined topic,
+// keyed by the topic base name.
+func (ctrl *topicsCtrl) ScoreParams() map[string]*pubsub.TopicScoreParams {
+	ctrl.scoreParamsMu.Lock()
+	defer ctrl.scoreParamsMu.Unlock()
+
+	params := make(map[string]*pubsub.TopicScoreParams, len(ctrl.scoreParams))
+	for _, name := range ctrl.ps.GetTopics() {
+		if p, ok := ctrl.scoreParams[name]; ok {
+			params[commons.GetTopicBaseName(name)] = p
+		}
+	}
+	return params
+}
+
+// TraceLog returns whether pubsub events are logged, and the event types which are logged (all if empty).
+func (ctrl *topicsCtrl) TraceLog() (bool, []string) {
+	return ctrl.tracer.logSettings()
+}
+
+// SetTraceLog turns logging of pubsub events on or off, limited to the given event types or all if empty.
+func (ctrl *topicsCtrl) SetTraceLog(enabled bool, types []string) error {
+	return ctrl.tracer.setLog(enabled, types)
+}
+
 // Subscribe subscribes to the given topic, it can handle multiple concurrent calls.
 // it will create a single goroutine and channel for every topic
 func (ctrl *topicsCtrl) Subscribe(logger *zap.Logger, name string) error {
@@ -87,6 +87,32 @@ func TestTopicManager(t *testing.T) {
 	})
 }
 
+func TestTopicScoreParams(t *testing.T) {
+	logger := logging.TestLogger(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	validator := validation.NewMessageValidator(networkconfig.TestNetwork)
+	p := newPeer(ctx, logger, t, validator, true, func(map[peer.ID]*pubsub.PeerScoreSnapshot) {})
+	p.tm.scoreParamsFactory = topicScoreParams(logger, &PubSubConfig{
+		GetValidatorStats: func() (uint64, uint64, uint64, error) {
+			return 100000, 100000, 100, nil
+		},
+	})
+
+	topic := commons.SubnetTopicID(1)
+	require.NoError(t, p.tm.Subscribe(logger, topic))
+
+	params := p.tm.ScoreParams()
+	require.Contains(t, params, topic)
+	require.Positive(t, params[topic].MeshMessageDeliveriesThreshold)
+
+	// Params of topics that were left aren't reported.
+	require.NoError(t, p.tm.Unsubscribe(logger, commons.GetTopicFullName(topic), true))
+	require.NoError(t, p.tm.container.Leave(commons.GetTopicFullName(topic)))
+	require.NotContains(t, p.tm.ScoreParams(), topic)
+}
+
 func baseTest(t *testing.T, ctx context.Context, logger *zap.Logger, peers []*P, pks []string, minMsgCount, maxMsgCount int) {
 	nValidators := len(pks)
 	// nPeers := len(peers)
@@ -197,6 +223,8 @@ func banningTest(t *testing.T, ctx context.Context, logger *zap.Logger, peers []
 		for _, p := range peers {
 			peerList, err := p.tm.Peers(pk)
 			require.NoError(t, err)
+			require.Equal(t, len(peerList), p.tm.TopicPeers(pk))
+			require.LessOrEqual(t, p.tm.MeshPeers(pk), len(peerList))
 
 			for _, pid := range peerList {
 				scoreMapMu.Lock()
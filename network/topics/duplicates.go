@@ -0,0 +1,56 @@
+package topics
+
+import (
+	"sync"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// duplicateTracker counts the duplicate message deliveries of each peer,
+// which pubsub doesn't include in peer score snapshots.
+// It implements pubsub.RawTracer.
+type duplicateTracker struct {
+	mu         sync.Mutex
+	duplicates map[peer.ID]uint64
+}
+
+func newDuplicateTracker() *duplicateTracker {
+	return &duplicateTracker{
+		duplicates: make(map[peer.ID]uint64),
+	}
+}
+
+// take returns the duplicate message deliveries of each peer since the last call.
+func (dt *duplicateTracker) take() map[peer.ID]uint64 {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	duplicates := dt.duplicates
+	dt.duplicates = make(map[peer.ID]uint64, len(duplicates))
+	return duplicates
+}
+
+// DuplicateMessage is invoked when a duplicate message is dropped.
+func (dt *duplicateTracker) DuplicateMessage(msg *pubsub.Message) {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	dt.duplicates[msg.ReceivedFrom]++
+}
+
+func (dt *duplicateTracker) AddPeer(peer.ID, protocol.ID)          {}
+func (dt *duplicateTracker) RemovePeer(peer.ID)                    {}
+func (dt *duplicateTracker) Join(string)                           {}
+func (dt *duplicateTracker) Leave(string)                          {}
+func (dt *duplicateTracker) Graft(peer.ID, string)                 {}
+func (dt *duplicateTracker) Prune(peer.ID, string)                 {}
+func (dt *duplicateTracker) ValidateMessage(*pubsub.Message)       {}
+func (dt *duplicateTracker) DeliverMessage(*pubsub.Message)        {}
+func (dt *duplicateTracker) RejectMessage(*pubsub.Message, string) {}
+func (dt *duplicateTracker) ThrottlePeer(peer.ID)                  {}
+func (dt *duplicateTracker) RecvRPC(*pubsub.RPC)                   {}
+func (dt *duplicateTracker) SendRPC(*pubsub.RPC, peer.ID)          {}
+func (dt *duplicateTracker) DropRPC(*pubsub.RPC, peer.ID)          {}
+func (dt *duplicateTracker) UndeliverableMessage(*pubsub.Message)  {}
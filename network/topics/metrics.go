@@ -10,6 +10,9 @@ import (
 type Metrics interface {
 	PeerScore(peer.ID, float64)
 	PeerP4Score(peer.ID, float64)
+	PeerDuplicateMessages(peer.ID, float64)
+	PeerValidationRejects(pid peer.ID, topic string, count float64)
+	PeerTotalValidationRejects(pid peer.ID, count float64)
 	ResetPeerScores()
 }
 
@@ -27,6 +30,24 @@ var (
 		Name: "ssv:p2p:pubsub:msg:in",
 		Help: "Count incoming messages",
 	}, []string{"topic", "msg_type"})
+	// metricPubsubPeerScoreStats tracks the min, max and median of peer scores (and of their EWMA) across peers
+	metricPubsubPeerScoreStats = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:p2p:pubsub:score:stats",
+		Help: "Pubsub peer score statistics across peers",
+	}, []string{"score", "stat"})
+	metricPubsubTopicPeers = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:p2p:pubsub:topic:peers",
+		Help: "Count of peers on each topic",
+	}, []string{"topic"})
+	// metricPubsubMeshPeers tracks the mesh size of each topic, as traced by GRAFT and PRUNE events
+	metricPubsubMeshPeers = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:p2p:pubsub:topic:mesh_peers",
+		Help: "Count of mesh peers on each topic",
+	}, []string{"topic"})
+	metricPubsubGatedMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:p2p:pubsub:msg:gated",
+		Help: "Count of consensus messages ignored until a topic has enough peers",
+	}, []string{"topic"})
 )
 
 func init() {
@@ -36,6 +57,10 @@ func init() {
 		metricPubsubTrace,
 		metricPubsubOutbound,
 		metricPubsubInbound,
+		metricPubsubPeerScoreStats,
+		metricPubsubTopicPeers,
+		metricPubsubMeshPeers,
+		metricPubsubGatedMessages,
 	}
 
 	for i, c := range allMetrics {
@@ -24,6 +24,9 @@ const (
 
 	// P5
 	appSpecificWeight = 0
+	// maxValidationRejectsAllowed is the number of validation rejects on a topic
+	// at which the app-specific score alone reaches the graylist threshold
+	maxValidationRejectsAllowed = 20
 
 	// P6
 	ipColocationFactorThreshold = 10
@@ -44,6 +47,22 @@ func PeerScoreThresholds() *pubsub.PeerScoreThresholds {
 	}
 }
 
+// ValidationRejectsWeight returns the app-specific weight (P5) for an app-specific score
+// which is the sum of the squares of a peer's validation rejects on each topic.
+// The weight graylists a peer once it has maxValidationRejectsAllowed rejects on a single topic
+// (as decayed over 100 epochs), so that an occasional reject, e.g. of a message racing a registry
+// update, is tolerated, while a peer which keeps sending invalid messages is graylisted
+// regardless of the topic scores it gained meanwhile.
+func ValidationRejectsWeight() float64 {
+	return graylistThreshold / (maxValidationRejectsAllowed * maxValidationRejectsAllowed)
+}
+
+// IPColocationWeight returns the default weight of the IP colocation penalty (P6),
+// which offsets the topic score of a peer sharing its IP with too many others.
+func IPColocationWeight() float64 {
+	return ipColocationFactorWeight
+}
+
 // PeerScoreParams returns peer score params according to the given options
 func PeerScoreParams(oneEpoch, msgIDCacheTTL time.Duration, ipWhilelist ...*net.IPNet) *pubsub.PeerScoreParams {
 	if oneEpoch == 0 {
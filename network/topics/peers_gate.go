@@ -0,0 +1,102 @@
+package topics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/bloxapp/ssv/network/commons"
+)
+
+// topicPeersCheckInterval is the minimal interval between checks of the number of peers of a topic.
+const topicPeersCheckInterval = time.Second
+
+// consensusPeersGate ignores the consensus messages of a topic until the node has at least minPeers peers on it,
+// so that a freshly started node doesn't act on consensus messages before it has a representative view of the subnet.
+// Once a topic reaches minPeers, its gate remains open.
+type consensusPeersGate struct {
+	validator  messageValidator
+	selfPID    peer.ID
+	minPeers   int
+	topicPeers TopicPeersProvider
+
+	mu     sync.Mutex
+	topics map[string]*topicPeersState
+}
+
+type topicPeersState struct {
+	open    bool
+	checked time.Time
+}
+
+func newConsensusPeersGate(validator messageValidator, selfPID peer.ID, minPeers int, topicPeers TopicPeersProvider) *consensusPeersGate {
+	return &consensusPeersGate{
+		validator:  validator,
+		selfPID:    selfPID,
+		minPeers:   minPeers,
+		topicPeers: topicPeers,
+		topics:     map[string]*topicPeersState{},
+	}
+}
+
+// ValidatorForTopic returns the topic's validator, ignoring consensus messages from other peers while the topic's gate is closed.
+func (g *consensusPeersGate) ValidatorForTopic(topic string) func(ctx context.Context, p peer.ID, pmsg *pubsub.Message) pubsub.ValidationResult {
+	validate := g.validator.ValidatorForTopic(topic)
+	return func(ctx context.Context, p peer.ID, pmsg *pubsub.Message) pubsub.ValidationResult {
+		if !g.open(topic) && p != g.selfPID && isConsensusMessage(pmsg) {
+			metricPubsubGatedMessages.WithLabelValues(topic).Inc()
+			return pubsub.ValidationIgnore
+		}
+		return validate(ctx, p, pmsg)
+	}
+}
+
+// open returns whether the topic's gate is open, checking the number of its peers at most once per topicPeersCheckInterval.
+func (g *consensusPeersGate) open(topic string) bool {
+	g.mu.Lock()
+	state, ok := g.topics[topic]
+	if !ok {
+		state = &topicPeersState{}
+		g.topics[topic] = state
+	}
+	if time.Since(state.checked) < topicPeersCheckInterval {
+		open := state.open
+		g.mu.Unlock()
+		return open
+	}
+	state.checked = time.Now()
+	g.mu.Unlock()
+
+	peers := g.topicPeers.TopicPeers(topic)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if peers >= g.minPeers {
+		state.open = true
+	}
+	return state.open
+}
+
+// isConsensusMessage returns whether the message is a consensus message,
+// either plain or wrapped with its operator's signature (after PermissionlessActivationEpoch).
+func isConsensusMessage(pmsg *pubsub.Message) bool {
+	msg, err := commons.DecodeNetworkMsg(pmsg.GetData())
+	if err != nil {
+		encodedMsg, _, _, err := commons.DecodeSignedSSVMessage(pmsg.GetData())
+		if err != nil {
+			// Left for the validator to handle.
+			return false
+		}
+		msg, err = commons.DecodeNetworkMsg(encodedMsg)
+		if err != nil {
+			// Left for the validator to handle.
+			return false
+		}
+	}
+	return msg.MsgType == spectypes.SSVConsensusMsgType
+}
@@ -0,0 +1,94 @@
+package topics
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	ps_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/network/commons"
+)
+
+func TestConsensusPeersGate(t *testing.T) {
+	const (
+		topic   = "ssv.v2.1"
+		selfPID = peer.ID("self")
+		peerID  = peer.ID("peer")
+	)
+
+	var peers atomic.Int64
+	gate := newConsensusPeersGate(acceptingValidator{}, selfPID, 3, fakeTopicPeers{peers: &peers})
+	validate := gate.ValidatorForTopic(topic)
+
+	consensusMsg := gatedTestMessage(t, spectypes.SSVConsensusMsgType)
+	partialSigMsg := gatedTestMessage(t, spectypes.SSVPartialSignatureMsgType)
+	signedConsensusMsg := signedGatedTestMessage(t, spectypes.SSVConsensusMsgType)
+	signedPartialSigMsg := signedGatedTestMessage(t, spectypes.SSVPartialSignatureMsgType)
+
+	// Only consensus messages from other peers are ignored while the topic has too few peers.
+	peers.Store(2)
+	require.Equal(t, pubsub.ValidationIgnore, validate(context.Background(), peerID, consensusMsg))
+	require.Equal(t, pubsub.ValidationIgnore, validate(context.Background(), peerID, signedConsensusMsg))
+	require.Equal(t, pubsub.ValidationAccept, validate(context.Background(), peerID, signedPartialSigMsg))
+	require.Equal(t, pubsub.ValidationAccept, validate(context.Background(), selfPID, consensusMsg))
+	require.Equal(t, pubsub.ValidationAccept, validate(context.Background(), peerID, partialSigMsg))
+
+	// The number of peers is checked again after an interval.
+	peers.Store(3)
+	require.Equal(t, pubsub.ValidationIgnore, validate(context.Background(), peerID, consensusMsg))
+	gate.topics[topic].checked = time.Time{}
+	require.Equal(t, pubsub.ValidationAccept, validate(context.Background(), peerID, consensusMsg))
+
+	// Once open, the gate remains open.
+	peers.Store(0)
+	gate.topics[topic].checked = time.Time{}
+	require.Equal(t, pubsub.ValidationAccept, validate(context.Background(), peerID, consensusMsg))
+}
+
+// fakeTopicPeers reports the same number of peers for any topic, all of which are in its mesh.
+type fakeTopicPeers struct {
+	peers *atomic.Int64
+}
+
+func (p fakeTopicPeers) TopicPeers(string) int {
+	return int(p.peers.Load())
+}
+
+func (p fakeTopicPeers) MeshPeers(string) int {
+	return int(p.peers.Load())
+}
+
+// acceptingValidator accepts all messages.
+type acceptingValidator struct{}
+
+func (acceptingValidator) ValidatorForTopic(string) func(context.Context, peer.ID, *pubsub.Message) pubsub.ValidationResult {
+	return func(context.Context, peer.ID, *pubsub.Message) pubsub.ValidationResult {
+		return pubsub.ValidationAccept
+	}
+}
+
+func gatedTestMessage(t *testing.T, msgType spectypes.MsgType) *pubsub.Message {
+	data, err := commons.EncodeNetworkMsg(&spectypes.SSVMessage{
+		MsgType: msgType,
+		Data:    []byte{1},
+	})
+	require.NoError(t, err)
+	return &pubsub.Message{Message: &ps_pb.Message{Data: data}}
+}
+
+// signedGatedTestMessage wraps the message with an operator's signature, as sent after PermissionlessActivationEpoch.
+func signedGatedTestMessage(t *testing.T, msgType spectypes.MsgType) *pubsub.Message {
+	data, err := commons.EncodeNetworkMsg(&spectypes.SSVMessage{
+		MsgType: msgType,
+		Data:    []byte{1},
+	})
+	require.NoError(t, err)
+	signed := commons.EncodeSignedSSVMessage(data, 1, make([]byte, 256))
+	return &pubsub.Message{Message: &ps_pb.Message{Data: signed}}
+}
@@ -60,6 +60,11 @@ type PubSubConfig struct {
 	ValidationQueueSize int
 	OutboundQueueSize   int
 	MsgIDCacheTTL       time.Duration
+	// MinConsensusPeers is the number of peers a topic must reach before its consensus messages
+	// are validated rather than ignored. Zero disables the gate.
+	MinConsensusPeers int
+	// TraceLogTypes limits the events logged if TraceLog is enabled to the given types, logging all of them if empty.
+	TraceLogTypes []string
 
 	GetValidatorStats      network.GetValidatorStats
 	ScoreInspector         pubsub.ExtendedPeerScoreInspectFn
@@ -71,6 +76,14 @@ type ScoringConfig struct {
 	IPWhilelist        []*net.IPNet
 	IPColocationWeight float64
 	OneEpochDuration   time.Duration
+	// DisableIPColocation disables the IP colocation penalty (P6), which otherwise penalizes peers sharing an IP
+	// with too many others. It suits private networks of trusted peers only, since it lets a single host
+	// run enough sybil peers to dominate the mesh.
+	DisableIPColocation bool
+	// ValidationRejects enables the app-specific penalty (P5) of peers whose messages message validation rejects,
+	// see rejectTally. It's opt-in, as rejects caused by clock skew or a lagging view of the registry
+	// would otherwise penalize honest peers.
+	ValidationRejects bool
 }
 
 // PubsubBundle includes the pubsub router, plus involved components
@@ -137,24 +150,38 @@ func NewPubSub(ctx context.Context, logger *zap.Logger, cfg *PubSubConfig, metri
 	}
 
 	var topicScoreFactory func(string) *pubsub.TopicScoreParams
+	var rejects *rejectTally
 
 	inspector := cfg.ScoreInspector
 	inspectInterval := cfg.ScoreInspectorInterval
 	if cfg.ScoreIndex != nil || inspector != nil {
 		cfg.initScoring()
 
+		if inspectInterval == 0 {
+			inspectInterval = defaultScoreInspectInterval
+		}
+
 		if inspector == nil {
 			peerConnected := func(pid peer.ID) bool {
 				return cfg.Host.Network().Connectedness(pid) == libp2pnetwork.Connected
 			}
-			inspector = scoreInspector(logger, cfg.ScoreIndex, scoreInspectLogFrequency, metrics, peerConnected)
+			duplicates := newDuplicateTracker()
+			psOpts = append(psOpts, pubsub.WithRawTracer(duplicates))
+			if cfg.Scoring.ValidationRejects {
+				rejects = newRejectTally(rejectsDecay(cfg.Scoring.OneEpochDuration, inspectInterval))
+			}
+			inspector = scoreInspector(logger, cfg.ScoreIndex, scoreInspectLogFrequency, metrics, peerConnected, duplicates, rejects)
 		}
 
-		if inspectInterval == 0 {
-			inspectInterval = defaultScoreInspectInterval
+		if cfg.Scoring.DisableIPColocation {
+			logger.Warn("IP colocation scoring is disabled, peers sharing an IP aren't penalized")
+		}
+		peerScoreParams := cfg.Scoring.peerScoreParams(cfg.MsgIDCacheTTL)
+		if rejects != nil {
+			// The app-specific score is refreshed by the score inspector.
+			peerScoreParams.AppSpecificScore = rejects.appSpecificScore
+			peerScoreParams.AppSpecificWeight = params.ValidationRejectsWeight()
 		}
-
-		peerScoreParams := params.PeerScoreParams(cfg.Scoring.OneEpochDuration, cfg.MsgIDCacheTTL, cfg.Scoring.IPWhilelist...)
 		psOpts = append(psOpts, pubsub.WithPeerScore(peerScoreParams, params.PeerScoreThresholds()),
 			pubsub.WithPeerScoreInspect(inspector, inspectInterval))
 		if cfg.GetValidatorStats == nil {
@@ -174,16 +201,29 @@ func NewPubSub(ctx context.Context, logger *zap.Logger, cfg *PubSubConfig, metri
 		psOpts = append(psOpts, pubsub.WithDirectPeers(cfg.StaticPeers))
 	}
 
-	if cfg.TraceLog {
-		psOpts = append(psOpts, pubsub.WithEventTracer(newTracer(logger)))
+	// The tracer reports mesh metrics, and logs the events only if TraceLog is enabled.
+	tracer, err := newTracer(logger, cfg.TraceLog, cfg.TraceLogTypes)
+	if err != nil {
+		return nil, nil, err
 	}
+	psOpts = append(psOpts, pubsub.WithEventTracer(tracer))
 
 	ps, err := pubsub.NewGossipSub(ctx, cfg.Host, psOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	ctrl := NewTopicsController(ctx, logger, cfg.MsgHandler, cfg.MsgValidator, sf, ps, topicScoreFactory)
+	msgValidator := cfg.MsgValidator
+	if msgValidator != nil {
+		if rejects != nil {
+			msgValidator = newRejectRecorder(msgValidator, cfg.Host.ID(), rejects)
+		}
+		if cfg.MinConsensusPeers > 0 {
+			msgValidator = newConsensusPeersGate(msgValidator, cfg.Host.ID(), cfg.MinConsensusPeers, newTopicPeers(ps, tracer))
+		}
+	}
+
+	ctrl := NewTopicsController(ctx, logger, cfg.MsgHandler, msgValidator, sf, ps, topicScoreFactory, tracer)
 
 	return ps, ctrl, nil
 }
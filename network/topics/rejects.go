@@ -0,0 +1,122 @@
+package topics
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// rejectsDecayEpochs is the number of epochs over which validation rejects decay to rejectsDecayToZero,
+	// as invalid message deliveries (P4) do.
+	rejectsDecayEpochs = 100
+	// rejectsDecayToZero is the tally below which validation rejects are forgotten.
+	rejectsDecayToZero = 0.01
+)
+
+// rejectsDecay returns the factor by which validation rejects decay on each score inspection.
+func rejectsDecay(oneEpoch, inspectInterval time.Duration) float64 {
+	return math.Pow(rejectsDecayToZero, float64(inspectInterval)/float64(rejectsDecayEpochs*oneEpoch))
+}
+
+// rejectTally tallies the messages of each peer which SSV's message validation rejected, by topic.
+// Unlike pubsub's invalid message deliveries (P4), it only counts rejects of the validator itself,
+// e.g. for unknown operators or spam. The tally decays on each score inspection, which also refreshes
+// the app-specific score (P5) of each peer to the sum of the squares of its tallies.
+type rejectTally struct {
+	decay float64
+
+	mu      sync.Mutex
+	rejects map[peer.ID]map[string]float64
+	scores  map[peer.ID]float64 // app-specific scores as of the last inspection
+}
+
+func newRejectTally(decay float64) *rejectTally {
+	return &rejectTally{
+		decay:   decay,
+		rejects: make(map[peer.ID]map[string]float64),
+		scores:  make(map[peer.ID]float64),
+	}
+}
+
+// record counts a rejected message of the given peer on the given topic.
+func (t *rejectTally) record(pid peer.ID, topic string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	topics, ok := t.rejects[pid]
+	if !ok {
+		topics = make(map[string]float64)
+		t.rejects[pid] = topics
+	}
+	topics[topic]++
+}
+
+// inspect refreshes the app-specific scores from the tallies, decays the tallies
+// and returns them as they were before decaying.
+func (t *rejectTally) inspect() map[peer.ID]map[string]float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tallies := make(map[peer.ID]map[string]float64, len(t.rejects))
+	scores := make(map[peer.ID]float64, len(t.rejects))
+	for pid, topics := range t.rejects {
+		tallies[pid] = make(map[string]float64, len(topics))
+		for topic, count := range topics {
+			tallies[pid][topic] = count
+			scores[pid] += count * count
+
+			if count *= t.decay; count < rejectsDecayToZero {
+				delete(topics, topic)
+			} else {
+				topics[topic] = count
+			}
+		}
+		if len(topics) == 0 {
+			delete(t.rejects, pid)
+		}
+	}
+	t.scores = scores
+	return tallies
+}
+
+// appSpecificScore returns the app-specific score of the given peer as of the last inspection.
+// It's meant to be weighted negatively, see params.ValidationRejectsWeight.
+func (t *rejectTally) appSpecificScore(pid peer.ID) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.scores[pid]
+}
+
+// rejectRecorder records the messages which the validator rejects in a rejectTally.
+// Rejects of the node's own messages aren't recorded.
+type rejectRecorder struct {
+	validator messageValidator
+	selfPID   peer.ID
+	rejects   *rejectTally
+}
+
+func newRejectRecorder(validator messageValidator, selfPID peer.ID, rejects *rejectTally) *rejectRecorder {
+	return &rejectRecorder{
+		validator: validator,
+		selfPID:   selfPID,
+		rejects:   rejects,
+	}
+}
+
+// ValidatorForTopic returns the topic's validator, recording the messages it rejects.
+func (r *rejectRecorder) ValidatorForTopic(topic string) func(ctx context.Context, p peer.ID, pmsg *pubsub.Message) pubsub.ValidationResult {
+	validate := r.validator.ValidatorForTopic(topic)
+	return func(ctx context.Context, p peer.ID, pmsg *pubsub.Message) pubsub.ValidationResult {
+		result := validate(ctx, p, pmsg)
+		if result == pubsub.ValidationReject && p != r.selfPID {
+			r.rejects.record(p, topic)
+		}
+		return result
+	}
+}
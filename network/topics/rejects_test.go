@@ -0,0 +1,102 @@
+package topics
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/network/topics/params"
+)
+
+type rejectingValidator struct{}
+
+func (rejectingValidator) ValidatorForTopic(string) func(ctx context.Context, p peer.ID, pmsg *pubsub.Message) pubsub.ValidationResult {
+	return func(ctx context.Context, p peer.ID, pmsg *pubsub.Message) pubsub.ValidationResult {
+		if p == "ignored" {
+			return pubsub.ValidationIgnore
+		}
+		return pubsub.ValidationReject
+	}
+}
+
+type rejectsRecorder struct {
+	Metrics
+	rejects      map[peer.ID]map[string]float64
+	totalRejects map[peer.ID]float64
+}
+
+func (r *rejectsRecorder) PeerScore(peer.ID, float64)             {}
+func (r *rejectsRecorder) PeerP4Score(peer.ID, float64)           {}
+func (r *rejectsRecorder) PeerDuplicateMessages(peer.ID, float64) {}
+func (r *rejectsRecorder) ResetPeerScores() {
+	r.rejects = map[peer.ID]map[string]float64{}
+	r.totalRejects = map[peer.ID]float64{}
+}
+func (r *rejectsRecorder) PeerValidationRejects(pid peer.ID, topic string, count float64) {
+	if r.rejects[pid] == nil {
+		r.rejects[pid] = map[string]float64{}
+	}
+	r.rejects[pid][topic] = count
+}
+func (r *rejectsRecorder) PeerTotalValidationRejects(pid peer.ID, count float64) {
+	r.totalRejects[pid] = count
+}
+
+func TestRejectRecorder(t *testing.T) {
+	rejects := newRejectTally(0.5)
+	recorder := newRejectRecorder(rejectingValidator{}, "self", rejects)
+	validate1, validate2 := recorder.ValidatorForTopic("ssv.v2.1"), recorder.ValidatorForTopic("ssv.v2.2")
+	ctx := context.Background()
+
+	require.Equal(t, pubsub.ValidationReject, validate1(ctx, "a", &pubsub.Message{}))
+	require.Equal(t, pubsub.ValidationReject, validate1(ctx, "a", &pubsub.Message{}))
+	require.Equal(t, pubsub.ValidationReject, validate2(ctx, "a", &pubsub.Message{}))
+	require.Equal(t, pubsub.ValidationReject, validate2(ctx, "b", &pubsub.Message{}))
+	// Neither ignored messages nor the node's own messages are tallied.
+	require.Equal(t, pubsub.ValidationIgnore, validate1(ctx, "ignored", &pubsub.Message{}))
+	require.Equal(t, pubsub.ValidationReject, validate1(ctx, "self", &pubsub.Message{}))
+
+	// App-specific scores are refreshed by inspections only.
+	require.Zero(t, rejects.appSpecificScore("a"))
+
+	metrics := &rejectsRecorder{}
+	inspect := scoreInspector(zap.NewNop(), nil, 1, metrics, func(peer.ID) bool { return true }, nil, rejects)
+	inspect(map[peer.ID]*pubsub.PeerScoreSnapshot{"a": {}})
+	require.Equal(t, map[peer.ID]map[string]float64{
+		"a": {"ssv.v2.1": 2, "ssv.v2.2": 1},
+		"b": {"ssv.v2.2": 1},
+	}, metrics.rejects)
+	require.Equal(t, map[peer.ID]float64{"a": 3, "b": 1}, metrics.totalRejects)
+	require.Equal(t, 5.0, rejects.appSpecificScore("a"))
+	require.Equal(t, 1.0, rejects.appSpecificScore("b"))
+	require.Negative(t, rejects.appSpecificScore("a")*params.ValidationRejectsWeight())
+
+	// Tallies decay with each inspection until they're forgotten.
+	inspect(map[peer.ID]*pubsub.PeerScoreSnapshot{"a": {}})
+	require.Equal(t, 1.0, metrics.rejects["a"]["ssv.v2.1"])
+	require.Equal(t, 1.5, metrics.totalRejects["a"])
+	require.Equal(t, 1.25, rejects.appSpecificScore("a"))
+	for i := 0; i < 10; i++ {
+		inspect(map[peer.ID]*pubsub.PeerScoreSnapshot{"a": {}})
+	}
+	require.Empty(t, metrics.rejects)
+	require.Empty(t, metrics.totalRejects)
+	require.Zero(t, rejects.appSpecificScore("a"))
+}
+
+func TestRejectsDecay(t *testing.T) {
+	oneEpoch := 32 * 12 * time.Second
+	decay := rejectsDecay(oneEpoch, time.Minute)
+	require.Greater(t, decay, 0.99)
+	require.Less(t, decay, 1.0)
+
+	// Rejects decay to zero over rejectsDecayEpochs.
+	inspections := float64(rejectsDecayEpochs*oneEpoch) / float64(time.Minute)
+	require.InDelta(t, rejectsDecayToZero, 1*math.Pow(decay, inspections), 1e-9)
+}
@@ -2,6 +2,7 @@ package topics
 
 import (
 	"math"
+	"sort"
 	"time"
 
 	"github.com/bloxapp/ssv/logging/fields"
@@ -13,25 +14,174 @@ import (
 
 	"github.com/bloxapp/ssv/network/peers"
 	"github.com/bloxapp/ssv/network/topics/params"
+	"github.com/bloxapp/ssv/networkconfig"
 )
 
 // DefaultScoringConfig returns the default scoring config
 func DefaultScoringConfig() *ScoringConfig {
 	return &ScoringConfig{
-		IPColocationWeight: -35.11,
-		OneEpochDuration:   (12 * time.Second) * 32,
+		OneEpochDuration: (12 * time.Second) * 32,
 	}
 }
 
-// scoreInspector inspects scores and updates the score index accordingly
+// networkScoringConfigs are the default scoring configs of known networks by name
+var networkScoringConfigs = map[string]ScoringConfig{
+	networkconfig.Mainnet.Name: {
+		OneEpochDuration:   epochDuration(networkconfig.Mainnet),
+		IPColocationWeight: params.IPColocationWeight(),
+	},
+	networkconfig.Holesky.Name: {
+		OneEpochDuration:   epochDuration(networkconfig.Holesky),
+		IPColocationWeight: params.IPColocationWeight(),
+	},
+	networkconfig.HoleskyStage.Name: {
+		OneEpochDuration:   epochDuration(networkconfig.HoleskyStage),
+		IPColocationWeight: params.IPColocationWeight(),
+	},
+	networkconfig.JatoV2Stage.Name: {
+		OneEpochDuration:   epochDuration(networkconfig.JatoV2Stage),
+		IPColocationWeight: params.IPColocationWeight(),
+	},
+	networkconfig.JatoV2.Name: {
+		OneEpochDuration:   epochDuration(networkconfig.JatoV2),
+		IPColocationWeight: params.IPColocationWeight(),
+	},
+	networkconfig.LocalTestnet.Name: {
+		OneEpochDuration:   epochDuration(networkconfig.LocalTestnet),
+		IPColocationWeight: params.IPColocationWeight(),
+	},
+	networkconfig.HoleskyE2E.Name: {
+		OneEpochDuration:   epochDuration(networkconfig.HoleskyE2E),
+		IPColocationWeight: params.IPColocationWeight(),
+	},
+	// nodes of the test network usually run on the same host, so they mustn't be penalized for it
+	networkconfig.TestNetwork.Name: {
+		OneEpochDuration:    epochDuration(networkconfig.TestNetwork),
+		DisableIPColocation: true,
+	},
+}
+
+// NetworkScoringConfig returns the default scoring config of the given network,
+// or DefaultScoringConfig if the network isn't known
+func NetworkScoringConfig(netCfg networkconfig.NetworkConfig) *ScoringConfig {
+	cfg, ok := networkScoringConfigs[netCfg.Name]
+	if !ok {
+		return DefaultScoringConfig()
+	}
+	return &cfg
+}
+
+func epochDuration(netCfg networkconfig.NetworkConfig) time.Duration {
+	return netCfg.SlotDurationSec() * time.Duration(netCfg.SlotsPerEpoch())
+}
+
+// peerScoreParams returns the peer score params according to the scoring config.
+// A zero IPColocationWeight keeps the default weight of the params package.
+func (cfg *ScoringConfig) peerScoreParams(msgIDCacheTTL time.Duration) *pubsub.PeerScoreParams {
+	peerScoreParams := params.PeerScoreParams(cfg.OneEpochDuration, msgIDCacheTTL, cfg.IPWhilelist...)
+	if cfg.IPColocationWeight != 0 {
+		peerScoreParams.IPColocationFactorWeight = cfg.IPColocationWeight
+	}
+	if cfg.DisableIPColocation {
+		peerScoreParams.IPColocationFactorWeight = 0
+	}
+	return peerScoreParams
+}
+
+// peerScoreEWMAWeight is the weight of the latest score in a peer's exponentially weighted moving average score
+const peerScoreEWMAWeight = 0.1
+
+// scoreStats holds statistics of scores across peers
+type scoreStats struct {
+	min, max, median float64
+}
+
+// newScoreStats computes the statistics of the given scores, which are sorted in place
+func newScoreStats(scores []float64) scoreStats {
+	if len(scores) == 0 {
+		return scoreStats{}
+	}
+	sort.Float64s(scores)
+	stats := scoreStats{
+		min: scores[0],
+		max: scores[len(scores)-1],
+	}
+	if mid := len(scores) / 2; len(scores)%2 == 0 {
+		stats.median = (scores[mid-1] + scores[mid]) / 2
+	} else {
+		stats.median = scores[mid]
+	}
+	return stats
+}
+
+// report sets the score statistics gauges of the given score kind
+func (s scoreStats) report(score string) {
+	metricPubsubPeerScoreStats.WithLabelValues(score, "min").Set(s.min)
+	metricPubsubPeerScoreStats.WithLabelValues(score, "max").Set(s.max)
+	metricPubsubPeerScoreStats.WithLabelValues(score, "median").Set(s.median)
+}
+
+// updateScoreEWMAs updates the moving averages with the given scores,
+// and forgets peers that are no longer scored
+func updateScoreEWMAs(ewmas map[peer.ID]float64, scores map[peer.ID]*pubsub.PeerScoreSnapshot) {
+	for pid := range ewmas {
+		if _, ok := scores[pid]; !ok {
+			delete(ewmas, pid)
+		}
+	}
+	for pid, peerScores := range scores {
+		ewma, ok := ewmas[pid]
+		if !ok {
+			ewmas[pid] = peerScores.Score
+			continue
+		}
+		ewmas[pid] = peerScoreEWMAWeight*peerScores.Score + (1-peerScoreEWMAWeight)*ewma
+	}
+}
+
+// scoreInspector inspects scores and updates the score index accordingly,
+// reporting the duplicate message deliveries of each peer since the previous inspection
+// and refreshing the app-specific scores from the validation rejects of each peer
 // TODO: finalize once validation is in place
-func scoreInspector(logger *zap.Logger, scoreIdx peers.ScoreIndex, logFrequency int, metrics Metrics, peerConnected func(pid peer.ID) bool) pubsub.ExtendedPeerScoreInspectFn {
+func scoreInspector(logger *zap.Logger, scoreIdx peers.ScoreIndex, logFrequency int, metrics Metrics, peerConnected func(pid peer.ID) bool, duplicates *duplicateTracker, rejects *rejectTally) pubsub.ExtendedPeerScoreInspectFn {
 	inspections := 0
+	ewmas := make(map[peer.ID]float64)
 
 	return func(scores map[peer.ID]*pubsub.PeerScoreSnapshot) {
 		// Reset metrics before updating them.
 		metrics.ResetPeerScores()
 
+		// Update aggregated metrics, which unlike per-peer metrics, are suitable for trend analysis.
+		updateScoreEWMAs(ewmas, scores)
+		currentScores := make([]float64, 0, len(scores))
+		ewmaScores := make([]float64, 0, len(ewmas))
+		for pid, peerScores := range scores {
+			currentScores = append(currentScores, peerScores.Score)
+			ewmaScores = append(ewmaScores, ewmas[pid])
+		}
+		newScoreStats(currentScores).report("current")
+		newScoreStats(ewmaScores).report("ewma")
+
+		// Pubsub doesn't track duplicate message deliveries in score snapshots, so they're counted separately.
+		var peerDuplicates map[peer.ID]uint64
+		if duplicates != nil {
+			peerDuplicates = duplicates.take()
+		}
+
+		// The tally of validation rejects applies to the app-specific scores of the next refresh.
+		var peerRejects map[peer.ID]map[string]float64
+		if rejects != nil {
+			peerRejects = rejects.inspect()
+		}
+		for pid, topics := range peerRejects {
+			var count float64
+			for topic, topicCount := range topics {
+				metrics.PeerValidationRejects(pid, topic, topicCount)
+				count += topicCount
+			}
+			metrics.PeerTotalValidationRejects(pid, count)
+		}
+
 		for pid, peerScores := range scores {
 			// Compute score-related stats for this peer.
 			filtered := make(map[string]*pubsub.TopicScoreSnapshot)
@@ -55,6 +205,7 @@ func scoreInspector(logger *zap.Logger, scoreIdx peers.ScoreIndex, logFrequency
 			// Update metrics.
 			metrics.PeerScore(pid, peerScores.Score)
 			metrics.PeerP4Score(pid, p4ScoreSquaresSum)
+			metrics.PeerDuplicateMessages(pid, float64(peerDuplicates[pid]))
 
 			if inspections%logFrequency != 0 {
 				// Don't log yet.
@@ -71,6 +222,7 @@ func scoreInspector(logger *zap.Logger, scoreIdx peers.ScoreIndex, logFrequency
 				zap.Float64("app_specific_penalty", peerScores.AppSpecificScore),
 				zap.Float64("total_low_mesh_deliveries", float64(totalLowMeshDeliveries)),
 				zap.Float64("total_invalid_messages", totalInvalidMessages),
+				zap.Uint64("duplicate_messages", peerDuplicates[pid]),
 				zap.Any("invalid_messages", filtered),
 			}
 			if peerConnected(pid) {
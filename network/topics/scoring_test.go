@@ -0,0 +1,107 @@
+package topics
+
+import (
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/network/topics/params"
+	"github.com/bloxapp/ssv/networkconfig"
+)
+
+func TestScoreStats(t *testing.T) {
+	require.Equal(t, scoreStats{}, newScoreStats(nil))
+	require.Equal(t, scoreStats{min: 5, max: 5, median: 5}, newScoreStats([]float64{5}))
+	require.Equal(t, scoreStats{min: -10, max: 7, median: 1}, newScoreStats([]float64{7, -10, 1}))
+	require.Equal(t, scoreStats{min: -10, max: 7, median: 2}, newScoreStats([]float64{7, 3, -10, 1}))
+}
+
+func TestUpdateScoreEWMAs(t *testing.T) {
+	ewmas := make(map[peer.ID]float64)
+
+	updateScoreEWMAs(ewmas, map[peer.ID]*pubsub.PeerScoreSnapshot{
+		"a": {Score: 10},
+		"b": {Score: -10},
+	})
+	require.Equal(t, map[peer.ID]float64{"a": 10, "b": -10}, ewmas)
+
+	updateScoreEWMAs(ewmas, map[peer.ID]*pubsub.PeerScoreSnapshot{
+		"a": {Score: 20},
+		"c": {Score: 1},
+	})
+	require.Len(t, ewmas, 2)
+	require.InDelta(t, 11, ewmas["a"], 1e-9)
+	require.Equal(t, float64(1), ewmas["c"])
+}
+
+type duplicatesRecorder struct {
+	Metrics
+	duplicates map[peer.ID]float64
+}
+
+func (r *duplicatesRecorder) PeerScore(peer.ID, float64)   {}
+func (r *duplicatesRecorder) PeerP4Score(peer.ID, float64) {}
+func (r *duplicatesRecorder) ResetPeerScores()             { r.duplicates = map[peer.ID]float64{} }
+func (r *duplicatesRecorder) PeerDuplicateMessages(pid peer.ID, count float64) {
+	r.duplicates[pid] = count
+}
+
+func TestScoreInspectorDuplicates(t *testing.T) {
+	duplicates := newDuplicateTracker()
+	metrics := &duplicatesRecorder{}
+	peerConnected := func(peer.ID) bool { return true }
+	inspect := scoreInspector(zap.NewNop(), nil, 1, metrics, peerConnected, duplicates, nil)
+	scores := map[peer.ID]*pubsub.PeerScoreSnapshot{"a": {}, "b": {}}
+
+	duplicates.DuplicateMessage(&pubsub.Message{ReceivedFrom: "a"})
+	duplicates.DuplicateMessage(&pubsub.Message{ReceivedFrom: "a"})
+	duplicates.DuplicateMessage(&pubsub.Message{ReceivedFrom: "b"})
+	inspect(scores)
+	require.Equal(t, map[peer.ID]float64{"a": 2, "b": 1}, metrics.duplicates)
+
+	// Only duplicates since the previous inspection are reported.
+	duplicates.DuplicateMessage(&pubsub.Message{ReceivedFrom: "b"})
+	inspect(scores)
+	require.Equal(t, map[peer.ID]float64{"a": 0, "b": 1}, metrics.duplicates)
+}
+
+func TestDisableIPColocation(t *testing.T) {
+	cfg := DefaultScoringConfig()
+	peerScoreParams := cfg.peerScoreParams(msgIDCacheTTL)
+	require.Negative(t, peerScoreParams.IPColocationFactorWeight)
+
+	cfg.DisableIPColocation = true
+	peerScoreParams = cfg.peerScoreParams(msgIDCacheTTL)
+	require.Zero(t, peerScoreParams.IPColocationFactorWeight)
+}
+
+func TestNetworkScoringConfig(t *testing.T) {
+	mainnet := NetworkScoringConfig(networkconfig.Mainnet)
+	require.Equal(t, 384*time.Second, mainnet.OneEpochDuration)
+	require.False(t, mainnet.DisableIPColocation)
+
+	// Every supported network has an explicit colocation weight.
+	for name, netCfg := range networkconfig.SupportedConfigs {
+		cfg := NetworkScoringConfig(netCfg)
+		require.Equal(t, params.IPColocationWeight(), cfg.IPColocationWeight, name)
+		require.Equal(t, params.IPColocationWeight(), cfg.peerScoreParams(msgIDCacheTTL).IPColocationFactorWeight, name)
+	}
+
+	testnet := NetworkScoringConfig(networkconfig.TestNetwork)
+	require.True(t, testnet.DisableIPColocation)
+	require.Zero(t, testnet.peerScoreParams(msgIDCacheTTL).IPColocationFactorWeight)
+
+	// Configs are copied, so changes don't affect other nodes of the network.
+	testnet.DisableIPColocation = false
+	require.True(t, NetworkScoringConfig(networkconfig.TestNetwork).DisableIPColocation)
+
+	// Unknown networks fall back to the default config.
+	require.Equal(t, DefaultScoringConfig(), NetworkScoringConfig(networkconfig.NetworkConfig{Name: "unknown"}))
+
+	cfg := &ScoringConfig{OneEpochDuration: mainnet.OneEpochDuration, IPColocationWeight: -10}
+	require.Equal(t, -10.0, cfg.peerScoreParams(msgIDCacheTTL).IPColocationFactorWeight)
+}
@@ -0,0 +1,42 @@
+package topics
+
+import (
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+)
+
+// TopicPeersProvider reports the current peers of topics by their full names,
+// for decisions which depend on the node's connectivity on a topic.
+type TopicPeersProvider interface {
+	// TopicPeers returns the number of connected peers subscribed to the topic.
+	TopicPeers(topic string) int
+	// MeshPeers returns the number of peers in the topic's mesh.
+	MeshPeers(topic string) int
+}
+
+// pubsubTopicPeers implements TopicPeersProvider by pubsub's subscribed peers
+// and the mesh tracked by the tracer from GRAFT and PRUNE events.
+type pubsubTopicPeers struct {
+	ps     *pubsub.PubSub
+	tracer *psTracer
+}
+
+func newTopicPeers(ps *pubsub.PubSub, tracer *psTracer) *pubsubTopicPeers {
+	return &pubsubTopicPeers{
+		ps:     ps,
+		tracer: tracer,
+	}
+}
+
+func (p *pubsubTopicPeers) TopicPeers(topic string) int {
+	peers := len(p.ps.ListPeers(topic))
+	metricPubsubTopicPeers.WithLabelValues(topic).Set(float64(peers))
+	return peers
+}
+
+// MeshPeers returns the number of mesh peers of the topic, whose gauge the tracer updates with every change.
+func (p *pubsubTopicPeers) MeshPeers(topic string) int {
+	if p.tracer == nil {
+		return 0
+	}
+	return p.tracer.meshPeers(topic)
+}
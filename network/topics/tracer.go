@@ -2,10 +2,13 @@ package topics
 
 import (
 	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
 
 	"github.com/bloxapp/ssv/logging"
 
-	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	ps_pb "github.com/libp2p/go-libp2p-pubsub/pb"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"go.uber.org/zap"
@@ -14,23 +17,156 @@ import (
 // psTracer helps to trace pubsub events
 // it can run with logging in addition to reporting (on by default)
 type psTracer struct {
-	logger *zap.Logger // struct logger to implement pubsub.EventTracer
+	logger    *zap.Logger // struct logger to implement pubsub.EventTracer
+	logEvents atomic.Bool
+	logTypes  atomic.Pointer[map[ps_pb.TraceEvent_Type]struct{}] // event types to log, or all if nil
+
+	mu   sync.Mutex
+	mesh map[string]map[peer.ID]struct{} // mesh peers by topic, tracked by GRAFT and PRUNE events
 }
 
-// newTracer creates an instance of psTracer
-func newTracer(logger *zap.Logger) pubsub.EventTracer {
-	return &psTracer{logger: logger.Named(logging.NamePubsubTrace)}
+// newTracer creates an instance of psTracer, which logs events of the given types (all if empty) if logEvents is set
+func newTracer(logger *zap.Logger, logEvents bool, logTypes []string) (*psTracer, error) {
+	pst := &psTracer{
+		logger: logger.Named(logging.NamePubsubTrace),
+		mesh:   make(map[string]map[peer.ID]struct{}),
+	}
+	if err := pst.setLog(logEvents, logTypes); err != nil {
+		return nil, err
+	}
+	return pst, nil
 }
 
 // Trace handles events, implementation of pubsub.EventTracer
 func (pst *psTracer) Trace(evt *ps_pb.TraceEvent) {
 	pst.report(evt)
-	pst.log(pst.logger, evt)
+	if pst.logs(evt.GetType()) {
+		pst.log(pst.logger, evt)
+	}
+}
+
+// logs returns whether events of the given type are logged
+func (pst *psTracer) logs(eventType ps_pb.TraceEvent_Type) bool {
+	if !pst.logEvents.Load() {
+		return false
+	}
+	logTypes := pst.logTypes.Load()
+	if logTypes == nil {
+		return true
+	}
+	_, ok := (*logTypes)[eventType]
+	return ok
+}
+
+// setLog turns logging of events on or off, limited to the given event types (e.g. REJECT_MESSAGE) or all if empty
+func (pst *psTracer) setLog(enabled bool, types []string) error {
+	var logTypes *map[ps_pb.TraceEvent_Type]struct{}
+	if len(types) > 0 {
+		parsed := make(map[ps_pb.TraceEvent_Type]struct{}, len(types))
+		for _, name := range types {
+			eventType, ok := ps_pb.TraceEvent_Type_value[name]
+			if !ok {
+				return fmt.Errorf("unknown pubsub event type %q", name)
+			}
+			parsed[ps_pb.TraceEvent_Type(eventType)] = struct{}{}
+		}
+		logTypes = &parsed
+	}
+	pst.logTypes.Store(logTypes)
+	pst.logEvents.Store(enabled)
+	return nil
+}
+
+// logSettings returns whether events are logged, and the event types which are logged (all if empty)
+func (pst *psTracer) logSettings() (bool, []string) {
+	var types []string
+	if logTypes := pst.logTypes.Load(); logTypes != nil {
+		for eventType := range *logTypes {
+			types = append(types, eventType.String())
+		}
+		sort.Strings(types)
+	}
+	return pst.logEvents.Load(), types
 }
 
 // report reports metric
 func (pst *psTracer) report(evt *ps_pb.TraceEvent) {
 	metricPubsubTrace.WithLabelValues(evt.GetType().String()).Inc()
+
+	switch evt.GetType() {
+	case ps_pb.TraceEvent_GRAFT:
+		if pid, err := peer.IDFromBytes(evt.GetGraft().GetPeerID()); err == nil {
+			pst.graft(evt.GetGraft().GetTopic(), pid)
+		}
+	case ps_pb.TraceEvent_PRUNE:
+		if pid, err := peer.IDFromBytes(evt.GetPrune().GetPeerID()); err == nil {
+			pst.prune(evt.GetPrune().GetTopic(), pid)
+		}
+	case ps_pb.TraceEvent_REMOVE_PEER:
+		// Disconnected peers leave the mesh without PRUNE events.
+		if pid, err := peer.IDFromBytes(evt.GetRemovePeer().GetPeerID()); err == nil {
+			pst.removePeer(pid)
+		}
+	case ps_pb.TraceEvent_LEAVE:
+		pst.leave(evt.GetLeave().GetTopic())
+	}
+}
+
+// graft adds the peer to the mesh of the topic.
+func (pst *psTracer) graft(topic string, pid peer.ID) {
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
+
+	peers, ok := pst.mesh[topic]
+	if !ok {
+		peers = make(map[peer.ID]struct{})
+		pst.mesh[topic] = peers
+	}
+	peers[pid] = struct{}{}
+	metricPubsubMeshPeers.WithLabelValues(topic).Set(float64(len(peers)))
+}
+
+// prune removes the peer from the mesh of the topic.
+func (pst *psTracer) prune(topic string, pid peer.ID) {
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
+
+	peers, ok := pst.mesh[topic]
+	if !ok {
+		return
+	}
+	delete(peers, pid)
+	metricPubsubMeshPeers.WithLabelValues(topic).Set(float64(len(peers)))
+}
+
+// removePeer removes the peer from the meshes of all topics.
+func (pst *psTracer) removePeer(pid peer.ID) {
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
+
+	for topic, peers := range pst.mesh {
+		if _, ok := peers[pid]; ok {
+			delete(peers, pid)
+			metricPubsubMeshPeers.WithLabelValues(topic).Set(float64(len(peers)))
+		}
+	}
+}
+
+// leave drops the mesh of the topic.
+func (pst *psTracer) leave(topic string) {
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
+
+	delete(pst.mesh, topic)
+	metricPubsubMeshPeers.DeleteLabelValues(topic)
+}
+
+// meshPeers returns the count of mesh peers of the topic.
+func (pst *psTracer) meshPeers(topic string) int {
+	pst.mu.Lock()
+	defer pst.mu.Unlock()
+
+	return len(pst.mesh[topic])
 }
 
 // log prints event to log
@@ -0,0 +1,94 @@
+package topics
+
+import (
+	"testing"
+
+	ps_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTracerMeshPeers(t *testing.T) {
+	tracer, err := newTracer(zap.NewNop(), false, nil)
+	require.NoError(t, err)
+	topic := "ssv.v2.1"
+	newPeer := func() []byte {
+		sk, _, err := crypto.GenerateEd25519Key(nil)
+		require.NoError(t, err)
+		pid, err := peer.IDFromPrivateKey(sk)
+		require.NoError(t, err)
+		return []byte(pid)
+	}
+	peer1, peer2 := newPeer(), newPeer()
+
+	graft := func(pid []byte) *ps_pb.TraceEvent {
+		return &ps_pb.TraceEvent{
+			Type:  ps_pb.TraceEvent_GRAFT.Enum(),
+			Graft: &ps_pb.TraceEvent_Graft{PeerID: pid, Topic: &topic},
+		}
+	}
+	meshPeers := func() float64 {
+		return testutil.ToFloat64(metricPubsubMeshPeers.WithLabelValues(topic))
+	}
+
+	tracer.Trace(graft(peer1))
+	tracer.Trace(graft(peer2))
+	tracer.Trace(graft(peer2))
+	require.Equal(t, 2, tracer.meshPeers(topic))
+	require.Equal(t, 2.0, meshPeers())
+	require.Equal(t, 2, newTopicPeers(nil, tracer).MeshPeers(topic))
+
+	tracer.Trace(&ps_pb.TraceEvent{
+		Type:  ps_pb.TraceEvent_PRUNE.Enum(),
+		Prune: &ps_pb.TraceEvent_Prune{PeerID: peer1, Topic: &topic},
+	})
+	require.Equal(t, 1, tracer.meshPeers(topic))
+	require.Equal(t, 1.0, meshPeers())
+
+	// Disconnected peers leave the mesh.
+	tracer.Trace(&ps_pb.TraceEvent{
+		Type:       ps_pb.TraceEvent_REMOVE_PEER.Enum(),
+		RemovePeer: &ps_pb.TraceEvent_RemovePeer{PeerID: peer2},
+	})
+	require.Equal(t, 0, tracer.meshPeers(topic))
+	require.Equal(t, 0.0, meshPeers())
+
+	// Leaving the topic drops its mesh.
+	tracer.Trace(graft(peer1))
+	tracer.Trace(&ps_pb.TraceEvent{
+		Type:  ps_pb.TraceEvent_LEAVE.Enum(),
+		Leave: &ps_pb.TraceEvent_Leave{Topic: &topic},
+	})
+	require.Equal(t, 0, tracer.meshPeers(topic))
+}
+
+func TestTracerLogTypes(t *testing.T) {
+	_, err := newTracer(zap.NewNop(), true, []string{"FOO"})
+	require.ErrorContains(t, err, "unknown pubsub event type")
+
+	// By default, all event types are logged.
+	tracer, err := newTracer(zap.NewNop(), true, nil)
+	require.NoError(t, err)
+	require.True(t, tracer.logs(ps_pb.TraceEvent_GRAFT))
+	enabled, types := tracer.logSettings()
+	require.True(t, enabled)
+	require.Empty(t, types)
+
+	require.NoError(t, tracer.setLog(true, []string{"REJECT_MESSAGE", "DELIVER_MESSAGE"}))
+	require.True(t, tracer.logs(ps_pb.TraceEvent_REJECT_MESSAGE))
+	require.True(t, tracer.logs(ps_pb.TraceEvent_DELIVER_MESSAGE))
+	require.False(t, tracer.logs(ps_pb.TraceEvent_GRAFT))
+	enabled, types = tracer.logSettings()
+	require.True(t, enabled)
+	require.Equal(t, []string{"DELIVER_MESSAGE", "REJECT_MESSAGE"}, types)
+
+	// Invalid types leave the settings unchanged.
+	require.Error(t, tracer.setLog(false, []string{"FOO"}))
+	require.True(t, tracer.logs(ps_pb.TraceEvent_REJECT_MESSAGE))
+
+	require.NoError(t, tracer.setLog(false, []string{"REJECT_MESSAGE"}))
+	require.False(t, tracer.logs(ps_pb.TraceEvent_REJECT_MESSAGE))
+}
@@ -0,0 +1,51 @@
+package nodeprobe
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// readyRetryInterval is the interval between checks of whether the consensus client is ready.
+const readyRetryInterval = time.Second
+
+// HistorySyncWaiter is implemented by the event syncer.
+type HistorySyncWaiter interface {
+	// WaitHistorySynced blocks until historical registry events are synced.
+	WaitHistorySynced(ctx context.Context) error
+}
+
+// ReadyChecker is implemented by the consensus client.
+type ReadyChecker interface {
+	// Ready returns an error if the node can't serve duties.
+	Ready(ctx context.Context) error
+}
+
+// WaitForSync blocks until the node is fully up: historical registry events are synced,
+// and then the consensus client is ready. It fails once the timeout expires, identifying
+// the subsystem which wasn't ready. Zero timeout waits until the context is done.
+func WaitForSync(ctx context.Context, timeout time.Duration, eventSyncer HistorySyncWaiter, consensusClient ReadyChecker) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	if err := eventSyncer.WaitHistorySynced(ctx); err != nil {
+		return fmt.Errorf("event syncer isn't synced: %w", err)
+	}
+
+	ticker := time.NewTicker(readyRetryInterval)
+	defer ticker.Stop()
+	for {
+		err := consensusClient.Ready(ctx)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("consensus client isn't ready: %w: %w", ctx.Err(), err)
+		case <-ticker.C:
+		}
+	}
+}
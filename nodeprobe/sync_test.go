@@ -0,0 +1,62 @@
+package nodeprobe
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForSync(t *testing.T) {
+	ctx := context.Background()
+	syncer := &historySyncer{synced: make(chan struct{})}
+	client := &readyClient{}
+
+	// The event syncer is awaited first.
+	err := WaitForSync(ctx, 20*time.Millisecond, syncer, client)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "event syncer")
+
+	// Then the consensus client, whose last error is reported.
+	close(syncer.synced)
+	notReady := errors.New("syncing")
+	client.err.Store(&notReady)
+	err = WaitForSync(ctx, 20*time.Millisecond, syncer, client)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorIs(t, err, notReady)
+	require.ErrorContains(t, err, "consensus client")
+
+	// The consensus client is checked again until it's ready.
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		client.err.Store(nil)
+	}()
+	require.NoError(t, WaitForSync(ctx, 5*time.Second, syncer, client))
+}
+
+type historySyncer struct {
+	synced chan struct{}
+}
+
+func (s *historySyncer) WaitHistorySynced(ctx context.Context) error {
+	select {
+	case <-s.synced:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type readyClient struct {
+	err atomic.Pointer[error]
+}
+
+func (c *readyClient) Ready(context.Context) error {
+	if err := c.err.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
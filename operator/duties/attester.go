@@ -155,7 +155,7 @@ func (h *AttesterHandler) processFetching(ctx context.Context, epoch phase0.Epoc
 			h.logger.Error("failed to fetch duties for current epoch", zap.Error(err))
 			return
 		}
-		h.fetchCurrentEpoch = false
+		h.fetchCurrentEpoch = h.beaconNodeWarmingUp()
 	}
 
 	if h.fetchNextEpoch && h.shouldFetchNexEpoch(slot) {
@@ -163,7 +163,7 @@ func (h *AttesterHandler) processFetching(ctx context.Context, epoch phase0.Epoc
 			h.logger.Error("failed to fetch duties for next epoch", zap.Error(err))
 			return
 		}
-		h.fetchNextEpoch = false
+		h.fetchNextEpoch = h.beaconNodeWarmingUp()
 	}
 }
 
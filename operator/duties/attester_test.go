@@ -12,8 +12,13 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/networkconfig"
 	"github.com/bloxapp/ssv/operator/duties/dutystore"
 	"github.com/bloxapp/ssv/operator/duties/mocks"
+	"github.com/bloxapp/ssv/operator/slotticker"
+	mockslotticker "github.com/bloxapp/ssv/operator/slotticker/mocks"
+	mocknetwork "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon/mocks"
 )
 
 func setupAttesterDutiesMock(s *Scheduler, dutiesMap *hashmap.Map[phase0.Epoch, []*eth2apiv1.AttesterDuty]) (chan struct{}, chan []*spectypes.Duty) {
@@ -927,3 +932,39 @@ func TestScheduler_Attester_Fetch_Execute_Next_Epoch_Duty(t *testing.T) {
 	cancel()
 	require.NoError(t, schedulerPool.Wait())
 }
+
+// warmingUpBeaconNode is a beacon node which reports whether it's warming up.
+type warmingUpBeaconNode struct {
+	BeaconNode
+	warmingUp bool
+}
+
+func (b *warmingUpBeaconNode) WarmingUp() bool {
+	return b.warmingUp
+}
+
+func TestScheduler_Attester_Fetch_During_Warm_Up(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	beaconNode := &warmingUpBeaconNode{BeaconNode: mocks.NewMockBeaconNode(ctrl), warmingUp: true}
+	validatorController := mocks.NewMockValidatorController(ctrl)
+	validatorController.EXPECT().CommitteeActiveIndices(gomock.Any()).Return(nil).AnyTimes()
+	network := networkconfig.NetworkConfig{Beacon: mocknetwork.NewMockBeaconNetwork(ctrl)}
+	network.Beacon.(*mocknetwork.MockBeaconNetwork).EXPECT().GetSlotStartTime(gomock.Any()).Return(time.Now()).AnyTimes()
+	network.Beacon.(*mocknetwork.MockBeaconNetwork).EXPECT().SlotsPerEpoch().Return(uint64(32)).AnyTimes()
+
+	handler := NewAttesterHandler(dutystore.NewDuties[eth2apiv1.AttesterDuty]())
+	handler.Setup("attester", logging.TestLogger(t), beaconNode, nil, network, validatorController, nil, func() slotticker.SlotTicker {
+		return mockslotticker.NewMockSlotTicker(ctrl)
+	}, nil, nil)
+
+	// Duties fetched while the beacon node is warming up are fetched again in the next slot.
+	handler.fetchNextEpoch = true
+	handler.processFetching(context.Background(), 0, 20)
+	require.True(t, handler.fetchCurrentEpoch)
+	require.True(t, handler.fetchNextEpoch)
+
+	beaconNode.warmingUp = false
+	handler.processFetching(context.Background(), 0, 21)
+	require.False(t, handler.fetchCurrentEpoch)
+	require.False(t, handler.fetchNextEpoch)
+}
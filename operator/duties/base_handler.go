@@ -8,6 +8,7 @@ import (
 
 	"github.com/bloxapp/ssv/networkconfig"
 	"github.com/bloxapp/ssv/operator/slotticker"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 )
 
 //go:generate mockgen -package=duties -destination=./base_handler_mock.go -source=./base_handler.go
@@ -55,6 +56,17 @@ func (h *baseHandler) warnMisalignedSlotAndDuty(dutyType string) {
 		"assuming diff caused by a time drift - ignoring and executing duty", zap.String("type", dutyType))
 }
 
+// beaconNodeWarmingUp returns whether the beacon node recently reconnected, in which case the duties it serves
+// may be stale, so they should be fetched again in the next slot.
+func (h *baseHandler) beaconNodeWarmingUp() bool {
+	reporter, ok := h.beaconNode.(beaconprotocol.WarmUpReporter)
+	if !ok || !reporter.WarmingUp() {
+		return false
+	}
+	h.logger.Debug("beacon node is warming up, fetching duties again in the next slot")
+	return true
+}
+
 func (b *baseHandler) HandleInitialDuties(context.Context) {
 	// Do nothing
 }
@@ -127,6 +127,9 @@ func (h *ProposerHandler) processFetching(ctx context.Context, epoch phase0.Epoc
 		h.logger.Error("failed to fetch duties for current epoch", zap.Error(err))
 		return
 	}
+	if h.beaconNodeWarmingUp() {
+		h.fetchFirst = true
+	}
 }
 
 func (h *ProposerHandler) processExecution(epoch phase0.Epoch, slot phase0.Slot) {
@@ -158,7 +158,7 @@ func (h *SyncCommitteeHandler) processFetching(ctx context.Context, period uint6
 			h.logger.Error("failed to fetch duties for current epoch", zap.Error(err))
 			return
 		}
-		h.fetchCurrentPeriod = false
+		h.fetchCurrentPeriod = h.beaconNodeWarmingUp()
 	}
 
 	if h.fetchNextPeriod {
@@ -166,7 +166,7 @@ func (h *SyncCommitteeHandler) processFetching(ctx context.Context, period uint6
 			h.logger.Error("failed to fetch duties for next epoch", zap.Error(err))
 			return
 		}
-		h.fetchNextPeriod = false
+		h.fetchNextPeriod = h.beaconNodeWarmingUp()
 	}
 }
 
@@ -7,8 +7,11 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"go.uber.org/zap"
 
+	"github.com/bloxapp/ssv/logging/fields"
 	"github.com/bloxapp/ssv/networkconfig"
 	operatordatastore "github.com/bloxapp/ssv/operator/datastore"
 	"github.com/bloxapp/ssv/operator/slotticker"
@@ -17,6 +20,18 @@ import (
 	"github.com/bloxapp/ssv/registry/storage"
 )
 
+var distinctRecipientsGauge = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "ssv_fee_recipient_distinct_recipients",
+	Help: "Number of distinct fee recipients in the last submitted proposal preparations",
+})
+
+func init() {
+	logger := zap.L()
+	if err := prometheus.Register(distinctRecipientsGauge); err != nil {
+		logger.Debug("could not register prometheus collector")
+	}
+}
+
 //go:generate mockgen -package=mocks -destination=./mocks/controller.go -source=./controller.go
 
 // RecipientController submit proposal preparation to beacon node for all committee validators
@@ -33,6 +48,7 @@ type ControllerOptions struct {
 	RecipientStorage   storage.Recipients
 	SlotTickerProvider slotticker.Provider
 	OperatorDataStore  operatordatastore.OperatorDataStore
+	Policy             *Policy // Optional.
 }
 
 // recipientController implementation of RecipientController
@@ -44,6 +60,7 @@ type recipientController struct {
 	recipientStorage   storage.Recipients
 	slotTickerProvider slotticker.Provider
 	operatorDataStore  operatordatastore.OperatorDataStore
+	policy             *Policy
 }
 
 func NewController(opts *ControllerOptions) *recipientController {
@@ -55,6 +72,7 @@ func NewController(opts *ControllerOptions) *recipientController {
 		recipientStorage:   opts.RecipientStorage,
 		slotTickerProvider: opts.SlotTickerProvider,
 		operatorDataStore:  opts.OperatorDataStore,
+		policy:             opts.Policy,
 	}
 }
 
@@ -95,6 +113,7 @@ func (rc *recipientController) prepareAndSubmit(logger *zap.Logger, slot phase0.
 
 	const batchSize = 500
 	var submitted int
+	recipients := make(map[bellatrix.ExecutionAddress]struct{})
 	for start := 0; start < len(shares); start += batchSize {
 		end := start + batchSize
 		if end > len(shares) {
@@ -102,7 +121,7 @@ func (rc *recipientController) prepareAndSubmit(logger *zap.Logger, slot phase0.
 		}
 		batch := shares[start:end]
 
-		count, err := rc.submit(logger, batch)
+		m, err := rc.submit(logger, batch)
 		if err != nil {
 			logger.Warn("could not submit proposal preparation batch",
 				zap.Int("start_index", start),
@@ -110,29 +129,35 @@ func (rc *recipientController) prepareAndSubmit(logger *zap.Logger, slot phase0.
 			)
 			continue
 		}
-		submitted += count
+		submitted += len(m)
+		for _, recipient := range m {
+			recipients[recipient] = struct{}{}
+		}
 	}
+	distinctRecipientsGauge.Set(float64(len(recipients)))
 
 	logger.Debug("✅  successfully submitted proposal preparations",
 		zap.Int("submitted", submitted),
 		zap.Int("total", len(shares)),
+		zap.Int("recipients", len(recipients)),
 	)
 	return nil
 }
 
-func (rc *recipientController) submit(logger *zap.Logger, shares []*types.SSVShare) (int, error) {
-	m, err := rc.toProposalPreparation(shares)
+// submit submits the proposal preparations of the given shares, and returns the submitted preparations.
+func (rc *recipientController) submit(logger *zap.Logger, shares []*types.SSVShare) (map[phase0.ValidatorIndex]bellatrix.ExecutionAddress, error) {
+	m, err := rc.toProposalPreparation(logger, shares)
 	if err != nil {
-		return 0, errors.Wrap(err, "could not build proposal preparation batch")
+		return nil, errors.Wrap(err, "could not build proposal preparation batch")
 	}
 	err = rc.beaconClient.SubmitProposalPreparation(m)
 	if err != nil {
-		return 0, errors.Wrap(err, "could not submit proposal preparation batch")
+		return nil, errors.Wrap(err, "could not submit proposal preparation batch")
 	}
-	return len(m), nil
+	return m, nil
 }
 
-func (rc *recipientController) toProposalPreparation(shares []*types.SSVShare) (map[phase0.ValidatorIndex]bellatrix.ExecutionAddress, error) {
+func (rc *recipientController) toProposalPreparation(logger *zap.Logger, shares []*types.SSVShare) (map[phase0.ValidatorIndex]bellatrix.ExecutionAddress, error) {
 	// build unique owners
 	keys := make(map[common.Address]bool)
 	var uniq []common.Address
@@ -149,12 +174,30 @@ func (rc *recipientController) toProposalPreparation(shares []*types.SSVShare) (
 		return nil, errors.Wrap(err, "could not get recipients data")
 	}
 
-	// build proposal preparation
+	// build proposal preparation, where each validator's fee recipient is set by its owner
 	m := make(map[phase0.ValidatorIndex]bellatrix.ExecutionAddress)
 	for _, share := range shares {
 		feeRecipient, found := rds[share.OwnerAddress]
-		if !found {
-			copy(feeRecipient[:], share.OwnerAddress.Bytes())
+		if found && feeRecipient == (bellatrix.ExecutionAddress{}) {
+			// Fees paid to the zero address are burned.
+			logger.Warn("fee recipient of owner is the zero address",
+				fields.Validator(share.ValidatorPubKey),
+				fields.Owner(share.OwnerAddress),
+				zap.Bool("overridden", rc.policy.OverridesZero()),
+			)
+		}
+		feeRecipient = rc.policy.FeeRecipient(share.OwnerAddress, feeRecipient, found)
+		if reason, reject := rc.policy.Check(share.OwnerAddress, feeRecipient); reason != "" {
+			logger.Warn("fee recipient doesn't match policy",
+				fields.Validator(share.ValidatorPubKey),
+				fields.Owner(share.OwnerAddress),
+				fields.FeeRecipient(feeRecipient[:]),
+				zap.String("reason", reason),
+				zap.Bool("rejected", reject),
+			)
+			if reject {
+				continue
+			}
 		}
 		m[share.BeaconMetadata.Index] = feeRecipient
 	}
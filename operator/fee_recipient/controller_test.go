@@ -124,6 +124,70 @@ func TestSubmitProposal(t *testing.T) {
 	})
 }
 
+func TestToProposalPreparation(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, _, recipientStorage := createStorage(t)
+	defer db.Close()
+
+	owner1 := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	owner2 := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	owner3 := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	recipient1 := toExecutionAddress("0x4444444444444444444444444444444444444444")
+	fallback := toExecutionAddress("0x5555555555555555555555555555555555555555")
+
+	_, err := recipientStorage.SaveRecipientData(nil, &registrystorage.RecipientData{Owner: owner1, FeeRecipient: recipient1})
+	require.NoError(t, err)
+	_, err = recipientStorage.SaveRecipientData(nil, &registrystorage.RecipientData{Owner: owner2})
+	require.NoError(t, err)
+
+	share := func(index phase0.ValidatorIndex, owner common.Address) *types.SSVShare {
+		return &types.SSVShare{
+			Share: spectypes.Share{ValidatorPubKey: []byte{byte(index)}},
+			Metadata: types.Metadata{
+				BeaconMetadata: &beacon.ValidatorMetadata{Index: index},
+				OwnerAddress:   owner,
+			},
+		}
+	}
+	shares := []*types.SSVShare{share(1, owner1), share(2, owner1), share(3, owner2), share(4, owner3)}
+
+	// Without a default fee recipient, validators of owners without one pay their owners.
+	frCtrl := NewController(&ControllerOptions{RecipientStorage: recipientStorage})
+	m, err := frCtrl.toProposalPreparation(logger, shares)
+	require.NoError(t, err)
+	require.Equal(t, map[phase0.ValidatorIndex]bellatrix.ExecutionAddress{
+		1: recipient1,
+		2: recipient1,
+		3: {},
+		4: toExecutionAddress(owner3.Hex()),
+	}, m)
+
+	// The zero address set by an owner is kept unless the policy overrides it.
+	policy, err := NewPolicy(PolicyOptions{DefaultFeeRecipient: fallback.String()})
+	require.NoError(t, err)
+	frCtrl = NewController(&ControllerOptions{RecipientStorage: recipientStorage, Policy: policy})
+	m, err = frCtrl.toProposalPreparation(logger, shares)
+	require.NoError(t, err)
+	require.Equal(t, map[phase0.ValidatorIndex]bellatrix.ExecutionAddress{
+		1: recipient1,
+		2: recipient1,
+		3: {},
+		4: fallback,
+	}, m)
+
+	policy, err = NewPolicy(PolicyOptions{DefaultFeeRecipient: fallback.String(), OverrideZeroRecipient: true})
+	require.NoError(t, err)
+	frCtrl = NewController(&ControllerOptions{RecipientStorage: recipientStorage, Policy: policy})
+	m, err = frCtrl.toProposalPreparation(logger, shares)
+	require.NoError(t, err)
+	require.Equal(t, map[phase0.ValidatorIndex]bellatrix.ExecutionAddress{
+		1: recipient1,
+		2: recipient1,
+		3: fallback,
+		4: fallback,
+	}, m)
+}
+
 func createStorage(t *testing.T) (basedb.Database, registrystorage.Shares, registrystorage.Recipients) {
 	logger := logging.TestLogger(t)
 	db, err := kv.NewInMemory(logger, basedb.Options{})
@@ -0,0 +1,158 @@
+package fee_recipient
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+var mismatchedPreparationsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "ssv_fee_recipient_mismatched_preparations",
+	Help: "Count of proposal preparations with a fee recipient not matching the configured policy",
+}, []string{"reason", "rejected"})
+
+func init() {
+	logger := zap.L()
+	if err := prometheus.Register(mismatchedPreparationsCounter); err != nil {
+		logger.Debug("could not register prometheus collector")
+	}
+}
+
+// Reasons for a fee recipient mismatch. Used as metric labels.
+const (
+	mismatchNotAllowed = "not_allowed"
+	mismatchUnexpected = "unexpected_for_owner"
+)
+
+// PolicyOptions configures the validation of fee recipients before proposal preparations are submitted.
+type PolicyOptions struct {
+	AllowedFeeRecipients  []string          `yaml:"AllowedFeeRecipients" env:"ALLOWED_FEE_RECIPIENTS" env-separator:"," env-description:"Comma-separated list of fee recipient addresses allowed in proposal preparations. Empty allows any address"`
+	ExpectedFeeRecipients map[string]string `yaml:"ExpectedFeeRecipients" env:"EXPECTED_FEE_RECIPIENTS" env-description:"Map of owner address to the single fee recipient address expected for its validators (owner:recipient,...)"`
+	RejectMismatched      bool              `yaml:"RejectMismatchedFeeRecipients" env:"REJECT_MISMATCHED_FEE_RECIPIENTS" env-default:"false" env-description:"Whether to exclude validators with a mismatching fee recipient from proposal preparations instead of only warning"`
+	DefaultFeeRecipient   string            `yaml:"DefaultFeeRecipient" env:"DEFAULT_FEE_RECIPIENT" env-description:"Fee recipient address for validators whose owner didn't set one. Defaults to the owner address"`
+	OverrideZeroRecipient bool              `yaml:"OverrideZeroFeeRecipient" env:"OVERRIDE_ZERO_FEE_RECIPIENT" env-default:"false" env-description:"Whether validators whose owner set the zero address as fee recipient, which burns their fees, pay the default fee recipient instead"`
+}
+
+// Policy validates fee recipients against an allowlist and/or an expected address per owner,
+// and provides the fee recipient of validators whose owner didn't set one.
+// A nil Policy accepts any fee recipient, and defaults to the owner address.
+type Policy struct {
+	allowed  map[bellatrix.ExecutionAddress]struct{}
+	expected map[common.Address]bellatrix.ExecutionAddress
+	reject   bool
+	fallback *bellatrix.ExecutionAddress
+	// overrideZero replaces the zero address set by owners with the default fee recipient.
+	overrideZero bool
+}
+
+// NewPolicy parses the given options into a Policy.
+// Returns nil if the options neither restrict fee recipients nor set a default one.
+func NewPolicy(opts PolicyOptions) (*Policy, error) {
+	if len(opts.AllowedFeeRecipients) == 0 && len(opts.ExpectedFeeRecipients) == 0 && opts.DefaultFeeRecipient == "" && !opts.OverrideZeroRecipient {
+		return nil, nil
+	}
+
+	p := &Policy{
+		allowed:  make(map[bellatrix.ExecutionAddress]struct{}, len(opts.AllowedFeeRecipients)),
+		expected: make(map[common.Address]bellatrix.ExecutionAddress, len(opts.ExpectedFeeRecipients)),
+		reject:   opts.RejectMismatched,
+
+		overrideZero: opts.OverrideZeroRecipient,
+	}
+
+	for _, addr := range opts.AllowedFeeRecipients {
+		recipient, err := parseExecutionAddress(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed fee recipient: %w", err)
+		}
+		p.allowed[recipient] = struct{}{}
+	}
+
+	for owner, addr := range opts.ExpectedFeeRecipients {
+		if !common.IsHexAddress(owner) {
+			return nil, fmt.Errorf("invalid owner address %q", owner)
+		}
+		recipient, err := parseExecutionAddress(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expected fee recipient for owner %s: %w", owner, err)
+		}
+		p.expected[common.HexToAddress(owner)] = recipient
+	}
+
+	if opts.DefaultFeeRecipient != "" {
+		recipient, err := parseExecutionAddress(opts.DefaultFeeRecipient)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default fee recipient: %w", err)
+		}
+		if recipient == (bellatrix.ExecutionAddress{}) {
+			return nil, fmt.Errorf("default fee recipient can't be the zero address")
+		}
+		p.fallback = &recipient
+	}
+
+	return p, nil
+}
+
+// FeeRecipient returns the fee recipient of validators owned by the given owner, given the recipient
+// the owner set on-chain, if any. The owner's recipient is used as is, unless it's the zero address
+// and the policy overrides it. Otherwise, the default fee recipient is used, see Default.
+func (p *Policy) FeeRecipient(owner common.Address, recipient bellatrix.ExecutionAddress, set bool) bellatrix.ExecutionAddress {
+	if !set || (p.OverridesZero() && recipient == bellatrix.ExecutionAddress{}) {
+		return p.Default(owner)
+	}
+	return recipient
+}
+
+// OverridesZero returns whether the zero address set by owners is replaced with the default fee recipient.
+func (p *Policy) OverridesZero() bool {
+	return p != nil && p.overrideZero
+}
+
+// Default returns the fee recipient of validators owned by the given owner which didn't set one:
+// the configured default fee recipient, or the owner address if none is configured.
+func (p *Policy) Default(owner common.Address) bellatrix.ExecutionAddress {
+	if p != nil && p.fallback != nil {
+		return *p.fallback
+	}
+	var recipient bellatrix.ExecutionAddress
+	copy(recipient[:], owner.Bytes())
+	return recipient
+}
+
+// Check validates the fee recipient of a validator owned by the given owner.
+// It returns the mismatch reason if the fee recipient doesn't comply with the policy,
+// and whether the validator should be excluded from the proposal preparations.
+func (p *Policy) Check(owner common.Address, recipient bellatrix.ExecutionAddress) (reason string, reject bool) {
+	if p == nil {
+		return "", false
+	}
+
+	// An expected address configured for the owner takes precedence over the allowlist.
+	if expected, ok := p.expected[owner]; ok {
+		if expected != recipient {
+			reason = mismatchUnexpected
+		}
+	} else if _, ok := p.allowed[recipient]; len(p.allowed) != 0 && !ok {
+		reason = mismatchNotAllowed
+	}
+
+	if reason == "" {
+		return "", false
+	}
+
+	mismatchedPreparationsCounter.WithLabelValues(reason, fmt.Sprint(p.reject)).Inc()
+	return reason, p.reject
+}
+
+func parseExecutionAddress(addr string) (bellatrix.ExecutionAddress, error) {
+	var recipient bellatrix.ExecutionAddress
+	if !common.IsHexAddress(addr) {
+		return recipient, fmt.Errorf("%q is not an address", addr)
+	}
+	copy(recipient[:], common.HexToAddress(addr).Bytes())
+	return recipient, nil
+}
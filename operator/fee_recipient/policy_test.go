@@ -0,0 +1,122 @@
+package fee_recipient
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy(t *testing.T) {
+	owner := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	otherOwner := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	allowed := toExecutionAddress("0x3333333333333333333333333333333333333333")
+	expected := toExecutionAddress("0x4444444444444444444444444444444444444444")
+	unknown := toExecutionAddress("0x5555555555555555555555555555555555555555")
+
+	t.Run("no restrictions", func(t *testing.T) {
+		policy, err := NewPolicy(PolicyOptions{RejectMismatched: true})
+		require.NoError(t, err)
+		require.Nil(t, policy)
+
+		reason, reject := policy.Check(owner, unknown)
+		require.Empty(t, reason)
+		require.False(t, reject)
+	})
+
+	t.Run("invalid addresses", func(t *testing.T) {
+		_, err := NewPolicy(PolicyOptions{AllowedFeeRecipients: []string{"0x1234"}})
+		require.Error(t, err)
+
+		_, err = NewPolicy(PolicyOptions{ExpectedFeeRecipients: map[string]string{"owner": expected.String()}})
+		require.Error(t, err)
+
+		_, err = NewPolicy(PolicyOptions{ExpectedFeeRecipients: map[string]string{owner.Hex(): "recipient"}})
+		require.Error(t, err)
+	})
+
+	t.Run("warn only", func(t *testing.T) {
+		policy, err := NewPolicy(PolicyOptions{
+			AllowedFeeRecipients: []string{allowed.String()},
+		})
+		require.NoError(t, err)
+
+		reason, reject := policy.Check(owner, allowed)
+		require.Empty(t, reason)
+		require.False(t, reject)
+
+		reason, reject = policy.Check(owner, unknown)
+		require.Equal(t, mismatchNotAllowed, reason)
+		require.False(t, reject)
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		policy, err := NewPolicy(PolicyOptions{
+			AllowedFeeRecipients:  []string{allowed.String()},
+			ExpectedFeeRecipients: map[string]string{owner.Hex(): expected.String()},
+			RejectMismatched:      true,
+		})
+		require.NoError(t, err)
+
+		// The owner's expected recipient passes even though it isn't allowlisted.
+		reason, reject := policy.Check(owner, expected)
+		require.Empty(t, reason)
+		require.False(t, reject)
+
+		reason, reject = policy.Check(owner, allowed)
+		require.Equal(t, mismatchUnexpected, reason)
+		require.True(t, reject)
+
+		reason, reject = policy.Check(otherOwner, allowed)
+		require.Empty(t, reason)
+		require.False(t, reject)
+
+		reason, reject = policy.Check(otherOwner, unknown)
+		require.Equal(t, mismatchNotAllowed, reason)
+		require.True(t, reject)
+	})
+
+	t.Run("default fee recipient", func(t *testing.T) {
+		var noPolicy *Policy
+		require.Equal(t, toExecutionAddress(owner.Hex()), noPolicy.Default(owner))
+
+		_, err := NewPolicy(PolicyOptions{DefaultFeeRecipient: "recipient"})
+		require.Error(t, err)
+		_, err = NewPolicy(PolicyOptions{DefaultFeeRecipient: common.Address{}.Hex()})
+		require.Error(t, err)
+
+		policy, err := NewPolicy(PolicyOptions{DefaultFeeRecipient: allowed.String()})
+		require.NoError(t, err)
+		require.Equal(t, allowed, policy.Default(owner))
+
+		// A default fee recipient alone doesn't restrict fee recipients.
+		reason, reject := policy.Check(owner, unknown)
+		require.Empty(t, reason)
+		require.False(t, reject)
+	})
+
+	t.Run("fee recipient", func(t *testing.T) {
+		var noPolicy *Policy
+		require.Equal(t, allowed, noPolicy.FeeRecipient(owner, allowed, true))
+		require.Equal(t, bellatrix.ExecutionAddress{}, noPolicy.FeeRecipient(owner, bellatrix.ExecutionAddress{}, true))
+		require.Equal(t, toExecutionAddress(owner.Hex()), noPolicy.FeeRecipient(owner, bellatrix.ExecutionAddress{}, false))
+
+		// The zero address set by the owner is replaced only if the policy overrides it.
+		policy, err := NewPolicy(PolicyOptions{DefaultFeeRecipient: expected.String()})
+		require.NoError(t, err)
+		require.Equal(t, bellatrix.ExecutionAddress{}, policy.FeeRecipient(owner, bellatrix.ExecutionAddress{}, true))
+		require.Equal(t, expected, policy.FeeRecipient(owner, bellatrix.ExecutionAddress{}, false))
+
+		policy, err = NewPolicy(PolicyOptions{DefaultFeeRecipient: expected.String(), OverrideZeroRecipient: true})
+		require.NoError(t, err)
+		require.Equal(t, allowed, policy.FeeRecipient(owner, allowed, true))
+		require.Equal(t, expected, policy.FeeRecipient(owner, bellatrix.ExecutionAddress{}, true))
+	})
+}
+
+func toExecutionAddress(addr string) bellatrix.ExecutionAddress {
+	var recipient bellatrix.ExecutionAddress
+	copy(recipient[:], common.HexToAddress(addr).Bytes())
+	return recipient
+}
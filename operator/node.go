@@ -43,6 +43,7 @@ type Options struct {
 	DB                  basedb.Database
 	ValidatorController validator.Controller
 	ValidatorOptions    validator.ControllerOptions `yaml:"ValidatorOptions"`
+	FeeRecipientPolicy  *fee_recipient.Policy
 	DutyStore           *dutystore.Store
 	WS                  api.WebSocketServer
 	WsAPIPort           int
@@ -116,6 +117,7 @@ func New(logger *zap.Logger, opts Options, slotTickerProvider slotticker.Provide
 			RecipientStorage:   opts.ValidatorOptions.RegistryStorage,
 			OperatorDataStore:  opts.ValidatorOptions.OperatorDataStore,
 			SlotTickerProvider: slotTickerProvider,
+			Policy:             opts.FeeRecipientPolicy,
 		}),
 
 		ws:        opts.WS,
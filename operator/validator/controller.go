@@ -24,8 +24,10 @@ import (
 	"github.com/bloxapp/ssv/logging/fields"
 	"github.com/bloxapp/ssv/message/validation"
 	"github.com/bloxapp/ssv/network"
+	"github.com/bloxapp/ssv/network/commons"
 	operatordatastore "github.com/bloxapp/ssv/operator/datastore"
 	"github.com/bloxapp/ssv/operator/duties"
+	"github.com/bloxapp/ssv/operator/fee_recipient"
 	nodestorage "github.com/bloxapp/ssv/operator/storage"
 	"github.com/bloxapp/ssv/operator/validatorsmap"
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
@@ -63,6 +65,7 @@ type ControllerOptions struct {
 	MetadataUpdateInterval     time.Duration `yaml:"MetadataUpdateInterval" env:"METADATA_UPDATE_INTERVAL" env-default:"12m" env-description:"Interval for updating metadata"`
 	HistorySyncBatchSize       int           `yaml:"HistorySyncBatchSize" env:"HISTORY_SYNC_BATCH_SIZE" env-default:"25" env-description:"Maximum number of messages to sync in a single batch"`
 	MinPeers                   int           `yaml:"MinimumPeers" env:"MINIMUM_PEERS" env-default:"2" env-description:"The required minimum peers for sync"`
+	BackboneSubnets            int           `yaml:"BackboneSubnets" env:"BACKBONE_SUBNETS" env-default:"0" env-description:"Number of random subnets to subscribe to in addition to the ones required by own validators"`
 	BeaconNetwork              beaconprotocol.Network
 	Network                    P2PNetwork
 	Beacon                     beaconprotocol.BeaconNode
@@ -79,6 +82,7 @@ type ControllerOptions struct {
 	MessageValidator           validation.MessageValidator
 	ValidatorsMap              *validatorsmap.ValidatorsMap
 	Graffiti                   []byte
+	FeeRecipientPolicy         *fee_recipient.Policy
 
 	// worker flags
 	WorkersCount    int `yaml:"MsgWorkersCount" env:"MSG_WORKERS_COUNT" env-default:"256" env-description:"Number of goroutines to use for message workers"`
@@ -136,6 +140,7 @@ type P2PNetwork interface {
 	UseMessageRouter(router network.MessageRouter)
 	Peers(pk spectypes.ValidatorPK) ([]peer.ID, error)
 	SubscribeRandoms(logger *zap.Logger, numSubnets int) error
+	SubscribeSubnets(logger *zap.Logger, subnets []int) error
 	RegisterHandlers(logger *zap.Logger, handlers ...*p2pprotocol.SyncHandler)
 }
 
@@ -149,6 +154,7 @@ type controller struct {
 	sharesStorage     SharesStorage
 	operatorsStorage  registrystorage.Operators
 	recipientsStorage Recipients
+	recipientPolicy   *fee_recipient.Policy
 	ibftStorageMap    *storage.QBFTStores
 
 	beacon     beaconprotocol.BeaconNode
@@ -162,6 +168,7 @@ type controller struct {
 	committeeValidatorSetup chan struct{}
 
 	metadataUpdateInterval time.Duration
+	backboneSubnets        int
 
 	operatorsIDs         *sync.Map
 	network              P2PNetwork
@@ -231,6 +238,7 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 		sharesStorage:     options.RegistryStorage.Shares(),
 		operatorsStorage:  options.RegistryStorage,
 		recipientsStorage: options.RegistryStorage,
+		recipientPolicy:   options.FeeRecipientPolicy,
 		ibftStorageMap:    options.StorageMap,
 		context:           options.Context,
 		beacon:            options.Beacon,
@@ -242,6 +250,7 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 		validatorOptions: validatorOptions,
 
 		metadataUpdateInterval: options.MetadataUpdateInterval,
+		backboneSubnets:        options.BackboneSubnets,
 
 		operatorsIDs: operatorsIDs,
 
@@ -420,13 +429,7 @@ func (c *controller) StartValidators() {
 	} else {
 		// Setup committee validators.
 		inited := c.setupValidators(ownShares)
-		if len(inited) == 0 {
-			// If no validators were started and therefore we're not subscribed to any subnets,
-			// then subscribe to a random subnet to participate in the network.
-			if err := c.network.SubscribeRandoms(c.logger, 1); err != nil {
-				c.logger.Error("failed to subscribe to random subnets", zap.Error(err))
-			}
-		}
+		c.subscribeToRequiredSubnets(ownShares, len(inited) > 0)
 		close(c.committeeValidatorSetup)
 
 		// Start validators.
@@ -457,6 +460,31 @@ func (c *controller) StartValidators() {
 	}
 }
 
+// subscribeToRequiredSubnets subscribes only to the subnets of our own validators,
+// plus the configured number of random backbone subnets to keep the mesh healthy.
+func (c *controller) subscribeToRequiredSubnets(ownShares []*ssvtypes.SSVShare, hasStartedValidators bool) {
+	requiredSubnets := commons.RequiredSubnets(ownShares)
+	if err := c.network.SubscribeSubnets(c.logger, requiredSubnets); err != nil {
+		c.logger.Error("failed to subscribe to required subnets", zap.Error(err))
+	}
+
+	backboneSubnets := c.backboneSubnets
+	if backboneSubnets == 0 && !hasStartedValidators {
+		// If no validators were started and therefore we're not subscribed to any subnets,
+		// then subscribe to a random subnet to participate in the network.
+		backboneSubnets = 1
+	}
+	if backboneSubnets > 0 {
+		if err := c.network.SubscribeRandoms(c.logger, backboneSubnets); err != nil {
+			c.logger.Error("failed to subscribe to random subnets", zap.Error(err))
+		}
+	}
+
+	c.logger.Debug("subscribed to subnets",
+		zap.Ints("required_subnets", requiredSubnets),
+		zap.Int("backbone_subnets", backboneSubnets))
+}
+
 // setupValidators setup and starts validators from the given shares.
 // shares w/o validator's metadata won't start, but the metadata will be fetched and the validator will start afterwards
 func (c *controller) setupValidators(shares []*ssvtypes.SSVShare) []*validator.Validator {
@@ -681,6 +709,15 @@ func (c *controller) onShareStop(pubKey spectypes.ValidatorPK) {
 	if v != nil {
 		v.Stop()
 	}
+
+	// stop submitting the validator's registration
+	if remover, ok := c.beacon.(beaconprotocol.RegistrationRemover); ok {
+		var blsPubKey phase0.BLSPubKey
+		copy(blsPubKey[:], pubKey)
+		if err := remover.RemoveValidatorRegistration(blsPubKey); err != nil {
+			c.logger.Warn("failed to remove validator registration", fields.PubKey(pubKey), zap.Error(err))
+		}
+	}
 }
 
 func (c *controller) onShareInit(share *ssvtypes.SSVShare) (*validator.Validator, error) {
@@ -748,16 +785,15 @@ func (c *controller) setShareFeeRecipient(share *ssvtypes.SSVShare, getRecipient
 		return errors.Wrap(err, "could not get recipient data")
 	}
 
-	var feeRecipient bellatrix.ExecutionAddress
-	if !found {
-		c.logger.Debug("setting fee recipient to owner address",
-			fields.Validator(share.ValidatorPubKey), fields.FeeRecipient(share.OwnerAddress.Bytes()))
-		copy(feeRecipient[:], share.OwnerAddress.Bytes())
-	} else {
-		c.logger.Debug("setting fee recipient to storage data",
-			fields.Validator(share.ValidatorPubKey), fields.FeeRecipient(data.FeeRecipient[:]))
-		feeRecipient = data.FeeRecipient
+	var recipient bellatrix.ExecutionAddress
+	if found {
+		recipient = data.FeeRecipient
 	}
+	feeRecipient := c.recipientPolicy.FeeRecipient(share.OwnerAddress, recipient, found)
+	c.logger.Debug("setting fee recipient",
+		fields.Validator(share.ValidatorPubKey),
+		fields.FeeRecipient(feeRecipient[:]),
+		zap.Bool("set_by_owner", found))
 	share.SetFeeRecipient(feeRecipient)
 
 	return nil
@@ -484,6 +484,20 @@ func (mr *MockP2PNetworkMockRecorder) SubscribeRandoms(logger, numSubnets interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeRandoms", reflect.TypeOf((*MockP2PNetwork)(nil).SubscribeRandoms), logger, numSubnets)
 }
 
+// SubscribeSubnets mocks base method.
+func (m *MockP2PNetwork) SubscribeSubnets(logger *zap.Logger, subnets []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeSubnets", logger, subnets)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeSubnets indicates an expected call of SubscribeSubnets.
+func (mr *MockP2PNetworkMockRecorder) SubscribeSubnets(logger, subnets interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeSubnets", reflect.TypeOf((*MockP2PNetwork)(nil).SubscribeSubnets), logger, subnets)
+}
+
 // UseMessageRouter mocks base method.
 func (m *MockP2PNetwork) UseMessageRouter(router network.MessageRouter) {
 	m.ctrl.T.Helper()
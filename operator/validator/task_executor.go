@@ -3,6 +3,7 @@ package validator
 import (
 	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -89,9 +90,11 @@ func (c *controller) UpdateFeeRecipient(owner, recipient common.Address) error {
 		zap.String("owner", owner.String()),
 		zap.String("fee_recipient", recipient.String()))
 
+	feeRecipient := c.recipientPolicy.FeeRecipient(owner, bellatrix.ExecutionAddress(recipient), true)
+
 	c.validatorsMap.ForEach(func(v *validator.Validator) bool {
 		if v.Share.OwnerAddress == owner {
-			v.Share.FeeRecipientAddress = recipient
+			v.Share.SetFeeRecipient(feeRecipient)
 
 			logger.Debug("updated recipient address")
 		}
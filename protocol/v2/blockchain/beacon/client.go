@@ -9,6 +9,9 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	specssv "github.com/bloxapp/ssv-spec/ssv"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/bloxapp/ssv/storage/basedb"
 )
 
 // TODO: add missing tests
@@ -36,6 +39,38 @@ type beaconValidator interface {
 	GetValidatorData(validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, error)
 }
 
+// TimestampedRegistrationSubmitter is implemented by beacon nodes which submit validator registrations
+// with the timestamp they were signed with, rather than with the start of the current epoch.
+type TimestampedRegistrationSubmitter interface {
+	SubmitTimestampedValidatorRegistration(pubkey []byte, feeRecipient bellatrix.ExecutionAddress, timestamp time.Time, sig phase0.BLSSignature) error
+}
+
+// RegistrationRemover is implemented by beacon nodes which cache validator registrations,
+// to remove the registrations of validators which were removed.
+type RegistrationRemover interface {
+	RemoveValidatorRegistration(pubKey phase0.BLSPubKey) error
+}
+
+// ValidatorsInvalidator is implemented by beacon nodes which prefetch the operator's validators,
+// to be notified when the operator's validators change.
+type ValidatorsInvalidator interface {
+	InvalidateValidators()
+}
+
+// PartialValidatorsProvider is implemented by beacon nodes which can fetch validators in a mode
+// that tolerates failures of some of them, rather than failing the whole fetch.
+type PartialValidatorsProvider interface {
+	// GetValidatorDataPartial returns the validators which were fetched successfully, and the public keys
+	// of the ones which failed to be fetched, so that these can be retried. It fails only if no validator was fetched.
+	GetValidatorDataPartial(validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, []phase0.BLSPubKey, error)
+}
+
+// WarmUpReporter is implemented by beacon nodes which warm up after their events stream reconnects.
+type WarmUpReporter interface {
+	// WarmingUp returns whether the beacon node recently reconnected, in which case the data it serves may be stale.
+	WarmingUp() bool
+}
+
 type proposer interface {
 	// SubmitProposalPreparation with fee recipients
 	SubmitProposalPreparation(feeRecipients map[phase0.ValidatorIndex]bellatrix.ExecutionAddress) error
@@ -65,4 +100,108 @@ type Options struct {
 	GasLimit       uint64
 	CommonTimeout  time.Duration // Optional.
 	LongTimeout    time.Duration // Optional.
+
+	// ValidatorsProvider returns the operator's validators, whose data is prefetched every epoch
+	// if PrefetchValidators is set, so that lookups during duties are served from the cache. Optional.
+	ValidatorsProvider func() []phase0.BLSPubKey
+	PrefetchValidators bool `yaml:"PrefetchValidators" env:"PREFETCH_VALIDATORS" env-description:"Prefetch the data of the operator's validators at every epoch boundary and whenever they change, to serve duty-time lookups from a cache"`
+
+	// Per-request timeouts, overriding CommonTimeout (LongTimeout for validators) when set,
+	// so that latency-critical requests don't share the deadline of bulk requests.
+	AttestationDataTimeout      time.Duration `yaml:"AttestationDataTimeout" env:"ATTESTATION_DATA_TIMEOUT" env-description:"Timeout for attestation data requests"`
+	AggregateAttestationTimeout time.Duration `yaml:"AggregateAttestationTimeout" env:"AGGREGATE_ATTESTATION_TIMEOUT" env-description:"Timeout for aggregate attestation requests"`
+	ProposalTimeout             time.Duration `yaml:"ProposalTimeout" env:"PROPOSAL_TIMEOUT" env-description:"Timeout for block proposal requests, half a slot by default. Must leave the common timeout to submit the block within the slot"`
+	ValidatorsTimeout           time.Duration `yaml:"ValidatorsTimeout" env:"VALIDATORS_TIMEOUT" env-description:"Timeout for validators requests"`
+
+	// AttestationDataSlack is the maximum additional time to wait past 1/3 of the slot for a head event
+	// of the current slot before fetching attestation data. Zero disables waiting.
+	AttestationDataSlack time.Duration `yaml:"AttestationDataSlack" env:"ATTESTATION_DATA_SLACK" env-description:"Maximum time to wait past 1/3 of the slot for the slot's head event before fetching attestation data"`
+
+	// AttestationDataHeadCheck compares the head vote of fetched attestation data with the head of its slot
+	// reported by the beacon node's head events, reporting a mismatch, which indicates a stale head.
+	AttestationDataHeadCheck bool `yaml:"AttestationDataHeadCheck" env:"ATTESTATION_DATA_HEAD_CHECK" env-description:"Report attestation data whose head vote differs from the head of its slot reported by head events"`
+
+	// AttestationBatchWindow is the time to accumulate attestations of different validators
+	// before submitting them in a single request. Zero submits each attestation immediately.
+	AttestationBatchWindow time.Duration `yaml:"AttestationBatchWindow" env:"ATTESTATION_BATCH_WINDOW" env-description:"Time to accumulate attestations before submitting them to the beacon node in a single request"`
+
+	// SubmissionRateLimit limits the submissions to the beacon node to the given number per second,
+	// separately for each class of submission endpoints. Zero disables the limit.
+	SubmissionRateLimit float64 `yaml:"SubmissionRateLimit" env:"SUBMISSION_RATE_LIMIT" env-description:"Maximum submissions per second to the beacon node for each class of endpoints (0 disables the limit)"`
+	SubmissionRateBurst int     `yaml:"SubmissionRateBurst" env:"SUBMISSION_RATE_BURST" env-description:"Maximum burst of submissions to the beacon node for each class of endpoints (defaults to the rate limit)"`
+
+	// ForceNodeClient overrides the consensus client type detected from the node's version,
+	// for nodes which report a version that can't be recognized (e.g. custom builds).
+	ForceNodeClient string `yaml:"ForceNodeClient" env:"FORCE_NODE_CLIENT" env-description:"Consensus client type to use instead of the detected one (lighthouse, prysm or nimbus)"`
+
+	// GraffitiTemplate renders the graffiti of each block proposal, overriding Graffiti.
+	// It may include the variables {slot}, {operator_id} and {version}. Optional.
+	GraffitiTemplate string `yaml:"GraffitiTemplate" env:"GRAFFITI_TEMPLATE" env-description:"Template for the graffiti of block proposals with the variables {slot}, {operator_id} and {version}, overriding the static graffiti"`
+
+	// AttestationSanityWarnOnly submits attestations whose data is inconsistent or stale with a warning,
+	// rather than rejecting them.
+	AttestationSanityWarnOnly bool `yaml:"AttestationSanityWarnOnly" env:"ATTESTATION_SANITY_WARN_ONLY" env-description:"Submit attestations with inconsistent or stale data with a warning instead of rejecting them"`
+
+	// EventTopics are the event topics which the client subscribes to on startup, shared by all
+	// subscriptions to these topics rather than each opening a connection to the beacon node.
+	EventTopics []string `yaml:"EventTopics" env:"EVENT_TOPICS" env-separator:"," env-default:"head,finalized_checkpoint,chain_reorg" env-description:"Comma-separated event topics to subscribe to once and share among all internal consumers (e.g. head, finalized_checkpoint, chain_reorg, payload_attributes)"`
+
+	// OptimisticSuppressionSlots suppresses attestations and proposals once the beacon node reports
+	// that it's optimistic, for the given number of slots and until it's no longer optimistic. Zero disables suppression.
+	OptimisticSuppressionSlots uint64 `yaml:"OptimisticSuppressionSlots" env:"OPTIMISTIC_SUPPRESSION_SLOTS" env-description:"Number of slots to suppress attestations and proposals for once the beacon node reports it's optimistic, and until it no longer is (0 disables suppression)"`
+
+	// WarmUpSlots is the number of slots the beacon node is warming up for once its events stream reconnects,
+	// during which duties it serves are flagged as potentially stale and fetched again, and validator registrations
+	// are delayed. Zero disables warm-up.
+	WarmUpSlots uint64 `yaml:"WarmUpSlots" env:"WARM_UP_SLOTS" env-description:"Number of slots to treat the beacon node's data with caution for once its events stream reconnects, fetching duties again and delaying validator registrations (0 disables warm-up)"`
+
+	// ClockOffsetThreshold is the estimated offset between the local clock and the beacon node's clock, derived from
+	// the arrival of head events into their slots, beyond which a warning is logged every epoch. Zero disables the check.
+	ClockOffsetThreshold time.Duration `yaml:"ClockOffsetThreshold" env:"CLOCK_OFFSET_THRESHOLD" env-description:"Estimated offset from the beacon node's clock beyond which a warning is logged, e.g. for NTP problems (0 disables the check)"`
+
+	// PreloadDomains fetches the signing domains of attestations, proposals, sync committee messages and RANDAO reveals
+	// of the current and next epoch at startup, so that the first duties don't wait for them.
+	PreloadDomains bool `yaml:"PreloadDomains" env:"PRELOAD_DOMAINS" env-description:"Fetch the signing domains of the first duties at startup rather than on first use"`
+
+	// AggregationCacheSize is the number of aggregation decisions cached per slot, so that repeated checks
+	// of the same validator within a slot don't hash its selection proof again. The cache is disabled by default (zero).
+	AggregationCacheSize int `yaml:"AggregationCacheSize" env:"AGGREGATION_CACHE_SIZE" env-description:"Number of aggregation decisions cached per slot (0 disables the cache, default)"`
+
+	// ReadBeaconNodeAddr and WriteBeaconNodeAddr are the addresses of beacon nodes dedicated to duty and validator
+	// queries and to submissions respectively, in addition to BeaconNodeAddr which serves any other request.
+	// Requests fall back to BeaconNodeAddr for an epoch once a dedicated node fails. Optional.
+	ReadBeaconNodeAddr  string `yaml:"ReadBeaconNodeAddr" env:"READ_BEACON_NODE_ADDR" env-description:"Address of a beacon node dedicated to duty and validator queries"`
+	WriteBeaconNodeAddr string `yaml:"WriteBeaconNodeAddr" env:"WRITE_BEACON_NODE_ADDR" env-description:"Address of a beacon node dedicated to submissions"`
+
+	// SlotStickyRouting fetches the data of duties from WriteBeaconNodeAddr too, and keeps routing the requests
+	// of a duty to the node which served its first request within the duty's slot, unless that node fails,
+	// so that the data of a duty and its submission aren't served by nodes on different heads.
+	SlotStickyRouting bool `yaml:"SlotStickyRouting" env:"SLOT_STICKY_ROUTING" env-description:"Keep using the beacon node which served the first request of a duty for the duty's other requests within its slot, unless it fails"`
+
+	// RelayFallbackProposals prefers local blocks for the given number of proposals once a blinded proposal
+	// fails to be submitted or pays less than RelayMinValueGwei. Zero disables the fallback.
+	RelayFallbackProposals uint64 `yaml:"RelayFallbackProposals" env:"RELAY_FALLBACK_PROPOSALS" env-description:"Number of proposals to prefer local blocks for after a failed or low-value blinded proposal (0 disables the fallback)"`
+	RelayMinValueGwei      uint64 `yaml:"RelayMinValueGwei" env:"RELAY_MIN_VALUE_GWEI" env-description:"Minimum execution value of blinded proposals in Gwei, below which local blocks are preferred for the next proposals (0 disables the check)"`
+
+	// SlotTickerFallback ticks by the wall clock whenever the slot ticker stalls for longer than
+	// 1.5 slots, so that periodic work such as registration submission continues. Stalls are reported regardless.
+	SlotTickerFallback bool `yaml:"SlotTickerFallback" env:"SLOT_TICKER_FALLBACK" env-description:"Tick by the wall clock when the slot ticker stalls, so that periodic beacon node work continues"`
+
+	// EnabledEndpoints are the classes of beacon node endpoints which may be called, failing calls to the others
+	// with an error, e.g. to rule out blinded proposals without MEV. Duty, validator, domain, event and status
+	// endpoints are always enabled. All endpoints are enabled if none are given.
+	EnabledEndpoints []string `yaml:"EnabledEndpoints" env:"ENABLED_ENDPOINTS" env-separator:"," env-description:"Comma-separated classes of beacon node endpoints to enable: attestation, aggregation, proposal, blinded_proposal, sync_committee, registration, subscription and exit (all when empty)"`
+
+	// RegistrationsDB persists the cached validator registrations, so that they're submitted
+	// right after a restart rather than once each validator's registration duty comes around.
+	// Registrations of validators which ValidatorsProvider no longer returns are deleted on startup. Optional.
+	RegistrationsDB basedb.Database
+
+	// TracingEndpoint is the host and port of an OTLP/HTTP collector, to which each beacon node request
+	// of a duty is exported as an OpenTelemetry span over plain HTTP, unless TracerProvider is set.
+	TracingEndpoint string `yaml:"TracingEndpoint" env:"BEACON_NODE_TRACING_ENDPOINT" env-description:"Host and port of an OTLP/HTTP collector to export beacon node requests of duties to as OpenTelemetry spans, over plain HTTP (disabled when empty)"`
+
+	// TracerProvider exports each beacon node request of a duty as an OpenTelemetry span,
+	// with its role, request and outcome as attributes, as a child of the span of its duty. Optional.
+	TracerProvider trace.TracerProvider
 }
@@ -68,13 +68,17 @@ type OnUpdated func(pk string, meta *ValidatorMetadata)
 
 // UpdateValidatorsMetadata updates validator information for the given public keys
 func UpdateValidatorsMetadata(logger *zap.Logger, pubKeys [][]byte, collection ValidatorMetadataStorage, bc BeaconNode, onUpdated OnUpdated) error {
-	results, err := FetchValidatorsMetadata(bc, pubKeys)
+	results, failed, err := FetchValidatorsMetadataPartial(bc, pubKeys)
 	if err != nil {
 		return errors.Wrap(err, "failed to get validator data from Beacon")
 	}
 	// TODO: importing logging/fields causes import cycle
 	logger.Debug("🆕 got validators metadata", zap.Int("requested", len(pubKeys)),
 		zap.Int("received", len(results)))
+	if len(failed) > 0 {
+		// The metadata of the failed validators remains outdated, so it's fetched again by the next update.
+		logger.Warn("failed to get metadata of some validators", zap.Int("failed", len(failed)))
+	}
 
 	var errs []error
 	for pk, meta := range results {
@@ -102,16 +106,47 @@ func FetchValidatorsMetadata(bc BeaconNode, pubKeys [][]byte) (map[string]*Valid
 	if len(pubKeys) == 0 {
 		return nil, nil
 	}
+	validatorsIndexMap, err := bc.GetValidatorData(blsPubKeys(pubKeys))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get validators data from beacon")
+	}
+	return validatorsMetadata(validatorsIndexMap), nil
+}
+
+// FetchValidatorsMetadataPartial is fetching validators data from beacon, returning the public keys
+// of the validators which failed to be fetched rather than failing, if the beacon node supports it.
+func FetchValidatorsMetadataPartial(bc BeaconNode, pubKeys [][]byte) (map[string]*ValidatorMetadata, [][]byte, error) {
+	partialProvider, ok := bc.(PartialValidatorsProvider)
+	if !ok {
+		results, err := FetchValidatorsMetadata(bc, pubKeys)
+		return results, nil, err
+	}
+	if len(pubKeys) == 0 {
+		return nil, nil, nil
+	}
+	validatorsIndexMap, failed, err := partialProvider.GetValidatorDataPartial(blsPubKeys(pubKeys))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get validators data from beacon")
+	}
+	failedPubKeys := make([][]byte, 0, len(failed))
+	for _, pk := range failed {
+		pk := pk
+		failedPubKeys = append(failedPubKeys, pk[:])
+	}
+	return validatorsMetadata(validatorsIndexMap), failedPubKeys, nil
+}
+
+func blsPubKeys(pubKeys [][]byte) []phase0.BLSPubKey {
 	var pubkeys []phase0.BLSPubKey
 	for _, pk := range pubKeys {
 		blsPubKey := phase0.BLSPubKey{}
 		copy(blsPubKey[:], pk)
 		pubkeys = append(pubkeys, blsPubKey)
 	}
-	validatorsIndexMap, err := bc.GetValidatorData(pubkeys)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get validators data from beacon")
-	}
+	return pubkeys
+}
+
+func validatorsMetadata(validatorsIndexMap map[phase0.ValidatorIndex]*eth2apiv1.Validator) map[string]*ValidatorMetadata {
 	ret := make(map[string]*ValidatorMetadata)
 	for _, v := range validatorsIndexMap {
 		pk := hex.EncodeToString(v.Validator.PublicKey[:])
@@ -123,5 +158,5 @@ func FetchValidatorsMetadata(bc BeaconNode, pubKeys [][]byte) (map[string]*Valid
 		}
 		ret[pk] = meta
 	}
-	return ret, nil
+	return ret
 }
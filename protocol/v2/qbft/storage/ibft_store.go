@@ -46,6 +46,10 @@ type InstanceStore interface {
 
 	// CleanAllInstances removes all historical and highest instances for the given identifier.
 	CleanAllInstances(logger *zap.Logger, msgID []byte) error
+
+	// PruneInstances removes the historical instances below the given height of all identifiers,
+	// returning the number of removed instances.
+	PruneInstances(below specqbft.Height) (int, error)
 }
 
 // QBFTStore is the store used by QBFT components
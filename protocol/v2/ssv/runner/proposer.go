@@ -26,6 +26,11 @@ import (
 	"github.com/bloxapp/ssv/protocol/v2/ssv/runner/metrics"
 )
 
+// registrationEnsurer is implemented by beacon nodes which can submit a validator's registration on demand.
+type registrationEnsurer interface {
+	EnsureValidatorRegistration(pubKey phase0.BLSPubKey) error
+}
+
 type ProposerRunner struct {
 	BaseRunner *BaseRunner
 	// ProducesBlindedBlocks is true when the runner will only produce blinded blocks
@@ -113,6 +118,15 @@ func (r *ProposerRunner) ProcessPreConsensus(logger *zap.Logger, signedMsg *spec
 	var obj ssz.Marshaler
 	var start = time.Now()
 	if r.ProducesBlindedBlocks {
+		// Relays reject blinded blocks of validators they have no registration of.
+		if ensurer, ok := r.GetBeaconNode().(registrationEnsurer); ok {
+			var pubKey phase0.BLSPubKey
+			copy(pubKey[:], r.GetShare().ValidatorPubKey)
+			if err := ensurer.EnsureValidatorRegistration(pubKey); err != nil {
+				logger.Warn("validator registration is not available for blinded block proposal", zap.Error(err))
+			}
+		}
+
 		// get block data
 		obj, ver, err = r.GetBeaconNode().GetBlindedBeaconBlock(duty.Slot, r.graffiti, fullSig)
 		if err != nil {
@@ -15,6 +15,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/logging/fields"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v2/qbft/controller"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/runner/metrics"
 )
@@ -84,7 +85,7 @@ func (r *ValidatorRegistrationRunner) ProcessPreConsensus(logger *zap.Logger, si
 	specSig := phase0.BLSSignature{}
 	copy(specSig[:], fullSig)
 
-	if err := r.beacon.SubmitValidatorRegistration(r.BaseRunner.Share.ValidatorPubKey, r.BaseRunner.Share.FeeRecipientAddress, specSig); err != nil {
+	if err := r.submitValidatorRegistration(specSig); err != nil {
 		return errors.Wrap(err, "could not submit validator registration")
 	}
 
@@ -161,6 +162,22 @@ func (r *ValidatorRegistrationRunner) executeDuty(logger *zap.Logger, duty *spec
 	return nil
 }
 
+// submitValidatorRegistration submits the registration with the given signature. If the beacon node supports it,
+// the registration is submitted with the timestamp it was signed with, so that it's compared correctly
+// with the validator's cached registration.
+func (r *ValidatorRegistrationRunner) submitValidatorRegistration(sig phase0.BLSSignature) error {
+	submitter, ok := r.beacon.(beaconprotocol.TimestampedRegistrationSubmitter)
+	if !ok {
+		return r.beacon.SubmitValidatorRegistration(r.BaseRunner.Share.ValidatorPubKey, r.BaseRunner.Share.FeeRecipientAddress, sig)
+	}
+
+	vr, err := r.calculateValidatorRegistration()
+	if err != nil {
+		return errors.Wrap(err, "could not calculate validator registration")
+	}
+	return submitter.SubmitTimestampedValidatorRegistration(vr.Pubkey[:], vr.FeeRecipient, vr.Timestamp, sig)
+}
+
 func (r *ValidatorRegistrationRunner) calculateValidatorRegistration() (*v1.ValidatorRegistration, error) {
 	pk := phase0.BLSPubKey{}
 	copy(pk[:], r.BaseRunner.Share.ValidatorPubKey)